@@ -47,7 +47,13 @@ func TestAddLicense(t *testing.T) {
 	// TODO(https://github.com/googleapis/librarian/issues/5576): remove
 	// -ignore after license headers have been added to pom.xml and
 	// *_pom.xml files.
-	rungo(t, "tool", "addlicense", "-check", "-c", "Google LLC", "-l", "apache", "-ignore", "**/*pom.xml", ".")
+	//
+	// The gcloud golden files hold the marshaled commands without the header
+	// that command_writer adds when writing them out.
+	rungo(t, "tool", "addlicense", "-check", "-c", "Google LLC", "-l", "apache",
+		"-ignore", "**/*pom.xml",
+		"-ignore", "internal/sidekick/gcloud/testdata/**",
+		".")
 }
 
 func rungo(t *testing.T, args ...string) string {
@@ -0,0 +1,92 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build codecoptionsdocgen
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/googleapis/librarian/internal/sidekick/dart"
+	"github.com/googleapis/librarian/internal/sidekick/docs"
+	"github.com/googleapis/librarian/internal/sidekick/language"
+	"github.com/googleapis/librarian/internal/sidekick/protofile"
+	"github.com/googleapis/librarian/internal/sidekick/rust"
+	"github.com/googleapis/librarian/internal/sidekick/rust_prost"
+	"github.com/googleapis/librarian/internal/sidekick/swift"
+)
+
+var outputFile = flag.String("output", "doc/codec-options.md", "Output file for documentation")
+
+var codecOptionsTemplate = template.Must(template.New("doc").Parse(`# Codec Options
+
+This document describes the codec options accepted by each sidekick generator.
+Unknown options and values of the wrong type are rejected.
+
+Options ending in ` + "`:`" + ` are prefixes, the rest of the key is chosen by the
+user. Options of type ` + "`list`" + ` are comma-separated.
+{{range .}}
+## {{.Codec}}
+{{range .Extends}}
+Also accepts the options of the [{{.Codec}}](#{{.Codec}}) codec.
+{{end}}
+| Option | Type | Description |
+| :--- | :--- | :--- |
+{{range .Options}}| ` + "`{{.Name}}`" + ` | {{template "type" .}} | {{.Doc}} |
+{{end}}{{end}}
+{{- define "type"}}{{if .Values}}{{range $i, $v := .Values}}{{if $i}}, {{end}}` + "`{{$v}}`" + `{{end}}{{else}}{{.Type}}{{end}}{{end}}`))
+
+func main() {
+	flag.Parse()
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run() (err error) {
+	output, err := os.Create(*outputFile)
+	if err != nil {
+		return fmt.Errorf("creating output file: %w", err)
+	}
+	defer func() {
+		cerr := output.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+	schemas := []*language.OptionSchema{dart.Options, docs.Options, protofile.Options, rust.Options, rust_prost.Options, swift.Options}
+	if err := generateCodecOptions(output, schemas); err != nil {
+		return fmt.Errorf("generating documentation: %w", err)
+	}
+	return nil
+}
+
+// generateCodecOptions writes the Markdown reference for schemas.
+func generateCodecOptions(w io.Writer, schemas []*language.OptionSchema) error {
+	for _, s := range schemas {
+		for _, o := range s.Options {
+			if strings.Contains(o.Doc, "|") {
+				return fmt.Errorf("the documentation for %s option %q cannot contain a %q", s.Codec, o.Name, "|")
+			}
+		}
+	}
+	return codecOptionsTemplate.Execute(w, schemas)
+}
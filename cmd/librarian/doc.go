@@ -93,14 +93,22 @@ one of <library> or --all must be provided.
 Generation is delegated to the language-specific tooling configured in
 librarian.yaml. Libraries marked with skip_generate are skipped.
 
+The --verify flag runs the language's compile check in the output of each
+generated library, e.g. go build and go vet for Go, cargo check for Rust, or
+surfer validate for gcloud. The tools are resolved through the preinstalled
+map in the release config. Failures are reported for every library that does
+not build.
+
 Examples:
 
-	librarian generate <library>   # regenerate one library
-	librarian generate --all       # regenerate every library
+	librarian generate <library>            # regenerate one library
+	librarian generate --all                # regenerate every library
+	librarian generate --all --verify       # regenerate and check every library builds
 
 Flags:
 
 	--all       generate all libraries
+	--verify    check that the generated libraries build
 
 A typical librarian workflow for regenerating every library against the
 latest API definitions is:
@@ -120,15 +128,33 @@ If a library name is given, only that library is updated. The --all flag updates
 library in the workspace. When a library is specified explicitly, the --version flag can
 be used to override the new version.
 
+For Go libraries, the exported API of each module is compared against its last release
+tag. Any API change requires at least a minor version bump: the derived version is raised
+to the next minor version, while a --version that is not a minor bump is rejected.
+Incompatible changes to a stable (v1+) module are rejected unless the library is migrating
+to a new major version through module_path_version. The check is skipped if the release
+tag or the module at that tag does not exist, or if --allow-breaking is set.
+
+The --major flag migrates a Go library to the next major version of its module path,
+e.g. from cloud.google.com/go/foo to cloud.google.com/go/foo/v2. It updates go.mod, the
+imports of the module's packages, the import paths in librarian.yaml, the snippets module
+and go.work, and sets the new version to N.0.0. Other references to the old module path,
+such as in README files or snippet metadata, are not rewritten; regenerate the library
+and update handwritten files afterwards. As a new major version may change the API in any
+way, the API compatibility check is skipped.
+
 Examples:
 
 	librarian bump <library>           # update version for one library
+	librarian bump --major <library>   # migrate a Go library to its next major version
 	librarian bump --all               # update versions for all libraries
 
 Flags:
 
 	--all             update all libraries in the workspace
 	--version string  specific version to update to; not valid with --all
+	--allow-breaking  skip the Go API compatibility check, allowing incompatible changes
+	--major           migrate a Go library to its next major module path version; not valid with --all or --version
 
 # Install tool dependencies for a language
 
@@ -235,14 +261,22 @@ one of <library> or --all must be provided.
 Generation is delegated to the language-specific tooling configured in
 librarian.yaml. Libraries marked with skip_generate are skipped.
 
+The --verify flag runs the language's compile check in the output of each
+generated library, e.g. go build and go vet for Go, cargo check for Rust, or
+surfer validate for gcloud. The tools are resolved through the preinstalled
+map in the release config. Failures are reported for every library that does
+not build.
+
 Examples:
 
-	librarian generate <library>   # regenerate one library
-	librarian generate --all       # regenerate every library
+	librarian generate <library>            # regenerate one library
+	librarian generate --all                # regenerate every library
+	librarian generate --all --verify       # regenerate and check every library builds
 
 Flags:
 
 	--all       generate all libraries
+	--verify    check that the generated libraries build
 
 A typical librarian workflow for regenerating every library against the
 latest API definitions is:
@@ -262,15 +296,33 @@ If a library name is given, only that library is updated. The --all flag updates
 library in the workspace. When a library is specified explicitly, the --version flag can
 be used to override the new version.
 
+For Go libraries, the exported API of each module is compared against its last release
+tag. Any API change requires at least a minor version bump: the derived version is raised
+to the next minor version, while a --version that is not a minor bump is rejected.
+Incompatible changes to a stable (v1+) module are rejected unless the library is migrating
+to a new major version through module_path_version. The check is skipped if the release
+tag or the module at that tag does not exist, or if --allow-breaking is set.
+
+The --major flag migrates a Go library to the next major version of its module path,
+e.g. from cloud.google.com/go/foo to cloud.google.com/go/foo/v2. It updates go.mod, the
+imports of the module's packages, the import paths in librarian.yaml, the snippets module
+and go.work, and sets the new version to N.0.0. Other references to the old module path,
+such as in README files or snippet metadata, are not rewritten; regenerate the library
+and update handwritten files afterwards. As a new major version may change the API in any
+way, the API compatibility check is skipped.
+
 Examples:
 
 	librarian bump <library>           # update version for one library
+	librarian bump --major <library>   # migrate a Go library to its next major version
 	librarian bump --all               # update versions for all libraries
 
 Flags:
 
 	--all             update all libraries in the workspace
 	--version string  specific version to update to; not valid with --all
+	--allow-breaking  skip the Go API compatibility check, allowing incompatible changes
+	--major           migrate a Go library to its next major module path version; not valid with --all or --version
 
 # Publish client libraries
 
@@ -334,6 +386,209 @@ Flags:
 	--release-commit string  the release commit to tag; default finds latest release commit
 	--create-release-tag     whether to create a tag of the form release-{PR number}
 
+# Inspect the API models used by sidekick generators
+
+Usage:
+
+	librarian sidekick [command]
+
+# Write the cross-referenced API model as JSON or YAML
+
+Usage:
+
+	librarian sidekick dump [library] [flags]
+
+dump parses an API into the sidekick model, runs the same cross-reference,
+resource identification, skip and documentation passes used by the code
+generators, and writes the result.
+
+Back-pointers in the model are replaced by element IDs, so the output is
+stable and can be checked in as golden data.
+
+If [library] is given, the model configuration is derived from librarian.yaml
+the same way as "librarian generate", and --api selects the API of a library
+with more than one. Otherwise the API is described by the
+--specification-* flags and the sources configured in librarian.yaml.
+
+Examples:
+
+	librarian sidekick dump google-cloud-secretmanager-v1
+	librarian sidekick dump google-cloud-secretmanager-v1 --format yaml \
+	    --filter .google.cloud.secretmanager.v1.Secret
+	librarian sidekick dump --specification-source google/cloud/secretmanager/v1 \
+	    --service-config google/cloud/secretmanager/v1/secretmanager_v1.yaml
+
+Flags:
+
+	--format string                      output format, one of json or yaml (default: "json")
+	--filter string [ --filter string ]  only include elements with these IDs, and their children
+	--out string                         output file, defaults to stdout
+	--api string                         path of the API to use from a library with more than one API
+	--specification-format string        format of the ad-hoc API specification (default: "protobuf")
+	--specification-source string        path of the ad-hoc API specification
+	--service-config string              path of the ad-hoc API service config
+
+# Check an API for violations of the API Improvement Proposals
+
+Usage:
+
+	librarian sidekick lint [library] [flags]
+
+lint parses an API into the sidekick model and reports violations of the
+API Improvement Proposals (https://google.aip.dev), such as list methods
+without pagination, update methods without an update_mask, references to
+unknown resource types, and long-running operations without a metadata type.
+
+Each finding includes the AIP number, the ID of the method, message, or field,
+and its location in the source specification when known. The command exits
+with an error if there are any findings.
+
+If [library] is given, the model configuration is derived from librarian.yaml
+the same way as "librarian generate", and --api selects the API of a library
+with more than one. Otherwise the API is described by the
+--specification-* flags and the sources configured in librarian.yaml.
+
+Examples:
+
+	librarian sidekick lint google-cloud-secretmanager-v1
+	librarian sidekick lint google-cloud-secretmanager-v1 --format text
+	librarian sidekick lint --specification-source google/cloud/secretmanager/v1 \
+	    --service-config google/cloud/secretmanager/v1/secretmanager_v1.yaml
+
+Flags:
+
+	--format string                output format, one of json or text (default: "json")
+	--out string                   output file, defaults to stdout
+	--api string                   path of the API to use from a library with more than one API
+	--specification-format string  format of the ad-hoc API specification (default: "protobuf")
+	--specification-source string  path of the ad-hoc API specification
+	--service-config string        path of the ad-hoc API service config
+
+# Generate a language-neutral API reference
+
+Usage:
+
+	librarian sidekick docs [library] [flags]
+
+docs parses an API into the sidekick model and generates a Markdown reference,
+with a page for each service, method, message and enum. The pages include the
+HTTP bindings, resource patterns, field behaviors and deprecation markers, and
+the cross-reference links in the documentation point to the corresponding
+pages.
+
+The output directory includes a docs.metadata.json file. Use --archive to
+package the output as a tarball suitable for uploading.
+
+If [library] is given, the model configuration is derived from librarian.yaml
+the same way as "librarian generate", and --api selects the API of a library
+with more than one. Otherwise the API is described by the
+--specification-* flags and the sources configured in librarian.yaml.
+
+Examples:
+
+	librarian sidekick docs google-cloud-secretmanager-v1 --output docs/secretmanager
+	librarian sidekick docs google-cloud-secretmanager-v1 --output docs/secretmanager \
+	    --version 1.2.3 --archive secretmanager-docs.tar.gz
+
+Flags:
+
+	--output string                directory for the generated reference
+	--version string               version of the docset, defaults to the API version
+	--archive string               path of a tarball to package the output, if any
+	--api string                   path of the API to use from a library with more than one API
+	--specification-format string  format of the ad-hoc API specification (default: "protobuf")
+	--specification-source string  path of the ad-hoc API specification
+	--service-config string        path of the ad-hoc API service config
+
+# Convert an API into a .proto file and a service config
+
+Usage:
+
+	librarian sidekick proto [library] [flags]
+
+proto parses an API into the sidekick model and writes it as a .proto file,
+with the HTTP, field behavior, resource and long-running operation
+annotations, plus a service config listing the services. This is useful to
+onboard APIs described by OpenAPI or Discovery documents into tools that
+consume Protobuf specifications.
+
+The files are written in the directory matching the package name, such as
+google/cloud/compute/v1/compute.proto. OpenAPI and Discovery documents do not
+have field numbers; use --field-numbers hash to derive the numbers from the
+field names, so they are stable across revisions of the API.
+
+If [library] is given, the model configuration is derived from librarian.yaml
+the same way as "librarian generate", and --api selects the API of a library
+with more than one. Otherwise the API is described by the
+--specification-* flags and the sources configured in librarian.yaml.
+
+Examples:
+
+	librarian sidekick proto --specification-format discovery \
+	    --specification-source discoveries/compute.v1.json \
+	    --service-config google/cloud/compute/v1/compute_v1.yaml --output protos
+
+Flags:
+
+	--output string                directory for the generated files
+	--field-numbers string         how to assign field numbers, one of sequential or hash (default: "sequential")
+	--api string                   path of the API to use from a library with more than one API
+	--specification-format string  format of the ad-hoc API specification (default: "protobuf")
+	--specification-source string  path of the ad-hoc API specification
+	--service-config string        path of the ad-hoc API service config
+
+# Inspect the template overlays used by a library
+
+Usage:
+
+	librarian templates diff [library]
+
+# Compare a library's template overlay with the built-in templates
+
+Usage:
+
+	librarian templates diff [library]
+
+diff prints a unified diff for each template in the library's
+template_overlay that shadows a built-in template, and lists the templates
+that only exist in the overlay.
+
+Run diff after upgrading librarian to find the changes in the built-in
+templates that need to be merged into the overlay.
+
+Only the dart, rust and swift languages generate code from templates. For
+rust, the built-in templates include the prost and tonic templates.
+
+# Run the Protobuf JSON conformance tests against the generated messages
+
+Usage:
+
+	librarian conformance [flags]
+
+conformance generates the Protobuf conformance test messages with the codec
+for the language in librarian.yaml, builds a testee program around them, and
+runs the upstream conformance_test_runner against the testee.
+
+The conformance source in librarian.yaml selects the protobuf repository with
+the test messages. Only the JSON tests are exercised, the testee skips all
+other formats.
+
+The failures are compared against the expected failures list, which uses the
+same format as the upstream failure lists: one test name per line, with '#'
+starting a comment. The command fails if a test fails and is not listed, or if
+a listed test passes.
+
+Examples:
+
+	librarian conformance
+	librarian conformance --runner ~/protobuf/bazel-bin/conformance/conformance_test_runner
+
+Flags:
+
+	--runner string             path to the upstream conformance test runner (default: "conformance_test_runner")
+	--expected-failures string  list of tests known to fail, defaults to conformance/failure_list_<language>.txt
+	--output string             directory for the generated testee, defaults to a temporary directory
+
 # Print the binary version
 
 Usage:
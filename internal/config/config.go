@@ -89,7 +89,8 @@ type Tool struct {
 
 // Sources references external source repositories.
 type Sources struct {
-	// Conformance is the path to the `conformance-tests` repository, used as include directory for `protoc`.
+	// Conformance is the path to the `conformance-tests` repository, used as include directory for `protoc`
+	// and by `librarian conformance` to generate the conformance test messages.
 	Conformance *Source `yaml:"conformance,omitempty"`
 
 	// Discovery is the discovery-artifact-manager repository configuration.
@@ -267,6 +268,11 @@ type Library struct {
 	// Keep lists files and directories to preserve during regeneration.
 	Keep []string `yaml:"keep,omitempty"`
 
+	// Mixins controls which mixin methods, such as those from
+	// google.cloud.location.Locations, are added to the generated services.
+	// By default all the mixins listed in the service config are included.
+	Mixins *Mixins `yaml:"mixins,omitempty"`
+
 	// Output is the directory where code is written. This overrides
 	// Default.Output.
 	Output string `yaml:"output,omitempty"`
@@ -284,6 +290,13 @@ type Library struct {
 	// are "protobuf" (default) or "discovery".
 	SpecificationFormat string `yaml:"specification_format,omitempty"`
 
+	// TemplateOverlay is a directory, relative to the repository root, with
+	// Mustache templates that shadow the built-in templates of the generator.
+	// Templates that only exist in the overlay are generated too. Use
+	// `librarian templates diff` to compare the overlay with the built-in
+	// templates.
+	TemplateOverlay string `yaml:"template_overlay,omitempty"`
+
 	// Language-specific fields are below.
 
 	// Dotnet contains .NET-specific library configuration.
@@ -292,6 +305,9 @@ type Library struct {
 	// Dart contains Dart-specific library configuration.
 	Dart *DartPackage `yaml:"dart,omitempty"`
 
+	// Gcloud contains gcloud-specific library configuration.
+	Gcloud *GcloudSurface `yaml:"gcloud,omitempty"`
+
 	// Go contains Go-specific library configuration.
 	Go *GoModule `yaml:"go,omitempty"`
 
@@ -317,3 +333,17 @@ type API struct {
 	// libraries).
 	Path string `yaml:"path,omitempty"`
 }
+
+// Mixins selects the mixin methods added to the generated services.
+//
+// Entries are mixin service names, such as "google.iam.v1.IAMPolicy", or
+// mixin method names, such as "google.cloud.location.Locations.GetLocation".
+type Mixins struct {
+	// Allow lists the mixin services or methods to include. If empty, all the
+	// mixins in the service config are included.
+	Allow []string `yaml:"allow,omitempty"`
+
+	// Deny lists the mixin services or methods to exclude. It takes precedence
+	// over Allow.
+	Deny []string `yaml:"deny,omitempty"`
+}
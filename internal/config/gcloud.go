@@ -0,0 +1,38 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// GcloudSurface contains gcloud-specific library configuration.
+type GcloudSurface struct {
+	// Config is the path of a gcloud.yaml file with the overrides for the
+	// generated surface, such as help text, output formatting and release
+	// tracks. If empty, the generator uses the gcloud.yaml file in the
+	// library output directory, if any.
+	Config string `yaml:"config,omitempty"`
+
+	// IncludeList is a subset of proto files under each API path to include
+	// (e.g., ["service.proto", "resources.proto"]). If empty, all the proto
+	// files in the API directory are included.
+	IncludeList []string `yaml:"include_list,omitempty"`
+
+	// DescriptorFiles is a list of paths to FileDescriptorSet files. If set,
+	// the API model is loaded from these files instead of running protoc.
+	DescriptorFiles []string `yaml:"descriptor_files,omitempty"`
+
+	// DescriptorFilesToGenerate is the list of files in DescriptorFiles to
+	// generate (e.g., ["google/cloud/parallelstore/v1/parallelstore.proto"]).
+	// If empty, the proto files selected from each API path are used.
+	DescriptorFilesToGenerate []string `yaml:"descriptor_files_to_generate,omitempty"`
+}
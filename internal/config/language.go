@@ -152,9 +152,9 @@ type RustModule struct {
 	// IncludeList is a list of proto files to include (e.g., "date.proto", "expr.proto").
 	IncludeList yaml.StringSlice `yaml:"include_list,omitempty"`
 
-	// IncludeStreamingMethods indicates whether to include gRPC streaming
-	// methods.
-	IncludeStreamingMethods bool `yaml:"include_streaming_methods,omitempty"`
+	// IncludeStreamingMethods indicates whether to include streaming
+	// methods. Defaults to true.
+	IncludeStreamingMethods *bool `yaml:"include_streaming_methods,omitempty"`
 
 	// InternalBuilders indicates whether generated builders should be internal to the crate.
 	InternalBuilders bool `yaml:"internal_builders,omitempty"`
@@ -251,9 +251,9 @@ type RustCrate struct {
 	// IncludeGrpcOnlyMethods indicates whether to include gRPC-only methods.
 	IncludeGrpcOnlyMethods bool `yaml:"include_grpc_only_methods,omitempty"`
 
-	// IncludeStreamingMethods indicates whether to include gRPC streaming
-	// methods.
-	IncludeStreamingMethods bool `yaml:"include_streaming_methods,omitempty"`
+	// IncludeStreamingMethods indicates whether to include streaming
+	// methods. Defaults to true.
+	IncludeStreamingMethods *bool `yaml:"include_streaming_methods,omitempty"`
 
 	// PostProcessProtos indicates whether to post-process protos.
 	PostProcessProtos string `yaml:"post_process_protos,omitempty"`
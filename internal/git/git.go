@@ -70,6 +70,16 @@ func Tag(ctx context.Context, gitExe, tagName, revision string) error {
 	return nil
 }
 
+// TagExists reports whether a tag with the given name exists in the local
+// repository.
+func TagExists(ctx context.Context, gitExe, tagName string) (bool, error) {
+	output, err := command.Output(ctx, gitExe, "tag", "--list", tagName)
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(output) != "", nil
+}
+
 // GetCommitHash returns the commit hash pointed at by the given revision,
 // which could be a tag name, a branch name, a relative revision (e.g. "HEAD~").
 func GetCommitHash(ctx context.Context, gitExe, revision string) (string, error) {
@@ -185,3 +195,24 @@ func GetCommitSubject(ctx context.Context, gitExe, revision string) (string, err
 	}
 	return strings.TrimSuffix(output, "\n"), nil
 }
+
+// AddWorktree checks out the given revision into a new detached worktree at
+// dir. The worktree must be removed with [RemoveWorktree] once it is no
+// longer needed.
+func AddWorktree(ctx context.Context, gitExe, dir, revision string) error {
+	_, err := command.Output(ctx, gitExe, "worktree", "add", "--detach", dir, revision)
+	if err != nil {
+		return fmt.Errorf("failed to add worktree for revision %s: %w", revision, err)
+	}
+	return nil
+}
+
+// RemoveWorktree removes the worktree at dir, discarding any changes made
+// within it.
+func RemoveWorktree(ctx context.Context, gitExe, dir string) error {
+	_, err := command.Output(ctx, gitExe, "worktree", "remove", "--force", dir)
+	if err != nil {
+		return fmt.Errorf("failed to remove worktree %s: %w", dir, err)
+	}
+	return nil
+}
@@ -477,6 +477,40 @@ func TestTag(t *testing.T) {
 	}
 }
 
+func TestTagExists(t *testing.T) {
+	testhelper.RequireCommand(t, command.Git)
+	testhelper.SetupRepo(t)
+	if err := Tag(t.Context(), command.Git, "test-tag", "HEAD"); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		name    string
+		tagName string
+		want    bool
+	}{
+		{
+			name:    "existing tag",
+			tagName: "test-tag",
+			want:    true,
+		},
+		{
+			name:    "missing tag",
+			tagName: "missing-tag",
+			want:    false,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := TagExists(t.Context(), command.Git, test.tagName)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("TagExists(%q) = %v, want %v", test.tagName, got, test.want)
+			}
+		})
+	}
+}
+
 func TestTag_Error(t *testing.T) {
 	testhelper.RequireCommand(t, command.Git)
 	for _, test := range []struct {
@@ -604,3 +638,45 @@ func TestGetCommitSubject_Error(t *testing.T) {
 		t.Fatal("wanted an error; got none")
 	}
 }
+
+func TestAddWorktree(t *testing.T) {
+	testhelper.RequireCommand(t, command.Git)
+	opts := testhelper.SetupOptions{
+		WithChanges: []string{testhelper.ReadmeFile},
+	}
+	testhelper.Setup(t, opts)
+	dir := path.Join(t.TempDir(), "worktree")
+	if err := AddWorktree(t.Context(), command.Git, dir, "HEAD~"); err != nil {
+		t.Fatal(err)
+	}
+	readmeContent, err := os.ReadFile(path.Join(dir, testhelper.ReadmeFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(testhelper.ReadmeContents, string(readmeContent)); diff != "" {
+		t.Errorf("mismatch of readme content in worktree (-want, +got):\n%s", diff)
+	}
+	if err := RemoveWorktree(t.Context(), command.Git, dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(dir); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("worktree %s still exists after RemoveWorktree(), err = %v", dir, err)
+	}
+}
+
+func TestAddWorktree_Error(t *testing.T) {
+	testhelper.RequireCommand(t, command.Git)
+	testhelper.SetupRepo(t)
+	dir := path.Join(t.TempDir(), "worktree")
+	if err := AddWorktree(t.Context(), command.Git, dir, "invalid-revision"); err == nil {
+		t.Errorf("expected error when adding a worktree for a non-existent revision, but did not get one")
+	}
+}
+
+func TestRemoveWorktree_Error(t *testing.T) {
+	testhelper.RequireCommand(t, command.Git)
+	testhelper.SetupRepo(t)
+	if err := RemoveWorktree(t.Context(), command.Git, t.TempDir()); err == nil {
+		t.Errorf("expected error when removing a directory that is not a worktree, but did not get one")
+	}
+}
@@ -18,15 +18,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/googleapis/librarian/internal/command"
 	"github.com/googleapis/librarian/internal/config"
 	"github.com/googleapis/librarian/internal/git"
+	"github.com/googleapis/librarian/internal/librarian/dart"
 	"github.com/googleapis/librarian/internal/librarian/golang"
 	"github.com/googleapis/librarian/internal/librarian/python"
 	"github.com/googleapis/librarian/internal/librarian/rust"
+	"github.com/googleapis/librarian/internal/librarian/swift"
 	"github.com/googleapis/librarian/internal/semver"
 	"github.com/googleapis/librarian/internal/yaml"
 	"github.com/urfave/cli/v3"
@@ -37,8 +42,12 @@ const (
 )
 
 var (
-	errBothVersionAndAllFlag = errors.New("cannot specify both --version and --all")
-	errReleaseCommitNotFound = errors.New("no release commit found")
+	errBothVersionAndAllFlag     = errors.New("cannot specify both --version and --all")
+	errBothMajorAndAllFlag       = errors.New("cannot specify both --major and --all")
+	errBothMajorAndVersion       = errors.New("cannot specify both --major and --version")
+	errMajorNotSupported         = errors.New("--major is only supported for Go libraries")
+	errAllowBreakingNotSupported = errors.New("--allow-breaking is only supported for Go libraries")
+	errReleaseCommitNotFound     = errors.New("no release commit found")
 	// languageVersioningOptions contains language-specific SemVer versioning
 	// options. Over time, languages should align on versioning semantics and
 	// this should be removed. If a language does not have specific needs, a
@@ -69,9 +78,25 @@ If a library name is given, only that library is updated. The --all flag updates
 library in the workspace. When a library is specified explicitly, the --version flag can
 be used to override the new version.
 
+For Go libraries, the exported API of each module is compared against its last release
+tag. Any API change requires at least a minor version bump: the derived version is raised
+to the next minor version, while a --version that is not a minor bump is rejected.
+Incompatible changes to a stable (v1+) module are rejected unless the library is migrating
+to a new major version through module_path_version. The check is skipped if the release
+tag or the module at that tag does not exist, or if --allow-breaking is set.
+
+The --major flag migrates a Go library to the next major version of its module path,
+e.g. from cloud.google.com/go/foo to cloud.google.com/go/foo/v2. It updates go.mod, the
+imports of the module's packages, the import paths in librarian.yaml, the snippets module
+and go.work, and sets the new version to N.0.0. Other references to the old module path,
+such as in README files or snippet metadata, are not rewritten; regenerate the library
+and update handwritten files afterwards. As a new major version may change the API in any
+way, the API compatibility check is skipped.
+
 Examples:
 
 	librarian bump <library>           # update version for one library
+	librarian bump --major <library>   # migrate a Go library to its next major version
 	librarian bump --all               # update versions for all libraries`,
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
@@ -82,11 +107,21 @@ Examples:
 				Name:  "version",
 				Usage: "specific version to update to; not valid with --all",
 			},
+			&cli.BoolFlag{
+				Name:  "allow-breaking",
+				Usage: "skip the Go API compatibility check, allowing incompatible changes",
+			},
+			&cli.BoolFlag{
+				Name:  "major",
+				Usage: "migrate a Go library to its next major module path version; not valid with --all or --version",
+			},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			all := cmd.Bool("all")
 			libraryName := cmd.Args().First()
 			versionOverride := cmd.String("version")
+			allowBreaking := cmd.Bool("allow-breaking")
+			major := cmd.Bool("major")
 			if !all && libraryName == "" {
 				return errMissingLibraryOrAllFlag
 			}
@@ -96,18 +131,32 @@ Examples:
 			if all && versionOverride != "" {
 				return errBothVersionAndAllFlag
 			}
+			if all && major {
+				return errBothMajorAndAllFlag
+			}
+			if major && versionOverride != "" {
+				return errBothMajorAndVersion
+			}
 			cfg, err := yaml.Read[config.Config](config.LibrarianYAML)
 			if err != nil {
 				return err
 			}
-			return runBump(ctx, cfg, all, libraryName, versionOverride)
+			return runBump(ctx, cfg, all, libraryName, versionOverride, allowBreaking, major)
 		},
 	}
 }
 
 // runBump performs the actual work of the bump command, after all the command
 // lines arguments have been validated and the configuration loaded.
-func runBump(ctx context.Context, cfg *config.Config, all bool, libraryName, versionOverride string) error {
+func runBump(ctx context.Context, cfg *config.Config, all bool, libraryName, versionOverride string, allowBreaking, major bool) error {
+	if cfg.Language != config.LanguageGo {
+		if major {
+			return fmt.Errorf("%w: %q", errMajorNotSupported, cfg.Language)
+		}
+		if allowBreaking {
+			return fmt.Errorf("%w: %q", errAllowBreakingNotSupported, cfg.Language)
+		}
+	}
 	var preinstalled map[string]string
 	if cfg.Release != nil {
 		preinstalled = cfg.Release.Preinstalled
@@ -131,7 +180,7 @@ func runBump(ctx context.Context, cfg *config.Config, all bool, libraryName, ver
 	}
 
 	for _, lib := range librariesToBump {
-		if err := bumpLibrary(cfg, lib, versionOverride); err != nil {
+		if err := bumpLibrary(ctx, cfg, lib, gitExe, versionOverride, allowBreaking, major); err != nil {
 			return err
 		}
 	}
@@ -210,28 +259,121 @@ func hasChangesIn(dir, exclusion string, filesChanged []string) bool {
 
 // bumpLibrary determines the next version of a library (using versionOverride
 // if that is non-empty), and applies the language-specific version bump logic
-// to update manifests, version files etc.
-func bumpLibrary(cfg *config.Config, lib *config.Library, versionOverride string) error {
-	opts := languageVersioningOptions[cfg.Language]
-	version, err := deriveNextVersion(lib, opts, versionOverride)
-	if err != nil {
-		return err
-	}
+// to update manifests, version files etc. For Go libraries, the API changes
+// since the last release are checked against the next version first unless
+// allowBreaking is set; see [golang.CheckAPICompatibility]. If major is set,
+// the library is instead migrated to its next major version; see
+// [golang.MigrateMajorVersion].
+func bumpLibrary(ctx context.Context, cfg *config.Config, lib *config.Library, gitExe, versionOverride string, allowBreaking, major bool) error {
 	output := libraryOutput(cfg.Language, lib, cfg.Default)
+	var (
+		version string
+		err     error
+	)
+	if major {
+		if cfg.Language != config.LanguageGo {
+			return fmt.Errorf("%w: %q", errMajorNotSupported, cfg.Language)
+		}
+		// A new major version may change the API in any way, so there is
+		// nothing to check before migrating.
+		version, err = golang.MigrateMajorVersion(ctx, lib, output)
+		if err != nil {
+			return err
+		}
+	} else {
+		version, err = deriveNextVersion(lib, languageVersioningOptions[cfg.Language], versionOverride)
+		if err != nil {
+			return err
+		}
+		version, err = checkGoAPICompatibility(ctx, cfg, lib, gitExe, output, version, versionOverride != "", allowBreaking)
+		if err != nil {
+			return err
+		}
+	}
 	lib.Version = version
 
 	switch cfg.Language {
+	case config.LanguageDart:
+		return dart.Bump(output, version)
 	case config.LanguageFake:
 		return fakeBumpLibrary(output, version)
 	case config.LanguageGo:
 		return golang.Bump(lib, output, version)
 	case config.LanguagePython:
 		return python.Bump(output, version)
+	case config.LanguageSwift:
+		return swift.Bump(output, version)
 	default:
 		return fmt.Errorf("%q does not support bump", cfg.Language)
 	}
 }
 
+// checkGoAPICompatibility checks the API changes of a Go library since its
+// last release against version, and returns the version to release. API
+// changes that need a minor version bump raise a derived version to the next
+// minor version, while an explicit version is rejected. Other languages,
+// libraries that have never been released and bumps with allowBreaking set
+// are not checked.
+func checkGoAPICompatibility(ctx context.Context, cfg *config.Config, lib *config.Library, gitExe, output, version string, explicit, allowBreaking bool) (string, error) {
+	if cfg.Language != config.LanguageGo || lib.Version == "" || allowBreaking {
+		return version, nil
+	}
+	changes, err := goAPIChanges(ctx, cfg, lib, gitExe, output)
+	if err != nil {
+		return "", err
+	}
+	if changes == nil {
+		return version, nil
+	}
+	err = golang.CheckAPICompatibility(lib, changes, version)
+	if explicit || !errors.Is(err, golang.ErrMinorBumpRequired) {
+		return version, err
+	}
+	minor, err := semver.DeriveNext(semver.Minor, lib.Version, semver.DeriveNextOptions{BumpVersionCore: true})
+	if err != nil {
+		return "", err
+	}
+	slog.Info("API changes require a minor version bump", "library", lib.Name, "version", minor)
+	return minor, golang.CheckAPICompatibility(lib, changes, minor)
+}
+
+// goAPIChanges compares the exported API of the Go module in output at the
+// library's last release tag with the current working tree. It returns nil
+// changes if there is nothing to compare against, because the release tag or
+// the module at that tag does not exist.
+func goAPIChanges(ctx context.Context, cfg *config.Config, lib *config.Library, gitExe, output string) (changes *golang.APIChanges, err error) {
+	tagName := formatTagName(cfg.Default.TagFormat, lib)
+	exists, err := git.TagExists(ctx, gitExe, tagName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		slog.Warn("release tag not found, skipping API compatibility check", "library", lib.Name, "tag", tagName)
+		return nil, nil
+	}
+	tmpDir, err := os.MkdirTemp("", "librarian-bump-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+	worktree := filepath.Join(tmpDir, "worktree")
+	if err := git.AddWorktree(ctx, gitExe, worktree, tagName); err != nil {
+		return nil, fmt.Errorf("error checking out tag %s (from library %s version %s): %w", tagName, lib.Name, lib.Version, err)
+	}
+	defer func() {
+		err = errors.Join(err, git.RemoveWorktree(context.WithoutCancel(ctx), gitExe, worktree))
+	}()
+	previous := filepath.Join(worktree, output)
+	if _, err := os.Stat(filepath.Join(previous, "go.mod")); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			slog.Warn("module not found at release tag, skipping API compatibility check", "library", lib.Name, "tag", tagName)
+			return nil, nil
+		}
+		return nil, err
+	}
+	return golang.CompareAPI(ctx, previous, output)
+}
+
 // postBump performs post version bump cleanup and maintenance tasks after libraries have been processed.
 func postBump(ctx context.Context, cfg *config.Config) error {
 	switch cfg.Language {
@@ -24,8 +24,10 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/command"
 	"github.com/googleapis/librarian/internal/config"
 	"github.com/googleapis/librarian/internal/git"
+	"github.com/googleapis/librarian/internal/librarian/golang"
 	"github.com/googleapis/librarian/internal/sample"
 	"github.com/googleapis/librarian/internal/semver"
 	"github.com/googleapis/librarian/internal/testhelper"
@@ -171,6 +173,38 @@ func TestBumpCommand_Error(t *testing.T) {
 			args:    []string{"librarian", "bump", "--version=1.2.3", "--all"},
 			wantErr: errBothVersionAndAllFlag,
 		},
+		{
+			name:    "major flag and all flag",
+			args:    []string{"librarian", "bump", "--major", "--all"},
+			wantErr: errBothMajorAndAllFlag,
+		},
+		{
+			name:    "major flag and version flag",
+			args:    []string{"librarian", "bump", "--major", "--version=2.0.0", "foo"},
+			wantErr: errBothMajorAndVersion,
+		},
+		{
+			name:    "major flag for non-Go library",
+			args:    []string{"librarian", "bump", "--major", sample.Lib1Name},
+			cfg:     sample.Config(),
+			wantErr: errMajorNotSupported,
+		},
+		{
+			name: "major flag for Rust library",
+			args: []string{"librarian", "bump", "--major", sample.Lib1Name},
+			cfg: func() *config.Config {
+				c := sample.Config()
+				c.Language = config.LanguageRust
+				return c
+			}(),
+			wantErr: errMajorNotSupported,
+		},
+		{
+			name:    "allow-breaking flag for non-Go library",
+			args:    []string{"librarian", "bump", "--allow-breaking", sample.Lib1Name},
+			cfg:     sample.Config(),
+			wantErr: errAllowBreakingNotSupported,
+		},
 		{
 			name:    "missing librarian yaml file",
 			args:    []string{"librarian", "bump", "--all"},
@@ -286,7 +320,7 @@ func TestRunBump_Error(t *testing.T) {
 			}
 			testhelper.Setup(t, opts)
 
-			gotErr := runBump(t.Context(), cfg, false, test.libraryName, test.versionOverride)
+			gotErr := runBump(t.Context(), cfg, false, test.libraryName, test.versionOverride, false, false)
 			if !errors.Is(gotErr, test.wantErr) {
 				t.Errorf("runBump() error = %v, wantErr %v", gotErr, test.wantErr)
 			}
@@ -329,7 +363,7 @@ func TestBumpLibrary(t *testing.T) {
 			testhelper.Setup(t, opts)
 
 			targetLibCfg := test.cfg.Libraries[0]
-			err := bumpLibrary(test.cfg, targetLibCfg, test.versionOverride)
+			err := bumpLibrary(t.Context(), test.cfg, targetLibCfg, command.Git, test.versionOverride, false, false)
 			if err != nil {
 				t.Fatalf("bumpLibrary() error = %v", err)
 			}
@@ -356,6 +390,7 @@ func TestBumpLibrary_Error(t *testing.T) {
 		name            string
 		cfg             *config.Config
 		versionOverride string
+		major           bool
 		wantErr         error
 	}{
 		{
@@ -364,6 +399,12 @@ func TestBumpLibrary_Error(t *testing.T) {
 			versionOverride: "0.9.0",
 			wantErr:         semver.ErrInvalidNextVersion,
 		},
+		{
+			name:    "major migration of non-Go library",
+			cfg:     sample.Config(),
+			major:   true,
+			wantErr: errMajorNotSupported,
+		},
 		{
 			name: "unsupported language",
 			cfg: func() *config.Config {
@@ -385,7 +426,7 @@ func TestBumpLibrary_Error(t *testing.T) {
 			testhelper.Setup(t, opts)
 
 			targetLibCfg := test.cfg.Libraries[0]
-			gotErr := bumpLibrary(test.cfg, targetLibCfg, test.versionOverride)
+			gotErr := bumpLibrary(t.Context(), test.cfg, targetLibCfg, command.Git, test.versionOverride, false, test.major)
 			if gotErr == nil {
 				t.Fatal("expected error; got nil")
 			}
@@ -396,6 +437,244 @@ func TestBumpLibrary_Error(t *testing.T) {
 	}
 }
 
+func TestBumpLibrary_GoAPICompatibility(t *testing.T) {
+	testhelper.RequireCommand(t, command.Git)
+	testhelper.RequireCommand(t, command.Go)
+	const (
+		initialAPI    = "package foo\n\nfunc Foo() {}\n\nfunc Bar() {}\n"
+		additionAPI   = "package foo\n\nfunc Foo() {}\n\nfunc Bar() {}\n\nfunc Baz() {}\n"
+		removalAPI    = "package foo\n\nfunc Foo() {}\n"
+		unchangedAPI  = initialAPI
+		libraryOutput = "foo"
+	)
+	for _, test := range []struct {
+		name            string
+		version         string
+		newAPI          string
+		goModule        *config.GoModule
+		versionOverride string
+		allowBreaking   bool
+		wantVersion     string
+		wantErr         error
+	}{
+		{
+			name:            "unchanged API allows patch release",
+			version:         "1.2.3",
+			newAPI:          unchangedAPI,
+			versionOverride: "1.2.4",
+			wantVersion:     "1.2.4",
+		},
+		{
+			name:        "compatible addition",
+			version:     "1.2.3",
+			newAPI:      additionAPI,
+			wantVersion: "1.3.0",
+		},
+		{
+			name:        "compatible addition in prerelease",
+			version:     "1.2.0-rc.1",
+			newAPI:      additionAPI,
+			wantVersion: "1.3.0-rc.1",
+		},
+		{
+			name:        "unchanged API in prerelease",
+			version:     "1.2.0-rc.1",
+			newAPI:      unchangedAPI,
+			wantVersion: "1.2.0-rc.2",
+		},
+		{
+			name:            "compatible addition in patch release",
+			version:         "1.2.3",
+			newAPI:          additionAPI,
+			versionOverride: "1.2.4",
+			wantErr:         golang.ErrMinorBumpRequired,
+		},
+		{
+			name:    "incompatible removal",
+			version: "1.2.3",
+			newAPI:  removalAPI,
+			wantErr: golang.ErrIncompatibleAPIChange,
+		},
+		{
+			name:          "incompatible removal allowed",
+			version:       "1.2.3",
+			newAPI:        removalAPI,
+			allowBreaking: true,
+			wantVersion:   "1.3.0",
+		},
+		{
+			name:            "incompatible removal in patch release allowed",
+			version:         "1.2.3",
+			newAPI:          removalAPI,
+			versionOverride: "1.2.4",
+			allowBreaking:   true,
+			wantVersion:     "1.2.4",
+		},
+		{
+			name:            "incompatible removal in major migration",
+			version:         "1.2.3",
+			newAPI:          removalAPI,
+			goModule:        &config.GoModule{ModulePathVersion: "v2"},
+			versionOverride: "2.0.0",
+			wantVersion:     "2.0.0",
+		},
+		{
+			name:        "incompatible removal in preview module",
+			version:     "0.2.3",
+			newAPI:      removalAPI,
+			wantVersion: "0.3.0",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			testhelper.ContinueInNewGitRepository(t, t.TempDir())
+			writeGoModule(t, libraryOutput, initialAPI)
+			testhelper.RunGit(t, "add", ".")
+			testhelper.RunGit(t, "commit", "-m", "initial version")
+			testhelper.RunGit(t, "tag", fmt.Sprintf("foo/v%s", test.version))
+			writeGoModule(t, libraryOutput, test.newAPI)
+			testhelper.RunGit(t, "commit", "--allow-empty", "-m", "feat: change API", ".")
+
+			cfg := &config.Config{
+				Language: config.LanguageGo,
+				Default:  &config.Default{TagFormat: "{name}/v{version}"},
+				Libraries: []*config.Library{
+					{Name: "foo", Version: test.version, Output: libraryOutput, Go: test.goModule},
+				},
+			}
+			lib := cfg.Libraries[0]
+			err := bumpLibrary(t.Context(), cfg, lib, command.Git, test.versionOverride, test.allowBreaking, false)
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Fatalf("bumpLibrary() error = %v, wantErr %v", err, test.wantErr)
+				}
+				if lib.Version != test.version {
+					t.Errorf("library version changed on error: want %q, got %q", test.version, lib.Version)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("bumpLibrary() error = %v", err)
+			}
+			if lib.Version != test.wantVersion {
+				t.Errorf("library %q version mismatch: want %q, got %q", lib.Name, test.wantVersion, lib.Version)
+			}
+		})
+	}
+}
+
+func TestBumpLibrary_GoAPICompatibilitySkipped(t *testing.T) {
+	testhelper.RequireCommand(t, command.Git)
+	for _, test := range []struct {
+		name string
+		tag  string
+	}{
+		{
+			name: "missing tag",
+		},
+		{
+			name: "module missing at tag",
+			tag:  "foo/v1.2.3",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			testhelper.ContinueInNewGitRepository(t, t.TempDir())
+			if err := os.WriteFile("README.md", []byte("# Test\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			testhelper.RunGit(t, "add", ".")
+			testhelper.RunGit(t, "commit", "-m", "initial commit")
+			if test.tag != "" {
+				testhelper.RunGit(t, "tag", test.tag)
+			}
+			writeGoModule(t, "foo", "package foo\n")
+			testhelper.RunGit(t, "add", ".")
+			testhelper.RunGit(t, "commit", "-m", "feat: add foo")
+			cfg := &config.Config{
+				Language: config.LanguageGo,
+				Default:  &config.Default{TagFormat: "{name}/v{version}"},
+				Libraries: []*config.Library{
+					{Name: "foo", Version: "1.2.3", Output: "foo"},
+				},
+			}
+			lib := cfg.Libraries[0]
+			if err := bumpLibrary(t.Context(), cfg, lib, command.Git, "1.2.4", false, false); err != nil {
+				t.Fatalf("bumpLibrary() error = %v", err)
+			}
+			if lib.Version != "1.2.4" {
+				t.Errorf("library version mismatch: want %q, got %q", "1.2.4", lib.Version)
+			}
+		})
+	}
+}
+
+func TestBumpLibrary_GoMajor(t *testing.T) {
+	testhelper.RequireCommand(t, command.Git)
+	testhelper.RequireCommand(t, command.Go)
+	testhelper.ContinueInNewGitRepository(t, t.TempDir())
+	files := map[string]string{
+		"foo/go.mod":          "module cloud.google.com/go/foo\n\ngo 1.21\n",
+		"foo/foo.go":          "package foo\n\nimport \"cloud.google.com/go/foo/bar\"\n\nfunc Foo() bar.Bar { return bar.Bar{} }\n\nfunc Old() {}\n",
+		"foo/bar/bar.go":      "package bar\n\ntype Bar struct{}\n",
+		"foo/internal/ver.go": "package internal\n\nconst Version = \"1.4.0\"\n",
+	}
+	for name, content := range files {
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	testhelper.RunGit(t, "add", ".")
+	testhelper.RunGit(t, "commit", "-m", "initial version")
+	testhelper.RunGit(t, "tag", "foo/v1.4.0")
+	// Removing Old is an incompatible change, which is allowed when
+	// migrating to a new major version.
+	if err := os.WriteFile("foo/foo.go", []byte("package foo\n\nimport \"cloud.google.com/go/foo/bar\"\n\nfunc Foo() bar.Bar { return bar.Bar{} }\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	testhelper.RunGit(t, "commit", "-m", "feat!: remove Old", ".")
+
+	cfg := &config.Config{
+		Language: config.LanguageGo,
+		Default:  &config.Default{TagFormat: "{name}/v{version}"},
+		Libraries: []*config.Library{
+			{Name: "foo", Version: "1.4.0", Output: "foo"},
+		},
+	}
+	lib := cfg.Libraries[0]
+	if err := bumpLibrary(t.Context(), cfg, lib, command.Git, "", false, true); err != nil {
+		t.Fatalf("bumpLibrary() error = %v", err)
+	}
+	if lib.Version != "2.0.0" {
+		t.Errorf("library version mismatch: want %q, got %q", "2.0.0", lib.Version)
+	}
+	if lib.Go == nil || lib.Go.ModulePathVersion != "v2" {
+		t.Errorf("library module path version mismatch: want %q, got %+v", "v2", lib.Go)
+	}
+	got, err := os.ReadFile("foo/foo.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(got), `import "cloud.google.com/go/foo/v2/bar"`) {
+		t.Errorf("foo.go imports were not migrated:\n%s", got)
+	}
+}
+
+// writeGoModule writes a single-file Go module named example.com/foo to dir.
+func writeGoModule(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/foo\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "foo.go"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestFindLibrariesToBump(t *testing.T) {
 	testhelper.RequireCommand(t, "git")
 	lib1Change := filepath.Join(sample.Lib1Output, "src", "lib.rs")
@@ -0,0 +1,119 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/librarian/conformance"
+	"github.com/googleapis/librarian/internal/sources"
+	"github.com/googleapis/librarian/internal/yaml"
+	"github.com/urfave/cli/v3"
+)
+
+var errConformanceFailures = errors.New("conformance failures do not match the expected failures")
+
+// conformanceCommand returns the CLI command for running the Protobuf JSON
+// conformance tests.
+func conformanceCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "conformance",
+		Usage:     "run the Protobuf JSON conformance tests against the generated messages",
+		UsageText: "librarian conformance [flags]",
+		Description: `conformance generates the Protobuf conformance test messages with the codec
+for the language in librarian.yaml, builds a testee program around them, and
+runs the upstream conformance_test_runner against the testee.
+
+The conformance source in librarian.yaml selects the protobuf repository with
+the test messages. Only the JSON tests are exercised, the testee skips all
+other formats.
+
+The failures are compared against the expected failures list, which uses the
+same format as the upstream failure lists: one test name per line, with '#'
+starting a comment. The command fails if a test fails and is not listed, or if
+a listed test passes.
+
+Examples:
+
+	librarian conformance
+	librarian conformance --runner ~/protobuf/bazel-bin/conformance/conformance_test_runner`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "runner",
+				Value: "conformance_test_runner",
+				Usage: "path to the upstream conformance test runner",
+			},
+			&cli.StringFlag{
+				Name:  "expected-failures",
+				Usage: "list of tests known to fail, defaults to conformance/failure_list_<language>.txt",
+			},
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "directory for the generated testee, defaults to a temporary directory",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return runConformance(ctx, cmd.Root().Writer, cmd.String("runner"), cmd.String("expected-failures"), cmd.String("output"))
+		},
+	}
+}
+
+func runConformance(ctx context.Context, w io.Writer, runner, expectedFailures, output string) error {
+	cfg, err := yaml.Read[config.Config](config.LibrarianYAML)
+	if err != nil {
+		return err
+	}
+	if cfg.Sources == nil || cfg.Sources.Conformance == nil {
+		return conformance.ErrMissingConformanceSource
+	}
+	dir, err := fetchSource(ctx, cfg.Sources.Conformance, protobufRepo)
+	if err != nil {
+		return err
+	}
+	if expectedFailures == "" {
+		expectedFailures = filepath.Join("conformance", fmt.Sprintf("failure_list_%s.txt", cfg.Language))
+	}
+	if output == "" {
+		tmp, err := os.MkdirTemp("", "librarian-conformance-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmp)
+		output = tmp
+	}
+	report, err := conformance.Run(ctx, &conformance.Options{
+		Language:         cfg.Language,
+		Sources:          &sources.Sources{Conformance: dir},
+		WorkDir:          output,
+		Runner:           runner,
+		ExpectedFailures: expectedFailures,
+	})
+	if err != nil {
+		return err
+	}
+	if err := report.Write(w); err != nil {
+		return err
+	}
+	if !report.OK() {
+		return errConformanceFailures
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package conformance runs the Protobuf JSON conformance tests against the
+// messages generated by the sidekick codecs.
+//
+// The conformance test messages are generated with the codec for the target
+// language, a small testee program is created from the embedded templates and
+// built with the language toolchain, and the upstream
+// `conformance_test_runner` exercises the testee. The failures reported by the
+// runner are compared against a checked-in list of expected failures.
+package conformance
+
+import (
+	"bufio"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/command"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/parser"
+	"github.com/googleapis/librarian/internal/sources"
+)
+
+//go:embed all:templates
+var templates embed.FS
+
+const (
+	// testMessagesDir is the directory with the conformance test messages,
+	// relative to the `src/` directory of the protobuf repository.
+	testMessagesDir = "google/protobuf"
+	// testMessagesProto is the file with the conformance test messages.
+	testMessagesProto = "test_messages_proto3.proto"
+	// failingTestsFile is written by the runner to its output directory.
+	failingTestsFile = "failing_tests.txt"
+)
+
+var (
+	// ErrMissingConformanceSource is returned when the conformance source is
+	// not configured.
+	ErrMissingConformanceSource = errors.New("must specify conformance source")
+	// ErrUnsupportedLanguage is returned for languages without a testee.
+	ErrUnsupportedLanguage = errors.New("conformance tests are not supported for language")
+)
+
+// Options configures a conformance run.
+type Options struct {
+	// Language selects the codec and the testee.
+	Language string
+	// Sources are the resolved source repositories. Sources.Conformance must
+	// be set.
+	Sources *sources.Sources
+	// WorkDir receives the generated messages, the testee, and the runner
+	// output.
+	WorkDir string
+	// Runner is the path to the upstream `conformance_test_runner`.
+	Runner string
+	// ExpectedFailures is the path to the list of tests known to fail. A
+	// missing file is treated as an empty list.
+	ExpectedFailures string
+}
+
+// testee generates and builds the program exercised by the runner.
+type testee struct {
+	// codec returns the codec options used to generate the test messages.
+	codec func() map[string]string
+	// generate writes the test messages into dir.
+	generate func(ctx context.Context, model *api.API, cfg *parser.ModelConfig, dir string) error
+	// build compiles the testee in dir and returns the path to the binary.
+	build func(ctx context.Context, dir string) (string, error)
+}
+
+var testees = map[string]*testee{
+	config.LanguageDart: dartTestee,
+	config.LanguageRust: rustTestee,
+}
+
+// Run generates the conformance test messages and the testee for
+// opts.Language, runs the conformance runner against the testee, and compares
+// the failures with the expected failures.
+func Run(ctx context.Context, opts *Options) (*Report, error) {
+	t, ok := testees[opts.Language]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedLanguage, opts.Language)
+	}
+	if opts.Sources == nil || opts.Sources.Conformance == "" {
+		return nil, ErrMissingConformanceSource
+	}
+	expected, err := readTestList(opts.ExpectedFailures)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	cfg := modelConfig(opts.Language, opts.Sources, t.codec())
+	model, err := parser.CreateModel(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := copyTemplates(opts.Language, opts.WorkDir); err != nil {
+		return nil, err
+	}
+	if err := t.generate(ctx, model, cfg, opts.WorkDir); err != nil {
+		return nil, err
+	}
+	binary, err := t.build(ctx, opts.WorkDir)
+	if err != nil {
+		return nil, err
+	}
+	failed, err := runRunner(ctx, opts.Runner, binary, filepath.Join(opts.WorkDir, "results"))
+	if err != nil {
+		return nil, err
+	}
+	return compare(expected, failed), nil
+}
+
+func modelConfig(language string, srcs *sources.Sources, codec map[string]string) *parser.ModelConfig {
+	return &parser.ModelConfig{
+		Language:            language,
+		SpecificationFormat: config.SpecProtobuf,
+		SpecificationSource: testMessagesDir,
+		Source: &sources.SourceConfig{
+			Sources:     &sources.Sources{ProtobufSrc: filepath.Join(srcs.Conformance, "src")},
+			ActiveRoots: []string{"protobuf-src"},
+			IncludeList: []string{testMessagesProto},
+		},
+		Codec: codec,
+	}
+}
+
+// copyTemplates copies the testee templates for language into dir.
+func copyTemplates(language, dir string) error {
+	root := path.Join("templates", language)
+	return fs.WalkDir(templates, root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, name)
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(dir, rel)
+		if d.IsDir() {
+			return os.MkdirAll(dst, 0755)
+		}
+		contents, err := templates.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(dst, contents, 0644)
+	})
+}
+
+// runRunner runs the conformance runner against binary and returns the
+// failing tests.
+//
+// The runner exits with an error when any test fails, the failures are
+// listed in its output directory.
+func runRunner(ctx context.Context, runner, binary, outDir string) ([]string, error) {
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return nil, err
+	}
+	runErr := command.Run(ctx, runner, "--output_dir", outDir, binary)
+	failed, err := readTestList(filepath.Join(outDir, failingTestsFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, runErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return failed, nil
+}
+
+// readTestList reads a list of test names in the format used by the upstream
+// failure lists: one test per line, with `#` starting a comment.
+func readTestList(name string) ([]string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseTestList(f)
+}
+
+func parseTestList(r io.Reader) ([]string, error) {
+	var tests []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line != "" {
+			tests = append(tests, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	slices.Sort(tests)
+	return slices.Compact(tests), nil
+}
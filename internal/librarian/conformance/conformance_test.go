@@ -0,0 +1,164 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conformance
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/sources"
+)
+
+func TestParseTestList(t *testing.T) {
+	input := `# Known failures.
+Required.Proto3.JsonInput.FieldNameDuplicate                 # fails
+Recommended.Proto3.JsonInput.FieldNameWithDoubleUnderscores
+
+Required.Proto3.JsonInput.FieldNameDuplicate
+`
+	got, err := parseTestList(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"Recommended.Proto3.JsonInput.FieldNameWithDoubleUnderscores",
+		"Required.Proto3.JsonInput.FieldNameDuplicate",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRun_Error(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		opts    *Options
+		wantErr error
+	}{
+		{
+			name:    "unsupported language",
+			opts:    &Options{Language: config.LanguageGo, Sources: &sources.Sources{Conformance: "protobuf"}},
+			wantErr: ErrUnsupportedLanguage,
+		},
+		{
+			name:    "missing sources",
+			opts:    &Options{Language: config.LanguageRust},
+			wantErr: ErrMissingConformanceSource,
+		},
+		{
+			name:    "missing conformance source",
+			opts:    &Options{Language: config.LanguageDart, Sources: &sources.Sources{}},
+			wantErr: ErrMissingConformanceSource,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Run(t.Context(), test.opts); !errors.Is(err, test.wantErr) {
+				t.Errorf("Run() error = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestCopyTemplates(t *testing.T) {
+	for _, test := range []struct {
+		language string
+		want     []string
+	}{
+		{
+			language: config.LanguageRust,
+			want: []string{
+				"Cargo.toml",
+				filepath.Join("testee", "Cargo.toml"),
+				filepath.Join("testee", "src", "main.rs"),
+			},
+		},
+		{
+			language: config.LanguageDart,
+			want: []string{
+				"pubspec.yaml",
+				filepath.Join("testee", "pubspec.yaml"),
+				filepath.Join("testee", "bin", "testee.dart"),
+			},
+		},
+	} {
+		t.Run(test.language, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := copyTemplates(test.language, dir); err != nil {
+				t.Fatal(err)
+			}
+			for _, name := range test.want {
+				if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+					t.Error(err)
+				}
+			}
+		})
+	}
+}
+
+func TestRunRunner(t *testing.T) {
+	dir := t.TempDir()
+	runner := writeScript(t, dir, `#!/bin/bash
+cat > "$2/failing_tests.txt" <<END
+Required.Proto3.JsonInput.Int32FieldTooLarge  # int32 out of range
+Recommended.Proto3.JsonInput.NullValueInOtherOneofNewFormat.Validator
+END
+exit 1
+`)
+	got, err := runRunner(t.Context(), runner, "testee", filepath.Join(dir, "results"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"Recommended.Proto3.JsonInput.NullValueInOtherOneofNewFormat.Validator",
+		"Required.Proto3.JsonInput.Int32FieldTooLarge",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRunRunner_NoFailures(t *testing.T) {
+	dir := t.TempDir()
+	runner := writeScript(t, dir, "#!/bin/bash\nexit 0\n")
+	got, err := runRunner(t.Context(), runner, "testee", filepath.Join(dir, "results"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("runRunner() = %v, want no failures", got)
+	}
+}
+
+func TestRunRunner_Error(t *testing.T) {
+	dir := t.TempDir()
+	runner := writeScript(t, dir, "#!/bin/bash\necho 'cannot start testee' >&2\nexit 1\n")
+	if _, err := runRunner(t.Context(), runner, "testee", filepath.Join(dir, "results")); err == nil {
+		t.Error("runRunner() expected an error when the runner fails without results")
+	}
+}
+
+func writeScript(t *testing.T, dir, contents string) string {
+	t.Helper()
+	name := filepath.Join(dir, "conformance_test_runner")
+	if err := os.WriteFile(name, []byte(contents), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return name
+}
@@ -0,0 +1,54 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conformance
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/googleapis/librarian/internal/command"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	sidekickdart "github.com/googleapis/librarian/internal/sidekick/dart"
+	"github.com/googleapis/librarian/internal/sidekick/parser"
+)
+
+// The Dart testee is a pub workspace with two packages: the generated test
+// messages, and the testee program.
+var dartTestee = &testee{
+	codec: func() map[string]string {
+		return map[string]string{
+			"package-name-override":         "conformance_test_messages",
+			"library-path-override":         "test_messages.dart",
+			"not-for-publication":           "true",
+			"issue-tracker-url":             "https://github.com/googleapis/librarian/issues",
+			"skip-format":                   "true",
+			"proto:google.protobuf":         "package:google_cloud_protobuf/protobuf.dart",
+			"package:google_cloud_protobuf": "any",
+		}
+	},
+	generate: func(ctx context.Context, model *api.API, cfg *parser.ModelConfig, dir string) error {
+		return sidekickdart.Generate(ctx, model, filepath.Join(dir, "test_messages"), cfg.Codec)
+	},
+	build: func(ctx context.Context, dir string) (string, error) {
+		if err := command.RunInDir(ctx, dir, "dart", "pub", "get"); err != nil {
+			return "", err
+		}
+		binary := filepath.Join(dir, "conformance-testee")
+		if err := command.RunInDir(ctx, dir, "dart", "compile", "exe", filepath.Join("testee", "bin", "testee.dart"), "-o", binary); err != nil {
+			return "", err
+		}
+		return binary, nil
+	},
+}
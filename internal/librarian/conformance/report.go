@@ -0,0 +1,81 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conformance
+
+import (
+	"fmt"
+	"io"
+	"slices"
+)
+
+// Report summarizes the results of a conformance run.
+type Report struct {
+	// Failed lists all the failing tests.
+	Failed []string
+	// UnexpectedFailures lists the failing tests missing from the expected
+	// failures.
+	UnexpectedFailures []string
+	// UnexpectedSuccesses lists the expected failures that now pass. They
+	// should be removed from the list.
+	UnexpectedSuccesses []string
+}
+
+// OK reports whether the failures match the expected failures.
+func (r *Report) OK() bool {
+	return len(r.UnexpectedFailures) == 0 && len(r.UnexpectedSuccesses) == 0
+}
+
+// Write prints a summary of the report to w.
+func (r *Report) Write(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "%d conformance tests failed\n", len(r.Failed)); err != nil {
+		return err
+	}
+	if err := writeList(w, "These tests failed, fix them or add them to the expected failures:", r.UnexpectedFailures); err != nil {
+		return err
+	}
+	return writeList(w, "These tests are expected to fail but passed, remove them from the expected failures:", r.UnexpectedSuccesses)
+}
+
+func writeList(w io.Writer, header string, tests []string) error {
+	if len(tests) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintln(w, header); err != nil {
+		return err
+	}
+	for _, t := range tests {
+		if _, err := fmt.Fprintf(w, "  %s\n", t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compare builds a report from the sorted lists of expected and actual
+// failures.
+func compare(expected, failed []string) *Report {
+	report := &Report{Failed: failed}
+	for _, t := range failed {
+		if _, found := slices.BinarySearch(expected, t); !found {
+			report.UnexpectedFailures = append(report.UnexpectedFailures, t)
+		}
+	}
+	for _, t := range expected {
+		if _, found := slices.BinarySearch(failed, t); !found {
+			report.UnexpectedSuccesses = append(report.UnexpectedSuccesses, t)
+		}
+	}
+	return report
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conformance
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCompare(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		expected []string
+		failed   []string
+		want     *Report
+	}{
+		{
+			name: "no failures",
+			want: &Report{},
+		},
+		{
+			name:     "expected failures",
+			expected: []string{"a", "b"},
+			failed:   []string{"a", "b"},
+			want:     &Report{Failed: []string{"a", "b"}},
+		},
+		{
+			name:     "unexpected failure",
+			expected: []string{"a"},
+			failed:   []string{"a", "b"},
+			want: &Report{
+				Failed:             []string{"a", "b"},
+				UnexpectedFailures: []string{"b"},
+			},
+		},
+		{
+			name:     "unexpected success",
+			expected: []string{"a", "b"},
+			failed:   []string{"b"},
+			want: &Report{
+				Failed:              []string{"b"},
+				UnexpectedSuccesses: []string{"a"},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := compare(test.expected, test.failed)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+			if wantOK := len(test.want.UnexpectedFailures)+len(test.want.UnexpectedSuccesses) == 0; got.OK() != wantOK {
+				t.Errorf("OK() = %v, want %v", got.OK(), wantOK)
+			}
+		})
+	}
+}
+
+func TestReport_Write(t *testing.T) {
+	report := &Report{
+		Failed:              []string{"a", "b"},
+		UnexpectedFailures:  []string{"b"},
+		UnexpectedSuccesses: []string{"c"},
+	}
+	var got strings.Builder
+	if err := report.Write(&got); err != nil {
+		t.Fatal(err)
+	}
+	want := `2 conformance tests failed
+These tests failed, fix them or add them to the expected failures:
+  b
+These tests are expected to fail but passed, remove them from the expected failures:
+  c
+`
+	if diff := cmp.Diff(want, got.String()); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
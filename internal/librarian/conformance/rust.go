@@ -0,0 +1,50 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conformance
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/googleapis/librarian/internal/command"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/parser"
+	sidekickrust "github.com/googleapis/librarian/internal/sidekick/rust"
+)
+
+// The Rust testee is a cargo workspace with two crates: the generated test
+// messages, and the testee binary.
+var rustTestee = &testee{
+	codec: func() map[string]string {
+		return map[string]string{
+			"package-name-override": "conformance-test-messages",
+			"not-for-publication":   "true",
+			"package:bytes":         "package=bytes,force-used=true",
+			"package:serde":         "package=serde,force-used=true",
+			"package:serde_json":    "package=serde_json,force-used=true",
+			"package:serde_with":    "package=serde_with,force-used=true,feature=base64,feature=macro,feature=std",
+			"package:wkt":           "package=google-cloud-wkt,source=google.protobuf,force-used=true",
+		}
+	},
+	generate: func(ctx context.Context, model *api.API, cfg *parser.ModelConfig, dir string) error {
+		return sidekickrust.Generate(ctx, model, filepath.Join(dir, "test-messages"), cfg)
+	},
+	build: func(ctx context.Context, dir string) (string, error) {
+		if err := command.RunInDir(ctx, dir, command.Cargo, "build", "--release", "--package", "conformance-testee"); err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, "target", "release", "conformance-testee"), nil
+	},
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/// A testee for the Protobuf conformance runner.
+///
+/// The runner writes length-prefixed `conformance.ConformanceRequest` messages
+/// to stdin, and expects length-prefixed `conformance.ConformanceResponse`
+/// messages on stdout. The generated messages only support JSON, so the
+/// envelope is decoded by hand and any other format is skipped.
+library;
+
+import 'dart:convert';
+import 'dart:io';
+import 'dart:typed_data';
+
+import 'package:conformance_test_messages/test_messages.dart';
+
+const _testAllTypesProto3 = 'protobuf_test_messages.proto3.TestAllTypesProto3';
+const _failureSet = 'conformance.FailureSet';
+// The value of `conformance.WireFormat.JSON`.
+const _wireFormatJson = 2;
+
+// The field numbers in `conformance.ConformanceResponse`.
+const _parseError = 1;
+const _runtimeError = 2;
+const _protobufPayload = 3;
+const _jsonPayload = 4;
+const _skipped = 5;
+const _serializeError = 6;
+
+void main() {
+  final input = File('/dev/stdin').openSync();
+  final output = File('/dev/stdout').openSync(mode: FileMode.writeOnlyAppend);
+  while (true) {
+    final prefix = _readExact(input, 4);
+    if (prefix == null) {
+      break;
+    }
+    final size = ByteData.sublistView(prefix).getUint32(0, Endian.little);
+    final payload = _readExact(input, size);
+    if (payload == null) {
+      break;
+    }
+    final (field, value) = _handle(payload);
+    final response = _encode(field, value);
+    final length = ByteData(4)..setUint32(0, response.length, Endian.little);
+    output
+      ..writeFromSync(length.buffer.asUint8List())
+      ..writeFromSync(response)
+      ..flushSync();
+  }
+}
+
+(int, List<int>) _handle(Uint8List payload) {
+  final _Request request;
+  try {
+    request = _Request.decode(payload);
+  } catch (e) {
+    return (_runtimeError, utf8.encode('$e'));
+  }
+  if (request.messageType == _failureSet) {
+    // An empty `conformance.FailureSet`. The expected failures are compared
+    // by librarian.
+    return (_protobufPayload, const []);
+  }
+  if (request.messageType != _testAllTypesProto3) {
+    return (
+      _skipped,
+      utf8.encode('unsupported message type ${request.messageType}'),
+    );
+  }
+  final json = request.jsonPayload;
+  if (json == null) {
+    return (_skipped, utf8.encode('only JSON input is supported'));
+  }
+  if (request.requestedOutputFormat != _wireFormatJson) {
+    return (_skipped, utf8.encode('only JSON output is supported'));
+  }
+  final TestAllTypesProto3 message;
+  try {
+    message = TestAllTypesProto3.fromJson(jsonDecode(json));
+  } catch (e) {
+    return (_parseError, utf8.encode('$e'));
+  }
+  try {
+    return (_jsonPayload, utf8.encode(jsonEncode(message.toJson())));
+  } catch (e) {
+    return (_serializeError, utf8.encode('$e'));
+  }
+}
+
+Uint8List? _readExact(RandomAccessFile file, int size) {
+  final builder = BytesBuilder(copy: false);
+  while (builder.length < size) {
+    final chunk = file.readSync(size - builder.length);
+    if (chunk.isEmpty) {
+      return null;
+    }
+    builder.add(chunk);
+  }
+  return builder.takeBytes();
+}
+
+Uint8List _encode(int field, List<int> value) {
+  final builder = BytesBuilder(copy: false);
+  _writeVarint(builder, (field << 3) | 2);
+  _writeVarint(builder, value.length);
+  builder.add(value);
+  return builder.takeBytes();
+}
+
+void _writeVarint(BytesBuilder builder, int value) {
+  while (value >= 0x80) {
+    builder.addByte((value & 0x7f) | 0x80);
+    value >>= 7;
+  }
+  builder.addByte(value);
+}
+
+final class _Request {
+  String? jsonPayload;
+  int requestedOutputFormat = 0;
+  String messageType = '';
+
+  _Request.decode(Uint8List buffer) {
+    var offset = 0;
+    int readVarint() {
+      var value = 0;
+      for (var shift = 0; shift < 64; shift += 7) {
+        if (offset >= buffer.length) {
+          throw const FormatException('truncated varint');
+        }
+        final b = buffer[offset++];
+        value |= (b & 0x7f) << shift;
+        if (b & 0x80 == 0) {
+          return value;
+        }
+      }
+      throw const FormatException('varint too long');
+    }
+
+    while (offset < buffer.length) {
+      final key = readVarint();
+      final field = key >> 3;
+      switch (key & 0x7) {
+        case 0:
+          final value = readVarint();
+          if (field == 3) {
+            requestedOutputFormat = value;
+          }
+        case 1:
+          offset += 8;
+        case 2:
+          final length = readVarint();
+          if (offset + length > buffer.length) {
+            throw const FormatException('truncated request');
+          }
+          final value = buffer.sublist(offset, offset + length);
+          offset += length;
+          switch (field) {
+            case 2:
+              jsonPayload = utf8.decode(value);
+            case 4:
+              messageType = utf8.decode(value);
+          }
+        case 5:
+          offset += 4;
+        case final wireType:
+          throw FormatException('unsupported wire type $wireType');
+      }
+    }
+  }
+}
@@ -0,0 +1,170 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//! A testee for the Protobuf conformance runner.
+//!
+//! The runner writes length-prefixed `conformance.ConformanceRequest` messages
+//! to stdin, and expects length-prefixed `conformance.ConformanceResponse`
+//! messages on stdout. The generated messages only support JSON, so the
+//! envelope is decoded by hand and any other format is skipped.
+
+use conformance_test_messages::model::TestAllTypesProto3;
+use std::io::{ErrorKind, Read, Write};
+
+const TEST_ALL_TYPES_PROTO3: &str = "protobuf_test_messages.proto3.TestAllTypesProto3";
+const FAILURE_SET: &str = "conformance.FailureSet";
+// The value of `conformance.WireFormat.JSON`.
+const WIRE_FORMAT_JSON: u64 = 2;
+
+#[derive(Default)]
+struct Request {
+    json_payload: Option<String>,
+    requested_output_format: u64,
+    message_type: String,
+}
+
+enum Response {
+    ParseError(String),
+    RuntimeError(String),
+    ProtobufPayload(Vec<u8>),
+    JsonPayload(String),
+    Skipped(String),
+    SerializeError(String),
+}
+
+fn main() -> std::io::Result<()> {
+    let mut stdin = std::io::stdin().lock();
+    let mut stdout = std::io::stdout().lock();
+    loop {
+        let mut len = [0_u8; 4];
+        match stdin.read_exact(&mut len) {
+            Ok(()) => {}
+            Err(e) if e.kind() == ErrorKind::UnexpectedEof => return Ok(()),
+            Err(e) => return Err(e),
+        }
+        let mut buf = vec![0_u8; u32::from_le_bytes(len) as usize];
+        stdin.read_exact(&mut buf)?;
+        let response = match decode_request(&buf) {
+            Ok(request) => handle(request),
+            Err(e) => Response::RuntimeError(e),
+        };
+        let buf = encode_response(response);
+        stdout.write_all(&(buf.len() as u32).to_le_bytes())?;
+        stdout.write_all(&buf)?;
+        stdout.flush()?;
+    }
+}
+
+fn handle(request: Request) -> Response {
+    if request.message_type == FAILURE_SET {
+        // An empty `conformance.FailureSet`. The expected failures are
+        // compared by librarian.
+        return Response::ProtobufPayload(Vec::new());
+    }
+    if request.message_type != TEST_ALL_TYPES_PROTO3 {
+        return Response::Skipped(format!(
+            "unsupported message type {}",
+            request.message_type
+        ));
+    }
+    let Some(json) = request.json_payload else {
+        return Response::Skipped("only JSON input is supported".to_string());
+    };
+    if request.requested_output_format != WIRE_FORMAT_JSON {
+        return Response::Skipped("only JSON output is supported".to_string());
+    }
+    let message = match serde_json::from_str::<TestAllTypesProto3>(&json) {
+        Ok(m) => m,
+        Err(e) => return Response::ParseError(e.to_string()),
+    };
+    match serde_json::to_string(&message) {
+        Ok(s) => Response::JsonPayload(s),
+        Err(e) => Response::SerializeError(e.to_string()),
+    }
+}
+
+fn decode_request(mut buf: &[u8]) -> Result<Request, String> {
+    let mut request = Request::default();
+    while !buf.is_empty() {
+        let key = read_varint(&mut buf)?;
+        let (field, wire_type) = (key >> 3, key & 0x7);
+        match wire_type {
+            0 => {
+                let value = read_varint(&mut buf)?;
+                if field == 3 {
+                    request.requested_output_format = value;
+                }
+            }
+            1 => buf = skip(buf, 8)?,
+            2 => {
+                let len = read_varint(&mut buf)? as usize;
+                let value = buf.get(..len).ok_or("truncated request")?;
+                buf = &buf[len..];
+                match field {
+                    2 => request.json_payload = Some(to_string(value)?),
+                    4 => request.message_type = to_string(value)?,
+                    _ => {}
+                }
+            }
+            5 => buf = skip(buf, 4)?,
+            _ => return Err(format!("unsupported wire type {wire_type}")),
+        }
+    }
+    Ok(request)
+}
+
+fn encode_response(response: Response) -> Vec<u8> {
+    let (field, value) = match response {
+        Response::ParseError(s) => (1, s.into_bytes()),
+        Response::RuntimeError(s) => (2, s.into_bytes()),
+        Response::ProtobufPayload(b) => (3, b),
+        Response::JsonPayload(s) => (4, s.into_bytes()),
+        Response::Skipped(s) => (5, s.into_bytes()),
+        Response::SerializeError(s) => (6, s.into_bytes()),
+    };
+    let mut buf = Vec::new();
+    write_varint(&mut buf, (field << 3) | 2);
+    write_varint(&mut buf, value.len() as u64);
+    buf.extend(value);
+    buf
+}
+
+fn read_varint(buf: &mut &[u8]) -> Result<u64, String> {
+    let mut value = 0_u64;
+    for shift in (0..64).step_by(7) {
+        let (&b, rest) = buf.split_first().ok_or("truncated varint")?;
+        *buf = rest;
+        value |= u64::from(b & 0x7f) << shift;
+        if b & 0x80 == 0 {
+            return Ok(value);
+        }
+    }
+    Err("varint too long".to_string())
+}
+
+fn write_varint(buf: &mut Vec<u8>, mut value: u64) {
+    while value >= 0x80 {
+        buf.push((value as u8) | 0x80);
+        value >>= 7;
+    }
+    buf.push(value as u8);
+}
+
+fn skip(buf: &[u8], n: usize) -> Result<&[u8], String> {
+    buf.get(n..).ok_or_else(|| "truncated request".to_string())
+}
+
+fn to_string(value: &[u8]) -> Result<String, String> {
+    String::from_utf8(value.to_vec()).map_err(|e| e.to_string())
+}
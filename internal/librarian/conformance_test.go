@@ -0,0 +1,60 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/googleapis/librarian/internal/librarian/conformance"
+)
+
+func TestRunConformance_Error(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		configYAML string
+		wantErr    error
+	}{
+		{
+			name:       "no sources",
+			configYAML: "language: rust\n",
+			wantErr:    conformance.ErrMissingConformanceSource,
+		},
+		{
+			name:       "no conformance source",
+			configYAML: "language: rust\nsources:\n  googleapis:\n    dir: googleapis\n",
+			wantErr:    conformance.ErrMissingConformanceSource,
+		},
+		{
+			name:       "unsupported language",
+			configYAML: "language: go\nsources:\n  conformance:\n    dir: protobuf\n",
+			wantErr:    conformance.ErrUnsupportedLanguage,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			if err := os.WriteFile("librarian.yaml", []byte(test.configYAML), 0644); err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			err := runConformance(t.Context(), &buf, "conformance_test_runner", "", "")
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("got error %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
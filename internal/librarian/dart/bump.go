@@ -0,0 +1,46 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dart
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/googleapis/librarian/internal/snippetmetadata"
+)
+
+var pubspecVersion = regexp.MustCompile(`(?m)^version:.*$`)
+
+// Bump updates the version of the Dart package in output, both in
+// pubspec.yaml and in the snippet metadata.
+func Bump(output, version string) error {
+	pubspec := filepath.Join(output, "pubspec.yaml")
+	content, err := os.ReadFile(pubspec)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		// The package has not been generated yet.
+	case err != nil:
+		return err
+	default:
+		content = pubspecVersion.ReplaceAll(content, []byte("version: "+version))
+		if err := os.WriteFile(pubspec, content, 0644); err != nil {
+			return err
+		}
+	}
+	return snippetmetadata.UpdateAllLibraryVersions(output, version)
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dart
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	snippetMetadataBefore = `{
+  "clientLibrary": {
+    "name": "google_cloud_secretmanager_v1",
+    "version": "0.1.0"
+  }
+}`
+	snippetMetadataAfter = `{
+  "clientLibrary": {
+    "name": "google_cloud_secretmanager_v1",
+    "version": "0.2.0"
+  }
+}`
+)
+
+func TestBump(t *testing.T) {
+	dir := t.TempDir()
+	for file, content := range map[string]string{
+		"pubspec.yaml": "name: google_cloud_secretmanager_v1\nversion: 0.1.0\nenvironment:\n  sdk: ^3.7.0\n",
+		"snippet_metadata_google.cloud.secretmanager.v1.json": snippetMetadataBefore,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := Bump(dir, "0.2.0"); err != nil {
+		t.Fatal(err)
+	}
+	for file, want := range map[string]string{
+		"pubspec.yaml": "name: google_cloud_secretmanager_v1\nversion: 0.2.0\nenvironment:\n  sdk: ^3.7.0\n",
+		"snippet_metadata_google.cloud.secretmanager.v1.json": snippetMetadataAfter,
+	} {
+		got, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, string(got)); diff != "" {
+			t.Errorf("%s mismatch (-want +got):\n%s", file, diff)
+		}
+	}
+}
+
+func TestBump_NoPubspec(t *testing.T) {
+	dir := t.TempDir()
+	if err := Bump(dir, "0.2.0"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "pubspec.yaml")); !os.IsNotExist(err) {
+		t.Errorf("pubspec.yaml should not be created, got %v", err)
+	}
+}
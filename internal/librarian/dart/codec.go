@@ -27,7 +27,9 @@ import (
 
 var errInvalidSpecificationFormat = errors.New("dart generation requires protobuf specification format")
 
-func toModelConfig(library *config.Library, ch *config.API, srcs *sources.Sources) (*parser.ModelConfig, error) {
+// ModelConfig returns the configuration used to parse an API of a library
+// into a sidekick model.
+func ModelConfig(library *config.Library, ch *config.API, srcs *sources.Sources) (*parser.ModelConfig, error) {
 	if library.SpecificationFormat != "" && library.SpecificationFormat != config.SpecProtobuf {
 		return nil, fmt.Errorf("%w, got %q", errInvalidSpecificationFormat, library.SpecificationFormat)
 	}
@@ -56,12 +58,17 @@ func toModelConfig(library *config.Library, ch *config.API, srcs *sources.Source
 		}
 	}
 
+	codec := buildCodec(library)
+	if svcConfig.HasTransport(config.LanguageDart) {
+		codec["transport"] = string(svcConfig.Transport(config.LanguageDart))
+	}
 	modelConfig := &parser.ModelConfig{
 		SpecificationFormat: config.SpecProtobuf,
 		ServiceConfig:       svcConfig.ServiceConfig,
 		SpecificationSource: ch.Path,
+		Mixins:              library.Mixins,
 		Source:              src,
-		Codec:               buildCodec(library),
+		Codec:               codec,
 		Override: api.ModelOverride{
 			Name:        name,
 			Description: library.DescriptionOverride,
@@ -82,6 +89,9 @@ func buildCodec(library *config.Library) map[string]string {
 	if library.SkipRelease {
 		codec["not-for-publication"] = "true"
 	}
+	if library.TemplateOverlay != "" {
+		codec["template-overlay"] = library.TemplateOverlay
+	}
 	if library.Dart == nil {
 		return codec
 	}
@@ -104,6 +104,15 @@ func TestBuildCodec(t *testing.T) {
 				"not-for-publication": "true",
 			},
 		},
+		{
+			name: "template overlay",
+			library: &config.Library{
+				TemplateOverlay: "overlays/dart",
+			},
+			want: map[string]string{
+				"template-overlay": "overlays/dart",
+			},
+		},
 		{
 			name: "part file",
 			library: &config.Library{
@@ -291,7 +300,7 @@ func TestBuildCodec(t *testing.T) {
 	}
 }
 
-func TestToModelConfig(t *testing.T) {
+func TestModelConfig(t *testing.T) {
 	googleapisDir := t.TempDir()
 	showcaseDir := t.TempDir()
 
@@ -359,6 +368,28 @@ func TestToModelConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "with transport",
+			library: &config.Library{},
+			channel: &config.API{
+				Path: "google/cloud/memorystore/v1",
+			},
+			googleapisDir: googleapisDir,
+			want: &parser.ModelConfig{
+				SpecificationFormat: config.SpecProtobuf,
+				SpecificationSource: "google/cloud/memorystore/v1",
+				Source: &sources.SourceConfig{
+					Sources: &sources.Sources{
+						Googleapis: googleapisDir,
+					},
+					ActiveRoots: []string{"googleapis"},
+				},
+				Codec: map[string]string{
+					"transport": "rest",
+				},
+				Override: api.ModelOverride{},
+			},
+		},
 		{
 			name: "with name override",
 			library: &config.Library{
@@ -559,10 +590,10 @@ func TestToModelConfig(t *testing.T) {
 			if test.showcaseDir != "" {
 				sources.Showcase = test.showcaseDir
 			}
-			got, err := toModelConfig(test.library, test.channel, sources)
+			got, err := ModelConfig(test.library, test.channel, sources)
 			if test.wantErr != nil {
 				if !errors.Is(err, test.wantErr) {
-					t.Errorf("toModelConfig() error: %v, wantErr: %v", err, test.wantErr)
+					t.Errorf("ModelConfig() error: %v, wantErr: %v", err, test.wantErr)
 				}
 				return
 			}
@@ -64,6 +64,12 @@ func fakeFormat(library *config.Library) error {
 	return os.WriteFile(readmePath, []byte(formatted), 0644)
 }
 
+// fakeVerify checks that the generated README.md exists.
+func fakeVerify(library *config.Library) error {
+	_, err := os.Stat(filepath.Join(library.Output, "README.md"))
+	return err
+}
+
 func fakePostGenerate() error {
 	return os.WriteFile("POST_GENERATE_README.md", []byte("PostGenerated\n"), 0644)
 }
@@ -0,0 +1,180 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloud
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/filesystem"
+)
+
+const (
+	// manifestFile lists the files written by the last generation, relative
+	// to the library output directory. Clean removes exactly these files.
+	manifestFile = ".librarian-gcloud-files"
+
+	// initExtensionsFile is the hand-written hook that extends a command
+	// group. It is scaffolded when missing and never overwritten or removed.
+	initExtensionsFile = "_init_extensions.py"
+)
+
+// Clean removes the files recorded in the manifest of the previous
+// generation, along with any directories left empty. Files listed in
+// library.Keep, hand-written _init_extensions.py hooks, and the gcloud.yaml
+// overrides are never removed. Clean is a no-op if the output directory has
+// no manifest.
+func Clean(library *config.Library) error {
+	files, err := readManifest(library.Output)
+	if err != nil {
+		return err
+	}
+	keep := keepSet(library.Keep)
+	var dirs []string
+	for _, name := range files {
+		if keep[name] {
+			continue
+		}
+		path := filepath.Join(library.Output, filepath.FromSlash(name))
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		dirs = append(dirs, filepath.Dir(path))
+	}
+	if err := os.Remove(filepath.Join(library.Output, manifestFile)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return removeEmptyDirs(library.Output, dirs)
+}
+
+// readManifest returns the files listed in the manifest in dir. It returns
+// nil if there is no manifest.
+func readManifest(dir string) ([]string, error) {
+	f, err := os.Open(filepath.Join(dir, manifestFile))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	var files []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if !filepath.IsLocal(filepath.FromSlash(line)) {
+			return nil, fmt.Errorf("invalid path %q in %s", line, manifestFile)
+		}
+		files = append(files, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// writeManifest records files, which must be relative to dir and use forward
+// slashes, in the manifest in dir.
+func writeManifest(dir string, files []string) error {
+	sorted := slices.Clone(files)
+	slices.Sort(sorted)
+	var content strings.Builder
+	for _, name := range sorted {
+		content.WriteString(name)
+		content.WriteString("\n")
+	}
+	return os.WriteFile(filepath.Join(dir, manifestFile), []byte(content.String()), 0644)
+}
+
+// install copies the files generated in stagingDir into outDir and returns
+// the files it copied, relative to outDir. Files in keep and existing
+// _init_extensions.py hooks are left untouched and are not returned.
+func install(stagingDir, outDir string, keep map[string]bool) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(stagingDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(stagingDir, path)
+		if err != nil {
+			return err
+		}
+		dest := filepath.Join(outDir, rel)
+		if d.IsDir() {
+			return os.MkdirAll(dest, 0755)
+		}
+		name := filepath.ToSlash(rel)
+		if keep[name] {
+			return nil
+		}
+		if d.Name() == initExtensionsFile {
+			if _, err := os.Stat(dest); err == nil {
+				return nil
+			}
+			return filesystem.CopyFile(path, dest)
+		}
+		files = append(files, name)
+		return filesystem.CopyFile(path, dest)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// removeEmptyDirs removes each of dirs, and then its parents, while they are
+// empty and below root.
+func removeEmptyDirs(root string, dirs []string) error {
+	root = filepath.Clean(root)
+	// Deeper directories sort after their parents, so visiting them in
+	// reverse order removes children first.
+	slices.Sort(dirs)
+	for _, dir := range slices.Backward(slices.Compact(dirs)) {
+		for dir = filepath.Clean(dir); dir != root && strings.HasPrefix(dir, root); dir = filepath.Dir(dir) {
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					continue
+				}
+				return err
+			}
+			if len(entries) != 0 {
+				break
+			}
+			if err := os.Remove(dir); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func keepSet(keep []string) map[string]bool {
+	set := make(map[string]bool, len(keep))
+	for _, k := range keep {
+		set[filepath.ToSlash(filepath.Clean(k))] = true
+	}
+	return set
+}
@@ -0,0 +1,206 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloud
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/config"
+)
+
+func TestClean(t *testing.T) {
+	out := t.TempDir()
+	for _, name := range []string{
+		"gcloud.yaml",
+		"parallelstore/__init__.py",
+		"parallelstore/_init_extensions.py",
+		"parallelstore/instances/__init__.py",
+		"parallelstore/instances/_init_extensions.py",
+		"parallelstore/instances/create.yaml",
+		"parallelstore/instances/_partials/_create_ga.yaml",
+		"parallelstore/instances/custom.yaml",
+		"parallelstore/locations/__init__.py",
+		"parallelstore/locations/list.yaml",
+		"parallelstore/locations/_partials/_list_ga.yaml",
+	} {
+		writeTestFile(t, out, name)
+	}
+	if err := writeManifest(out, []string{
+		"parallelstore/__init__.py",
+		"parallelstore/instances/__init__.py",
+		"parallelstore/instances/create.yaml",
+		"parallelstore/instances/_partials/_create_ga.yaml",
+		"parallelstore/locations/__init__.py",
+		"parallelstore/locations/list.yaml",
+		"parallelstore/locations/_partials/_list_ga.yaml",
+		"parallelstore/operations/describe.yaml",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	library := &config.Library{
+		Output: out,
+		Keep:   []string{"parallelstore/locations/list.yaml"},
+	}
+	if err := Clean(library); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"gcloud.yaml",
+		"parallelstore/_init_extensions.py",
+		"parallelstore/instances/_init_extensions.py",
+		"parallelstore/instances/custom.yaml",
+		"parallelstore/locations/list.yaml",
+	}
+	if diff := cmp.Diff(want, listFiles(t, out)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	for _, dir := range []string{"parallelstore/instances/_partials", "parallelstore/locations/_partials"} {
+		if _, err := os.Stat(filepath.Join(out, dir)); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("directory %q was not removed", dir)
+		}
+	}
+}
+
+func TestClean_NoManifest(t *testing.T) {
+	out := t.TempDir()
+	writeTestFile(t, out, "parallelstore/instances/create.yaml")
+	if err := Clean(&config.Library{Output: out}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"parallelstore/instances/create.yaml"}
+	if diff := cmp.Diff(want, listFiles(t, out)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestClean_MissingOutput(t *testing.T) {
+	library := &config.Library{Output: filepath.Join(t.TempDir(), "missing")}
+	if err := Clean(library); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClean_InvalidManifest(t *testing.T) {
+	out := t.TempDir()
+	if err := os.WriteFile(filepath.Join(out, manifestFile), []byte("../outside.yaml\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Clean(&config.Library{Output: out}); err == nil {
+		t.Error("Clean() error = nil, want error")
+	}
+}
+
+func TestInstall(t *testing.T) {
+	staging := t.TempDir()
+	for _, name := range []string{
+		"parallelstore/__init__.py",
+		"parallelstore/_init_extensions.py",
+		"parallelstore/instances/__init__.py",
+		"parallelstore/instances/_init_extensions.py",
+		"parallelstore/instances/create.yaml",
+		"parallelstore/instances/delete.yaml",
+	} {
+		writeTestFile(t, staging, name)
+	}
+	out := t.TempDir()
+	hooks := filepath.Join(out, "parallelstore", "_init_extensions.py")
+	if err := os.MkdirAll(filepath.Dir(hooks), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(hooks, []byte("hand-written"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := install(staging, out, keepSet([]string{"parallelstore/instances/delete.yaml"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"parallelstore/__init__.py",
+		"parallelstore/instances/__init__.py",
+		"parallelstore/instances/create.yaml",
+	}
+	sort.Strings(got)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	content, err := os.ReadFile(hooks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "hand-written" {
+		t.Errorf("install() overwrote %s, got %q", hooks, content)
+	}
+	if _, err := os.Stat(filepath.Join(out, "parallelstore", "instances", "_init_extensions.py")); err != nil {
+		t.Errorf("install() did not scaffold missing hooks: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(out, "parallelstore", "instances", "delete.yaml")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("install() wrote a file in the keep list, err = %v", err)
+	}
+}
+
+func TestManifestRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	files := []string{"b/create.yaml", "a/__init__.py"}
+	if err := writeManifest(dir, files); err != nil {
+		t.Fatal(err)
+	}
+	got, err := readManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"a/__init__.py", "b/create.yaml"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func writeTestFile(t *testing.T, dir, name string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(name), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func listFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(files)
+	return files
+}
@@ -39,14 +39,34 @@ import (
 // command groups.
 const baseModule = "googlecloudsdk"
 
+// gcloudConfigFile is the name of the gcloud.yaml overrides file discovered
+// in the library output directory.
+const gcloudConfigFile = "gcloud.yaml"
+
 // ErrNoProtosFound is returned when no .proto files are found in the API directory.
 var ErrNoProtosFound = errors.New("no .proto files found")
 
+// ErrNoDiscoveryDoc is returned when the specification format is discovery
+// and the API has no Discovery document.
+var ErrNoDiscoveryDoc = errors.New("no discovery document found")
+
+// ErrProtoNotFound is returned when a proto file in the include list does not
+// exist in the API directory.
+var ErrProtoNotFound = errors.New("proto file in include list not found")
+
 // Generate generates gcloud command YAML files for a library.
 //
-// It parses the protos and service config for each API in the library, builds
+// It parses the protos and service config for each API in the library, or the
+// Discovery document if the library's specification format is discovery, builds
 // a gcloud command tree, and writes the resulting YAML to the library's
-// output directory.
+// output directory. Overrides are read from the gcloud.yaml file named in the
+// library's gcloud configuration or, failing that, from a gcloud.yaml file in
+// the output directory.
+//
+// The generated files are recorded in a manifest in the output directory so
+// that [Clean] can remove them, including commands that no longer exist,
+// before the next generation. Files in library.Keep are not overwritten, and
+// _init_extensions.py hooks are only written if they do not exist yet.
 func Generate(ctx context.Context, library *config.Library, srcs *sources.Sources) error {
 	googleapisDir, err := filepath.Abs(srcs.Googleapis)
 	if err != nil {
@@ -59,17 +79,58 @@ func Generate(ctx context.Context, library *config.Library, srcs *sources.Source
 	if err := os.MkdirAll(outDir, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
+	overrides, err := readOverrides(library)
+	if err != nil {
+		return err
+	}
 
+	stagingDir, err := os.MkdirTemp("", "librarian-gcloud-")
+	if err != nil {
+		return fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(stagingDir)
 	for _, api := range library.APIs {
-		if err := generateAPI(api, googleapisDir, outDir); err != nil {
+		if library.SpecificationFormat == config.SpecDiscovery {
+			err = generateDiscoveryAPI(api, overrides, sources.NewSourceConfig(srcs, library.Roots), googleapisDir, stagingDir)
+		} else {
+			err = generateAPI(api, library.Gcloud, overrides, googleapisDir, stagingDir)
+		}
+		if err != nil {
 			return fmt.Errorf("failed to generate api %q: %w", api.Path, err)
 		}
 	}
-	return nil
+	files, err := install(stagingDir, outDir, keepSet(library.Keep))
+	if err != nil {
+		return fmt.Errorf("failed to install generated files: %w", err)
+	}
+	return writeManifest(outDir, files)
 }
 
-func generateAPI(api *config.API, googleapisDir, outDir string) error {
-	protos, err := collectProtos(googleapisDir, api.Path)
+// readOverrides loads the gcloud.yaml overrides for library. It returns nil
+// if the library does not name a config file and there is no gcloud.yaml in
+// its output directory.
+func readOverrides(library *config.Library) (*provider.Config, error) {
+	path := filepath.Join(library.Output, gcloudConfigFile)
+	if library.Gcloud != nil && library.Gcloud.Config != "" {
+		path = library.Gcloud.Config
+	} else if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	overrides, err := provider.ReadGcloudConfig(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gcloud overrides from %q: %w", path, err)
+	}
+	return overrides, nil
+}
+
+func generateAPI(api *config.API, surface *config.GcloudSurface, overrides *provider.Config, googleapisDir, outDir string) error {
+	var includeList, descriptorFiles, descriptorFilesToGenerate []string
+	if surface != nil {
+		includeList = surface.IncludeList
+		descriptorFiles = surface.DescriptorFiles
+		descriptorFilesToGenerate = surface.DescriptorFilesToGenerate
+	}
+	protos, err := collectProtos(googleapisDir, api.Path, includeList)
 	if err != nil {
 		return err
 	}
@@ -77,29 +138,61 @@ func generateAPI(api *config.API, googleapisDir, outDir string) error {
 	if err != nil {
 		return err
 	}
+	if len(descriptorFiles) != 0 && len(descriptorFilesToGenerate) == 0 {
+		descriptorFilesToGenerate = protos
+	}
 
 	model, err := provider.CreateAPIModel(
 		googleapisDir,
 		strings.Join(protos, ","),
 		serviceConfigPath,
-		"", // descriptorFiles
-		"", // descriptorFilesToGenerate
+		strings.Join(descriptorFiles, ","),
+		strings.Join(descriptorFilesToGenerate, ","),
 	)
 	if err != nil {
 		return err
 	}
-	return sidekickgcloud.Generate(model, nil, outDir, baseModule)
+	return sidekickgcloud.Generate(model, overrides, outDir, baseModule)
+}
+
+// generateDiscoveryAPI generates the gcloud surface for an API described by a
+// Discovery document. The document is named by the API's entry in the SDK
+// service config list and resolved against the library's source roots. LRO
+// pollers are read from the discovery section of the gcloud.yaml overrides.
+func generateDiscoveryAPI(api *config.API, overrides *provider.Config, src *sources.SourceConfig, googleapisDir, outDir string) error {
+	sc, err := serviceconfig.Find(googleapisDir, api.Path, config.LanguageGcloud)
+	if err != nil {
+		return err
+	}
+	if sc.Discovery == "" {
+		return fmt.Errorf("%w: %q", ErrNoDiscoveryDoc, api.Path)
+	}
+	var serviceConfigPath string
+	if sc.ServiceConfig != "" {
+		serviceConfigPath = filepath.Join(googleapisDir, sc.ServiceConfig)
+	}
+	var discovery *provider.Discovery
+	if overrides != nil {
+		discovery = overrides.Discovery
+	}
+	model, err := provider.CreateDiscoveryAPIModel(src.Resolve(sc.Discovery), serviceConfigPath, discovery)
+	if err != nil {
+		return err
+	}
+	return sidekickgcloud.Generate(model, overrides, outDir, baseModule)
 }
 
 // collectProtos returns proto file paths under apiPath, relative to
 // googleapisDir, using forward slashes so they can be matched against the
-// parser include list regardless of platform.
-func collectProtos(googleapisDir, apiPath string) ([]string, error) {
+// parser include list regardless of platform. If includeList is not empty,
+// only the named files are returned, and each of them must exist.
+func collectProtos(googleapisDir, apiPath string, includeList []string) ([]string, error) {
 	apiDir := filepath.Join(googleapisDir, apiPath)
 	entries, err := os.ReadDir(apiDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read API directory %q: %w", apiDir, err)
 	}
+	found := map[string]bool{}
 	var protos []string
 	for _, entry := range entries {
 		if entry.IsDir() {
@@ -108,7 +201,16 @@ func collectProtos(googleapisDir, apiPath string) ([]string, error) {
 		if filepath.Ext(entry.Name()) != ".proto" {
 			continue
 		}
-		protos = append(protos, filepath.ToSlash(filepath.Join(apiPath, entry.Name())))
+		found[entry.Name()] = true
+		if len(includeList) == 0 {
+			protos = append(protos, filepath.ToSlash(filepath.Join(apiPath, entry.Name())))
+		}
+	}
+	for _, name := range includeList {
+		if !found[name] {
+			return nil, fmt.Errorf("%w: %q in %q", ErrProtoNotFound, name, apiDir)
+		}
+		protos = append(protos, filepath.ToSlash(filepath.Join(apiPath, name)))
 	}
 	if len(protos) == 0 {
 		return nil, fmt.Errorf("%w: %q", ErrNoProtosFound, apiDir)
@@ -15,6 +15,7 @@
 package gcloud
 
 import (
+	"errors"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -22,6 +23,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/sidekick/gcloud/provider"
 	"github.com/googleapis/librarian/internal/sources"
 	"github.com/googleapis/librarian/internal/testhelper"
 )
@@ -59,6 +61,46 @@ func TestGenerate(t *testing.T) {
 	}
 }
 
+func TestGenerate_Discovery(t *testing.T) {
+	discoveryDir := t.TempDir()
+	contents, err := os.ReadFile("../../testdata/discovery/small-compute.v1.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(discoveryDir, "discoveries"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(discoveryDir, "discoveries", "compute.v1.json"), string(contents))
+
+	out := t.TempDir()
+	library := &config.Library{
+		Name:                "compute",
+		Output:              out,
+		SpecificationFormat: config.SpecDiscovery,
+		Roots:               []string{"discovery"},
+		APIs:                []*config.API{{Path: "google/cloud/compute/v1"}},
+	}
+	srcs := &sources.Sources{Googleapis: testGoogleapisDir, Discovery: discoveryDir}
+	if err := Generate(t.Context(), library, srcs); err != nil {
+		t.Fatal(err)
+	}
+	compareDirs(t, "testdata/compute", out)
+}
+
+func TestGenerate_NoDiscoveryDoc(t *testing.T) {
+	library := &config.Library{
+		Name:                "publicca",
+		Output:              t.TempDir(),
+		SpecificationFormat: config.SpecDiscovery,
+		APIs:                []*config.API{{Path: "google/cloud/security/publicca/v1"}},
+	}
+	srcs := &sources.Sources{Googleapis: testGoogleapisDir}
+	err := Generate(t.Context(), library, srcs)
+	if !errors.Is(err, ErrNoDiscoveryDoc) {
+		t.Errorf("Generate() error = %v, want %v", err, ErrNoDiscoveryDoc)
+	}
+}
+
 func TestGenerate_Errors(t *testing.T) {
 	for _, test := range []struct {
 		name       string
@@ -96,16 +138,118 @@ func TestCollectProtos(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	got, err := collectProtos(abs, apiPath)
+	for _, test := range []struct {
+		name        string
+		includeList []string
+		want        []string
+	}{
+		{
+			name: "all protos",
+			want: []string{
+				apiPath + "/resources.proto",
+				apiPath + "/service.proto",
+			},
+		},
+		{
+			name:        "include list",
+			includeList: []string{"service.proto"},
+			want:        []string{apiPath + "/service.proto"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := collectProtos(abs, apiPath, test.includeList)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCollectProtos_Error(t *testing.T) {
+	abs, err := filepath.Abs(testGoogleapisDir)
 	if err != nil {
 		t.Fatal(err)
 	}
-	want := []string{
-		filepath.ToSlash(filepath.Join(apiPath, "resources.proto")),
-		filepath.ToSlash(filepath.Join(apiPath, "service.proto")),
+	_, err = collectProtos(abs, "google/cloud/security/publicca/v1", []string{"missing.proto"})
+	if !errors.Is(err, ErrProtoNotFound) {
+		t.Errorf("collectProtos() error = %v, want %v", err, ErrProtoNotFound)
 	}
-	if diff := cmp.Diff(want, got); diff != "" {
-		t.Errorf("mismatch (-want +got):\n%s", diff)
+}
+
+func TestReadOverrides(t *testing.T) {
+	const content = "service_name: publicca.googleapis.com\n"
+	for _, test := range []struct {
+		name  string
+		setup func(t *testing.T, library *config.Library)
+		want  *provider.Config
+	}{
+		{
+			name:  "no overrides",
+			setup: func(t *testing.T, library *config.Library) {},
+		},
+		{
+			name: "discovered in output",
+			setup: func(t *testing.T, library *config.Library) {
+				writeFile(t, filepath.Join(library.Output, gcloudConfigFile), content)
+			},
+			want: &provider.Config{ServiceName: "publicca.googleapis.com"},
+		},
+		{
+			name: "explicit config",
+			setup: func(t *testing.T, library *config.Library) {
+				path := filepath.Join(t.TempDir(), "custom.yaml")
+				writeFile(t, path, content)
+				writeFile(t, filepath.Join(library.Output, gcloudConfigFile), "service_name: ignored.googleapis.com\n")
+				library.Gcloud = &config.GcloudSurface{Config: path}
+			},
+			want: &provider.Config{ServiceName: "publicca.googleapis.com"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			library := &config.Library{Name: "publicca", Output: t.TempDir()}
+			test.setup(t, library)
+			got, err := readOverrides(library)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadOverrides_Error(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		library func(t *testing.T) *config.Library
+	}{
+		{
+			name: "missing explicit config",
+			library: func(t *testing.T) *config.Library {
+				return &config.Library{
+					Output: t.TempDir(),
+					Gcloud: &config.GcloudSurface{Config: filepath.Join(t.TempDir(), "missing.yaml")},
+				}
+			},
+		},
+		{
+			name: "invalid config",
+			library: func(t *testing.T) *config.Library {
+				out := t.TempDir()
+				writeFile(t, filepath.Join(out, gcloudConfigFile), "resource_patterns:\n  - patterns: [\"projects/{project}\"]\n")
+				return &config.Library{Output: out}
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := readOverrides(test.library(t)); err == nil {
+				t.Error("readOverrides() error = nil, want error")
+			}
+		})
 	}
 }
 
@@ -179,3 +323,10 @@ func collectFiles(t *testing.T, root string) map[string]string {
 	}
 	return out
 }
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,31 @@
+# -*- coding: utf-8 -*- #
+# Copyright 2026 Google LLC. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+"""File to add optional custom code to extend __init__.py."""
+from googlecloudsdk.calliope import base
+
+
+class ComputeAlpha(base.Group):
+  """Optional no-auto-generated code for ALPHA."""
+  category = base.UNCATEGORIZED_CATEGORY
+
+
+class ComputeBeta(base.Group):
+  """Optional no-auto-generated code for BETA."""
+  category = base.UNCATEGORIZED_CATEGORY
+
+
+class ComputeGa(base.Group):
+  """Optional no-auto-generated code for GA."""
+  category = base.UNCATEGORIZED_CATEGORY
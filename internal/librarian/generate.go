@@ -58,10 +58,17 @@ one of <library> or --all must be provided.
 Generation is delegated to the language-specific tooling configured in
 librarian.yaml. Libraries marked with skip_generate are skipped.
 
+The --verify flag runs the language's compile check in the output of each
+generated library, e.g. go build and go vet for Go, cargo check for Rust, or
+surfer validate for gcloud. The tools are resolved through the preinstalled
+map in the release config. Failures are reported for every library that does
+not build.
+
 Examples:
 
-	librarian generate <library>   # regenerate one library
-	librarian generate --all       # regenerate every library
+	librarian generate <library>            # regenerate one library
+	librarian generate --all                # regenerate every library
+	librarian generate --all --verify       # regenerate and check every library builds
 
 [after-flags]
 A typical librarian workflow for regenerating every library against the
@@ -74,6 +81,10 @@ latest API definitions is:
 				Name:  "all",
 				Usage: "generate all libraries",
 			},
+			&cli.BoolFlag{
+				Name:  "verify",
+				Usage: "check that the generated libraries build",
+			},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			all := cmd.Bool("all")
@@ -88,12 +99,12 @@ latest API definitions is:
 			if err != nil {
 				return err
 			}
-			return runGenerate(ctx, cfg, all, libraryName)
+			return runGenerate(ctx, cfg, all, libraryName, cmd.Bool("verify"))
 		},
 	}
 }
 
-func runGenerate(ctx context.Context, cfg *config.Config, all bool, libraryName string) error {
+func runGenerate(ctx context.Context, cfg *config.Config, all bool, libraryName string, verify bool) error {
 	sources, err := LoadSources(ctx, cfg.Sources)
 	if err != nil {
 		return err
@@ -140,7 +151,13 @@ func runGenerate(ctx context.Context, cfg *config.Config, all bool, libraryName
 	if err := cleanLibraries(cfg.Language, libraries); err != nil {
 		return err
 	}
-	return generateLibraries(ctx, cfg, libraries, sources)
+	if err := generateLibraries(ctx, cfg, libraries, sources); err != nil {
+		return err
+	}
+	if !verify {
+		return nil
+	}
+	return verifyLibraries(ctx, cfg, libraries)
 }
 
 // cleanLibraries iterates over all the given libraries sequentially,
@@ -170,7 +187,7 @@ func cleanLibraries(language string, libraries []*config.Library) error {
 		case config.LanguageSwift:
 			err = checkAndClean(library.Output, library.Keep)
 		case config.LanguageGcloud:
-			// No-op. gcloud generation does not support cleaning yet.
+			err = gcloud.Clean(library)
 		default:
 			err = fmt.Errorf("language %q does not support cleaning", language)
 		}
@@ -99,6 +99,12 @@ func TestGenerateCommand(t *testing.T) {
 			want:             []string{lib1, lib2, lib1PreviewName},
 			wantPostGenerate: true,
 		},
+		{
+			name:             "all flag with verify",
+			args:             []string{"librarian", "generate", "--all", "--verify"},
+			want:             []string{lib1, lib2, lib1PreviewName},
+			wantPostGenerate: true,
+		},
 		{
 			name:    "skip generate",
 			args:    []string{"librarian", "generate", lib3},
@@ -0,0 +1,150 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golang
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/semver"
+	"golang.org/x/exp/apidiff"
+	"golang.org/x/tools/go/packages"
+)
+
+var (
+	// ErrIncompatibleAPIChange is returned when a stable module has
+	// incompatible API changes since its last release.
+	ErrIncompatibleAPIChange = errors.New("incompatible API changes in stable module")
+	// ErrMinorBumpRequired is returned when a module has API changes since its
+	// last release but the next version is only a patch release.
+	ErrMinorBumpRequired = errors.New("API changes require at least a minor version bump")
+
+	errNoPackages = errors.New("no packages found")
+)
+
+// APIChanges describes how the exported API of a Go module changed between
+// two revisions. Each entry is a human-readable description of one change,
+// as reported by apidiff.
+type APIChanges struct {
+	Incompatible []string
+	Compatible   []string
+}
+
+// CompareAPI loads the non-internal packages of the Go modules rooted at
+// oldDir and newDir and reports the differences in their exported API.
+func CompareAPI(ctx context.Context, oldDir, newDir string) (*APIChanges, error) {
+	oldModule, err := loadModule(ctx, oldDir)
+	if err != nil {
+		return nil, err
+	}
+	newModule, err := loadModule(ctx, newDir)
+	if err != nil {
+		return nil, err
+	}
+	changes := &APIChanges{}
+	for _, c := range apidiff.ModuleChanges(oldModule, newModule).Changes {
+		if c.Compatible {
+			changes.Compatible = append(changes.Compatible, c.Message)
+		} else {
+			changes.Incompatible = append(changes.Incompatible, c.Message)
+		}
+	}
+	// ModuleChanges iterates over maps, so the order is not stable.
+	slices.Sort(changes.Compatible)
+	slices.Sort(changes.Incompatible)
+	return changes, nil
+}
+
+// CheckAPICompatibility verifies that releasing library as nextVersion is
+// consistent with the API changes since its current version. Any API change
+// requires at least a minor version bump. Incompatible changes are rejected
+// for stable (v1+) modules unless the library is migrating to a new major
+// version via [config.GoModule.ModulePathVersion].
+func CheckAPICompatibility(library *config.Library, changes *APIChanges, nextVersion string) error {
+	current, err := semver.Parse(library.Version)
+	if err != nil {
+		return err
+	}
+	next, err := semver.Parse(nextVersion)
+	if err != nil {
+		return err
+	}
+	if len(changes.Incompatible) > 0 && current.Major >= 1 && !isMajorMigration(library, current.Major) {
+		return fmt.Errorf("%w: %s %s:\n  %s", ErrIncompatibleAPIChange, library.Name, library.Version, strings.Join(changes.Incompatible, "\n  "))
+	}
+	hasChanges := len(changes.Incompatible) > 0 || len(changes.Compatible) > 0
+	if hasChanges && next.Major == current.Major && next.Minor == current.Minor {
+		all := slices.Concat(changes.Incompatible, changes.Compatible)
+		return fmt.Errorf("%w: %s %s -> %s:\n  %s", ErrMinorBumpRequired, library.Name, library.Version, nextVersion, strings.Join(all, "\n  "))
+	}
+	return nil
+}
+
+// isMajorMigration reports whether the library's module path version
+// requests a major version later than currentMajor.
+func isMajorMigration(library *config.Library, currentMajor int) bool {
+	if library.Go == nil || library.Go.ModulePathVersion == "" {
+		return false
+	}
+	major, err := strconv.Atoi(strings.TrimPrefix(library.Go.ModulePathVersion, "v"))
+	if err != nil {
+		return false
+	}
+	return major > currentMajor
+}
+
+// loadModule loads the type information of every non-internal package in the
+// Go module rooted at dir. Nested modules are not included.
+func loadModule(ctx context.Context, dir string) (*apidiff.Module, error) {
+	cfg := &packages.Config{
+		Context: ctx,
+		Dir:     dir,
+		Mode:    packages.NeedName | packages.NeedTypes | packages.NeedImports | packages.NeedDeps | packages.NeedModule,
+	}
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load packages in %s: %w", dir, err)
+	}
+	if len(pkgs) == 0 || pkgs[0].Module == nil {
+		return nil, fmt.Errorf("%w: %s", errNoPackages, dir)
+	}
+	module := &apidiff.Module{Path: pkgs[0].Module.Path}
+	var errs []error
+	for _, p := range pkgs {
+		for _, e := range p.Errors {
+			errs = append(errs, e)
+		}
+		if isInternalPackage(p.PkgPath, module.Path) {
+			continue
+		}
+		module.Packages = append(module.Packages, p.Types)
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to load packages in %s: %w", dir, errors.Join(errs...))
+	}
+	return module, nil
+}
+
+// isInternalPackage reports whether pkgPath is an internal package of the
+// module with the given path, and therefore not part of its exported API.
+func isInternalPackage(pkgPath, modulePath string) bool {
+	rel := strings.TrimPrefix(pkgPath, modulePath)
+	return strings.Contains(rel+"/", "/internal/")
+}
@@ -0,0 +1,260 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golang
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/command"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/testhelper"
+)
+
+func TestCompareAPI(t *testing.T) {
+	testhelper.RequireCommand(t, command.Go)
+	for _, test := range []struct {
+		name     string
+		oldFiles map[string]string
+		newFiles map[string]string
+		want     *APIChanges
+	}{
+		{
+			name: "no changes",
+			oldFiles: map[string]string{
+				"foo.go": "package foo\n\nfunc Foo() {}\n",
+			},
+			newFiles: map[string]string{
+				"foo.go": "package foo\n\nfunc Foo() {}\n",
+			},
+			want: &APIChanges{},
+		},
+		{
+			name: "compatible addition",
+			oldFiles: map[string]string{
+				"foo.go": "package foo\n\nfunc Foo() {}\n",
+			},
+			newFiles: map[string]string{
+				"foo.go": "package foo\n\nfunc Foo() {}\n\nfunc Bar() {}\n",
+			},
+			want: &APIChanges{
+				Compatible: []string{"Bar: added"},
+			},
+		},
+		{
+			name: "incompatible removal",
+			oldFiles: map[string]string{
+				"foo.go": "package foo\n\nfunc Foo() {}\n\nfunc Bar() {}\n",
+			},
+			newFiles: map[string]string{
+				"foo.go": "package foo\n\nfunc Foo() {}\n",
+			},
+			want: &APIChanges{
+				Incompatible: []string{"Bar: removed"},
+			},
+		},
+		{
+			name: "internal packages ignored",
+			oldFiles: map[string]string{
+				"foo.go":               "package foo\n\nfunc Foo() {}\n",
+				"internal/internal.go": "package internal\n\nfunc Old() {}\n",
+			},
+			newFiles: map[string]string{
+				"foo.go":               "package foo\n\nfunc Foo() {}\n",
+				"internal/internal.go": "package internal\n\nfunc New() {}\n",
+			},
+			want: &APIChanges{},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			oldDir := writeTestModule(t, "example.com/foo", test.oldFiles)
+			newDir := writeTestModule(t, "example.com/foo", test.newFiles)
+			got, err := CompareAPI(t.Context(), oldDir, newDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCompareAPI_Error(t *testing.T) {
+	testhelper.RequireCommand(t, command.Go)
+	valid := writeTestModule(t, "example.com/foo", map[string]string{
+		"foo.go": "package foo\n\nfunc Foo() {}\n",
+	})
+	broken := writeTestModule(t, "example.com/foo", map[string]string{
+		"foo.go": "package foo\n\nfunc Foo() { undefined() }\n",
+	})
+	for _, test := range []struct {
+		name   string
+		oldDir string
+		newDir string
+	}{
+		{
+			name:   "old module does not compile",
+			oldDir: broken,
+			newDir: valid,
+		},
+		{
+			name:   "new module does not compile",
+			oldDir: valid,
+			newDir: broken,
+		},
+		{
+			name:   "not a module",
+			oldDir: valid,
+			newDir: t.TempDir(),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := CompareAPI(t.Context(), test.oldDir, test.newDir); err == nil {
+				t.Error("CompareAPI() expected error; got nil")
+			}
+		})
+	}
+}
+
+func TestCheckAPICompatibility(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		library     *config.Library
+		changes     *APIChanges
+		nextVersion string
+	}{
+		{
+			name:        "no changes patch release",
+			library:     &config.Library{Name: "foo", Version: "1.2.3"},
+			changes:     &APIChanges{},
+			nextVersion: "1.2.4",
+		},
+		{
+			name:        "compatible changes minor release",
+			library:     &config.Library{Name: "foo", Version: "1.2.3"},
+			changes:     &APIChanges{Compatible: []string{"Bar: added"}},
+			nextVersion: "1.3.0",
+		},
+		{
+			name:        "incompatible changes in preview module",
+			library:     &config.Library{Name: "foo", Version: "0.2.3"},
+			changes:     &APIChanges{Incompatible: []string{"Bar: removed"}},
+			nextVersion: "0.3.0",
+		},
+		{
+			name: "incompatible changes in major migration",
+			library: &config.Library{
+				Name:    "foo",
+				Version: "1.2.3",
+				Go:      &config.GoModule{ModulePathVersion: "v2"},
+			},
+			changes:     &APIChanges{Incompatible: []string{"Bar: removed"}},
+			nextVersion: "2.0.0",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if err := CheckAPICompatibility(test.library, test.changes, test.nextVersion); err != nil {
+				t.Errorf("CheckAPICompatibility() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckAPICompatibility_Error(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		library     *config.Library
+		changes     *APIChanges
+		nextVersion string
+		wantErr     error
+	}{
+		{
+			name:        "incompatible changes in stable module",
+			library:     &config.Library{Name: "foo", Version: "1.2.3"},
+			changes:     &APIChanges{Incompatible: []string{"Bar: removed"}},
+			nextVersion: "1.3.0",
+			wantErr:     ErrIncompatibleAPIChange,
+		},
+		{
+			name: "module path version already current",
+			library: &config.Library{
+				Name:    "foo",
+				Version: "2.1.0",
+				Go:      &config.GoModule{ModulePathVersion: "v2"},
+			},
+			changes:     &APIChanges{Incompatible: []string{"Bar: removed"}},
+			nextVersion: "2.2.0",
+			wantErr:     ErrIncompatibleAPIChange,
+		},
+		{
+			name:        "compatible changes in patch release",
+			library:     &config.Library{Name: "foo", Version: "1.2.3"},
+			changes:     &APIChanges{Compatible: []string{"Bar: added"}},
+			nextVersion: "1.2.4",
+			wantErr:     ErrMinorBumpRequired,
+		},
+		{
+			name:        "incompatible changes in preview patch release",
+			library:     &config.Library{Name: "foo", Version: "0.2.3"},
+			changes:     &APIChanges{Incompatible: []string{"Bar: removed"}},
+			nextVersion: "0.2.4",
+			wantErr:     ErrMinorBumpRequired,
+		},
+		{
+			name:        "invalid current version",
+			library:     &config.Library{Name: "foo", Version: "invalid"},
+			changes:     &APIChanges{},
+			nextVersion: "1.2.4",
+		},
+		{
+			name:        "invalid next version",
+			library:     &config.Library{Name: "foo", Version: "1.2.3"},
+			changes:     &APIChanges{},
+			nextVersion: "invalid",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := CheckAPICompatibility(test.library, test.changes, test.nextVersion)
+			if err == nil {
+				t.Fatal("CheckAPICompatibility() expected error; got nil")
+			}
+			if test.wantErr != nil && !errors.Is(err, test.wantErr) {
+				t.Errorf("CheckAPICompatibility() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+// writeTestModule creates a Go module with the given path and files in a new
+// temporary directory, and returns that directory.
+func writeTestModule(t *testing.T, modulePath string, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	files["go.mod"] = "module " + modulePath + "\n\ngo 1.21\n"
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
@@ -142,8 +142,14 @@ func updateSnippetsModule(ctx context.Context, library *config.Library, outDir s
 		return fmt.Errorf("failed to get relative path of module: %w", err)
 	}
 	modPath := modulePath(library)
+	// The replace directive makes the version irrelevant, but it must match
+	// the major version of the module path.
+	version := "v0.0.0"
+	if library.Go.ModulePathVersion != "" {
+		version = library.Go.ModulePathVersion + ".0.0"
+	}
 	return command.RunInDir(ctx, snippetsDir, command.Go, "mod", "edit",
-		"-require="+modPath+"@v0.0.0",
+		"-require="+modPath+"@"+version,
 		"-replace="+modPath+"="+filepath.Join("../../..", modDir))
 }
 
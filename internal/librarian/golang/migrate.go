@@ -0,0 +1,302 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golang
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/command"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/semver"
+)
+
+var (
+	errMajorMigrationPreGA   = errors.New("libraries before v1 do not need a module path migration")
+	errMajorMigrationStarted = errors.New("module path version is already ahead of the library version")
+
+	importCommentRegex = regexp.MustCompile(`^// import ("[^"]*")`)
+)
+
+// MigrateMajorVersion moves a stable library to the next major version of its
+// module path, e.g. from cloud.google.com/go/foo to cloud.google.com/go/foo/v2.
+// It updates the module directive in go.mod, rewrites imports of the module's
+// packages in the library and its snippets, adjusts each [config.GoAPI]
+// import path, and points the snippets module and go.work at the new module
+// path. Files are edited in place and none are removed, so files listed in
+// [config.Library.Keep] are preserved. Only import declarations and package
+// import comments are rewritten. Other references to the old module path,
+// such as in README files, documentation comments or snippet metadata, are
+// left unchanged: generated files pick up the new path when the library is
+// regenerated, and handwritten files must be updated by hand. Only the module
+// path version and the changed import paths are written back to library,
+// leaving the other Go defaults unset in its configuration. It returns the
+// next version of the library, e.g. "2.0.0".
+func MigrateMajorVersion(ctx context.Context, library *config.Library, output string) (string, error) {
+	current, err := semver.Parse(library.Version)
+	if err != nil {
+		return "", err
+	}
+	if current.Major < 1 {
+		return "", fmt.Errorf("%w: %s %s", errMajorMigrationPreGA, library.Name, library.Version)
+	}
+	if isMajorMigration(library, current.Major) {
+		return "", fmt.Errorf("%w: %s %s is at %s", errMajorMigrationStarted, library.Name, library.Version, library.Go.ModulePathVersion)
+	}
+	original := library
+	library, err = Fill(copyGoLibrary(library))
+	if err != nil {
+		return "", err
+	}
+	nextMajor := current.Major + 1
+	oldModulePath := modulePath(library)
+	oldPrefix := strings.TrimPrefix(oldModulePath, "cloud.google.com/go/")
+	library.Go.ModulePathVersion = fmt.Sprintf("v%d", nextMajor)
+	newModulePath := modulePath(library)
+	newPrefix := strings.TrimPrefix(newModulePath, "cloud.google.com/go/")
+
+	if err := command.RunInDir(ctx, output, command.Go, "mod", "edit", "-module="+newModulePath); err != nil {
+		return "", err
+	}
+	r := &importRewriter{oldPath: oldModulePath, newPath: newModulePath}
+	if library.Go.NestedModule != "" {
+		// The nested module keeps its own module path.
+		r.excluded = append(r.excluded, oldModulePath+"/"+library.Go.NestedModule)
+	}
+	if err := r.rewriteDir(output); err != nil {
+		return "", err
+	}
+	for _, goAPI := range library.Go.GoAPIs {
+		if goAPI.ImportPath == oldPrefix || strings.HasPrefix(goAPI.ImportPath, oldPrefix+"/") {
+			goAPI.ImportPath = newPrefix + strings.TrimPrefix(goAPI.ImportPath, oldPrefix)
+		}
+		snippetDir := findSnippetDirectory(library, goAPI, output)
+		if snippetDir == "" {
+			continue
+		}
+		if _, err := os.Stat(snippetDir); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err := r.rewriteDir(snippetDir); err != nil {
+			return "", err
+		}
+	}
+	if err := migrateSnippetsModule(ctx, library, output, oldModulePath); err != nil {
+		return "", err
+	}
+	if err := useInWorkspace(ctx, library, output); err != nil {
+		return "", err
+	}
+	copyMigratedConfig(original, library)
+	return fmt.Sprintf("%d.0.0", nextMajor), nil
+}
+
+// copyGoLibrary returns a copy of library that [Fill] can populate without
+// modifying library. The preview is dropped, as the migration does not
+// apply to it.
+func copyGoLibrary(library *config.Library) *config.Library {
+	c := *library
+	c.Preview = nil
+	if library.Go != nil {
+		goModule := *library.Go
+		goModule.GoAPIs = nil
+		for _, goAPI := range library.Go.GoAPIs {
+			g := *goAPI
+			goModule.GoAPIs = append(goModule.GoAPIs, &g)
+		}
+		c.Go = &goModule
+	}
+	return &c
+}
+
+// copyMigratedConfig copies the module path version and the changed import
+// paths of the migrated library back to library. A [config.GoAPI] is added
+// for an import path that was previously derived from the API path.
+func copyMigratedConfig(library, migrated *config.Library) {
+	if library.Go == nil {
+		library.Go = &config.GoModule{}
+	}
+	library.Go.ModulePathVersion = migrated.Go.ModulePathVersion
+	for _, m := range migrated.Go.GoAPIs {
+		goAPI := findGoAPI(library, m.Path)
+		importPath, _ := defaultImportPathAndClientPkg(m.Path)
+		if goAPI != nil && goAPI.ImportPath != "" {
+			importPath = goAPI.ImportPath
+		}
+		if m.ImportPath == importPath {
+			continue
+		}
+		if goAPI == nil {
+			goAPI = &config.GoAPI{Path: m.Path}
+			library.Go.GoAPIs = append(library.Go.GoAPIs, goAPI)
+		}
+		goAPI.ImportPath = m.ImportPath
+	}
+}
+
+// migrateSnippetsModule replaces the requirement and local replacement of
+// oldModulePath in the snippets module's go.mod file with the library's
+// current module path. Repositories without a snippets module are left
+// unchanged.
+func migrateSnippetsModule(ctx context.Context, library *config.Library, output, oldModulePath string) error {
+	snippetsDir := filepath.Join(repoRootPath(output, library.Name), "internal", "generated", "snippets")
+	if _, err := os.Stat(filepath.Join(snippetsDir, "go.mod")); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to stat snippets go.mod: %w", err)
+	}
+	hasSnippets := slices.ContainsFunc(library.Go.GoAPIs, func(api *config.GoAPI) bool {
+		return !api.NoSnippets
+	})
+	if !hasSnippets {
+		return nil
+	}
+	if err := command.RunInDir(ctx, snippetsDir, command.Go, "mod", "edit",
+		"-droprequire="+oldModulePath,
+		"-dropreplace="+oldModulePath); err != nil {
+		return err
+	}
+	return updateSnippetsModule(ctx, library, output)
+}
+
+// useInWorkspace makes sure the library's module is listed in the go.work
+// file at the repository root, if there is one.
+func useInWorkspace(ctx context.Context, library *config.Library, output string) error {
+	repoRoot := repoRootPath(output, library.Name)
+	if _, err := os.Stat(filepath.Join(repoRoot, "go.work")); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to stat go.work: %w", err)
+	}
+	modDir, err := filepath.Rel(repoRoot, output)
+	if err != nil {
+		return fmt.Errorf("failed to get relative path of module: %w", err)
+	}
+	return command.RunInDir(ctx, repoRoot, command.Go, "work", "use", "./"+filepath.ToSlash(modDir))
+}
+
+// importRewriter rewrites Go import paths under oldPath to the same path
+// under newPath.
+type importRewriter struct {
+	oldPath  string
+	newPath  string
+	excluded []string
+}
+
+// rewrite returns the import path p, moved from oldPath to newPath if
+// needed.
+func (r *importRewriter) rewrite(p string) string {
+	under := func(prefix string) bool {
+		return p == prefix || strings.HasPrefix(p, prefix+"/")
+	}
+	if under(r.newPath) || !under(r.oldPath) || slices.ContainsFunc(r.excluded, under) {
+		return p
+	}
+	return r.newPath + strings.TrimPrefix(p, r.oldPath)
+}
+
+// rewriteDir rewrites the imports of all Go files in dir, skipping any
+// nested modules.
+func (r *importRewriter) rewriteDir(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == dir {
+				return nil
+			}
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".go" {
+			return nil
+		}
+		return r.rewriteFile(path)
+	})
+}
+
+// rewriteFile rewrites the import declarations and the package import comment
+// of a single Go file. The rest of the file is left untouched.
+func (r *importRewriter) rewriteFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, content, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return err
+	}
+	type edit struct {
+		start, end int
+		value      string
+	}
+	var edits []edit
+	add := func(pos token.Pos, quoted string) error {
+		value, err := strconv.Unquote(quoted)
+		if err != nil {
+			return fmt.Errorf("%s: %w", fset.Position(pos), err)
+		}
+		if rewritten := r.rewrite(value); rewritten != value {
+			start := fset.Position(pos).Offset
+			edits = append(edits, edit{start: start, end: start + len(quoted), value: strconv.Quote(rewritten)})
+		}
+		return nil
+	}
+	packageLine := fset.Position(f.Package).Line
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			if fset.Position(c.Slash).Line != packageLine {
+				continue
+			}
+			if m := importCommentRegex.FindStringSubmatchIndex(c.Text); m != nil {
+				if err := add(c.Slash+token.Pos(m[2]), c.Text[m[2]:m[3]]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for _, spec := range f.Imports {
+		if err := add(spec.Path.Pos(), spec.Path.Value); err != nil {
+			return err
+		}
+	}
+	if len(edits) == 0 {
+		return nil
+	}
+	// Apply the edits back to front so earlier offsets remain valid.
+	slices.SortFunc(edits, func(a, b edit) int { return b.start - a.start })
+	for _, e := range edits {
+		content = slices.Concat(content[:e.start], []byte(e.value), content[e.end:])
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, info.Mode().Perm())
+}
@@ -0,0 +1,347 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golang
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/command"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/testhelper"
+)
+
+func TestMigrateMajorVersion(t *testing.T) {
+	testhelper.RequireCommand(t, command.Go)
+	for _, test := range []struct {
+		name              string
+		library           *config.Library
+		files             map[string]string
+		wantVersion       string
+		wantPathVersion   string
+		wantGoAPIs        []*config.GoAPI
+		wantFiles         map[string]string
+		wantSnippetsGoMod []string
+	}{
+		{
+			name: "v1 to v2",
+			library: &config.Library{
+				Name:    "secretmanager",
+				Version: "1.4.0",
+				APIs:    []*config.API{{Path: "google/cloud/secretmanager/v1"}},
+				Keep:    []string{"handwritten.go"},
+			},
+			files: map[string]string{
+				"secretmanager/go.mod": "module cloud.google.com/go/secretmanager\n\ngo 1.21\n",
+				"secretmanager/apiv1/doc.go": `package secretmanager // import "cloud.google.com/go/secretmanager/apiv1"
+
+import (
+	"context"
+
+	"cloud.google.com/go/secretmanager/internal"
+)
+`,
+				"secretmanager/handwritten.go": `package secretmanager
+
+import smpb "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+import "cloud.google.com/go/secretmanagerother"
+`,
+				"secretmanager/README.md": "cloud.google.com/go/secretmanager\n",
+				"internal/generated/snippets/secretmanager/apiv1/Client/main.go": "package main\n\nimport secretmanager \"cloud.google.com/go/secretmanager/apiv1\"\n",
+				"internal/generated/snippets/go.mod":                             "module cloud.google.com/go/internal/generated/snippets\n\ngo 1.21\n\nrequire cloud.google.com/go/secretmanager v0.0.0\n\nreplace cloud.google.com/go/secretmanager => ../../../secretmanager\n",
+			},
+			wantVersion:     "2.0.0",
+			wantPathVersion: "v2",
+			wantGoAPIs: []*config.GoAPI{
+				{Path: "google/cloud/secretmanager/v1", ImportPath: "secretmanager/v2/apiv1"},
+			},
+			wantFiles: map[string]string{
+				"secretmanager/apiv1/doc.go": `package secretmanager // import "cloud.google.com/go/secretmanager/v2/apiv1"
+
+import (
+	"context"
+
+	"cloud.google.com/go/secretmanager/v2/internal"
+)
+`,
+				"secretmanager/handwritten.go": `package secretmanager
+
+import smpb "cloud.google.com/go/secretmanager/v2/apiv1/secretmanagerpb"
+import "cloud.google.com/go/secretmanagerother"
+`,
+				"secretmanager/README.md": "cloud.google.com/go/secretmanager\n",
+				"internal/generated/snippets/secretmanager/apiv1/Client/main.go": "package main\n\nimport secretmanager \"cloud.google.com/go/secretmanager/v2/apiv1\"\n",
+			},
+			wantSnippetsGoMod: []string{
+				"require cloud.google.com/go/secretmanager/v2 v2.0.0",
+				"replace cloud.google.com/go/secretmanager/v2 => ../../../secretmanager",
+			},
+		},
+		{
+			name: "v2 to v3 with nested module",
+			library: &config.Library{
+				Name:    "pubsub",
+				Version: "2.3.1",
+				APIs:    []*config.API{{Path: "google/pubsub/v1"}},
+				Go: &config.GoModule{
+					ModulePathVersion: "v2",
+					NestedModule:      "nested",
+					GoAPIs: []*config.GoAPI{
+						{
+							Path:          "google/pubsub/v1",
+							ImportPath:    "pubsub/v2/apiv1",
+							ClientPackage: "pubsub",
+							NoSnippets:    true,
+						},
+					},
+				},
+			},
+			files: map[string]string{
+				"pubsub/go.mod": "module cloud.google.com/go/pubsub/v2\n\ngo 1.21\n",
+				"pubsub/pubsub.go": `package pubsub
+
+import (
+	"cloud.google.com/go/pubsub/v2/apiv1"
+	"cloud.google.com/go/pubsub/v2/nested"
+)
+`,
+				"pubsub/nested/go.mod":    "module cloud.google.com/go/pubsub/v2/nested\n\ngo 1.21\n",
+				"pubsub/nested/nested.go": "package nested\n\nimport \"cloud.google.com/go/pubsub/v2/apiv1\"\n",
+			},
+			wantVersion:     "3.0.0",
+			wantPathVersion: "v3",
+			wantGoAPIs: []*config.GoAPI{
+				{
+					Path:          "google/pubsub/v1",
+					ImportPath:    "pubsub/v3/apiv1",
+					ClientPackage: "pubsub",
+					NoSnippets:    true,
+				},
+			},
+			wantFiles: map[string]string{
+				"pubsub/pubsub.go": `package pubsub
+
+import (
+	"cloud.google.com/go/pubsub/v3/apiv1"
+	"cloud.google.com/go/pubsub/v2/nested"
+)
+`,
+				"pubsub/nested/go.mod":    "module cloud.google.com/go/pubsub/v2/nested\n\ngo 1.21\n",
+				"pubsub/nested/nested.go": "package nested\n\nimport \"cloud.google.com/go/pubsub/v2/apiv1\"\n",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			repoRoot := t.TempDir()
+			for name, content := range test.files {
+				path := filepath.Join(repoRoot, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			output := filepath.Join(repoRoot, test.library.Name)
+			got, err := MigrateMajorVersion(t.Context(), test.library, output)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.wantVersion {
+				t.Errorf("MigrateMajorVersion() = %q, want %q", got, test.wantVersion)
+			}
+			if test.library.Go.ModulePathVersion != test.wantPathVersion {
+				t.Errorf("ModulePathVersion = %q, want %q", test.library.Go.ModulePathVersion, test.wantPathVersion)
+			}
+			if diff := cmp.Diff(test.wantGoAPIs, test.library.Go.GoAPIs); diff != "" {
+				t.Errorf("GoAPIs mismatch (-want +got):\n%s", diff)
+			}
+			goMod, err := os.ReadFile(filepath.Join(output, "go.mod"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantModule := "module " + modulePath(test.library) + "\n"
+			if !strings.HasPrefix(string(goMod), wantModule) {
+				t.Errorf("go.mod = %q, want prefix %q", goMod, wantModule)
+			}
+			for name, want := range test.wantFiles {
+				content, err := os.ReadFile(filepath.Join(repoRoot, name))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(want, string(content)); diff != "" {
+					t.Errorf("%s mismatch (-want +got):\n%s", name, diff)
+				}
+			}
+			if len(test.wantSnippetsGoMod) == 0 {
+				return
+			}
+			snippetsGoMod, err := os.ReadFile(filepath.Join(repoRoot, "internal", "generated", "snippets", "go.mod"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range test.wantSnippetsGoMod {
+				if !strings.Contains(string(snippetsGoMod), want) {
+					t.Errorf("snippets/go.mod missing %q:\n%s", want, snippetsGoMod)
+				}
+			}
+			if strings.Contains(string(snippetsGoMod), "cloud.google.com/go/secretmanager v0.0.0") {
+				t.Errorf("snippets/go.mod still requires the old module path:\n%s", snippetsGoMod)
+			}
+		})
+	}
+}
+
+func TestMigrateMajorVersion_GoWork(t *testing.T) {
+	testhelper.RequireCommand(t, command.Go)
+	repoRoot := t.TempDir()
+	output := filepath.Join(repoRoot, "foo")
+	if err := os.MkdirAll(output, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(output, "go.mod"), []byte("module cloud.google.com/go/foo\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repoRoot, "go.work"), []byte("go 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	library := &config.Library{Name: "foo", Version: "1.0.0"}
+	if _, err := MigrateMajorVersion(t.Context(), library, output); err != nil {
+		t.Fatal(err)
+	}
+	goWork, err := os.ReadFile(filepath.Join(repoRoot, "go.work"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(goWork), "use ./foo") {
+		t.Errorf("go.work does not use the migrated module:\n%s", goWork)
+	}
+}
+
+func TestMigrateMajorVersion_Build(t *testing.T) {
+	testhelper.RequireCommand(t, command.Go)
+	repoRoot := t.TempDir()
+	for name, content := range map[string]string{
+		"foo/go.mod":                "module cloud.google.com/go/foo\n\ngo 1.21\n",
+		"foo/internal/version.go":   "package internal\n\nconst Version = \"1.2.0\"\n",
+		"foo/apiv1/version.go":      "package foo // import \"cloud.google.com/go/foo/apiv1\"\n\nimport \"cloud.google.com/go/foo/internal\"\n\nconst Version = internal.Version\n",
+		"foo/apiv1/foopb/foo.pb.go": "package foopb\n\ntype Foo struct{}\n",
+		"foo/apiv1/client.go":       "package foo\n\nimport foopb \"cloud.google.com/go/foo/apiv1/foopb\"\n\nfunc New() *foopb.Foo { return \u0026foopb.Foo{} }\n",
+		"internal/generated/snippets/go.mod": "module cloud.google.com/go/internal/generated/snippets\n\ngo 1.21\n\n" +
+			"require cloud.google.com/go/foo v0.0.0\n\nreplace cloud.google.com/go/foo => ../../../foo\n",
+		"internal/generated/snippets/foo/apiv1/Client/New/main.go": "package main\n\nimport foo \"cloud.google.com/go/foo/apiv1\"\n\nfunc main() {\n\t_ = foo.New()\n}\n",
+	} {
+		path := filepath.Join(repoRoot, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	library := &config.Library{
+		Name:    "foo",
+		Version: "1.2.0",
+		APIs:    []*config.API{{Path: "google/cloud/foo/v1"}},
+	}
+	output := filepath.Join(repoRoot, "foo")
+	if _, err := MigrateMajorVersion(t.Context(), library, output); err != nil {
+		t.Fatal(err)
+	}
+	env := map[string]string{"GOWORK": "off", "GOFLAGS": "-mod=mod", "GOPROXY": "off"}
+	for _, dir := range []string{output, filepath.Join(repoRoot, "internal", "generated", "snippets")} {
+		if err := command.RunInDirWithEnv(t.Context(), dir, env, command.Go, "build", "./..."); err != nil {
+			t.Errorf("go build in %s failed after the migration: %v", dir, err)
+		}
+	}
+}
+
+func TestMigrateMajorVersion_Error(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		library *config.Library
+		wantErr error
+	}{
+		{
+			name:    "pre-GA library",
+			library: &config.Library{Name: "foo", Version: "0.5.0"},
+			wantErr: errMajorMigrationPreGA,
+		},
+		{
+			name: "migration already started",
+			library: &config.Library{
+				Name:    "foo",
+				Version: "1.5.0",
+				Go:      &config.GoModule{ModulePathVersion: "v2"},
+			},
+			wantErr: errMajorMigrationStarted,
+		},
+		{
+			name:    "invalid version",
+			library: &config.Library{Name: "foo", Version: "invalid"},
+		},
+		{
+			name: "missing go.mod",
+			library: &config.Library{
+				Name:    "foo",
+				Version: "1.5.0",
+				APIs:    []*config.API{{Path: "google/cloud/foo/v1"}},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			want := copyGoLibrary(test.library)
+			_, err := MigrateMajorVersion(t.Context(), test.library, t.TempDir())
+			if err == nil {
+				t.Fatal("MigrateMajorVersion() expected error; got nil")
+			}
+			if test.wantErr != nil && !errors.Is(err, test.wantErr) {
+				t.Errorf("MigrateMajorVersion() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if diff := cmp.Diff(want, test.library); diff != "" {
+				t.Errorf("library mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestImportRewriter(t *testing.T) {
+	r := &importRewriter{
+		oldPath:  "cloud.google.com/go/foo",
+		newPath:  "cloud.google.com/go/foo/v2",
+		excluded: []string{"cloud.google.com/go/foo/nested"},
+	}
+	for _, test := range []struct {
+		path string
+		want string
+	}{
+		{path: "cloud.google.com/go/foo", want: "cloud.google.com/go/foo/v2"},
+		{path: "cloud.google.com/go/foo/apiv1", want: "cloud.google.com/go/foo/v2/apiv1"},
+		{path: "cloud.google.com/go/foo/v2/apiv1", want: "cloud.google.com/go/foo/v2/apiv1"},
+		{path: "cloud.google.com/go/foobar", want: "cloud.google.com/go/foobar"},
+		{path: "cloud.google.com/go/foo/nested/apiv1", want: "cloud.google.com/go/foo/nested/apiv1"},
+		{path: "context", want: "context"},
+	} {
+		t.Run(test.path, func(t *testing.T) {
+			if got := r.rewrite(test.path); got != test.want {
+				t.Errorf("rewrite(%q) = %q, want %q", test.path, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golang
+
+import (
+	"context"
+
+	"github.com/googleapis/librarian/internal/command"
+	"github.com/googleapis/librarian/internal/config"
+)
+
+// Verify checks that a generated Go library builds and passes go vet, using
+// goExe as the go command.
+func Verify(ctx context.Context, goExe string, library *config.Library) error {
+	// The root module does not have generated code, and building it would
+	// build the whole repository.
+	if library.Name == rootModule {
+		return nil
+	}
+	if err := command.RunInDir(ctx, library.Output, goExe, "build", "./..."); err != nil {
+		return err
+	}
+	return command.RunInDir(ctx, library.Output, goExe, "vet", "./...")
+}
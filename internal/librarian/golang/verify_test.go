@@ -0,0 +1,85 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golang
+
+import (
+	"testing"
+
+	"github.com/googleapis/librarian/internal/command"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/testhelper"
+)
+
+func TestVerify(t *testing.T) {
+	testhelper.RequireCommand(t, command.Go)
+	for _, test := range []struct {
+		name    string
+		library *config.Library
+		files   map[string]string
+	}{
+		{
+			name:    "module builds",
+			library: &config.Library{Name: "foo"},
+			files: map[string]string{
+				"foo.go": "package foo\n\nfunc Foo() {}\n",
+			},
+		},
+		{
+			name:    "root module",
+			library: &config.Library{Name: rootModule},
+			files: map[string]string{
+				"foo.go": "package foo\n\nfunc Foo() { undefined() }\n",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			test.library.Output = writeTestModule(t, "example.com/foo", test.files)
+			if err := Verify(t.Context(), command.Go, test.library); err != nil {
+				t.Errorf("Verify() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestVerify_Error(t *testing.T) {
+	testhelper.RequireCommand(t, command.Go)
+	for _, test := range []struct {
+		name  string
+		files map[string]string
+	}{
+		{
+			name: "build failure",
+			files: map[string]string{
+				"foo.go": "package foo\n\nfunc Foo() { undefined() }\n",
+			},
+		},
+		{
+			name: "vet failure",
+			files: map[string]string{
+				"foo.go": "package foo\n\nimport \"fmt\"\n\nfunc Foo() { fmt.Printf(\"%d\", \"foo\") }\n",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			library := &config.Library{
+				Name:   "foo",
+				Output: writeTestModule(t, "example.com/foo", test.files),
+			}
+			if err := Verify(t.Context(), command.Go, library); err == nil {
+				t.Error("Verify() expected error; got nil")
+			}
+		})
+	}
+}
@@ -64,6 +64,9 @@ func Run(ctx context.Context, args ...string) error {
 			bumpCommand(),
 			publishCommand(),
 			tagCommand(),
+			sidekickCommand(),
+			templatesCommand(),
+			conformanceCommand(),
 			versionCommand(),
 		},
 	}
@@ -331,6 +331,9 @@ func ResolvePreview(lib *config.Library, language string) *config.Library {
 	if p.Keep != nil {
 		res.Keep = p.Keep
 	}
+	if p.Mixins != nil {
+		res.Mixins = p.Mixins
+	}
 	if p.Output != "" {
 		res.Output = p.Output
 	}
@@ -346,11 +349,16 @@ func ResolvePreview(lib *config.Library, language string) *config.Library {
 	if p.SpecificationFormat != "" {
 		res.SpecificationFormat = p.SpecificationFormat
 	}
+	if p.TemplateOverlay != "" {
+		res.TemplateOverlay = p.TemplateOverlay
+	}
 	switch language {
 	case config.LanguageDotnet:
 		res.Dotnet = mergeDotnet(res.Dotnet, p.Dotnet)
 	case config.LanguageDart:
 		res.Dart = mergeDart(res.Dart, p.Dart)
+	case config.LanguageGcloud:
+		res.Gcloud = mergeGcloud(res.Gcloud, p.Gcloud)
 	case config.LanguageGo:
 		res.Go = mergeGo(res.Go, p.Go)
 	case config.LanguageJava:
@@ -585,6 +593,29 @@ func mergeJava(dst, src *config.JavaModule) *config.JavaModule {
 	return &res
 }
 
+func mergeGcloud(dst, src *config.GcloudSurface) *config.GcloudSurface {
+	if src == nil {
+		return dst
+	}
+	if dst == nil {
+		return src
+	}
+	res := *dst
+	if src.Config != "" {
+		res.Config = src.Config
+	}
+	if src.IncludeList != nil {
+		res.IncludeList = src.IncludeList
+	}
+	if src.DescriptorFiles != nil {
+		res.DescriptorFiles = src.DescriptorFiles
+	}
+	if src.DescriptorFilesToGenerate != nil {
+		res.DescriptorFilesToGenerate = src.DescriptorFilesToGenerate
+	}
+	return &res
+}
+
 func mergeNodejs(dst, src *config.NodejsPackage) *config.NodejsPackage {
 	if src == nil {
 		return dst
@@ -735,7 +766,7 @@ func mergeRust(dst, src *config.RustCrate) *config.RustCrate {
 	if src.IncludeGrpcOnlyMethods {
 		res.IncludeGrpcOnlyMethods = src.IncludeGrpcOnlyMethods
 	}
-	if src.IncludeStreamingMethods {
+	if src.IncludeStreamingMethods != nil {
 		res.IncludeStreamingMethods = src.IncludeStreamingMethods
 	}
 	if src.PostProcessProtos != "" {
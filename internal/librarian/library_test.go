@@ -714,6 +714,7 @@ func TestResolvePreview(t *testing.T) {
 				CopyrightYear:       "2024",
 				DescriptionOverride: "base desc",
 				Keep:                []string{"base-keep"},
+				Mixins:              &config.Mixins{Deny: []string{"google.iam.v1.IAMPolicy"}},
 				Output:              "base-out",
 				Roots:               []string{"base-root"},
 				SkipGenerate:        false,
@@ -729,6 +730,7 @@ func TestResolvePreview(t *testing.T) {
 					CopyrightYear:       "2025",
 					DescriptionOverride: "preview desc",
 					Keep:                []string{"preview-keep"},
+					Mixins:              &config.Mixins{Allow: []string{"google.cloud.location.Locations"}},
 					Output:              "preview-out",
 					Roots:               []string{"preview-root"},
 					SkipGenerate:        true,
@@ -746,6 +748,7 @@ func TestResolvePreview(t *testing.T) {
 				CopyrightYear:       "2025",
 				DescriptionOverride: "preview desc",
 				Keep:                []string{"preview-keep"},
+				Mixins:              &config.Mixins{Allow: []string{"google.cloud.location.Locations"}},
 				Output:              "preview-out",
 				Roots:               []string{"preview-root"},
 				SkipGenerate:        true,
@@ -1152,6 +1155,50 @@ func TestMergeJava(t *testing.T) {
 	}
 }
 
+func TestMergeGcloud(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		dst  *config.GcloudSurface
+		src  *config.GcloudSurface
+		want *config.GcloudSurface
+	}{
+		{
+			name: "nil src returns dst",
+			dst:  &config.GcloudSurface{Config: "foo.yaml"},
+			src:  nil,
+			want: &config.GcloudSurface{Config: "foo.yaml"},
+		},
+		{
+			name: "nil dst returns src",
+			dst:  nil,
+			src:  &config.GcloudSurface{Config: "bar.yaml"},
+			want: &config.GcloudSurface{Config: "bar.yaml"},
+		},
+		{
+			name: "merges all fields",
+			dst:  &config.GcloudSurface{Config: "foo.yaml", IncludeList: []string{"a.proto"}},
+			src: &config.GcloudSurface{
+				Config:                    "bar.yaml",
+				DescriptorFiles:           []string{"descriptors.pb"},
+				DescriptorFilesToGenerate: []string{"google/cloud/x/v1/x.proto"},
+			},
+			want: &config.GcloudSurface{
+				Config:                    "bar.yaml",
+				IncludeList:               []string{"a.proto"},
+				DescriptorFiles:           []string{"descriptors.pb"},
+				DescriptorFilesToGenerate: []string{"google/cloud/x/v1/x.proto"},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := mergeGcloud(test.dst, test.src)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestMergeNodejs(t *testing.T) {
 	for _, test := range []struct {
 		name string
@@ -1322,7 +1369,7 @@ func TestMergeRust(t *testing.T) {
 				HasVeneer:                 true,
 				RoutingRequired:           true,
 				IncludeGrpcOnlyMethods:    true,
-				IncludeStreamingMethods:   true,
+				IncludeStreamingMethods:   &detailedTracing,
 				PostProcessProtos:         "post",
 				DocumentationOverrides:    []config.RustDocumentationOverride{{ID: "id"}},
 				PaginationOverrides:       []config.RustPaginationOverride{{ID: "pid"}},
@@ -1353,7 +1400,7 @@ func TestMergeRust(t *testing.T) {
 				HasVeneer:                 true,
 				RoutingRequired:           true,
 				IncludeGrpcOnlyMethods:    true,
-				IncludeStreamingMethods:   true,
+				IncludeStreamingMethods:   &detailedTracing,
 				PostProcessProtos:         "post",
 				DocumentationOverrides:    []config.RustDocumentationOverride{{ID: "id"}},
 				PaginationOverrides:       []config.RustPaginationOverride{{ID: "pid"}},
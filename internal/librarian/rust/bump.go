@@ -26,6 +26,7 @@ import (
 
 	"github.com/googleapis/librarian/internal/config"
 	"github.com/googleapis/librarian/internal/semver"
+	"github.com/googleapis/librarian/internal/snippetmetadata"
 )
 
 var (
@@ -82,6 +83,9 @@ edition                = "2021"
 	if err := updateWorkspaceVersion("Cargo.toml", library.Name, version); err != nil && !errors.Is(err, fs.ErrNotExist) {
 		return err
 	}
+	if err := snippetmetadata.UpdateAllLibraryVersions(output, version.String()); err != nil {
+		return err
+	}
 	library.Version = version.String()
 	return nil
 }
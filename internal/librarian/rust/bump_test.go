@@ -115,6 +115,27 @@ func checkLibraryVersion(t *testing.T, library *config.Library, wantVersion stri
 	}
 }
 
+func TestBumpUpdatesSnippetMetadata(t *testing.T) {
+	t.Chdir(t.TempDir())
+	createCrate(t, storageDir, storageName, storageInitial)
+	metadata := filepath.Join(storageDir, "snippet_metadata_google.storage.v2.json")
+	if err := os.WriteFile(metadata, []byte(`{"clientLibrary": {"version": "1.0.0"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	library := &config.Library{Name: storageName, Version: storageInitial, Output: storageDir}
+	if err := writeVersion(library, storageDir, storageReleased); err != nil {
+		t.Fatal(err)
+	}
+	contents, err := os.ReadFile(metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := fmt.Sprintf(`"version": "%s"`, storageReleased)
+	if !strings.Contains(string(contents), want) {
+		t.Errorf("snippet metadata version mismatch:\nwant: %s\ngot:\n%s", want, contents)
+	}
+}
+
 func TestNoCargoFile(t *testing.T) {
 	err := writeVersion(&config.Library{Version: "1.0.0"}, "nonexistent/path", storageReleased)
 	if err == nil {
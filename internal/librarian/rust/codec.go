@@ -16,6 +16,7 @@ package rust
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/googleapis/librarian/internal/config"
@@ -25,7 +26,9 @@ import (
 	"github.com/googleapis/librarian/internal/sources"
 )
 
-func libraryToModelConfig(library *config.Library, ch *config.API, srcs *sources.Sources) (*parser.ModelConfig, error) {
+// ModelConfig returns the configuration used to parse an API of a library
+// into a sidekick model.
+func ModelConfig(library *config.Library, ch *config.API, srcs *sources.Sources) (*parser.ModelConfig, error) {
 	specFormat := config.SpecProtobuf
 	if library.SpecificationFormat != "" {
 		specFormat = library.SpecificationFormat
@@ -58,6 +61,7 @@ func libraryToModelConfig(library *config.Library, ch *config.API, srcs *sources
 		Source:              src,
 		ServiceConfig:       svcConfig.ServiceConfig,
 		Codec:               buildCodec(library, svcConfig.ReleaseLevel(config.LanguageRust, library.Version)),
+		Mixins:              library.Mixins,
 		Override: api.ModelOverride{
 			Description: library.DescriptionOverride,
 			Title:       svcConfig.Title,
@@ -130,8 +134,8 @@ func buildCodec(library *config.Library, releaseLevel string) map[string]string
 	if rust.IncludeGrpcOnlyMethods {
 		codec["include-grpc-only-methods"] = "true"
 	}
-	if rust.IncludeStreamingMethods {
-		codec["include-streaming-methods"] = "true"
+	if rust.IncludeStreamingMethods != nil {
+		codec["include-streaming-methods"] = strconv.FormatBool(*rust.IncludeStreamingMethods)
 	}
 	if rust.PerServiceFeatures {
 		codec["per-service-features"] = "true"
@@ -171,6 +175,9 @@ func newLibraryCodec(library *config.Library) map[string]string {
 	if library.Name != "" {
 		codec["package-name-override"] = library.Name
 	}
+	if library.TemplateOverlay != "" {
+		codec["template-overlay"] = library.TemplateOverlay
+	}
 	if library.Rust != nil {
 		for _, dep := range library.Rust.PackageDependencies {
 			codec["package:"+dep.Name] = formatPackageDependency(dep)
@@ -257,6 +264,7 @@ func moduleToModelConfig(library *config.Library, module *config.RustModule, src
 		SpecificationFormat: specificationFormat,
 		ServiceConfig:       module.ServiceConfig,
 		SpecificationSource: module.APIPath,
+		Mixins:              library.Mixins,
 		Source:              src,
 		Codec:               buildModuleCodec(library, module),
 		Override: api.ModelOverride{
@@ -288,6 +296,12 @@ func moduleToModelConfig(library *config.Library, module *config.RustModule, src
 	return modelCfg, nil
 }
 
+// isProstTemplate returns true if the module is generated by the Prost
+// generator instead of the Rust generator.
+func isProstTemplate(template string) bool {
+	return template == "prost" || template == "tonic"
+}
+
 func buildModuleCodec(library *config.Library, module *config.RustModule) map[string]string {
 	codec := newLibraryCodec(library)
 	if module.GenerateSetterSamples != "" {
@@ -302,8 +316,8 @@ func buildModuleCodec(library *config.Library, module *config.RustModule) map[st
 	if module.IncludeGrpcOnlyMethods {
 		codec["include-grpc-only-methods"] = "true"
 	}
-	if module.IncludeStreamingMethods {
-		codec["include-streaming-methods"] = "true"
+	if module.IncludeStreamingMethods != nil {
+		codec["include-streaming-methods"] = strconv.FormatBool(*module.IncludeStreamingMethods)
 	}
 	detailedTracingAttributes := library.Rust != nil && library.Rust.DetailedTracingAttributes != nil && *library.Rust.DetailedTracingAttributes
 	if module.DetailedTracingAttributes != nil {
@@ -318,9 +332,6 @@ func buildModuleCodec(library *config.Library, module *config.RustModule) map[st
 	if module.NameOverrides != "" {
 		codec["name-overrides"] = module.NameOverrides
 	}
-	if module.PostProcessProtos != "" {
-		codec["post-process-protos"] = module.PostProcessProtos
-	}
 	if module.RoutingRequired {
 		codec["routing-required"] = "true"
 	}
@@ -330,11 +341,21 @@ func buildModuleCodec(library *config.Library, module *config.RustModule) map[st
 	if module.Template != "" {
 		codec["template-override"] = "templates/" + module.Template
 	}
+	if module.Template == "grpc-client" {
+		// The grpc-client template generates clients over gRPC.
+		codec["transport"] = "grpc"
+	}
 	if module.DisabledRustdocWarnings != nil {
 		codec["disabled-rustdoc-warnings"] = strings.Join(module.DisabledRustdocWarnings, ",")
 	}
-	if module.RootName != "" {
-		codec["root-name"] = module.RootName
+	if isProstTemplate(module.Template) {
+		// Only the Prost generator accepts these options.
+		if module.PostProcessProtos != "" {
+			codec["post-process-protos"] = module.PostProcessProtos
+		}
+		if module.RootName != "" {
+			codec["root-name"] = module.RootName
+		}
 	}
 	if module.InternalBuilders {
 		codec["internal-builders"] = "true"
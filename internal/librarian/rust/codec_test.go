@@ -49,7 +49,7 @@ func ptr[T any](v T) *T {
 	return &v
 }
 
-func TestLibraryToModelConfig(t *testing.T) {
+func TestModelConfig(t *testing.T) {
 	for _, test := range []struct {
 		name             string
 		library          *config.Library
@@ -105,6 +105,29 @@ func TestLibraryToModelConfig(t *testing.T) {
 				ResourceNameHeuristic: true,
 			},
 		},
+		{
+			name: "with mixins",
+			library: &config.Library{
+				Name:   "google-cloud-secretmanager",
+				Mixins: &config.Mixins{Deny: []string{"google.iam.v1.IAMPolicy"}},
+			},
+			api: &config.API{
+				Path: "google/cloud/secretmanager/v1",
+			},
+			want: &parser.ModelConfig{
+				Language:            config.LanguageRust,
+				SpecificationFormat: config.SpecProtobuf,
+				SpecificationSource: "google/cloud/secretmanager/v1",
+				ServiceConfig:       "google/cloud/secretmanager/v1/secretmanager_v1.yaml",
+				Mixins:              &config.Mixins{Deny: []string{"google.iam.v1.IAMPolicy"}},
+				Source: &sources.SourceConfig{
+					ActiveRoots: []string{"googleapis"},
+				},
+				Override: api.ModelOverride{
+					Title: "Secret Manager API",
+				},
+			},
+		},
 		{
 			name: "with version",
 			library: &config.Library{
@@ -168,7 +191,7 @@ func TestLibraryToModelConfig(t *testing.T) {
 					ModulePath:              "gcs",
 					PerServiceFeatures:      true,
 					IncludeGrpcOnlyMethods:  true,
-					IncludeStreamingMethods: true,
+					IncludeStreamingMethods: ptr(true),
 					HasVeneer:               true,
 					RoutingRequired:         true,
 					DisabledClippyWarnings:  []string{"too_many_arguments"},
@@ -597,7 +620,7 @@ func TestLibraryToModelConfig(t *testing.T) {
 			if test.want.Source.Sources == nil {
 				test.want.Source.Sources = srcs
 			}
-			got, err := libraryToModelConfig(test.library, test.api, srcs)
+			got, err := ModelConfig(test.library, test.api, srcs)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -1095,12 +1118,22 @@ func TestBuildModuleCodec(t *testing.T) {
 			name:    "with IncludeStreamingMethods",
 			library: &config.Library{},
 			module: &config.RustModule{
-				IncludeStreamingMethods: true,
+				IncludeStreamingMethods: ptr(true),
 			},
 			want: map[string]string{
 				"include-streaming-methods": "true",
 			},
 		},
+		{
+			name:    "without IncludeStreamingMethods",
+			library: &config.Library{},
+			module: &config.RustModule{
+				IncludeStreamingMethods: ptr(false),
+			},
+			want: map[string]string{
+				"include-streaming-methods": "false",
+			},
+		},
 		{
 			name:    "with DetailedTracingAttributes set at module level",
 			library: &config.Library{},
@@ -1164,10 +1197,21 @@ func TestBuildModuleCodec(t *testing.T) {
 			library: &config.Library{},
 			module: &config.RustModule{
 				PostProcessProtos: "some-post-process",
+				Template:          "prost",
 			},
 			want: map[string]string{
 				"post-process-protos": "some-post-process",
+				"template-override":   "templates/prost",
+			},
+		},
+		{
+			name:    "without Prost template drops PostProcessProtos and RootName",
+			library: &config.Library{},
+			module: &config.RustModule{
+				PostProcessProtos: "some-post-process",
+				RootName:          "custom-root",
 			},
+			want: map[string]string{},
 		},
 		{
 			name:    "with RoutingRequired",
@@ -1220,9 +1264,11 @@ func TestBuildModuleCodec(t *testing.T) {
 			library: &config.Library{},
 			module: &config.RustModule{
 				RootName: "custom-root",
+				Template: "tonic",
 			},
 			want: map[string]string{
-				"root-name": "custom-root",
+				"root-name":         "custom-root",
+				"template-override": "templates/tonic",
 			},
 		},
 		{
@@ -1259,7 +1305,7 @@ func TestBuildModuleCodec(t *testing.T) {
 				GenerateRpcSamples:        "false",
 				HasVeneer:                 true,
 				IncludeGrpcOnlyMethods:    true,
-				IncludeStreamingMethods:   true,
+				IncludeStreamingMethods:   ptr(true),
 				DetailedTracingAttributes: ptr(true),
 				ModulePath:                "crate::model",
 				NameOverrides:             "a=b",
@@ -1285,11 +1331,10 @@ func TestBuildModuleCodec(t *testing.T) {
 				"detailed-tracing-attributes": "true",
 				"module-path":                 "crate::model",
 				"name-overrides":              "a=b",
-				"post-process-protos":         "post",
 				"routing-required":            "true",
 				"extend-grpc-transport":       "true",
 				"template-override":           "templates/grpc-client",
-				"root-name":                   "my-root",
+				"transport":                   "grpc",
 				"internal-builders":           "true",
 			},
 		},
@@ -1378,7 +1423,7 @@ func TestBuildCodec(t *testing.T) {
 					ModulePath:                "gcs",
 					TemplateOverride:          "custom-template",
 					IncludeGrpcOnlyMethods:    true,
-					IncludeStreamingMethods:   true,
+					IncludeStreamingMethods:   ptr(true),
 					PerServiceFeatures:        true,
 					HasVeneer:                 true,
 					RoutingRequired:           true,
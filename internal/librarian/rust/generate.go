@@ -65,7 +65,7 @@ func Generate(ctx context.Context, cfg *config.Config, library *config.Library,
 		return fmt.Errorf("the Rust generator only supports a single api per library")
 	}
 
-	modelConfig, err := libraryToModelConfig(library, library.APIs[0], sources)
+	modelConfig, err := ModelConfig(library, library.APIs[0], sources)
 	if err != nil {
 		return err
 	}
@@ -154,7 +154,7 @@ func generateVeneer(ctx context.Context, library *config.Library, sources *sourc
 		if err != nil {
 			return fmt.Errorf("CreateModel %q: %w", module.Output, err)
 		}
-		if module.Template == "prost" || module.Template == "tonic" {
+		if isProstTemplate(module.Template) {
 			err = rust_prost.Generate(ctx, model, module.Output, module.Template, modelConfig)
 		} else {
 			err = sidekickrust.Generate(ctx, model, module.Output, modelConfig)
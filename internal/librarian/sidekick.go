@@ -0,0 +1,456 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/docuploader"
+	"github.com/googleapis/librarian/internal/librarian/dart"
+	"github.com/googleapis/librarian/internal/librarian/rust"
+	"github.com/googleapis/librarian/internal/librarian/swift"
+	"github.com/googleapis/librarian/internal/sidekick/aiplint"
+	"github.com/googleapis/librarian/internal/sidekick/docs"
+	"github.com/googleapis/librarian/internal/sidekick/dump"
+	"github.com/googleapis/librarian/internal/sidekick/parser"
+	"github.com/googleapis/librarian/internal/sidekick/protofile"
+	"github.com/googleapis/librarian/internal/sources"
+	"github.com/googleapis/librarian/internal/yaml"
+	"github.com/urfave/cli/v3"
+)
+
+var (
+	errLibraryOrSourceRequired = errors.New("must specify a library name or --specification-source")
+	errNoSidekickModel         = errors.New("language does not use a sidekick model")
+	errAIPFindings             = errors.New("API has AIP violations")
+	errAPIRequired             = errors.New("library has more than one API, use --api to select one")
+	errAPINotFound             = errors.New("API not found in library")
+	errUnknownLintFormat       = errors.New("unknown lint report format")
+)
+
+const (
+	lintFormatJSON = "json"
+	lintFormatText = "text"
+)
+
+// sidekickCommand returns the CLI command for inspecting sidekick models.
+func sidekickCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "sidekick",
+		Usage:     "inspect the API models used by sidekick generators",
+		UsageText: "librarian sidekick [command]",
+		Commands: []*cli.Command{
+			sidekickDumpCommand(),
+			sidekickLintCommand(),
+			sidekickDocsCommand(),
+			sidekickProtoCommand(),
+		},
+	}
+}
+
+func sidekickDumpCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "dump",
+		Usage:     "write the cross-referenced API model as JSON or YAML",
+		UsageText: "librarian sidekick dump [library] [flags]",
+		Description: `dump parses an API into the sidekick model, runs the same cross-reference,
+resource identification, skip and documentation passes used by the code
+generators, and writes the result.
+
+Back-pointers in the model are replaced by element IDs, so the output is
+stable and can be checked in as golden data.
+
+If [library] is given, the model configuration is derived from librarian.yaml
+the same way as "librarian generate", and --api selects the API of a library
+with more than one. Otherwise the API is described by the
+--specification-* flags and the sources configured in librarian.yaml.
+
+Examples:
+
+	librarian sidekick dump google-cloud-secretmanager-v1
+	librarian sidekick dump google-cloud-secretmanager-v1 --format yaml \
+	    --filter .google.cloud.secretmanager.v1.Secret
+	librarian sidekick dump --specification-source google/cloud/secretmanager/v1 \
+	    --service-config google/cloud/secretmanager/v1/secretmanager_v1.yaml`,
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:  "format",
+				Value: dump.FormatJSON,
+				Usage: "output format, one of json or yaml",
+			},
+			&cli.StringSliceFlag{
+				Name:  "filter",
+				Usage: "only include elements with these IDs, and their children",
+			},
+		}, sidekickModelFlags()...),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			modelConfig, err := sidekickModelConfig(ctx, cmd)
+			if err != nil {
+				return err
+			}
+			w, closer, err := sidekickOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer closer()
+			return runSidekickDump(w, modelConfig, cmd.String("format"), cmd.StringSlice("filter"))
+		},
+	}
+}
+
+func sidekickLintCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "lint",
+		Usage:     "check an API for violations of the API Improvement Proposals",
+		UsageText: "librarian sidekick lint [library] [flags]",
+		Description: `lint parses an API into the sidekick model and reports violations of the
+API Improvement Proposals (https://google.aip.dev), such as list methods
+without pagination, update methods without an update_mask, references to
+unknown resource types, and long-running operations without a metadata type.
+
+Each finding includes the AIP number, the ID of the method, message, or field,
+and its location in the source specification when known. The command exits
+with an error if there are any findings.
+
+If [library] is given, the model configuration is derived from librarian.yaml
+the same way as "librarian generate", and --api selects the API of a library
+with more than one. Otherwise the API is described by the
+--specification-* flags and the sources configured in librarian.yaml.
+
+Examples:
+
+	librarian sidekick lint google-cloud-secretmanager-v1
+	librarian sidekick lint google-cloud-secretmanager-v1 --format text
+	librarian sidekick lint --specification-source google/cloud/secretmanager/v1 \
+	    --service-config google/cloud/secretmanager/v1/secretmanager_v1.yaml`,
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:  "format",
+				Value: lintFormatJSON,
+				Usage: "output format, one of json or text",
+			},
+		}, sidekickModelFlags()...),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			modelConfig, err := sidekickModelConfig(ctx, cmd)
+			if err != nil {
+				return err
+			}
+			w, closer, err := sidekickOutput(cmd)
+			if err != nil {
+				return err
+			}
+			defer closer()
+			return runSidekickLint(w, modelConfig, cmd.String("format"))
+		},
+	}
+}
+
+func sidekickDocsCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "docs",
+		Usage:     "generate a language-neutral API reference",
+		UsageText: "librarian sidekick docs [library] [flags]",
+		Description: `docs parses an API into the sidekick model and generates a Markdown reference,
+with a page for each service, method, message and enum. The pages include the
+HTTP bindings, resource patterns, field behaviors and deprecation markers, and
+the cross-reference links in the documentation point to the corresponding
+pages.
+
+The output directory includes a docs.metadata.json file. Use --archive to
+package the output as a tarball suitable for uploading.
+
+If [library] is given, the model configuration is derived from librarian.yaml
+the same way as "librarian generate", and --api selects the API of a library
+with more than one. Otherwise the API is described by the
+--specification-* flags and the sources configured in librarian.yaml.
+
+Examples:
+
+	librarian sidekick docs google-cloud-secretmanager-v1 --output docs/secretmanager
+	librarian sidekick docs google-cloud-secretmanager-v1 --output docs/secretmanager \
+	    --version 1.2.3 --archive secretmanager-docs.tar.gz`,
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:     "output",
+				Usage:    "directory for the generated reference",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "version",
+				Usage: "version of the docset, defaults to the API version",
+			},
+			&cli.StringFlag{
+				Name:  "archive",
+				Usage: "path of a tarball to package the output, if any",
+			},
+		}, sidekickSpecificationFlags()...),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			modelConfig, err := sidekickModelConfig(ctx, cmd)
+			if err != nil {
+				return err
+			}
+			return runSidekickDocs(ctx, modelConfig, cmd.String("output"), cmd.String("version"), cmd.String("archive"))
+		},
+	}
+}
+
+func sidekickProtoCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "proto",
+		Usage:     "convert an API into a .proto file and a service config",
+		UsageText: "librarian sidekick proto [library] [flags]",
+		Description: `proto parses an API into the sidekick model and writes it as a .proto file,
+with the HTTP, field behavior, resource and long-running operation
+annotations, plus a service config listing the services. This is useful to
+onboard APIs described by OpenAPI or Discovery documents into tools that
+consume Protobuf specifications.
+
+The files are written in the directory matching the package name, such as
+google/cloud/compute/v1/compute.proto. OpenAPI and Discovery documents do not
+have field numbers; use --field-numbers hash to derive the numbers from the
+field names, so they are stable across revisions of the API.
+
+If [library] is given, the model configuration is derived from librarian.yaml
+the same way as "librarian generate", and --api selects the API of a library
+with more than one. Otherwise the API is described by the
+--specification-* flags and the sources configured in librarian.yaml.
+
+Examples:
+
+	librarian sidekick proto --specification-format discovery \
+	    --specification-source discoveries/compute.v1.json \
+	    --service-config google/cloud/compute/v1/compute_v1.yaml --output protos`,
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:     "output",
+				Usage:    "directory for the generated files",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "field-numbers",
+				Usage: "how to assign field numbers, one of sequential or hash",
+				Value: "sequential",
+			},
+		}, sidekickSpecificationFlags()...),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			modelConfig, err := sidekickModelConfig(ctx, cmd)
+			if err != nil {
+				return err
+			}
+			return runSidekickProto(ctx, modelConfig, cmd.String("output"), cmd.String("field-numbers"))
+		},
+	}
+}
+
+// sidekickModelFlags returns the flags shared by the sidekick commands to
+// select an API and the output.
+func sidekickModelFlags() []cli.Flag {
+	return append([]cli.Flag{
+		&cli.StringFlag{
+			Name:  "out",
+			Usage: "output file, defaults to stdout",
+		},
+	}, sidekickSpecificationFlags()...)
+}
+
+// sidekickSpecificationFlags returns the flags shared by the sidekick
+// commands to select an API.
+func sidekickSpecificationFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "api",
+			Usage: "path of the API to use from a library with more than one API",
+		},
+		&cli.StringFlag{
+			Name:  "specification-format",
+			Value: config.SpecProtobuf,
+			Usage: "format of the ad-hoc API specification",
+		},
+		&cli.StringFlag{
+			Name:  "specification-source",
+			Usage: "path of the ad-hoc API specification",
+		},
+		&cli.StringFlag{
+			Name:  "service-config",
+			Usage: "path of the ad-hoc API service config",
+		},
+	}
+}
+
+// sidekickModelConfig returns the model configuration selected by the
+// command arguments and flags.
+func sidekickModelConfig(ctx context.Context, cmd *cli.Command) (*parser.ModelConfig, error) {
+	cfg, err := yaml.Read[config.Config](config.LibrarianYAML)
+	if err != nil {
+		return nil, err
+	}
+	srcs, err := LoadSources(ctx, cfg.Sources)
+	if err != nil {
+		return nil, err
+	}
+	if name := cmd.Args().First(); name != "" {
+		return libraryModelConfig(cfg, name, cmd.String("api"), srcs)
+	}
+	return adHocModelConfig(cmd.String("specification-format"), cmd.String("specification-source"), cmd.String("service-config"), srcs)
+}
+
+// sidekickOutput returns the writer selected by the --out flag, and a
+// function to close it.
+func sidekickOutput(cmd *cli.Command) (io.Writer, func(), error) {
+	out := cmd.String("out")
+	if out == "" {
+		return cmd.Root().Writer, func() {}, nil
+	}
+	f, err := os.Create(out)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, func() { f.Close() }, nil
+}
+
+func runSidekickDump(w io.Writer, modelConfig *parser.ModelConfig, format string, filter []string) error {
+	model, err := parser.CreateModel(modelConfig)
+	if err != nil {
+		return err
+	}
+	data, err := dump.Marshal(dump.New(model, filter), format)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func runSidekickDocs(ctx context.Context, modelConfig *parser.ModelConfig, output, version, archive string) error {
+	model, err := parser.CreateModel(modelConfig)
+	if err != nil {
+		return err
+	}
+	codec := map[string]string{}
+	if version != "" {
+		codec["version"] = version
+	}
+	if err := docs.Generate(ctx, model, output, codec); err != nil {
+		return err
+	}
+	if archive == "" {
+		return nil
+	}
+	return docuploader.CreateArchive(ctx, "tar", output, archive)
+}
+
+func runSidekickProto(ctx context.Context, modelConfig *parser.ModelConfig, output, fieldNumbers string) error {
+	model, err := parser.CreateModel(modelConfig)
+	if err != nil {
+		return err
+	}
+	return protofile.Generate(ctx, model, output, map[string]string{"field-numbers": fieldNumbers})
+}
+
+func runSidekickLint(w io.Writer, modelConfig *parser.ModelConfig, format string) error {
+	model, err := parser.CreateModel(modelConfig)
+	if err != nil {
+		return err
+	}
+	report := aiplint.Lint(model)
+	var data []byte
+	switch format {
+	case lintFormatJSON:
+		if data, err = report.JSON(); err != nil {
+			return err
+		}
+		data = append(data, '\n')
+	case lintFormatText:
+		data = []byte(report.Text())
+	default:
+		return fmt.Errorf("%w: %q", errUnknownLintFormat, format)
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if n := len(report.Findings); n != 0 {
+		return fmt.Errorf("%w: %d findings", errAIPFindings, n)
+	}
+	return nil
+}
+
+// libraryModelConfig returns the sidekick model configuration for an API of
+// a library in librarian.yaml. See [libraryAPI] for how the API is selected.
+func libraryModelConfig(cfg *config.Config, name, apiPath string, srcs *sources.Sources) (*parser.ModelConfig, error) {
+	var modelConfig func(*config.Library, *config.API, *sources.Sources) (*parser.ModelConfig, error)
+	switch cfg.Language {
+	case config.LanguageDart:
+		modelConfig = dart.ModelConfig
+	case config.LanguageRust:
+		modelConfig = rust.ModelConfig
+	case config.LanguageSwift:
+		modelConfig = swift.ModelConfig
+	default:
+		return nil, fmt.Errorf("%w: %q", errNoSidekickModel, cfg.Language)
+	}
+	lib, err := FindLibrary(cfg, name)
+	if err != nil {
+		return nil, err
+	}
+	lib, err = applyDefaults(cfg.Language, lib, cfg.Default)
+	if err != nil {
+		return nil, err
+	}
+	api, err := libraryAPI(lib, apiPath)
+	if err != nil {
+		return nil, err
+	}
+	return modelConfig(lib, api, srcs)
+}
+
+// libraryAPI returns the API of lib with the given path. If path is empty,
+// the library must have exactly one API.
+func libraryAPI(lib *config.Library, path string) (*config.API, error) {
+	if path == "" {
+		switch len(lib.APIs) {
+		case 0:
+			return nil, fmt.Errorf("%w: library %q has no APIs", errAPINotFound, lib.Name)
+		case 1:
+			return lib.APIs[0], nil
+		default:
+			return nil, fmt.Errorf("%w: %q", errAPIRequired, lib.Name)
+		}
+	}
+	for _, api := range lib.APIs {
+		if api.Path == path {
+			return api, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q in %q", errAPINotFound, path, lib.Name)
+}
+
+// adHocModelConfig returns a sidekick model configuration for an API that
+// is not configured in librarian.yaml.
+func adHocModelConfig(specFormat, specSource, serviceConfig string, srcs *sources.Sources) (*parser.ModelConfig, error) {
+	if specSource == "" {
+		return nil, errLibraryOrSourceRequired
+	}
+	return &parser.ModelConfig{
+		SpecificationFormat: specFormat,
+		SpecificationSource: specSource,
+		ServiceConfig:       serviceConfig,
+		Source:              sources.NewSourceConfig(srcs, nil),
+	}, nil
+}
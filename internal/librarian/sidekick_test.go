@@ -0,0 +1,289 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/sidekick/aiplint"
+	"github.com/googleapis/librarian/internal/sidekick/dump"
+	"github.com/googleapis/librarian/internal/sources"
+)
+
+func TestRunSidekickDump(t *testing.T) {
+	testdata, err := filepath.Abs("../testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modelConfig, err := adHocModelConfig(
+		config.SpecOpenAPI,
+		filepath.Join(testdata, "secretmanager_openapi_v1.json"),
+		filepath.Join(testdata, "googleapis/google/cloud/secretmanager/v1/secretmanager_v1.yaml"),
+		&sources.Sources{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := runSidekickDump(&buf, modelConfig, dump.FormatJSON, []string{".google.cloud.secretmanager.v1.Secret"}); err != nil {
+		t.Fatal(err)
+	}
+	got := &dump.Model{}
+	if err := json.Unmarshal(buf.Bytes(), got); err != nil {
+		t.Fatal(err)
+	}
+	var gotIDs []string
+	for _, m := range got.Messages {
+		gotIDs = append(gotIDs, m.ID)
+	}
+	want := []string{".google.cloud.secretmanager.v1.Secret"}
+	if diff := cmp.Diff(want, gotIDs); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if len(got.Services) != 0 {
+		t.Errorf("got %d services, want none", len(got.Services))
+	}
+}
+
+func TestRunSidekickLint(t *testing.T) {
+	testdata, err := filepath.Abs("../testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modelConfig, err := adHocModelConfig(
+		config.SpecOpenAPI,
+		filepath.Join(testdata, "secretmanager_openapi_v1.json"),
+		filepath.Join(testdata, "googleapis/google/cloud/secretmanager/v1/secretmanager_v1.yaml"),
+		&sources.Sources{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := runSidekickLint(&buf, modelConfig, lintFormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	got := &aiplint.Report{}
+	if err := json.Unmarshal(buf.Bytes(), got); err != nil {
+		t.Fatal(err)
+	}
+	want := &aiplint.Report{Name: "google.cloud.secretmanager.v1", Findings: []*aiplint.Finding{}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRunSidekickDocs(t *testing.T) {
+	testdata, err := filepath.Abs("../testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modelConfig, err := adHocModelConfig(
+		config.SpecOpenAPI,
+		filepath.Join(testdata, "secretmanager_openapi_v1.json"),
+		filepath.Join(testdata, "googleapis/google/cloud/secretmanager/v1/secretmanager_v1.yaml"),
+		&sources.Sources{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmp := t.TempDir()
+	output := filepath.Join(tmp, "docs")
+	archive := filepath.Join(tmp, "docs.tar.gz")
+	if err := runSidekickDocs(t.Context(), modelConfig, output, "1.2.3", archive); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{
+		"index.md",
+		"docs.metadata.json",
+		"services/google.cloud.secretmanager.v1.SecretManagerService.md",
+		"messages/google.cloud.secretmanager.v1.Secret.md",
+	} {
+		if _, err := os.Stat(filepath.Join(output, name)); err != nil {
+			t.Error(err)
+		}
+	}
+	if _, err := os.Stat(archive); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRunSidekickProto(t *testing.T) {
+	testdata, err := filepath.Abs("../testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	modelConfig, err := adHocModelConfig(
+		config.SpecOpenAPI,
+		filepath.Join(testdata, "secretmanager_openapi_v1.json"),
+		filepath.Join(testdata, "googleapis/google/cloud/secretmanager/v1/secretmanager_v1.yaml"),
+		&sources.Sources{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := t.TempDir()
+	if err := runSidekickProto(t.Context(), modelConfig, output, "hash"); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{
+		"google/cloud/secretmanager/v1/secretmanager.proto",
+		"google/cloud/secretmanager/v1/secretmanager_v1.yaml",
+	} {
+		if _, err := os.Stat(filepath.Join(output, name)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestRunSidekickLint_Error(t *testing.T) {
+	modelConfig, err := adHocModelConfig(config.SpecOpenAPI, "../testdata/secretmanager_openapi_v1.json", "", &sources.Sources{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runSidekickLint(&bytes.Buffer{}, modelConfig, "xml"); !errors.Is(err, errUnknownLintFormat) {
+		t.Errorf("runSidekickLint() error = %v, want %v", err, errUnknownLintFormat)
+	}
+}
+
+func TestAdHocModelConfig_Error(t *testing.T) {
+	_, err := adHocModelConfig(config.SpecProtobuf, "", "", &sources.Sources{})
+	if !errors.Is(err, errLibraryOrSourceRequired) {
+		t.Errorf("adHocModelConfig() error = %v, want %v", err, errLibraryOrSourceRequired)
+	}
+}
+
+func TestLibraryModelConfig_Error(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		cfg     *config.Config
+		library string
+		wantErr error
+	}{
+		{
+			name: "library not found",
+			cfg: &config.Config{
+				Language: config.LanguageRust,
+			},
+			library: "missing",
+			wantErr: ErrLibraryNotFound,
+		},
+		{
+			name: "language without sidekick",
+			cfg: &config.Config{
+				Language:  config.LanguageGo,
+				Libraries: []*config.Library{{Name: "secretmanager"}},
+			},
+			library: "secretmanager",
+			wantErr: errNoSidekickModel,
+		},
+		{
+			name: "multiple APIs without selection",
+			cfg: &config.Config{
+				Language: config.LanguageRust,
+				Default:  &config.Default{},
+				Libraries: []*config.Library{{
+					Name: "foo",
+					APIs: []*config.API{{Path: "google/cloud/foo/v1"}, {Path: "google/cloud/foo/v2"}},
+				}},
+			},
+			library: "foo",
+			wantErr: errAPIRequired,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := libraryModelConfig(test.cfg, test.library, "", &sources.Sources{})
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("libraryModelConfig() error = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestLibraryAPI(t *testing.T) {
+	v1 := &config.API{Path: "google/cloud/foo/v1"}
+	v2 := &config.API{Path: "google/cloud/foo/v2"}
+	for _, test := range []struct {
+		name string
+		lib  *config.Library
+		path string
+		want *config.API
+	}{
+		{
+			name: "single API",
+			lib:  &config.Library{Name: "foo", APIs: []*config.API{v1}},
+			want: v1,
+		},
+		{
+			name: "selected API",
+			lib:  &config.Library{Name: "foo", APIs: []*config.API{v1, v2}},
+			path: "google/cloud/foo/v2",
+			want: v2,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := libraryAPI(test.lib, test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLibraryAPI_Error(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		lib     *config.Library
+		path    string
+		wantErr error
+	}{
+		{
+			name:    "no APIs",
+			lib:     &config.Library{Name: "foo"},
+			wantErr: errAPINotFound,
+		},
+		{
+			name: "multiple APIs",
+			lib: &config.Library{Name: "foo", APIs: []*config.API{
+				{Path: "google/cloud/foo/v1"},
+				{Path: "google/cloud/foo/v2"},
+			}},
+			wantErr: errAPIRequired,
+		},
+		{
+			name:    "unknown API",
+			lib:     &config.Library{Name: "foo", APIs: []*config.API{{Path: "google/cloud/foo/v1"}}},
+			path:    "google/cloud/foo/v2",
+			wantErr: errAPINotFound,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := libraryAPI(test.lib, test.path)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("libraryAPI() error = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swift
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBump(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "snippet_metadata_google.cloud.secretmanager.v1.json")
+	before := `{
+  "clientLibrary": {
+    "name": "GoogleCloudSecretManagerV1",
+    "version": "0.1.0"
+  }
+}`
+	if err := os.WriteFile(file, []byte(before), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Bump(dir, "0.2.0"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "clientLibrary": {
+    "name": "GoogleCloudSecretManagerV1",
+    "version": "0.2.0"
+  }
+}`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
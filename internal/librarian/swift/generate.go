@@ -34,7 +34,7 @@ func Generate(ctx context.Context, cfg *config.Config, library *config.Library,
 	if len(library.APIs) != 1 {
 		return fmt.Errorf("the Swift generator only supports a single api per library")
 	}
-	modelConfig, err := libraryToModelConfig(library, library.APIs[0], src)
+	modelConfig, err := ModelConfig(library, library.APIs[0], src)
 	if err != nil {
 		return err
 	}
@@ -71,7 +71,9 @@ func camelLibraryName(api string) string {
 	return name.String()
 }
 
-func libraryToModelConfig(library *config.Library, api *config.API, src *sources.Sources) (*parser.ModelConfig, error) {
+// ModelConfig returns the configuration used to parse an API of a library
+// into a sidekick model.
+func ModelConfig(library *config.Library, api *config.API, src *sources.Sources) (*parser.ModelConfig, error) {
 	svcConfig, err := serviceconfig.Find(src.Googleapis, api.Path, config.LanguageSwift)
 	if err != nil {
 		return nil, err
@@ -82,14 +84,22 @@ func libraryToModelConfig(library *config.Library, api *config.API, src *sources
 		sourceConfig.IncludeList = library.Swift.IncludeList
 	}
 
+	codec := map[string]string{
+		"copyright-year": library.CopyrightYear,
+		"version":        library.Version,
+	}
+	if svcConfig.HasTransport(config.LanguageSwift) {
+		codec["transport"] = string(svcConfig.Transport(config.LanguageSwift))
+	}
+	if library.TemplateOverlay != "" {
+		codec["template-overlay"] = library.TemplateOverlay
+	}
 	return &parser.ModelConfig{
 		SpecificationFormat: config.SpecProtobuf,
 		ServiceConfig:       svcConfig.ServiceConfig,
 		SpecificationSource: api.Path,
+		Mixins:              library.Mixins,
 		Source:              sourceConfig,
-		Codec: map[string]string{
-			"copyright-year": library.CopyrightYear,
-			"version":        library.Version,
-		},
+		Codec:               codec,
 	}, nil
 }
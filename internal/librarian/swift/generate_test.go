@@ -56,6 +56,30 @@ func TestDefaultLibraryName(t *testing.T) {
 	}
 }
 
+func TestModelConfig_Transport(t *testing.T) {
+	for _, test := range []struct {
+		api  string
+		want string
+	}{
+		{"google/cloud/secretmanager/v1", ""},
+		{"google/cloud/memorystore/v1", "rest"},
+	} {
+		t.Run(test.api, func(t *testing.T) {
+			library := &config.Library{
+				Name: DefaultLibraryName(test.api),
+				APIs: []*config.API{{Path: test.api}},
+			}
+			got, err := ModelConfig(library, library.APIs[0], &sources.Sources{Googleapis: t.TempDir()})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Codec["transport"] != test.want {
+				t.Errorf("Codec[transport] = %q, want %q", got.Codec["transport"], test.want)
+			}
+		})
+	}
+}
+
 func TestGenerate(t *testing.T) {
 	testhelper.RequireCommand(t, "protoc")
 
@@ -0,0 +1,180 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/config"
+	sidekickdart "github.com/googleapis/librarian/internal/sidekick/dart"
+	"github.com/googleapis/librarian/internal/sidekick/language"
+	sidekickrust "github.com/googleapis/librarian/internal/sidekick/rust"
+	"github.com/googleapis/librarian/internal/sidekick/rust_prost"
+	sidekickswift "github.com/googleapis/librarian/internal/sidekick/swift"
+	"github.com/googleapis/librarian/internal/yaml"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/urfave/cli/v3"
+)
+
+var (
+	errLibraryRequired   = errors.New("library name is required")
+	errNoTemplateOverlay = errors.New("library does not set template_overlay")
+	errNoBuiltinTemplate = errors.New("language does not use sidekick templates")
+)
+
+// templatesCommand returns the CLI command for inspecting template overlays.
+func templatesCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "templates",
+		Usage:     "inspect the template overlays used by a library",
+		UsageText: "librarian templates diff [library]",
+		Commands: []*cli.Command{
+			{
+				Name:      "diff",
+				Usage:     "compare a library's template overlay with the built-in templates",
+				UsageText: "librarian templates diff [library]",
+				Description: `diff prints a unified diff for each template in the library's
+template_overlay that shadows a built-in template, and lists the templates
+that only exist in the overlay.
+
+Run diff after upgrading librarian to find the changes in the built-in
+templates that need to be merged into the overlay.
+
+Only the dart, rust and swift languages generate code from templates. For
+rust, the built-in templates include the prost and tonic templates.`,
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return runTemplatesDiff(cmd.Root().Writer, cmd.Args().First())
+				},
+			},
+		},
+	}
+}
+
+func runTemplatesDiff(w io.Writer, name string) error {
+	if name == "" {
+		return errLibraryRequired
+	}
+	cfg, err := yaml.Read[config.Config](config.LibrarianYAML)
+	if err != nil {
+		return err
+	}
+	library, err := FindLibrary(cfg, name)
+	if err != nil {
+		return err
+	}
+	if library.TemplateOverlay == "" {
+		return fmt.Errorf("%w: %q", errNoTemplateOverlay, name)
+	}
+	builtin, err := builtinTemplates(cfg.Language)
+	if err != nil {
+		return err
+	}
+	return diffTemplates(w, builtin, library.TemplateOverlay)
+}
+
+// builtinTemplates returns the templates embedded in the sidekick codecs for
+// language.
+//
+// Rust libraries share a single overlay between the Rust and the rust+prost
+// codecs, so their templates are combined.
+func builtinTemplates(lang string) (fs.FS, error) {
+	switch lang {
+	case config.LanguageDart:
+		return sidekickdart.Templates(), nil
+	case config.LanguageRust:
+		return unionFS{sidekickrust.Templates(), rust_prost.Templates()}, nil
+	case config.LanguageSwift:
+		return sidekickswift.Templates(), nil
+	default:
+		return nil, fmt.Errorf("%w: %q, templates are only used by %s, %s and %s",
+			errNoBuiltinTemplate, lang, config.LanguageDart, config.LanguageRust, config.LanguageSwift)
+	}
+}
+
+// unionFS opens each file from the first filesystem containing it.
+type unionFS []fs.FS
+
+func (u unionFS) Open(name string) (fs.File, error) {
+	for _, fsys := range u[:len(u)-1] {
+		f, err := fsys.Open(name)
+		if !errors.Is(err, fs.ErrNotExist) {
+			return f, err
+		}
+	}
+	return u[len(u)-1].Open(name)
+}
+
+// diffTemplates writes a unified diff from each built-in template to the file
+// shadowing it in the overlay directory. Files that only exist in the overlay
+// are listed after the diffs.
+func diffTemplates(w io.Writer, builtin fs.FS, overlay string) error {
+	overlayFS := os.DirFS(overlay)
+	var added []string
+	err := fs.WalkDir(overlayFS, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		want, err := fs.ReadFile(overlayFS, name)
+		if err != nil {
+			return err
+		}
+		builtinName := path.Join(language.TemplatesRoot, name)
+		got, err := fs.ReadFile(builtin, builtinName)
+		if errors.Is(err, fs.ErrNotExist) {
+			added = append(added, name)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(string(got)),
+			B:        splitLines(string(want)),
+			FromFile: builtinName,
+			ToFile:   path.Join(overlay, name),
+			Context:  3,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, diff)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	for _, name := range added {
+		if _, err := fmt.Fprintf(w, "Only in %s: %s\n", overlay, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitLines splits s into lines, keeping the line terminators.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/config"
+)
+
+func TestDiffTemplates(t *testing.T) {
+	builtin := fstest.MapFS{
+		"templates/crate/src/lib.rs.mustache":   {Data: []byte("line 1\nline 2\nline 3\n")},
+		"templates/crate/Cargo.toml.mustache":   {Data: []byte("unchanged\n")},
+		"templates/crate/src/model.rs.mustache": {Data: []byte("not in overlay\n")},
+	}
+	overlay := t.TempDir()
+	for name, contents := range map[string]string{
+		"crate/src/lib.rs.mustache":   "line 1\nline two\nline 3\n",
+		"crate/Cargo.toml.mustache":   "unchanged\n",
+		"crate/src/extra.rs.mustache": "new\n",
+	} {
+		path := filepath.Join(overlay, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var buf bytes.Buffer
+	if err := diffTemplates(&buf, builtin, overlay); err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		"--- templates/crate/src/lib.rs.mustache",
+		"+++ " + filepath.Join(overlay, "crate/src/lib.rs.mustache"),
+		"@@ -1,3 +1,3 @@",
+		" line 1",
+		"-line 2",
+		"+line two",
+		" line 3",
+		"Only in " + overlay + ": crate/src/extra.rs.mustache",
+		"",
+	}, "\n")
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBuiltinTemplates(t *testing.T) {
+	for _, test := range []struct {
+		lang string
+		want string
+	}{
+		{lang: config.LanguageDart, want: "templates/pubspec.yaml.mustache"},
+		{lang: config.LanguageRust, want: "templates/crate/Cargo.toml.mustache"},
+		{lang: config.LanguageRust, want: "templates/prost/Cargo.toml.mustache"},
+		{lang: config.LanguageSwift, want: "templates/package/Package.swift.mustache"},
+	} {
+		t.Run(test.want, func(t *testing.T) {
+			builtin, err := builtinTemplates(test.lang)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := fs.Stat(builtin, test.want); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestRunTemplatesDiff_Error(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		library    string
+		configYAML string
+		wantErr    error
+	}{
+		{
+			name:       "missing library name",
+			library:    "",
+			configYAML: "language: rust\n",
+			wantErr:    errLibraryRequired,
+		},
+		{
+			name:       "library not found",
+			library:    "missing",
+			configYAML: "language: rust\nlibraries:\n  - name: google-cloud-secretmanager-v1\n",
+			wantErr:    ErrLibraryNotFound,
+		},
+		{
+			name:       "no overlay",
+			library:    "google-cloud-secretmanager-v1",
+			configYAML: "language: rust\nlibraries:\n  - name: google-cloud-secretmanager-v1\n",
+			wantErr:    errNoTemplateOverlay,
+		},
+		{
+			name:       "unsupported language",
+			library:    "secretmanager",
+			configYAML: "language: go\nlibraries:\n  - name: secretmanager\n    template_overlay: overlay\n",
+			wantErr:    errNoBuiltinTemplate,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			if err := os.WriteFile("librarian.yaml", []byte(test.configYAML), 0644); err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			err := runTemplatesDiff(&buf, test.library)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("got error %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
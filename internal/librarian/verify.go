@@ -0,0 +1,75 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/googleapis/librarian/internal/command"
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/librarian/golang"
+)
+
+var errVerifyUnsupported = errors.New("language does not support verification")
+
+// verifyLibraries runs the language's compile check on the output of every
+// given library. Unlike generation, verification does not stop at the first
+// failure: the errors for all libraries are reported together.
+func verifyLibraries(ctx context.Context, cfg *config.Config, libraries []*config.Library) error {
+	var errs []error
+	for _, library := range libraries {
+		if err := verifyLibrary(ctx, cfg, library); err != nil {
+			errs = append(errs, fmt.Errorf("verify library %q (%s): %w", library.Name, cfg.Language, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// verifyLibrary delegates to the language-specific compile check for a single
+// library. Tools are resolved through the release preinstalled map, the same
+// way the release commands resolve them.
+func verifyLibrary(ctx context.Context, cfg *config.Config, library *config.Library) error {
+	var preinstalled map[string]string
+	if cfg.Release != nil {
+		preinstalled = cfg.Release.Preinstalled
+	}
+	exe := func(name string) string {
+		return command.GetExecutablePath(preinstalled, name)
+	}
+	switch cfg.Language {
+	case config.LanguageDart:
+		return command.Run(ctx, exe("dart"), "analyze", library.Output)
+	case config.LanguageFake:
+		return fakeVerify(library)
+	case config.LanguageGcloud:
+		return command.Run(ctx, exe("surfer"), "validate", "--surface", library.Output)
+	case config.LanguageGo:
+		return golang.Verify(ctx, exe(command.Go), library)
+	case config.LanguageJava:
+		return command.RunInDir(ctx, library.Output, exe("mvn"), "-o", "compile")
+	case config.LanguageNodejs:
+		return command.RunInDir(ctx, library.Output, exe("tsc"), "--noEmit")
+	case config.LanguagePython:
+		return command.Run(ctx, exe("python3"), "-m", "compileall", "-q", library.Output)
+	case config.LanguageRust:
+		return command.Run(ctx, exe(command.Cargo), "check", "-p", library.Name)
+	case config.LanguageSwift:
+		return command.Run(ctx, exe("swift"), "build", "--package-path", library.Output)
+	default:
+		return fmt.Errorf("%w: %q", errVerifyUnsupported, cfg.Language)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package librarian
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/testhelper"
+)
+
+func TestVerifyLibraries(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cfg := &config.Config{Language: config.LanguageFake}
+	var libraries []*config.Library
+	for _, name := range []string{"library-one", "library-two"} {
+		library := &config.Library{Name: name, Output: name}
+		if err := fakeGenerate(library); err != nil {
+			t.Fatal(err)
+		}
+		libraries = append(libraries, library)
+	}
+	if err := verifyLibraries(t.Context(), cfg, libraries); err != nil {
+		t.Errorf("verifyLibraries() error = %v", err)
+	}
+}
+
+func TestVerifyLibraries_Error(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cfg := &config.Config{Language: config.LanguageFake}
+	good := &config.Library{Name: "good", Output: "good"}
+	if err := fakeGenerate(good); err != nil {
+		t.Fatal(err)
+	}
+	brokenOne := &config.Library{Name: "broken-one", Output: "broken-one"}
+	brokenTwo := &config.Library{Name: "broken-two", Output: "broken-two"}
+	for _, library := range []*config.Library{brokenOne, brokenTwo} {
+		if err := fakeGenerate(library); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Remove(filepath.Join(library.Output, "README.md")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := verifyLibraries(t.Context(), cfg, []*config.Library{brokenOne, good, brokenTwo})
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("verifyLibraries() error = %v, wantErr %v", err, fs.ErrNotExist)
+	}
+	// All failing libraries are reported, not just the first one.
+	for _, want := range []string{`verify library "broken-one" (fake)`, `verify library "broken-two" (fake)`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("verifyLibraries() error = %v, want it to contain %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), `"good"`) {
+		t.Errorf("verifyLibraries() error = %v, want no error for library %q", err, good.Name)
+	}
+}
+
+func TestVerifyLibraries_Preinstalled(t *testing.T) {
+	testhelper.RequireCommand(t, "true")
+	testhelper.RequireCommand(t, "false")
+	for _, test := range []struct {
+		name    string
+		surfer  string
+		wantErr bool
+	}{
+		{name: "passes", surfer: "true"},
+		{name: "fails", surfer: "false", wantErr: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Language: config.LanguageGcloud,
+				Release: &config.Release{
+					Preinstalled: map[string]string{"surfer": test.surfer},
+				},
+			}
+			libraries := []*config.Library{{Name: "library-one", Output: t.TempDir()}}
+			err := verifyLibraries(t.Context(), cfg, libraries)
+			if (err != nil) != test.wantErr {
+				t.Errorf("verifyLibraries() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyLibraries_Unsupported(t *testing.T) {
+	cfg := &config.Config{Language: config.LanguagePhp}
+	libraries := []*config.Library{{Name: "library-one", Output: "library-one"}}
+	err := verifyLibraries(t.Context(), cfg, libraries)
+	if !errors.Is(err, errVerifyUnsupported) {
+		t.Errorf("verifyLibraries() error = %v, wantErr %v", err, errVerifyUnsupported)
+	}
+}
@@ -128,6 +128,16 @@ func (api *API) Transport(language string) Transport {
 	return GRPCRest
 }
 
+// HasTransport reports whether the transport for the given language is set
+// explicitly, either for the language or through the "all" language setting.
+func (api *API) HasTransport(language string) bool {
+	if _, ok := api.Transports[language]; ok {
+		return true
+	}
+	_, ok := api.Transports[config.LanguageAll]
+	return ok
+}
+
 // HasRESTNumericEnums reports whether the generator should pass the
 // rest-numeric-enums option for the given language. The default (when
 // SkipRESTNumericEnums is empty) is true.
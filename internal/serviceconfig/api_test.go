@@ -359,6 +359,59 @@ func TestGetTransport(t *testing.T) {
 	}
 }
 
+func TestHasTransport(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		sc   *API
+		lang string
+		want bool
+	}{
+		{
+			name: "empty serviceconfig",
+			sc:   &API{},
+			lang: config.LanguageDart,
+			want: false,
+		},
+		{
+			name: "language specific transport",
+			sc: &API{
+				Transports: map[string]Transport{
+					config.LanguageDart: GRPC,
+				},
+			},
+			lang: config.LanguageDart,
+			want: true,
+		},
+		{
+			name: "other language transport",
+			sc: &API{
+				Transports: map[string]Transport{
+					config.LanguageGo: GRPC,
+				},
+			},
+			lang: config.LanguageDart,
+			want: false,
+		},
+		{
+			name: "all language transport",
+			sc: &API{
+				Transports: map[string]Transport{
+					config.LanguageAll: Rest,
+				},
+			},
+			lang: config.LanguageSwift,
+			want: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.sc.HasTransport(test.lang)
+			if got != test.want {
+				t.Errorf("HasTransport(%q) = %v, want %v", test.lang, got, test.want)
+			}
+		})
+	}
+}
+
 func TestRepoMetadataTransport(t *testing.T) {
 	for _, test := range []struct {
 		name     string
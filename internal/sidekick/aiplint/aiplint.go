@@ -0,0 +1,203 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package aiplint checks a sidekick model for violations of the API
+// Improvement Proposals (https://google.aip.dev).
+//
+// The checks run over the cross-referenced model, so they can use the same
+// standard method detection, pagination, and resource information as the
+// code generators.
+package aiplint
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+const (
+	fieldMaskID = ".google.protobuf.FieldMask"
+	emptyID     = ".google.protobuf.Empty"
+
+	updateMaskJSONName = "updateMask"
+)
+
+// Finding is a single AIP violation.
+type Finding struct {
+	// AIP is the number of the violated AIP, for example 158.
+	AIP int `json:"aip"`
+	// Rule is a short identifier for the check, for example
+	// "list-pagination".
+	Rule string `json:"rule"`
+	// ID is the ID of the method, message, or field with the violation.
+	ID string `json:"id"`
+	// Message describes the violation.
+	Message string `json:"message"`
+	// Location is the position of the element in the source specification,
+	// if known.
+	Location *Location `json:"location,omitempty"`
+}
+
+// Location is a position in the source specification.
+type Location struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+// String returns the location as `file:line`.
+func (l *Location) String() string {
+	return fmt.Sprintf("%s:%d", l.File, l.Line)
+}
+
+type linter struct {
+	model    *api.API
+	seen     map[string]bool
+	findings []*Finding
+}
+
+// Lint checks the model and returns a report with all the findings.
+//
+// The model must be cross-referenced, see [api.CrossReference].
+func Lint(model *api.API) *Report {
+	l := &linter{model: model, seen: map[string]bool{}}
+	for _, s := range model.Services {
+		for _, m := range s.Methods {
+			l.checkMethod(m)
+		}
+	}
+	for _, m := range model.Messages {
+		l.checkMessage(m)
+	}
+	slices.SortStableFunc(l.findings, func(a, b *Finding) int {
+		if c := strings.Compare(a.ID, b.ID); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.AIP, b.AIP)
+	})
+	return &Report{Name: model.PackageName, Findings: l.findings}
+}
+
+func (l *linter) report(aip int, rule, id, format string, args ...any) {
+	f := &Finding{
+		AIP:     aip,
+		Rule:    rule,
+		ID:      id,
+		Message: fmt.Sprintf(format, args...),
+	}
+	if loc := l.model.SourceLocation(id); loc != nil {
+		f.Location = &Location{File: loc.File, Line: loc.Line}
+	}
+	l.findings = append(l.findings, f)
+}
+
+func (l *linter) checkMethod(m *api.Method) {
+	if m.PathInfo == nil || len(m.PathInfo.Bindings) == 0 {
+		if !m.ClientSideStreaming && !m.ServerSideStreaming {
+			l.report(127, "http-binding", m.ID, "method %s has no HTTP binding", m.Name)
+		}
+	}
+	if isStandard(m, "List") && m.OutputType != nil && m.OutputType.Pagination == nil && !m.ServerSideStreaming {
+		l.report(158, "list-pagination", m.ID, "list method %s is not paginated, it needs `page_size` and `page_token` fields in the request and `next_page_token` in the response", m.Name)
+	}
+	if isStandard(m, "Update") && !hasUpdateMask(m.InputType) {
+		l.report(134, "update-mask", m.ID, "update method %s has no `%s` field of type `%s`", m.Name, api.StandardFieldNameForUpdateMask, fieldMaskID)
+	}
+	if m.OperationInfo != nil {
+		l.checkOperationInfo(m)
+	}
+}
+
+func (l *linter) checkOperationInfo(m *api.Method) {
+	info := m.OperationInfo
+	switch {
+	case isMissingType(info.MetadataTypeID):
+		l.report(151, "lro-metadata", m.ID, "long-running method %s does not set `metadata_type`", m.Name)
+	case info.MetadataTypeID == emptyID:
+		l.report(151, "lro-metadata", m.ID, "long-running method %s uses `%s` as `metadata_type`", m.Name, emptyID)
+	case l.model.Message(info.MetadataTypeID) == nil:
+		l.report(151, "lro-metadata", m.ID, "long-running method %s has unknown `metadata_type` %q", m.Name, info.MetadataTypeID)
+	}
+	switch {
+	case isMissingType(info.ResponseTypeID):
+		l.report(151, "lro-response", m.ID, "long-running method %s does not set `response_type`", m.Name)
+	case info.ResponseTypeID != emptyID && l.model.Message(info.ResponseTypeID) == nil:
+		l.report(151, "lro-response", m.ID, "long-running method %s has unknown `response_type` %q", m.Name, info.ResponseTypeID)
+	}
+}
+
+func (l *linter) checkMessage(m *api.Message) {
+	if m.IsMap || l.seen[m.ID] {
+		return
+	}
+	l.seen[m.ID] = true
+	if r := m.Resource; r != nil {
+		if len(r.Patterns) == 0 {
+			l.report(123, "resource-pattern", m.ID, "resource %q has no patterns", r.Type)
+		}
+		if !slices.ContainsFunc(m.Fields, func(f *api.Field) bool { return f.Name == "name" }) {
+			l.report(122, "resource-name-field", m.ID, "resource %q has no `name` field", r.Type)
+		}
+	}
+	for _, f := range m.Fields {
+		l.checkField(f)
+	}
+	for _, child := range m.Messages {
+		l.checkMessage(child)
+	}
+}
+
+func (l *linter) checkField(f *api.Field) {
+	ref := f.ResourceReference
+	if ref == nil {
+		return
+	}
+	for _, typ := range []string{ref.Type, ref.ChildType} {
+		if typ == "" || typ == api.GenericResourceType {
+			continue
+		}
+		if l.model.Resource(typ) == nil {
+			l.report(122, "resource-reference", f.ID, "field %s references unknown resource type %q", f.Name, typ)
+		}
+	}
+}
+
+// isStandard returns true if the method looks like a standard method of the
+// given kind: its name and request message use the standard prefix.
+func isStandard(m *api.Method, verb string) bool {
+	noun, ok := strings.CutPrefix(m.Name, verb)
+	if !ok || noun == "" || m.InputType == nil {
+		return false
+	}
+	return m.InputType.Name == verb+noun+"Request"
+}
+
+// hasUpdateMask returns true if the request has an `update_mask` field. The
+// OpenAPI parser uses the JSON name for query parameters, so either name is
+// accepted.
+func hasUpdateMask(request *api.Message) bool {
+	return slices.ContainsFunc(request.Fields, func(f *api.Field) bool {
+		isMask := f.Name == api.StandardFieldNameForUpdateMask || f.JSONName == updateMaskJSONName
+		return isMask && f.TypezID == fieldMaskID
+	})
+}
+
+// isMissingType returns true if an LRO type annotation is empty. The
+// protobuf parser qualifies empty names with the package, producing an ID
+// that ends in a `.`.
+func isMissingType(id string) bool {
+	return id == "" || strings.HasSuffix(id, ".")
+}
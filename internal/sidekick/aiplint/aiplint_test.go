@@ -0,0 +1,248 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aiplint
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+func secretPattern() api.ResourcePattern {
+	return api.ResourcePattern{
+		*(&api.PathSegment{}).WithLiteral("secrets"),
+		*(&api.PathSegment{}).WithVariable(api.NewPathVariable("secret").WithMatch()),
+	}
+}
+
+func newSecret() *api.Message {
+	return api.NewTestMessage("Secret").
+		WithFields(api.NewTestField("name").WithType(api.TypezString)).
+		WithResource(api.NewTestResource("test.googleapis.com/Secret").WithPatterns(secretPattern()))
+}
+
+func lintModel(t *testing.T, messages []*api.Message, methods ...*api.Method) *Report {
+	t.Helper()
+	service := api.NewTestService("Service").WithMethods(methods...)
+	model := api.NewTestAPI(messages, []*api.Enum{}, []*api.Service{service})
+	if err := api.CrossReference(model); err != nil {
+		t.Fatal(err)
+	}
+	return Lint(model)
+}
+
+func TestLint(t *testing.T) {
+	secret := newSecret()
+	getRequest := api.NewTestMessage("GetSecretRequest").
+		WithFields(api.NewTestField("name").WithType(api.TypezString).WithResourceReference("test.googleapis.com/Secret"))
+	get := api.NewTestMethod("GetSecret").WithVerb("GET").WithInput(getRequest).WithOutput(secret)
+
+	got := lintModel(t, []*api.Message{secret, getRequest}, get)
+	if len(got.Findings) != 0 {
+		t.Errorf("expected no findings, got %v", got.Findings)
+	}
+}
+
+func TestLint_Findings(t *testing.T) {
+	secret := newSecret()
+	fieldMask := api.NewTestMessage("FieldMask").WithPackage("google.protobuf")
+	for _, test := range []struct {
+		name     string
+		messages []*api.Message
+		method   *api.Method
+		want     []*Finding
+	}{
+		{
+			name: "list not paginated",
+			messages: []*api.Message{
+				api.NewTestMessage("ListSecretsRequest"),
+				api.NewTestMessage("ListSecretsResponse").
+					WithFields(api.NewTestField("secrets").WithRepeated().WithMessageType(secret)),
+			},
+			method: api.NewTestMethod("ListSecrets").
+				WithInput(api.NewTestMessage("ListSecretsRequest")).
+				WithOutput(api.NewTestMessage("ListSecretsResponse")),
+			want: []*Finding{{AIP: 158, Rule: "list-pagination", ID: ".test.Service.ListSecrets"}},
+		},
+		{
+			name: "update without mask",
+			messages: []*api.Message{
+				api.NewTestMessage("UpdateSecretRequest").
+					WithFields(api.NewTestField("secret").WithMessageType(secret)),
+			},
+			method: api.NewTestMethod("UpdateSecret").
+				WithInput(api.NewTestMessage("UpdateSecretRequest")).
+				WithOutput(secret),
+			want: []*Finding{{AIP: 134, Rule: "update-mask", ID: ".test.Service.UpdateSecret"}},
+		},
+		{
+			name: "update with mask",
+			messages: []*api.Message{
+				api.NewTestMessage("UpdateSecretRequest").
+					WithFields(
+						api.NewTestField("secret").WithMessageType(secret),
+						api.NewTestField("update_mask").WithMessageType(fieldMask),
+					),
+			},
+			method: api.NewTestMethod("UpdateSecret").
+				WithInput(api.NewTestMessage("UpdateSecretRequest").
+					WithFields(
+						api.NewTestField("secret").WithMessageType(secret),
+						api.NewTestField("update_mask").WithMessageType(fieldMask),
+					)).
+				WithOutput(secret),
+		},
+		{
+			name: "unknown resource reference",
+			messages: []*api.Message{
+				api.NewTestMessage("GetWidgetRequest").
+					WithFields(api.NewTestField("name").WithType(api.TypezString).WithResourceReference("test.googleapis.com/Widget")),
+			},
+			method: api.NewTestMethod("GetWidget").
+				WithInput(api.NewTestMessage("GetWidgetRequest")).
+				WithOutput(secret),
+			want: []*Finding{{AIP: 122, Rule: "resource-reference", ID: ".test.GetWidgetRequest.name"}},
+		},
+		{
+			name: "unknown child type reference",
+			messages: []*api.Message{
+				api.NewTestMessage("ListWidgetsRequest").
+					WithFields(api.NewTestField("parent").WithType(api.TypezString).WithChildTypeReference("test.googleapis.com/Widget")),
+			},
+			method: api.NewTestMethod("Frobnicate").
+				WithInput(api.NewTestMessage("ListWidgetsRequest")).
+				WithOutput(secret),
+			want: []*Finding{{AIP: 122, Rule: "resource-reference", ID: ".test.ListWidgetsRequest.parent"}},
+		},
+		{
+			name: "generic resource reference",
+			messages: []*api.Message{
+				api.NewTestMessage("GetAnythingRequest").
+					WithFields(api.NewTestField("name").WithType(api.TypezString).WithResourceReference(api.GenericResourceType)),
+			},
+			method: api.NewTestMethod("GetAnything").
+				WithInput(api.NewTestMessage("GetAnythingRequest")).
+				WithOutput(secret),
+		},
+		{
+			name: "resource without patterns or name",
+			messages: []*api.Message{
+				api.NewTestMessage("Widget").
+					WithFields(api.NewTestField("id").WithType(api.TypezString)).
+					WithResource(api.NewTestResource("test.googleapis.com/Widget")),
+				api.NewTestMessage("GetSecretRequest"),
+			},
+			method: api.NewTestMethod("GetSecret").
+				WithInput(api.NewTestMessage("GetSecretRequest")).
+				WithOutput(secret),
+			want: []*Finding{
+				{AIP: 122, Rule: "resource-name-field", ID: ".test.Widget"},
+				{AIP: 123, Rule: "resource-pattern", ID: ".test.Widget"},
+			},
+		},
+		{
+			name:     "no http binding",
+			messages: []*api.Message{api.NewTestMessage("FrobnicateRequest")},
+			method: func() *api.Method {
+				m := api.NewTestMethod("Frobnicate").
+					WithInput(api.NewTestMessage("FrobnicateRequest")).
+					WithOutput(secret)
+				m.PathInfo = &api.PathInfo{}
+				return m
+			}(),
+			want: []*Finding{{AIP: 127, Rule: "http-binding", ID: ".test.Service.Frobnicate"}},
+		},
+		{
+			name:     "no http binding for streaming",
+			messages: []*api.Message{api.NewTestMessage("FrobnicateRequest")},
+			method: func() *api.Method {
+				m := api.NewTestMethod("Frobnicate").
+					WithInput(api.NewTestMessage("FrobnicateRequest")).
+					WithOutput(secret)
+				m.PathInfo = &api.PathInfo{}
+				m.ServerSideStreaming = true
+				return m
+			}(),
+		},
+		{
+			name:     "lro with empty metadata",
+			messages: []*api.Message{api.NewTestMessage("CreateSecretRequest")},
+			method:   lro(".google.protobuf.Empty", ".test.Secret"),
+			want:     []*Finding{{AIP: 151, Rule: "lro-metadata", ID: ".test.Service.CreateSecret"}},
+		},
+		{
+			name:     "lro without metadata",
+			messages: []*api.Message{api.NewTestMessage("CreateSecretRequest")},
+			method:   lro(".test.", ".test.Secret"),
+			want:     []*Finding{{AIP: 151, Rule: "lro-metadata", ID: ".test.Service.CreateSecret"}},
+		},
+		{
+			name:     "lro with unknown types",
+			messages: []*api.Message{api.NewTestMessage("CreateSecretRequest")},
+			method:   lro(".test.Missing", ".test.AlsoMissing"),
+			want: []*Finding{
+				{AIP: 151, Rule: "lro-metadata", ID: ".test.Service.CreateSecret"},
+				{AIP: 151, Rule: "lro-response", ID: ".test.Service.CreateSecret"},
+			},
+		},
+		{
+			name:     "lro with empty response",
+			messages: []*api.Message{api.NewTestMessage("CreateSecretRequest"), api.NewTestMessage("OperationMetadata")},
+			method:   lro(".test.OperationMetadata", ".google.protobuf.Empty"),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			operation := api.NewTestMessage("Operation").WithPackage("google.longrunning")
+			messages := append([]*api.Message{newSecret(), operation}, test.messages...)
+			got := lintModel(t, messages, test.method)
+			if diff := cmp.Diff(test.want, got.Findings, cmpopts.IgnoreFields(Finding{}, "Message")); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func lro(metadata, response string) *api.Method {
+	m := api.NewTestMethod("CreateSecret").
+		WithInput(api.NewTestMessage("CreateSecretRequest")).
+		WithOutput(api.NewTestMessage("Operation").WithPackage("google.longrunning"))
+	m.OperationInfo = &api.OperationInfo{MetadataTypeID: metadata, ResponseTypeID: response}
+	return m
+}
+
+func TestLint_Location(t *testing.T) {
+	request := api.NewTestMessage("UpdateSecretRequest")
+	update := api.NewTestMethod("UpdateSecret").WithInput(request).WithOutput(newSecret())
+	service := api.NewTestService("Service").WithMethods(update)
+	model := api.NewTestAPI([]*api.Message{request}, []*api.Enum{}, []*api.Service{service})
+	model.State.SourceLocationByID = map[string]*api.SourceLocation{
+		".test.Service.UpdateSecret": {File: "test/v1/service.proto", Line: 42},
+	}
+	got := Lint(model)
+	want := []*Finding{
+		{
+			AIP:      134,
+			Rule:     "update-mask",
+			ID:       ".test.Service.UpdateSecret",
+			Message:  "update method UpdateSecret has no `update_mask` field of type `.google.protobuf.FieldMask`",
+			Location: &Location{File: "test/v1/service.proto", Line: 42},
+		},
+	}
+	if diff := cmp.Diff(want, got.Findings); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aiplint
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Report contains the result of linting a model.
+type Report struct {
+	// Name is the package name of the linted model.
+	Name string `json:"name"`
+	// Findings is the list of violations, sorted by element ID.
+	Findings []*Finding `json:"findings"`
+}
+
+// JSON returns the report formatted as indented JSON.
+func (r *Report) JSON() ([]byte, error) {
+	if r.Findings == nil {
+		r.Findings = []*Finding{}
+	}
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// Text returns the report with one finding per line, in the
+// `location: message (AIP-N rule)` format used by compilers and linters.
+// Findings without a location use the element ID instead.
+func (r *Report) Text() string {
+	var b strings.Builder
+	for _, f := range r.Findings {
+		where := f.ID
+		if f.Location != nil {
+			where = f.Location.String()
+		}
+		fmt.Fprintf(&b, "%s: %s (AIP-%d %s)\n", where, f.Message, f.AIP, f.Rule)
+	}
+	return b.String()
+}
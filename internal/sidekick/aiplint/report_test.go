@@ -0,0 +1,78 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aiplint
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func testReport() *Report {
+	return &Report{
+		Name: "test",
+		Findings: []*Finding{
+			{
+				AIP:      158,
+				Rule:     "list-pagination",
+				ID:       ".test.Service.ListSecrets",
+				Message:  "list method ListSecrets is not paginated",
+				Location: &Location{File: "test/v1/service.proto", Line: 12},
+			},
+			{
+				AIP:     122,
+				Rule:    "resource-reference",
+				ID:      ".test.GetWidgetRequest.name",
+				Message: "field name references unknown resource type",
+			},
+		},
+	}
+}
+
+func TestReportJSON(t *testing.T) {
+	want := testReport()
+	data, err := want.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := &Report{}
+	if err := json.Unmarshal(data, got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReportJSON_Empty(t *testing.T) {
+	data, err := (&Report{Name: "test"}).JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "{\n  \"name\": \"test\",\n  \"findings\": []\n}"
+	if diff := cmp.Diff(want, string(data)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReportText(t *testing.T) {
+	want := `test/v1/service.proto:12: list method ListSecrets is not paginated (AIP-158 list-pagination)
+.test.GetWidgetRequest.name: field name references unknown resource type (AIP-122 resource-reference)
+`
+	if diff := cmp.Diff(want, testReport().Text()); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -318,6 +318,15 @@ func (a *API) Resource(typ string) *Resource {
 	return a.State.ResourceByType[typ]
 }
 
+// SourceLocation returns the location of an element in the source
+// specification, or nil if it is not known.
+func (a *API) SourceLocation(id string) *SourceLocation {
+	if a.State == nil {
+		return nil
+	}
+	return a.State.SourceLocationByID[id]
+}
+
 // AllResources returns an iterator over the resources in the API.
 func (a *API) AllResources() iter.Seq[*Resource] {
 	return maps.Values(a.State.ResourceByType)
@@ -347,6 +356,17 @@ type APIState struct {
 	EnumByID map[string]*Enum
 	// ResourceByType returns a resource that is associated with the API.
 	ResourceByType map[string]*Resource
+	// SourceLocationByID returns the location in the source specification
+	// of an element. Only populated by parsers that track locations.
+	SourceLocationByID map[string]*SourceLocation
+}
+
+// SourceLocation is a position in the source specification.
+type SourceLocation struct {
+	// File is the name of the file, relative to the specification root.
+	File string
+	// Line is the 1-based line number.
+	Line int
 }
 
 // Service represents a service in an API.
@@ -969,6 +989,8 @@ type Field struct {
 	// JSONName is the name of the field as it appears in JSON. Useful for
 	// serializing to JSON.
 	JSONName string
+	// Number is the field number, if the source specification defines one.
+	Number int32
 	// Optional indicates that the field is marked as optional in proto3.
 	Optional bool
 
@@ -1167,6 +1189,21 @@ type Resource struct {
 // ResourcePattern is a sequence of path segments that defines the structure of a resource's unique identifier.
 type ResourcePattern []PathSegment
 
+// String returns the pattern in its canonical form, such as
+// `projects/{project}/secrets/{secret}`.
+func (p ResourcePattern) String() string {
+	var segments []string
+	for _, s := range p {
+		switch {
+		case s.Literal != nil:
+			segments = append(segments, *s.Literal)
+		case s.Variable != nil:
+			segments = append(segments, fmt.Sprintf("{%s}", strings.Join(s.Variable.FieldPath, ".")))
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
 // ResourceReference describes a field's relationship to another resource type.
 // It acts as a foreign key, indicating that the field's value identifies an instance of another resource.
 // This relationship is established via the `google.api.resource_reference` annotation in Protobuf.
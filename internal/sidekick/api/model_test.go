@@ -325,3 +325,33 @@ func TestService_HasClientSideStreaming(t *testing.T) {
 		})
 	}
 }
+
+func TestResourcePatternString(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		pattern ResourcePattern
+		want    string
+	}{
+		{
+			name: "simple",
+			pattern: ResourcePattern{
+				*(&PathSegment{}).WithLiteral("projects"),
+				*(&PathSegment{}).WithVariable(NewPathVariable("project").WithMatch()),
+				*(&PathSegment{}).WithLiteral("secrets"),
+				*(&PathSegment{}).WithVariable(NewPathVariable("secret").WithMatch()),
+			},
+			want: "projects/{project}/secrets/{secret}",
+		},
+		{
+			name:    "empty",
+			pattern: nil,
+			want:    "",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if diff := cmp.Diff(test.want, test.pattern.String()); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
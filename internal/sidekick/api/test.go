@@ -80,6 +80,41 @@ func NewTestAPI(messages []*Message, enums []*Enum, services []*Service) *API {
 	return model
 }
 
+// NewTestEchoAPI creates an API with an Echo service, which has a method for
+// each streaming shape and a Wait method without HTTP bindings. The model is
+// not cross-referenced.
+func NewTestEchoAPI() *API {
+	const pkg = "google.cloud.test.v1"
+	request := NewTestMessage("EchoRequest").WithPackage(pkg).
+		WithFields(NewTestField("content").WithType(TypezString))
+	response := NewTestMessage("EchoResponse").WithPackage(pkg).
+		WithFields(NewTestField("content").WithType(TypezString))
+	newMethod := func(name string, client, server bool) *Method {
+		m := NewTestMethod(name).
+			WithVerb("POST").
+			WithPathTemplate((&PathTemplate{}).WithLiteral("v1").WithLiteral(strings.ToLower(name))).
+			WithInput(request).
+			WithOutput(response)
+		m.Documentation = "The " + name + " method."
+		m.PathInfo.BodyFieldPath = "*"
+		m.ClientSideStreaming = client
+		m.ServerSideStreaming = server
+		return m
+	}
+	wait := newMethod("Wait", false, false)
+	wait.PathInfo = nil
+	service := NewTestService("Echo").WithPackage(pkg).WithMethods(
+		newMethod("Echo", false, false),
+		newMethod("Expand", false, true),
+		newMethod("Collect", true, false),
+		newMethod("Chat", true, true),
+		wait,
+	)
+	service.Documentation = "A service that echoes requests."
+	service.DefaultHost = "echo.googleapis.com"
+	return NewTestAPI([]*Message{request, response}, []*Enum{}, []*Service{service})
+}
+
 // parentName returns the parent's name from a fully qualified identifier.
 func parentName(id string) string {
 	if lastIndex := strings.LastIndex(id, "."); lastIndex != -1 {
@@ -219,6 +254,12 @@ func (f *Field) WithType(t Typez) *Field {
 	return f
 }
 
+// WithNumber sets the field number.
+func (f *Field) WithNumber(n int32) *Field {
+	f.Number = n
+	return f
+}
+
 // WithRepeated marks the field as repeated.
 func (f *Field) WithRepeated() *Field {
 	f.Repeated = true
@@ -279,6 +320,20 @@ func (r *Resource) WithSingular(singular string) *Resource {
 	return r
 }
 
+// NewTestResourcePattern creates a resource pattern from alternating
+// literals and variable names, e.g. ("projects", "project").
+func NewTestResourcePattern(segments ...string) ResourcePattern {
+	var pattern ResourcePattern
+	for i, s := range segments {
+		if i%2 == 0 {
+			pattern = append(pattern, *(&PathSegment{}).WithLiteral(s))
+			continue
+		}
+		pattern = append(pattern, *(&PathSegment{}).WithVariable(NewPathVariable(s).WithMatch()))
+	}
+	return pattern
+}
+
 // ParseTemplateForTest converts a string literal into a []PathSegment slice for testing purposes.
 func ParseTemplateForTest(template string) []PathSegment {
 	var segments []PathSegment
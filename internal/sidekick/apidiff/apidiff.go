@@ -0,0 +1,457 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package apidiff computes a semantic diff between two versions of an
+// [api.API] model.
+//
+// Each change is classified as breaking or non-breaking following the rules
+// in [AIP-180](https://google.aip.dev/180).
+package apidiff
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+// ChangeKind describes how an element changed between two models.
+type ChangeKind string
+
+const (
+	// Added means the element only exists in the new model.
+	Added ChangeKind = "added"
+	// Removed means the element only exists in the old model.
+	Removed ChangeKind = "removed"
+	// Changed means the element exists in both models, with differences.
+	Changed ChangeKind = "changed"
+)
+
+// ElementKind identifies the type of model element that changed.
+type ElementKind string
+
+const (
+	// ElementService is an [api.Service].
+	ElementService ElementKind = "service"
+	// ElementMethod is an [api.Method].
+	ElementMethod ElementKind = "method"
+	// ElementMessage is an [api.Message].
+	ElementMessage ElementKind = "message"
+	// ElementField is an [api.Field].
+	ElementField ElementKind = "field"
+	// ElementEnum is an [api.Enum].
+	ElementEnum ElementKind = "enum"
+	// ElementEnumValue is an [api.EnumValue].
+	ElementEnumValue ElementKind = "enum_value"
+	// ElementResource is an [api.Resource].
+	ElementResource ElementKind = "resource"
+	// ElementResourcePattern is an [api.ResourcePattern].
+	ElementResourcePattern ElementKind = "resource_pattern"
+	// ElementHTTPBinding is an [api.PathBinding].
+	ElementHTTPBinding ElementKind = "http_binding"
+	// ElementRouting is an [api.RoutingInfo].
+	ElementRouting ElementKind = "routing"
+	// ElementLRO is the long-running operation configuration of a method.
+	ElementLRO ElementKind = "lro"
+)
+
+// Change is a single difference between two models.
+type Change struct {
+	// Kind is the type of change.
+	Kind ChangeKind `json:"kind"`
+	// Element is the type of element that changed.
+	Element ElementKind `json:"element"`
+	// ID identifies the element, for example `.google.cloud.foo.v1.Bar.baz`.
+	ID string `json:"id"`
+	// Detail is a human readable description of the change, if any.
+	Detail string `json:"detail,omitempty"`
+	// Breaking is true if the change is breaking per AIP-180.
+	Breaking bool `json:"breaking"`
+}
+
+// Compare returns the changes required to go from the old model to the new
+// model.
+//
+// Only the elements defined by each API are compared. Well-known types and
+// other dependencies are ignored unless they are referenced by a field or
+// method.
+func Compare(old, new *api.API) *Report {
+	d := &differ{}
+	o := collect(old)
+	n := collect(new)
+	compareMap(d, ElementService, o.services, n.services, d.service)
+	compareMap(d, ElementMethod, o.methods, n.methods, d.method)
+	compareMap(d, ElementMessage, o.messages, n.messages, func(om, nm *api.Message) {
+		d.message(om, nm, n.requests[nm.ID])
+	})
+	compareMap(d, ElementEnum, o.enums, n.enums, d.enum)
+	compareMap(d, ElementResource, o.resources, n.resources, d.resource)
+	slices.SortStableFunc(d.changes, func(a, b *Change) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return &Report{
+		OldRevision: revision(old),
+		NewRevision: revision(new),
+		Changes:     d.changes,
+	}
+}
+
+func revision(model *api.API) string {
+	if model.Revision != "" {
+		return fmt.Sprintf("%s@%s", model.PackageName, model.Revision)
+	}
+	return model.PackageName
+}
+
+type differ struct {
+	changes []*Change
+}
+
+func (d *differ) add(kind ChangeKind, element ElementKind, id string, breaking bool, detail string) {
+	d.changes = append(d.changes, &Change{
+		Kind:     kind,
+		Element:  element,
+		ID:       id,
+		Detail:   detail,
+		Breaking: breaking,
+	})
+}
+
+// compareMap reports removed and added elements, and calls `changed` for
+// the elements present in both maps. Removing an element is always
+// breaking, adding one never is.
+func compareMap[T any](d *differ, element ElementKind, old, new map[string]T, changed func(o, n T)) {
+	for _, id := range slices.Sorted(maps.Keys(old)) {
+		n, ok := new[id]
+		if !ok {
+			d.add(Removed, element, id, true, "")
+			continue
+		}
+		changed(old[id], n)
+	}
+	for _, id := range slices.Sorted(maps.Keys(new)) {
+		if _, ok := old[id]; !ok {
+			d.add(Added, element, id, false, "")
+		}
+	}
+}
+
+func (d *differ) service(o, n *api.Service) {
+	if o.DefaultHost != n.DefaultHost {
+		d.add(Changed, ElementService, n.ID, true, fmt.Sprintf("default host changed from %q to %q", o.DefaultHost, n.DefaultHost))
+	}
+	d.deprecated(ElementService, n.ID, o.Deprecated, n.Deprecated)
+}
+
+func (d *differ) method(o, n *api.Method) {
+	if o.InputTypeID != n.InputTypeID {
+		d.add(Changed, ElementMethod, n.ID, true, fmt.Sprintf("input type changed from %s to %s", o.InputTypeID, n.InputTypeID))
+	}
+	if o.OutputTypeID != n.OutputTypeID {
+		d.add(Changed, ElementMethod, n.ID, true, fmt.Sprintf("output type changed from %s to %s", o.OutputTypeID, n.OutputTypeID))
+	}
+	if o.ClientSideStreaming != n.ClientSideStreaming || o.ServerSideStreaming != n.ServerSideStreaming {
+		d.add(Changed, ElementMethod, n.ID, true, fmt.Sprintf("streaming changed from %s to %s", streaming(o), streaming(n)))
+	}
+	d.deprecated(ElementMethod, n.ID, o.Deprecated, n.Deprecated)
+	d.bindings(o, n)
+	d.routing(o, n)
+	d.lro(o, n)
+}
+
+func streaming(m *api.Method) string {
+	switch {
+	case m.ClientSideStreaming && m.ServerSideStreaming:
+		return "bidi"
+	case m.ClientSideStreaming:
+		return "client"
+	case m.ServerSideStreaming:
+		return "server"
+	default:
+		return "unary"
+	}
+}
+
+// bindings compares the HTTP bindings of a method. Removing a binding or
+// changing the request body breaks REST clients, adding a binding does not.
+func (d *differ) bindings(o, n *api.Method) {
+	compareMap(d, ElementHTTPBinding, bindingMap(o), bindingMap(n), func(_, _ *api.PathBinding) {})
+	if o.PathInfo != nil && n.PathInfo != nil && o.PathInfo.BodyFieldPath != n.PathInfo.BodyFieldPath {
+		d.add(Changed, ElementHTTPBinding, n.ID, true, fmt.Sprintf("body changed from %q to %q", o.PathInfo.BodyFieldPath, n.PathInfo.BodyFieldPath))
+	}
+}
+
+func bindingMap(m *api.Method) map[string]*api.PathBinding {
+	bindings := map[string]*api.PathBinding{}
+	if m.PathInfo == nil {
+		return bindings
+	}
+	for _, b := range m.PathInfo.Bindings {
+		if b.PathTemplate == nil {
+			continue
+		}
+		bindings[fmt.Sprintf("%s[%s %s]", m.ID, b.Verb, pathTemplate(b.PathTemplate))] = b
+	}
+	return bindings
+}
+
+func pathTemplate(t *api.PathTemplate) string {
+	path := t.FlatPath()
+	if t.Verb != nil {
+		path = fmt.Sprintf("%s:%s", path, *t.Verb)
+	}
+	return path
+}
+
+// routing compares the routing headers of a method. New routing parameters
+// are harmless, removing or changing existing ones may send requests to the
+// wrong backend.
+func (d *differ) routing(o, n *api.Method) {
+	compareMap(d, ElementRouting, routingMap(o), routingMap(n), func(ov, nv *api.RoutingInfo) {
+		if routingVariants(ov) != routingVariants(nv) {
+			d.add(Changed, ElementRouting, fmt.Sprintf("%s[%s]", n.ID, nv.Name), true,
+				fmt.Sprintf("routing changed from %q to %q", routingVariants(ov), routingVariants(nv)))
+		}
+	})
+}
+
+func routingMap(m *api.Method) map[string]*api.RoutingInfo {
+	routing := map[string]*api.RoutingInfo{}
+	for _, r := range m.Routing {
+		routing[fmt.Sprintf("%s[%s]", m.ID, r.Name)] = r
+	}
+	return routing
+}
+
+func routingVariants(r *api.RoutingInfo) string {
+	var variants []string
+	for _, v := range r.Variants {
+		variants = append(variants, fmt.Sprintf("%s=%s", v.FieldName(), v.TemplateAsString()))
+	}
+	return strings.Join(variants, ", ")
+}
+
+// lro compares the long-running operation configuration of a method. Any
+// change alters the type returned to the application.
+func (d *differ) lro(o, n *api.Method) {
+	switch {
+	case o.OperationInfo == nil && n.OperationInfo != nil:
+		d.add(Added, ElementLRO, n.ID, true, "method became a long-running operation")
+	case o.OperationInfo != nil && n.OperationInfo == nil:
+		d.add(Removed, ElementLRO, n.ID, true, "method is no longer a long-running operation")
+	case o.OperationInfo != nil && n.OperationInfo != nil:
+		if o.OperationInfo.ResponseTypeID != n.OperationInfo.ResponseTypeID {
+			d.add(Changed, ElementLRO, n.ID, true, fmt.Sprintf("response type changed from %s to %s", o.OperationInfo.ResponseTypeID, n.OperationInfo.ResponseTypeID))
+		}
+		if o.OperationInfo.MetadataTypeID != n.OperationInfo.MetadataTypeID {
+			d.add(Changed, ElementLRO, n.ID, true, fmt.Sprintf("metadata type changed from %s to %s", o.OperationInfo.MetadataTypeID, n.OperationInfo.MetadataTypeID))
+		}
+	}
+	if (o.DiscoveryLro == nil) != (n.DiscoveryLro == nil) {
+		d.add(Changed, ElementLRO, n.ID, true, "discovery long-running operation polling changed")
+	}
+}
+
+// message compares two versions of a message. `isRequest` is true if the
+// new message is used as the input of any method, in which case adding a
+// required field is a breaking change.
+func (d *differ) message(o, n *api.Message, isRequest bool) {
+	d.deprecated(ElementMessage, n.ID, o.Deprecated, n.Deprecated)
+	of := fieldMap(o)
+	nf := fieldMap(n)
+	for _, name := range slices.Sorted(maps.Keys(of)) {
+		if _, ok := nf[name]; !ok {
+			d.add(Removed, ElementField, of[name].ID, true, "")
+			continue
+		}
+		d.field(of[name], nf[name])
+	}
+	for _, name := range slices.Sorted(maps.Keys(nf)) {
+		if _, ok := of[name]; ok {
+			continue
+		}
+		f := nf[name]
+		if isRequest && f.DocumentAsRequired() {
+			d.add(Added, ElementField, f.ID, true, "new required field in request message")
+			continue
+		}
+		d.add(Added, ElementField, f.ID, false, "")
+	}
+}
+
+func fieldMap(m *api.Message) map[string]*api.Field {
+	fields := map[string]*api.Field{}
+	for _, f := range m.Fields {
+		fields[f.Name] = f
+	}
+	return fields
+}
+
+func (d *differ) field(o, n *api.Field) {
+	if o.Number != n.Number {
+		d.add(Changed, ElementField, n.ID, true, fmt.Sprintf("number changed from %d to %d", o.Number, n.Number))
+	}
+	if o.Typez != n.Typez || o.TypezID != n.TypezID {
+		d.add(Changed, ElementField, n.ID, true, fmt.Sprintf("type changed from %s to %s", fieldType(o), fieldType(n)))
+	}
+	if o.Repeated != n.Repeated || o.Map != n.Map {
+		d.add(Changed, ElementField, n.ID, true, fmt.Sprintf("cardinality changed from %s to %s", cardinality(o), cardinality(n)))
+	}
+	if o.Optional != n.Optional {
+		d.add(Changed, ElementField, n.ID, true, fmt.Sprintf("optional changed from %t to %t", o.Optional, n.Optional))
+	}
+	if oneOfName(o) != oneOfName(n) {
+		d.add(Changed, ElementField, n.ID, true, fmt.Sprintf("oneof changed from %q to %q", oneOfName(o), oneOfName(n)))
+	}
+	if o.JSONName != n.JSONName {
+		d.add(Changed, ElementField, n.ID, true, fmt.Sprintf("JSON name changed from %q to %q", o.JSONName, n.JSONName))
+	}
+	if o.DocumentAsRequired() != n.DocumentAsRequired() {
+		d.add(Changed, ElementField, n.ID, n.DocumentAsRequired(), fmt.Sprintf("required changed from %t to %t", o.DocumentAsRequired(), n.DocumentAsRequired()))
+	}
+	if resourceReference(o) != resourceReference(n) {
+		d.add(Changed, ElementField, n.ID, true, fmt.Sprintf("resource reference changed from %q to %q", resourceReference(o), resourceReference(n)))
+	}
+	d.deprecated(ElementField, n.ID, o.Deprecated, n.Deprecated)
+}
+
+func fieldType(f *api.Field) string {
+	if f.TypezID != "" {
+		return f.TypezID
+	}
+	return strings.ToLower(f.Typez.String())
+}
+
+func cardinality(f *api.Field) string {
+	switch {
+	case f.Map:
+		return "map"
+	case f.Repeated:
+		return "repeated"
+	default:
+		return "singular"
+	}
+}
+
+func oneOfName(f *api.Field) string {
+	if !f.IsOneOf || f.Group == nil {
+		return ""
+	}
+	return f.Group.Name
+}
+
+func resourceReference(f *api.Field) string {
+	if f.ResourceReference == nil {
+		return ""
+	}
+	if f.ResourceReference.ChildType != "" {
+		return "child_type=" + f.ResourceReference.ChildType
+	}
+	return "type=" + f.ResourceReference.Type
+}
+
+func (d *differ) enum(o, n *api.Enum) {
+	d.deprecated(ElementEnum, n.ID, o.Deprecated, n.Deprecated)
+	compareMap(d, ElementEnumValue, enumValueMap(o), enumValueMap(n), func(ov, nv *api.EnumValue) {
+		if ov.Number != nv.Number {
+			d.add(Changed, ElementEnumValue, nv.ID, true, fmt.Sprintf("number changed from %d to %d", ov.Number, nv.Number))
+		}
+		d.deprecated(ElementEnumValue, nv.ID, ov.Deprecated, nv.Deprecated)
+	})
+}
+
+func enumValueMap(e *api.Enum) map[string]*api.EnumValue {
+	values := map[string]*api.EnumValue{}
+	for _, v := range e.Values {
+		values[v.ID] = v
+	}
+	return values
+}
+
+// resource compares the patterns of a resource. Clients may parse resource
+// names using any of the existing patterns, removing one is breaking.
+func (d *differ) resource(o, n *api.Resource) {
+	compareMap(d, ElementResourcePattern, patternMap(o), patternMap(n), func(_, _ api.ResourcePattern) {})
+}
+
+func patternMap(r *api.Resource) map[string]api.ResourcePattern {
+	patterns := map[string]api.ResourcePattern{}
+	for _, p := range r.Patterns {
+		patterns[fmt.Sprintf("%s[%s]", r.Type, p.String())] = p
+	}
+	return patterns
+}
+
+func (d *differ) deprecated(element ElementKind, id string, o, n bool) {
+	if o == n {
+		return
+	}
+	d.add(Changed, element, id, false, fmt.Sprintf("deprecated changed from %t to %t", o, n))
+}
+
+// elements indexes the elements defined by a model.
+type elements struct {
+	services  map[string]*api.Service
+	methods   map[string]*api.Method
+	messages  map[string]*api.Message
+	enums     map[string]*api.Enum
+	resources map[string]*api.Resource
+	// requests contains the IDs of the messages used as method inputs.
+	requests map[string]bool
+}
+
+func collect(model *api.API) *elements {
+	e := &elements{
+		services:  map[string]*api.Service{},
+		methods:   map[string]*api.Method{},
+		messages:  map[string]*api.Message{},
+		enums:     map[string]*api.Enum{},
+		resources: map[string]*api.Resource{},
+		requests:  map[string]bool{},
+	}
+	for _, s := range model.Services {
+		e.services[s.ID] = s
+		for _, m := range s.Methods {
+			e.methods[m.ID] = m
+			e.requests[m.InputTypeID] = true
+		}
+	}
+	var addMessages func([]*api.Message)
+	addMessages = func(messages []*api.Message) {
+		for _, m := range messages {
+			if m.IsMap {
+				continue
+			}
+			e.messages[m.ID] = m
+			for _, en := range m.Enums {
+				e.enums[en.ID] = en
+			}
+			if m.Resource != nil {
+				e.resources[m.Resource.Type] = m.Resource
+			}
+			addMessages(m.Messages)
+		}
+	}
+	addMessages(model.Messages)
+	for _, en := range model.Enums {
+		e.enums[en.ID] = en
+	}
+	for _, r := range model.ResourceDefinitions {
+		e.resources[r.Type] = r
+	}
+	return e
+}
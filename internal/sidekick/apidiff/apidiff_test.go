@@ -0,0 +1,269 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apidiff
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+func TestCompare_NoChanges(t *testing.T) {
+	got := Compare(testModel(), testModel())
+	if len(got.Changes) != 0 {
+		t.Errorf("Compare() = %v, want no changes", got.Changes)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		update func(*api.API)
+		want   []*Change
+	}{
+		{
+			name: "remove method",
+			update: func(model *api.API) {
+				model.Services[0].Methods = model.Services[0].Methods[1:]
+			},
+			want: []*Change{
+				{Kind: Removed, Element: ElementMethod, ID: ".test.Service.GetSecret", Breaking: true},
+			},
+		},
+		{
+			name: "add service",
+			update: func(model *api.API) {
+				model.Services = append(model.Services, api.NewTestService("Other"))
+			},
+			want: []*Change{
+				{Kind: Added, Element: ElementService, ID: ".test.Other"},
+			},
+		},
+		{
+			name: "add optional field",
+			update: func(model *api.API) {
+				model.Messages[0].WithFields(api.NewTestField("etag").WithType(api.TypezString))
+			},
+			want: []*Change{
+				{Kind: Added, Element: ElementField, ID: ".test.Secret.etag"},
+			},
+		},
+		{
+			name: "add required field to request",
+			update: func(model *api.API) {
+				model.Messages[1].WithFields(api.NewTestField("etag").WithType(api.TypezString).WithBehavior(api.FieldBehaviorRequired))
+			},
+			want: []*Change{
+				{Kind: Added, Element: ElementField, ID: ".test.GetSecretRequest.etag", Detail: "new required field in request message", Breaking: true},
+			},
+		},
+		{
+			name: "change field type",
+			update: func(model *api.API) {
+				model.Messages[0].Fields[1].Typez = api.TypezInt64
+			},
+			want: []*Change{
+				{Kind: Changed, Element: ElementField, ID: ".test.Secret.size", Detail: "type changed from int32 to int64", Breaking: true},
+			},
+		},
+		{
+			name: "change field cardinality",
+			update: func(model *api.API) {
+				model.Messages[0].Fields[1].Repeated = true
+			},
+			want: []*Change{
+				{Kind: Changed, Element: ElementField, ID: ".test.Secret.size", Detail: "cardinality changed from singular to repeated", Breaking: true},
+			},
+		},
+		{
+			name: "renumber field",
+			update: func(model *api.API) {
+				model.Messages[0].Fields[1].Number = 3
+			},
+			want: []*Change{
+				{Kind: Changed, Element: ElementField, ID: ".test.Secret.size", Detail: "number changed from 2 to 3", Breaking: true},
+			},
+		},
+		{
+			name: "field no longer required",
+			update: func(model *api.API) {
+				model.Messages[1].Fields[0].Behavior = nil
+			},
+			want: []*Change{
+				{Kind: Changed, Element: ElementField, ID: ".test.GetSecretRequest.name", Detail: "required changed from true to false"},
+			},
+		},
+		{
+			name: "deprecate message",
+			update: func(model *api.API) {
+				model.Messages[0].Deprecated = true
+			},
+			want: []*Change{
+				{Kind: Changed, Element: ElementMessage, ID: ".test.Secret", Detail: "deprecated changed from false to true"},
+			},
+		},
+		{
+			name: "remove enum value",
+			update: func(model *api.API) {
+				model.Enums[0].Values = model.Enums[0].Values[:1]
+			},
+			want: []*Change{
+				{Kind: Removed, Element: ElementEnumValue, ID: ".test.State.ENABLED", Breaking: true},
+			},
+		},
+		{
+			name: "renumber enum value",
+			update: func(model *api.API) {
+				model.Enums[0].Values[1].Number = 2
+			},
+			want: []*Change{
+				{Kind: Changed, Element: ElementEnumValue, ID: ".test.State.ENABLED", Detail: "number changed from 1 to 2", Breaking: true},
+			},
+		},
+		{
+			name: "add resource pattern",
+			update: func(model *api.API) {
+				model.Messages[0].Resource.Patterns = append(model.Messages[0].Resource.Patterns, pattern("projects", "project", "locations", "location", "secrets", "secret"))
+			},
+			want: []*Change{
+				{Kind: Added, Element: ElementResourcePattern, ID: "test.googleapis.com/Secret[projects/{project}/locations/{location}/secrets/{secret}]"},
+			},
+		},
+		{
+			name: "remove resource pattern",
+			update: func(model *api.API) {
+				model.Messages[0].Resource.Patterns = nil
+			},
+			want: []*Change{
+				{Kind: Removed, Element: ElementResourcePattern, ID: "test.googleapis.com/Secret[projects/{project}/secrets/{secret}]", Breaking: true},
+			},
+		},
+		{
+			name: "change http binding",
+			update: func(model *api.API) {
+				model.Services[0].Methods[0].PathInfo.Bindings[0].Verb = "POST"
+			},
+			want: []*Change{
+				{Kind: Removed, Element: ElementHTTPBinding, ID: ".test.Service.GetSecret[GET v1/{name}]", Breaking: true},
+				{Kind: Added, Element: ElementHTTPBinding, ID: ".test.Service.GetSecret[POST v1/{name}]"},
+			},
+		},
+		{
+			name: "change routing",
+			update: func(model *api.API) {
+				model.Services[0].Methods[0].Routing[0].Variants[0].FieldPath = []string{"parent"}
+			},
+			want: []*Change{
+				{Kind: Changed, Element: ElementRouting, ID: ".test.Service.GetSecret[name]", Detail: `routing changed from "name=projects/*" to "parent=projects/*"`, Breaking: true},
+			},
+		},
+		{
+			name: "change lro response",
+			update: func(model *api.API) {
+				model.Services[0].Methods[1].OperationInfo.ResponseTypeID = ".test.GetSecretRequest"
+			},
+			want: []*Change{
+				{Kind: Changed, Element: ElementLRO, ID: ".test.Service.CreateSecret", Detail: "response type changed from .test.Secret to .test.GetSecretRequest", Breaking: true},
+			},
+		},
+		{
+			name: "method stops being lro",
+			update: func(model *api.API) {
+				model.Services[0].Methods[1].OperationInfo = nil
+			},
+			want: []*Change{
+				{Kind: Removed, Element: ElementLRO, ID: ".test.Service.CreateSecret", Detail: "method is no longer a long-running operation", Breaking: true},
+			},
+		},
+		{
+			name: "method becomes streaming",
+			update: func(model *api.API) {
+				model.Services[0].Methods[0].ServerSideStreaming = true
+			},
+			want: []*Change{
+				{Kind: Changed, Element: ElementMethod, ID: ".test.Service.GetSecret", Detail: "streaming changed from unary to server", Breaking: true},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			updated := testModel()
+			test.update(updated)
+			got := Compare(testModel(), updated)
+			if diff := cmp.Diff(test.want, got.Changes); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func testModel() *api.API {
+	secret := api.NewTestMessage("Secret").
+		WithFields(
+			api.NewTestField("name").WithType(api.TypezString).WithNumber(1),
+			api.NewTestField("size").WithType(api.TypezInt32).WithNumber(2),
+		).
+		WithResource(api.NewTestResource("test.googleapis.com/Secret").
+			WithPatterns(pattern("projects", "project", "secrets", "secret")))
+	request := api.NewTestMessage("GetSecretRequest").
+		WithFields(api.NewTestField("name").WithType(api.TypezString).WithBehavior(api.FieldBehaviorRequired))
+	state := &api.Enum{
+		Name:    "State",
+		ID:      ".test.State",
+		Package: "test",
+		Values: []*api.EnumValue{
+			{Name: "STATE_UNSPECIFIED", ID: ".test.State.STATE_UNSPECIFIED", Number: 0},
+			{Name: "ENABLED", ID: ".test.State.ENABLED", Number: 1},
+		},
+	}
+	get := api.NewTestMethod("GetSecret").
+		WithVerb("GET").
+		WithInput(request).
+		WithOutput(secret).
+		WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1").WithVariableNamed("name"))
+	get.Routing = []*api.RoutingInfo{
+		{
+			Name: "name",
+			Variants: []*api.RoutingInfoVariant{
+				{FieldPath: []string{"name"}, Matching: api.RoutingPathSpec{Segments: []string{"projects", "*"}}},
+			},
+		},
+	}
+	create := api.NewTestMethod("CreateSecret").
+		WithVerb("POST").
+		WithInput(secret).
+		WithOutput(secret).
+		WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1").WithLiteral("secrets"))
+	create.OperationInfo = &api.OperationInfo{
+		MetadataTypeID: ".google.protobuf.Empty",
+		ResponseTypeID: ".test.Secret",
+	}
+	service := api.NewTestService("Service").WithMethods(get, create)
+	return api.NewTestAPI([]*api.Message{secret, request}, []*api.Enum{state}, []*api.Service{service})
+}
+
+// pattern creates a resource pattern from alternating literals and variable
+// names.
+func pattern(parts ...string) api.ResourcePattern {
+	var p api.ResourcePattern
+	for i, part := range parts {
+		if i%2 == 0 {
+			p = append(p, *(&api.PathSegment{}).WithLiteral(part))
+			continue
+		}
+		p = append(p, *(&api.PathSegment{}).WithVariable(api.NewPathVariable(part).WithMatch()))
+	}
+	return p
+}
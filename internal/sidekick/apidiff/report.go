@@ -0,0 +1,76 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apidiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Report contains the result of comparing two models.
+type Report struct {
+	// OldRevision identifies the old model, using its package name and
+	// revision (if any).
+	OldRevision string `json:"old"`
+	// NewRevision identifies the new model.
+	NewRevision string `json:"new"`
+	// Changes is the list of changes, sorted by element ID.
+	Changes []*Change `json:"changes"`
+}
+
+// Breaking returns the breaking changes in the report.
+func (r *Report) Breaking() []*Change {
+	return slices.DeleteFunc(slices.Clone(r.Changes), func(c *Change) bool { return !c.Breaking })
+}
+
+// NonBreaking returns the non-breaking changes in the report.
+func (r *Report) NonBreaking() []*Change {
+	return slices.DeleteFunc(slices.Clone(r.Changes), func(c *Change) bool { return c.Breaking })
+}
+
+// JSON returns the report formatted as indented JSON.
+func (r *Report) JSON() ([]byte, error) {
+	if r.Changes == nil {
+		r.Changes = []*Change{}
+	}
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// Markdown returns the report formatted as a Markdown document.
+func (r *Report) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# API changes from %s to %s\n", r.OldRevision, r.NewRevision)
+	if len(r.Changes) == 0 {
+		b.WriteString("\nNo changes.\n")
+		return b.String()
+	}
+	writeTable(&b, "Breaking changes", r.Breaking())
+	writeTable(&b, "Non-breaking changes", r.NonBreaking())
+	return b.String()
+}
+
+func writeTable(b *strings.Builder, title string, changes []*Change) {
+	if len(changes) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n## %s\n\n", title)
+	b.WriteString("| Change | Element | ID | Detail |\n")
+	b.WriteString("| ------ | ------- | -- | ------ |\n")
+	for _, c := range changes {
+		fmt.Fprintf(b, "| %s | %s | `%s` | %s |\n", c.Kind, c.Element, c.ID, strings.ReplaceAll(c.Detail, "|", `\|`))
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apidiff
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func testReport() *Report {
+	return &Report{
+		OldRevision: "test@1",
+		NewRevision: "test@2",
+		Changes: []*Change{
+			{Kind: Removed, Element: ElementMethod, ID: ".test.Service.GetSecret", Breaking: true},
+			{Kind: Added, Element: ElementField, ID: ".test.Secret.etag"},
+			{Kind: Changed, Element: ElementField, ID: ".test.Secret.size", Detail: "a|b", Breaking: true},
+		},
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	want := "# API changes from test@1 to test@2\n" +
+		"\n## Breaking changes\n\n" +
+		"| Change | Element | ID | Detail |\n" +
+		"| ------ | ------- | -- | ------ |\n" +
+		"| removed | method | `.test.Service.GetSecret` |  |\n" +
+		"| changed | field | `.test.Secret.size` | a\\|b |\n" +
+		"\n## Non-breaking changes\n\n" +
+		"| Change | Element | ID | Detail |\n" +
+		"| ------ | ------- | -- | ------ |\n" +
+		"| added | field | `.test.Secret.etag` |  |\n"
+	if diff := cmp.Diff(want, testReport().Markdown()); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestMarkdown_NoChanges(t *testing.T) {
+	want := "# API changes from test@1 to test@2\n\nNo changes.\n"
+	got := (&Report{OldRevision: "test@1", NewRevision: "test@2"}).Markdown()
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	want := testReport()
+	data, err := want.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := &Report{}
+	if err := json.Unmarshal(data, got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBreaking(t *testing.T) {
+	r := testReport()
+	if got := len(r.Breaking()); got != 2 {
+		t.Errorf("len(Breaking()) = %d, want 2", got)
+	}
+	if got := len(r.NonBreaking()); got != 1 {
+		t.Errorf("len(NonBreaking()) = %d, want 1", got)
+	}
+}
@@ -29,15 +29,19 @@ var templates embed.FS
 
 // Generate generates code from the model.
 func Generate(ctx context.Context, model *api.API, outdir string, cfg *parser.ModelConfig) error {
-	// A template provide converts a template name into the contents.
-	provider := func(name string) (string, error) {
-		contents, err := templates.ReadFile(name)
-		if err != nil {
-			return "", err
-		}
-		return string(contents), nil
+	// Decoding the codec options also rejects any unknown or malformed
+	// options.
+	opts := &codecOptions{}
+	if err := Options.Decode(cfg.Codec, opts); err != nil {
+		return err
 	}
-	// The list of files to generate, just load them from the embedded templates.
-	generatedFiles := language.WalkTemplatesDir(templates, "templates/readme")
+	// Files in the `template-overlay` directory, if any, shadow the embedded
+	// templates.
+	fsys := language.OverlayTemplates(templates, opts.TemplateOverlay)
+	// A template provide converts a template name into the contents.
+	provider := language.TemplatesProvider(fsys)
+	// The list of files to generate, just load them from the embedded templates
+	// and the overlay.
+	generatedFiles := language.WalkTemplatesDir(fsys, "templates/readme")
 	return language.GenerateFromModel(outdir, model, provider, generatedFiles)
 }
@@ -24,6 +24,7 @@ import (
 	"testing"
 
 	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/sidekick/api"
 	"github.com/googleapis/librarian/internal/sidekick/parser"
 	"github.com/googleapis/librarian/internal/sources"
 )
@@ -48,14 +49,6 @@ func TestFromProtobuf(t *testing.T) {
 			},
 			ActiveRoots: []string{"googleapis"},
 		},
-		Codec: map[string]string{
-			"copyright-year":              "2025",
-			"not-for-publication":         "true",
-			"version":                     "0.1.0",
-			"skip-format":                 "true",
-			"proto:google.protobuf":       "package:google_cloud_protobuf/protobuf.dart",
-			"proto:google.cloud.location": "package:google_cloud_location/location.dart",
-		},
 	}
 	model, err := parser.CreateModel(cfg)
 	if err != nil {
@@ -73,3 +66,13 @@ func TestFromProtobuf(t *testing.T) {
 		t.Errorf("generated files should not be executable %s: %o", filename, stat.Mode())
 	}
 }
+
+func TestGenerate_UnknownOption(t *testing.T) {
+	model := api.NewTestAPI([]*api.Message{}, []*api.Enum{}, []*api.Service{})
+	cfg := &parser.ModelConfig{
+		Codec: map[string]string{"skip-format": "true"},
+	}
+	if err := Generate(t.Context(), model, t.TempDir(), cfg); err == nil {
+		t.Fatal("expected an error with an unknown option")
+	}
+}
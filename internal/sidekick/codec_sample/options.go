@@ -0,0 +1,30 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec_sample
+
+import "github.com/googleapis/librarian/internal/sidekick/language"
+
+// Options describes the codec options accepted by the sample generator.
+var Options = &language.OptionSchema{
+	Codec: "codec_sample",
+	Options: []language.Option{
+		{Name: "template-overlay", Doc: "A directory with templates that shadow the built-in templates."},
+	},
+}
+
+// codecOptions holds the decoded [Options].
+type codecOptions struct {
+	TemplateOverlay string `option:"template-overlay"`
+}
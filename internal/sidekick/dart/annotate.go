@@ -18,14 +18,15 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"slices"
 	"sort"
-	"strconv"
 	"strings"
 
 	"github.com/googleapis/librarian/internal/license"
 	"github.com/googleapis/librarian/internal/sidekick/api"
 	"github.com/googleapis/librarian/internal/sidekick/language"
+	"github.com/googleapis/librarian/internal/snippetmetadata"
 	"github.com/iancoleman/strcase"
 )
 
@@ -85,6 +86,12 @@ type modelAnnotations struct {
 	// A comma-separated list of service fakes, e.g. "FakeCacheService, FakeGenaiService".
 	FakeList    string
 	ProtoPrefix string
+	// The resource name helpers defined in this package.
+	ResourceNames []*resourceNameAnnotation
+	// GenerateRest is true if the package includes HTTP/JSON clients.
+	GenerateRest bool
+	// GenerateGrpc is true if the package includes gRPC clients.
+	GenerateGrpc bool
 }
 
 // HasServices returns true if the model has services.
@@ -92,11 +99,26 @@ func (m *modelAnnotations) HasServices() bool {
 	return len(m.Parent.Services) > 0
 }
 
+// HasRestServices returns true if the model has services with HTTP/JSON clients.
+func (m *modelAnnotations) HasRestServices() bool {
+	return m.HasServices() && m.GenerateRest
+}
+
+// HasGrpcServices returns true if the model has services with gRPC clients.
+func (m *modelAnnotations) HasGrpcServices() bool {
+	return m.HasServices() && m.GenerateGrpc
+}
+
 // HasDependencies returns true if the model has package dependencies.
 func (m *modelAnnotations) HasDependencies() bool {
 	return len(m.PackageDependencies) > 0
 }
 
+// HasResourceNames returns true if the model has resource name helpers.
+func (m *modelAnnotations) HasResourceNames() bool {
+	return len(m.ResourceNames) > 0
+}
+
 // HasDevDependencies returns whether the generated package specified any dev_dependencies.
 func (m *modelAnnotations) HasDevDependencies() bool {
 	return len(m.DevDependencies) > 0
@@ -111,6 +133,16 @@ type serviceAnnotations struct {
 	StructName  string
 	DefaultHost string
 	HasMethods  bool
+	// The name of the gRPC client class, such as `SecretManagerServiceGrpc`.
+	GrpcName string
+	// The methods in the gRPC client. This includes methods without HTTP
+	// annotations and client-side streaming methods.
+	GrpcMethods []*api.Method
+	// The method called in the quickstart sample, if any.
+	QuickstartMethod *api.Method
+	// The region tag for the quickstart sample, such as
+	// `secretmanager_v1_generated_SecretManagerService_ListSecrets_async`.
+	RegionTag string
 }
 
 type messageAnnotation struct {
@@ -156,6 +188,18 @@ type methodAnnotation struct {
 	ServerSideStreaming bool // Whether the method produces a stream of results (or list if `EnableSSE` is `false`).
 	EnableSSE           bool // Whether the target API supports Server-Sent Events (SSE).
 	IsLast              bool
+	// The type returned by the method, such as `Secret` or
+	// `Operation<Secret, OperationMetadata>`. Fakes use it to queue responses.
+	ReturnType string
+	// The gRPC path, such as `/google.cloud.secretmanager.v1.SecretManagerService/GetSecret`.
+	GrpcPath string
+	// The gRPC streaming shape. Exactly one of these is true.
+	GrpcUnary           bool
+	GrpcServerStreaming bool
+	GrpcClientStreaming bool
+	GrpcBidiStreaming   bool
+	// The function used to decode each gRPC response, such as `Secret.fromJson`.
+	GrpcDecoder string
 }
 
 // HasBody returns true if the method has a body.
@@ -195,6 +239,48 @@ type fieldAnnotation struct {
 	ConstDefault bool
 	FromJson     string
 	ToJson       string
+	// The resource name helper class for fields with a resource reference.
+	ResourceName string
+	// The name of the getter returning the field as a `ResourceName`.
+	ResourceGetter string
+}
+
+type resourceNameAnnotation struct {
+	// The class name, such as `SecretName`.
+	Name string
+	Type string
+	// The classes for each pattern. Resources with a single pattern have a
+	// single class named after the resource.
+	Patterns []*resourcePatternAnnotation
+	// The expression to parse a name using all the pattern classes, only used
+	// with multiple patterns.
+	ParseChain string
+}
+
+// IsMultiPattern returns true if the resource has multiple patterns.
+func (r *resourceNameAnnotation) IsMultiPattern() bool {
+	return len(r.Patterns) > 1
+}
+
+type resourcePatternAnnotation struct {
+	Template string
+	// The class name, such as `SecretName_ProjectSecret`.
+	Name string
+	// The base class, if any.
+	Extends    string
+	Properties []string
+	// The list of segments to match, such as `['projects', null, 'secrets', null]`.
+	Matcher string
+	// The constructor arguments, such as `project: values[0], secret: values[1]`.
+	Arguments string
+	// The string interpolation to format the name, such as `projects/$project/secrets/$secret`.
+	Format         string
+	WildcardParent string
+}
+
+// HasProperties returns true if the pattern has any variables.
+func (p *resourcePatternAnnotation) HasProperties() bool {
+	return len(p.Properties) > 0
 }
 
 type enumAnnotation struct {
@@ -235,6 +321,12 @@ type annotateModel struct {
 	dependencyConstraints map[string]string
 	// Whether the target API supports Server-Sent Events (SSE).
 	supportsSSE bool
+	// The resource name helpers for this package.
+	resourceNames []*language.ResourceName
+	// The transports to generate clients for.
+	transports language.Transports
+	// The decoded codec options.
+	options *codecOptions
 }
 
 func newAnnotateModel(model *api.API) *annotateModel {
@@ -244,6 +336,7 @@ func newAnnotateModel(model *api.API) *annotateModel {
 		packageMapping:        map[string]string{},
 		packagePrefixes:       map[string]string{},
 		dependencyConstraints: map[string]string{},
+		transports:            language.Transports{REST: true},
 	}
 }
 
@@ -252,136 +345,61 @@ func newAnnotateModel(model *api.API) *annotateModel {
 // tags. For example, the Mustache tag {{#Services}} uses the
 // [Template.Services] field.
 func (annotate *annotateModel) annotateModel(options map[string]string) error {
-	var (
-		packageNameOverride        string
-		generationYear             string
-		libraryPathOverride        string
-		packageVersion             string
-		partFileReference          string
-		doNotPublish               bool
-		dependencies               = []string{}
-		devDependencies            = []string{}
-		repositoryURL              string
-		readMeAfterTitleText       string
-		readMeQuickstartText       string
-		issueTrackerURL            string
-		apiKeyEnvironmentVariables = []string{}
-		exports                    = []string{}
-		protobufPrefix             string
-		pkgName                    string
-	)
-
-	for key, definition := range options {
-		switch {
-		case key == "api-keys-environment-variables":
-			// api-keys-environment-variables = "GOOGLE_API_KEY,GEMINI_API_KEY"
-			// A comma-separated list of environment variables to look for searching for
-			// a API key.
-			apiKeyEnvironmentVariables = strings.Split(definition, ",")
-			for i := range apiKeyEnvironmentVariables {
-				apiKeyEnvironmentVariables[i] = strings.TrimSpace(apiKeyEnvironmentVariables[i])
-			}
-		case key == "library-path-override":
-			// library-path-override = "src/buffers.dart"
-			// The path to use for the generated file, relative to the package's "lib/" directory.
-			libraryPathOverride = definition
-		case key == "package-name-override":
-			packageNameOverride = definition
-		case key == "copyright-year":
-			generationYear = definition
-		case key == "issue-tracker-url":
-			// issue-tracker-url = "http://www.example.com/issues"
-			// A link to the issue tracker for the service.
-			issueTrackerURL = definition
-		case key == "version":
-			packageVersion = definition
-		case key == "part-file":
-			partFileReference = definition
-		case key == "extra-exports":
-			// extra-export = "export 'package:google_cloud_gax/gax.dart' show Any; export 'package:google_cloud_gax/gax.dart' show Status;"
-			// Dart `export` statements that should be appended after any imports.
-			exports = strings.FieldsFunc(definition, func(c rune) bool { return c == ';' })
-			for i := range exports {
-				exports[i] = strings.TrimSpace(exports[i])
-			}
-		case key == "extra-imports":
-			// extra-imports = "dart:math;package:my_package/my_file.dart"
-			// Dart imports that should be included in the generated file.
-			extraImports := strings.FieldsFunc(definition, func(c rune) bool { return c == ';' })
-			for _, imp := range extraImports {
-				annotate.imports[strings.TrimSpace(imp)] = true
-			}
-		case key == "dependencies":
-			// dependencies = "http, googleapis_auth"
-			// A list of dependencies to add to pubspec.yaml. This can be used to add dependencies for hand-written code.
-			dependencies = strings.Split(definition, ",")
-			for i := range dependencies {
-				dependencies[i] = strings.TrimSpace(dependencies[i])
-			}
-		case key == "dev-dependencies":
-			devDependencies = strings.Split(definition, ",")
-		case key == "not-for-publication":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return fmt.Errorf(
-					"cannot convert `not-for-publication` value %q to boolean: %w",
-					definition,
-					err,
-				)
-			}
-			doNotPublish = value
-		case key == "supports-sse":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return fmt.Errorf(
-					"cannot convert `supports-sse` value %q to boolean: %w",
-					definition,
-					err,
-				)
-			}
-			annotate.supportsSSE = value
-		case key == "readme-after-title-text":
-			// Markdown that will be inserted into the README.md after the title section.
-			readMeAfterTitleText = definition
-		case key == "readme-quickstart-text":
-			// Markdown that will appear as a "Quickstart" section of README.md. Does not include
-			// the section title, i.e., you probably want it to start with "## Getting Started"`
-			// or similar.
-			readMeQuickstartText = definition
-		case key == "repository-url":
-			repositoryURL = definition
-		case strings.HasPrefix(key, "proto:"):
-			// "proto:google.protobuf" = "package:google_cloud_protobuf/protobuf.dart"
-			keys := strings.Split(key, ":")
-			if len(keys) != 2 {
-				return fmt.Errorf("key should be in the format proto:<proto-package>, got=%q", key)
-			}
-			protoPackage := keys[1]
-			annotate.packageMapping[protoPackage] = definition
-		case strings.HasPrefix(key, "prefix:"):
-			// 'prefix:google.protobuf' = 'protobuf'
-			keys := strings.Split(key, ":")
-			if len(keys) != 2 {
-				return fmt.Errorf("key should be in the format prefix:<proto-package>, got=%q", key)
-			}
-			protoPackage := keys[1]
-			annotate.packagePrefixes[protoPackage] = definition
-		case strings.HasPrefix(key, "package:"):
-			// Version constraints for a package.
-			//
-			// Expressed as: 'package:<package name>' = '<version constraint>'
-			// For example: 'package:http' = '^1.3.0'
-			//
-			// If the package is needed as a dependency, then this contract is used.
-			annotate.dependencyConstraints[strings.TrimPrefix(key, "package:")] = definition
+	opts := &codecOptions{
+		APIKeysEnvironmentVariables: []string{},
+		Dependencies:                []string{},
+		DevDependencies:             []string{},
+	}
+	if err := Options.Decode(options, opts); err != nil {
+		return err
+	}
+	annotate.options = opts
+	// Dart `export` statements that should be appended after any imports.
+	exports := []string{}
+	for _, export := range strings.FieldsFunc(opts.ExtraExports, func(c rune) bool { return c == ';' }) {
+		exports = append(exports, strings.TrimSpace(export))
+	}
+	// Dart imports that should be included in the generated file.
+	for _, imp := range strings.FieldsFunc(opts.ExtraImports, func(c rune) bool { return c == ';' }) {
+		annotate.imports[strings.TrimSpace(imp)] = true
+	}
+	if opts.Transport != "" {
+		transports, err := language.ParseTransport(opts.Transport)
+		if err != nil {
+			return err
+		}
+		annotate.transports = transports
+	}
+	annotate.supportsSSE = opts.SupportsSSE
+	for protoPackage, definition := range opts.Protos {
+		if strings.Contains(protoPackage, ":") {
+			return fmt.Errorf("key should be in the format proto:<proto-package>, got=%q", "proto:"+protoPackage)
+		}
+		annotate.packageMapping[protoPackage] = definition
+	}
+	for protoPackage, definition := range opts.Prefixes {
+		if strings.Contains(protoPackage, ":") {
+			return fmt.Errorf("key should be in the format prefix:<proto-package>, got=%q", "prefix:"+protoPackage)
 		}
+		annotate.packagePrefixes[protoPackage] = definition
 	}
+	// If a package is needed as a dependency, then its version constraint is
+	// used.
+	maps.Copy(annotate.dependencyConstraints, opts.Packages)
 
 	// Register any missing WKTs.
 	registerMissingWkt(annotate.model)
 
 	model := annotate.model
 
+	// Resource names must be annotated before the messages, as the fields
+	// referencing them need the helper names.
+	annotate.resourceNames = language.ResourceNames(model)
+	var resourceNames []*resourceNameAnnotation
+	for _, r := range annotate.resourceNames {
+		resourceNames = append(resourceNames, annotateResourceName(r))
+	}
+
 	// Traverse and annotate the enums defined in this API.
 	for _, e := range model.Enums {
 		annotate.annotateEnum(e)
@@ -397,8 +415,10 @@ func (annotate *annotateModel) annotateModel(options map[string]string) error {
 	}
 
 	var fakes []string
-	for _, s := range model.Services {
-		fakes = append(fakes, "Fake"+s.Name)
+	if annotate.transports.REST {
+		for _, s := range model.Services {
+			fakes = append(fakes, "Fake"+s.Name)
+		}
 	}
 	slices.Sort(fakes)
 
@@ -407,7 +427,13 @@ func (annotate *annotateModel) annotateModel(options map[string]string) error {
 
 	// Add the import for ServiceClient and related functionality.
 	if len(model.Services) > 0 {
-		annotate.imports[serviceClientImport] = true
+		if annotate.transports.REST {
+			annotate.imports[serviceClientImport] = true
+		}
+		if annotate.transports.GRPC {
+			annotate.imports[grpcImport] = true
+			annotate.imports[grpcClientImport] = true
+		}
 		annotate.imports[serviceExceptionImport] = true
 	}
 
@@ -417,17 +443,17 @@ func (annotate *annotateModel) annotateModel(options map[string]string) error {
 	// an `enum` or `message`.
 	annotate.imports[encodingImport] = true
 
-	if len(model.Services) > 0 && len(apiKeyEnvironmentVariables) == 0 {
+	if len(model.Services) > 0 && annotate.transports.REST && len(opts.APIKeysEnvironmentVariables) == 0 {
 		return errors.New("all packages that define a service must define 'api-keys-environment-variables'")
 	}
 
-	if issueTrackerURL == "" {
+	if opts.IssueTrackerURL == "" {
 		return errors.New("all packages must define 'issue-tracker-url'")
 	}
 
-	pkgName = packageName(model, packageNameOverride)
+	pkgName := packageName(model, opts.PackageNameOverride)
 	importedPackages := calculatePubPackages(annotate.imports)
-	for _, d := range dependencies {
+	for _, d := range opts.Dependencies {
 		importedPackages[d] = true
 	}
 
@@ -437,18 +463,18 @@ func (annotate *annotateModel) annotateModel(options map[string]string) error {
 	}
 
 	mainFileNameWithExtension := strcase.ToSnake(model.Name) + ".dart"
-	if libraryPathOverride != "" {
-		mainFileNameWithExtension = libraryPathOverride
+	if opts.LibraryPathOverride != "" {
+		mainFileNameWithExtension = opts.LibraryPathOverride
 	}
 
-	slices.Sort(devDependencies)
+	slices.Sort(opts.DevDependencies)
 
 	ann := &modelAnnotations{
 		Parent:                    model,
 		PackageName:               pkgName,
-		PackageVersion:            packageVersion,
+		PackageVersion:            opts.Version,
 		MainFileNameWithExtension: mainFileNameWithExtension,
-		CopyrightYear:             generationYear,
+		CopyrightYear:             opts.CopyrightYear,
 		BoilerPlate: append(license.HeaderBulk(),
 			"",
 			" Code generated by sidekick. DO NOT EDIT."),
@@ -460,18 +486,20 @@ func (annotate *annotateModel) annotateModel(options map[string]string) error {
 		}(),
 		DocLines:                   formatDocComments(model.Description, model),
 		Imports:                    calculateImports(annotate.imports, pkgName, mainFileNameWithExtension),
-		PartFileReference:          partFileReference,
+		PartFileReference:          opts.PartFile,
 		PackageDependencies:        packageDependencies,
-		DevDependencies:            devDependencies,
-		DoNotPublish:               doNotPublish,
-		RepositoryURL:              repositoryURL,
-		IssueTrackerURL:            issueTrackerURL,
-		ReadMeAfterTitleText:       readMeAfterTitleText,
-		ReadMeQuickstartText:       readMeQuickstartText,
-		ApiKeyEnvironmentVariables: apiKeyEnvironmentVariables,
+		DevDependencies:            opts.DevDependencies,
+		DoNotPublish:               opts.NotForPublication,
+		RepositoryURL:              opts.RepositoryURL,
+		IssueTrackerURL:            opts.IssueTrackerURL,
+		ReadMeAfterTitleText:       opts.ReadMeAfterTitleText,
+		ReadMeQuickstartText:       opts.ReadMeQuickstartText,
+		ApiKeyEnvironmentVariables: opts.APIKeysEnvironmentVariables,
 		Exports:                    exports,
 		FakeList:                   strings.Join(fakes, ", "),
-		ProtoPrefix:                protobufPrefix,
+		ResourceNames:              resourceNames,
+		GenerateRest:               annotate.transports.REST,
+		GenerateGrpc:               annotate.transports.GRPC,
 	}
 
 	model.Codec = ann
@@ -593,26 +621,48 @@ func formatImport(imp string) string {
 }
 
 func (annotate *annotateModel) annotateService(s *api.Service) {
-	// Add a package:http import if we're generating a service.
-	annotate.imports[httpImport] = true
+	var methods []*api.Method
+	if annotate.transports.REST {
+		// Add a package:http import if we're generating a service.
+		annotate.imports[httpImport] = true
 
-	// Some methods are skipped.
-	methods := language.FilterSlice(s.Methods, func(m *api.Method) bool {
-		return shouldGenerateMethod(m)
-	})
+		// Some methods are skipped.
+		methods = language.FilterSlice(s.Methods, func(m *api.Method) bool {
+			return shouldGenerateMethod(m)
+		})
+	}
 
 	for i, m := range methods {
 		annotate.annotateMethod(m)
 		m.Codec.(*methodAnnotation).IsLast = (i == len(methods)-1)
 	}
+	var grpcMethods []*api.Method
+	if annotate.transports.GRPC {
+		grpcMethods = s.Methods
+		for _, m := range grpcMethods {
+			annotate.annotateGrpcMethod(m)
+		}
+	}
+	var quickstartMethod *api.Method
+	if q := s.QuickstartMethod; q != nil && slices.Contains(methods, q) && !q.Codec.(*methodAnnotation).ServerSideStreaming {
+		quickstartMethod = q
+	}
+	var quickstartName string
+	if quickstartMethod != nil {
+		quickstartName = quickstartMethod.Name
+	}
 	ann := &serviceAnnotations{
-		Name:        s.Name,
-		DocLines:    formatDocComments(s.Documentation, annotate.model),
-		Methods:     methods,
-		FieldName:   strcase.ToLowerCamel(s.Name),
-		StructName:  s.Name,
-		DefaultHost: s.DefaultHost,
-		HasMethods:  len(methods) > 0,
+		Name:             s.Name,
+		DocLines:         formatDocComments(s.Documentation, annotate.model),
+		Methods:          methods,
+		FieldName:        strcase.ToLowerCamel(s.Name),
+		StructName:       s.Name,
+		DefaultHost:      s.DefaultHost,
+		HasMethods:       len(methods) > 0,
+		GrpcName:         s.Name + "Grpc",
+		GrpcMethods:      grpcMethods,
+		QuickstartMethod: quickstartMethod,
+		RegionTag:        snippetmetadata.RegionTag(s.DefaultHost, s.Package, s.Name, quickstartName),
 	}
 	s.Codec = ann
 }
@@ -718,11 +768,7 @@ func (annotate *annotateModel) annotateMethod(method *api.Method) {
 
 	// For 'GetOperation' mixins, we augment the method generation with
 	// additional generic type parameters.
-	isGetOperation := method.Name == "GetOperation" &&
-		method.OutputTypeID == ".google.longrunning.Operation"
-	if method.ID == ".google.longrunning.Operations.GetOperation" {
-		isGetOperation = false
-	}
+	isGetOperation := isLROGetOperation(method)
 
 	if method.OperationInfo != nil {
 		annotate.annotateOperationInfo(method.OperationInfo)
@@ -748,9 +794,59 @@ func (annotate *annotateModel) annotateMethod(method *api.Method) {
 		ServerSideStreaming: method.ServerSideStreaming,
 		EnableSSE:           method.ServerSideStreaming && annotate.supportsSSE,
 	}
+	annotation.ReturnType = annotation.ResponseType
+	if method.OperationInfo != nil {
+		info := method.OperationInfo.Codec.(*operationInfoAnnotation)
+		annotation.ReturnType = fmt.Sprintf("%s<%s, %s>", annotation.ResponseType, info.ResponseType, info.MetadataType)
+	}
 	method.Codec = annotation
 }
 
+// annotateGrpcMethod adds the gRPC annotations for a method.
+//
+// Methods without HTTP annotations are only available over gRPC, they only
+// get the annotations shared by both transports.
+func (annotate *annotateModel) annotateGrpcMethod(method *api.Method) {
+	if method.Codec == nil {
+		if method.InputType.Codec == nil {
+			annotate.annotateMessage(method.InputType)
+		}
+		if method.OutputType.Codec == nil {
+			annotate.annotateMessage(method.OutputType)
+		}
+		if method.OperationInfo != nil {
+			annotate.annotateOperationInfo(method.OperationInfo)
+		}
+		method.Codec = &methodAnnotation{
+			Parent:            method,
+			Name:              strcase.ToLowerCamel(method.Name),
+			RequestType:       annotate.resolveMessageName(method.InputType, true),
+			ResponseType:      annotate.resolveMessageName(method.OutputType, true),
+			DocLines:          formatDocComments(method.Documentation, annotate.model),
+			ReturnsValue:      !method.ReturnsEmpty,
+			IsLROGetOperation: isLROGetOperation(method),
+		}
+	}
+	ann := method.Codec.(*methodAnnotation)
+	ann.GrpcPath = language.GrpcPath(method)
+	ann.GrpcUnary = !method.ServerSideStreaming && !method.ClientSideStreaming
+	ann.GrpcServerStreaming = method.ServerSideStreaming && !method.ClientSideStreaming
+	ann.GrpcClientStreaming = method.ClientSideStreaming && !method.ServerSideStreaming
+	ann.GrpcBidiStreaming = method.ClientSideStreaming && method.ServerSideStreaming
+	switch {
+	case ann.IsLROGetOperation:
+		ann.GrpcDecoder = "(json) => Operation.fromJson(json, request.operationHelper)"
+	case method.OperationInfo != nil:
+		info := method.OperationInfo.Codec.(*operationInfoAnnotation)
+		ann.GrpcDecoder = fmt.Sprintf("(json) => %s.fromJson(json, OperationHelper(%s.fromJson, %s.fromJson))",
+			ann.ResponseType, info.ResponseType, info.MetadataType)
+	case method.ReturnsEmpty:
+		ann.GrpcDecoder = "(_) {}"
+	default:
+		ann.GrpcDecoder = ann.ResponseType + ".fromJson"
+	}
+}
+
 func (annotate *annotateModel) annotateOperationInfo(operationInfo *api.OperationInfo) {
 	response := annotate.model.Message(operationInfo.ResponseTypeID)
 	metadata := annotate.model.Message(operationInfo.MetadataTypeID)
@@ -835,7 +931,7 @@ func (annotate *annotateModel) annotateField(field *api.Field) {
 			constDefault = defaultValues[field.Typez].IsConst
 		}
 	}
-	field.Codec = &fieldAnnotation{
+	ann := &fieldAnnotation{
 		Name:                  fieldName(field),
 		Type:                  annotate.fieldType(field),
 		DocLines:              formatDocComments(field.Documentation, annotate.model),
@@ -847,6 +943,76 @@ func (annotate *annotateModel) annotateField(field *api.Field) {
 		ToJson:                createToJsonLine(field, annotate.model),
 		ConstDefault:          constDefault,
 	}
+	if r := annotate.resourceNameFor(field, implicitPresence); r != nil {
+		ann.ResourceName = r.Name
+		ann.ResourceGetter = strcase.ToLowerCamel(field.Name) + "Resource"
+	}
+	field.Codec = ann
+}
+
+// resourceNameFor returns the resource name helper for fields referencing a
+// resource, or nil if the field has no helper. Only singular, non-nullable
+// string fields get a helper.
+func (annotate *annotateModel) resourceNameFor(field *api.Field, implicitPresence bool) *language.ResourceName {
+	if field.ResourceReference == nil || field.Typez != api.TypezString {
+		return nil
+	}
+	if field.Repeated || field.Map || !implicitPresence {
+		return nil
+	}
+	return language.ResourceNameFor(annotate.resourceNames, field.ResourceReference.Type)
+}
+
+func annotateResourceName(r *language.ResourceName) *resourceNameAnnotation {
+	ann := &resourceNameAnnotation{
+		Name: r.Name,
+		Type: r.Resource.Type,
+	}
+	var chain []string
+	for _, p := range r.Patterns {
+		pattern := annotateResourcePattern(p)
+		pattern.Name = r.Name
+		if r.IsMultiPattern() {
+			pattern.Name = r.Name + nestedMessageChar + p.Name
+			pattern.Extends = r.Name
+		}
+		chain = append(chain, pattern.Name+".parse(name)")
+		ann.Patterns = append(ann.Patterns, pattern)
+	}
+	ann.ParseChain = strings.Join(chain, " ?? ")
+	r.Resource.Codec = ann
+	return ann
+}
+
+func annotateResourcePattern(p *language.ResourceNamePattern) *resourcePatternAnnotation {
+	var properties, arguments, matcher, format []string
+	for _, s := range p.Segments {
+		if s.Variable == "" {
+			matcher = append(matcher, fmt.Sprintf("'%s'", s.Literal))
+			format = append(format, s.Literal)
+			continue
+		}
+		name := strcase.ToLowerCamel(s.Variable)
+		if _, hasConflict := reservedNames[name]; hasConflict {
+			name = name + deconflictChar
+		}
+		properties = append(properties, name)
+		arguments = append(arguments, fmt.Sprintf("%s: values[%d]", name, len(arguments)))
+		matcher = append(matcher, "null")
+		if strings.Contains(name, "$") {
+			format = append(format, "${"+name+"}")
+		} else {
+			format = append(format, "$"+name)
+		}
+	}
+	return &resourcePatternAnnotation{
+		Template:       p.Template,
+		Properties:     properties,
+		Matcher:        "[" + strings.Join(matcher, ", ") + "]",
+		Arguments:      strings.Join(arguments, ", "),
+		Format:         strings.Join(format, "/"),
+		WildcardParent: p.WildcardParent,
+	}
 }
 
 func (annotate *annotateModel) decoder(typez api.Typez, typeid string) string {
@@ -1355,3 +1521,11 @@ func registerMissingWkt(model *api.API) {
 		}
 	}
 }
+
+// isLROGetOperation returns true for `GetOperation` mixins.
+func isLROGetOperation(method *api.Method) bool {
+	if method.ID == ".google.longrunning.Operations.GetOperation" {
+		return false
+	}
+	return method.Name == "GetOperation" && method.OutputTypeID == ".google.longrunning.Operation"
+}
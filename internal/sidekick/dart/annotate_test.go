@@ -15,6 +15,7 @@
 package dart
 
 import (
+	"errors"
 	"maps"
 	"slices"
 	"testing"
@@ -22,6 +23,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/librarian/internal/sample"
 	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/language"
 )
 
 var (
@@ -206,7 +208,25 @@ func TestAnnotateModel_Options(t *testing.T) {
 			},
 		},
 		{
-			map[string]string{"google_cloud_rpc": "^1.2.3", "package:http": "1.2.0"},
+			map[string]string{"transport": "grpc+rest", "package:grpc": "^4.0.0"},
+			func(t *testing.T, am *annotateModel) {
+				codec := model.Codec.(*modelAnnotations)
+				if !codec.GenerateGrpc || !codec.GenerateRest {
+					t.Errorf("expected gRPC and REST clients, got GenerateGrpc=%v, GenerateRest=%v", codec.GenerateGrpc, codec.GenerateRest)
+				}
+			},
+		},
+		{
+			map[string]string{"transport": "grpc", "package:grpc": "^4.0.0"},
+			func(t *testing.T, am *annotateModel) {
+				codec := model.Codec.(*modelAnnotations)
+				if !codec.GenerateGrpc || codec.GenerateRest {
+					t.Errorf("expected only gRPC clients, got GenerateGrpc=%v, GenerateRest=%v", codec.GenerateGrpc, codec.GenerateRest)
+				}
+			},
+		},
+		{
+			map[string]string{"package:google_cloud_rpc": "^1.2.3", "package:http": "1.2.0"},
 			func(t *testing.T, am *annotateModel) {
 				if diff := cmp.Diff(map[string]string{
 					"google_cloud_rpc":      "^1.2.3",
@@ -265,6 +285,26 @@ func TestAnnotateModel_Options_MissingRequired(t *testing.T) {
 	}
 }
 
+func TestAnnotateModel_Options_BadTransport(t *testing.T) {
+	model := api.NewTestAPI([]*api.Message{}, []*api.Enum{}, []*api.Service{})
+	annotate := newAnnotateModel(model)
+	options := maps.Clone(requiredConfig)
+	options["transport"] = "carrier-pigeon"
+	if err := annotate.annotateModel(options); err == nil {
+		t.Fatal("expected error with an invalid transport")
+	}
+}
+
+func TestAnnotateModel_Options_Unknown(t *testing.T) {
+	model := api.NewTestAPI([]*api.Message{}, []*api.Enum{}, []*api.Service{})
+	annotate := newAnnotateModel(model)
+	options := maps.Clone(requiredConfig)
+	options["skip-formatting"] = "true"
+	if err := annotate.annotateModel(options); !errors.Is(err, language.ErrUnknownOption) {
+		t.Errorf("annotateModel() error = %v, want %v", err, language.ErrUnknownOption)
+	}
+}
+
 func TestAnnotateModel_HasMethods(t *testing.T) {
 	method := sample.MethodListSecretVersions()
 	serviceWithMethods := &api.Service{
@@ -1942,3 +1982,136 @@ func TestAnnotateField(t *testing.T) {
 		})
 	}
 }
+
+func TestAnnotateResourceName(t *testing.T) {
+	secret := api.NewTestMessage("Secret").
+		WithResource(api.NewTestResource("test.googleapis.com/Secret").WithPatterns(
+			api.NewTestResourcePattern("projects", "project", "secrets", "secret"),
+			api.NewTestResourcePattern("projects", "project", "locations", "location", "secrets", "secret"),
+		))
+	project := api.NewTestMessage("Project").
+		WithResource(api.NewTestResource("test.googleapis.com/Project").WithPatterns(
+			api.NewTestResourcePattern("projects", "project"),
+		))
+	model := api.NewTestAPI([]*api.Message{secret, project}, []*api.Enum{}, []*api.Service{})
+	annotate := newAnnotateModel(model)
+	if err := annotate.annotateModel(requiredConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*resourceNameAnnotation{
+		{
+			Name: "ProjectName",
+			Type: "test.googleapis.com/Project",
+			Patterns: []*resourcePatternAnnotation{
+				{
+					Template:   "projects/{project}",
+					Name:       "ProjectName",
+					Properties: []string{"project"},
+					Matcher:    "['projects', null]",
+					Arguments:  "project: values[0]",
+					Format:     "projects/$project",
+				},
+			},
+			ParseChain: "ProjectName.parse(name)",
+		},
+		{
+			Name: "SecretName",
+			Type: "test.googleapis.com/Secret",
+			Patterns: []*resourcePatternAnnotation{
+				{
+					Template:       "projects/{project}/secrets/{secret}",
+					Name:           "SecretName_ProjectSecret",
+					Extends:        "SecretName",
+					Properties:     []string{"project", "secret"},
+					Matcher:        "['projects', null, 'secrets', null]",
+					Arguments:      "project: values[0], secret: values[1]",
+					Format:         "projects/$project/secrets/$secret",
+					WildcardParent: "projects/-",
+				},
+				{
+					Template:       "projects/{project}/locations/{location}/secrets/{secret}",
+					Name:           "SecretName_ProjectLocationSecret",
+					Extends:        "SecretName",
+					Properties:     []string{"project", "location", "secret"},
+					Matcher:        "['projects', null, 'locations', null, 'secrets', null]",
+					Arguments:      "project: values[0], location: values[1], secret: values[2]",
+					Format:         "projects/$project/locations/$location/secrets/$secret",
+					WildcardParent: "projects/-/locations/-",
+				},
+			},
+			ParseChain: "SecretName_ProjectSecret.parse(name) ?? SecretName_ProjectLocationSecret.parse(name)",
+		},
+	}
+	got := model.Codec.(*modelAnnotations).ResourceNames
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want, +got)\n:%s", diff)
+	}
+	if secret.Resource.Codec != got[1] {
+		t.Errorf("expected the resource annotations to be set in the resource")
+	}
+}
+
+func TestAnnotateResourceName_ReservedVariable(t *testing.T) {
+	widget := api.NewTestMessage("Widget").
+		WithResource(api.NewTestResource("test.googleapis.com/Widget").WithPatterns(
+			api.NewTestResourcePattern("widgets", "in"),
+		))
+	model := api.NewTestAPI([]*api.Message{widget}, []*api.Enum{}, []*api.Service{})
+	annotate := newAnnotateModel(model)
+	if err := annotate.annotateModel(requiredConfig); err != nil {
+		t.Fatal(err)
+	}
+	got := widget.Resource.Codec.(*resourceNameAnnotation).Patterns[0]
+	if want := "widgets/${in$}"; got.Format != want {
+		t.Errorf("Format = %q, want %q", got.Format, want)
+	}
+}
+
+func TestAnnotateField_ResourceReference(t *testing.T) {
+	secret := api.NewTestMessage("Secret").
+		WithResource(api.NewTestResource("test.googleapis.com/Secret").WithPatterns(
+			api.NewTestResourcePattern("projects", "project", "secrets", "secret"),
+		))
+	for _, test := range []struct {
+		name       string
+		field      *api.Field
+		wantName   string
+		wantGetter string
+	}{
+		{
+			name:       "reference",
+			field:      api.NewTestField("name").WithType(api.TypezString).WithResourceReference("test.googleapis.com/Secret"),
+			wantName:   "SecretName",
+			wantGetter: "nameResource",
+		},
+		{
+			name:  "unknown",
+			field: api.NewTestField("name").WithType(api.TypezString).WithResourceReference("test.googleapis.com/Unknown"),
+		},
+		{
+			name:  "repeated",
+			field: api.NewTestField("names").WithType(api.TypezString).WithRepeated().WithResourceReference("test.googleapis.com/Secret"),
+		},
+		{
+			name:  "child type",
+			field: api.NewTestField("parent").WithType(api.TypezString).WithChildTypeReference("test.googleapis.com/Secret"),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			request := api.NewTestMessage("Request").WithFields(test.field)
+			model := api.NewTestAPI([]*api.Message{secret, request}, []*api.Enum{}, []*api.Service{})
+			annotate := newAnnotateModel(model)
+			if err := annotate.annotateModel(requiredConfig); err != nil {
+				t.Fatal(err)
+			}
+			got := test.field.Codec.(*fieldAnnotation)
+			if got.ResourceName != test.wantName {
+				t.Errorf("ResourceName = %q, want %q", got.ResourceName, test.wantName)
+			}
+			if got.ResourceGetter != test.wantGetter {
+				t.Errorf("ResourceGetter = %q, want %q", got.ResourceGetter, test.wantGetter)
+			}
+		})
+	}
+}
@@ -30,6 +30,8 @@ import (
 const (
 	httpImport             = "package:http/http.dart as http"
 	serviceClientImport    = "package:google_cloud_rpc/service_client.dart"
+	grpcClientImport       = "package:google_cloud_rpc/grpc_client.dart"
+	grpcImport             = "package:grpc/grpc.dart as grpc"
 	serviceExceptionImport = "package:google_cloud_rpc/exceptions.dart"
 	encodingImport         = "package:google_cloud_protobuf/src/encoding.dart"
 	protobufImport         = "package:google_cloud_protobuf/protobuf.dart"
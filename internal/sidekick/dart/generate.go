@@ -17,10 +17,14 @@ package dart
 import (
 	"context"
 	"embed"
+	"io/fs"
+	"os"
 	"path/filepath"
 
 	"github.com/googleapis/librarian/internal/sidekick/api"
 	"github.com/googleapis/librarian/internal/sidekick/language"
+	"github.com/googleapis/librarian/internal/snippetmetadata"
+	"github.com/iancoleman/strcase"
 )
 
 //go:embed all:templates
@@ -33,34 +37,35 @@ func Generate(ctx context.Context, model *api.API, outdir string, codec map[stri
 		return err
 	}
 
-	provider := templatesProvider()
-	err := language.GenerateFromModel(outdir, model, provider, generatedFiles(model))
-	if err == nil {
-		// Check if we're configured to skip formatting.
-		skipFormat := codec["skip-format"]
-		if skipFormat != "true" {
-			err = formatDirectory(ctx, outdir)
+	fsys := language.OverlayTemplates(dartTemplates, annotate.options.TemplateOverlay)
+	provider := language.TemplatesProvider(fsys)
+	if err := language.GenerateFromModel(outdir, model, provider, generatedFiles(fsys, model)); err != nil {
+		return err
+	}
+	quickstarts, err := generateQuickstarts(outdir, model, provider)
+	if err != nil {
+		return err
+	}
+	// Check if we're configured to skip formatting.
+	if !annotate.options.SkipFormat {
+		if err := formatDirectory(ctx, outdir); err != nil {
+			return err
 		}
 	}
-	return err
+	// The line numbers in the snippet metadata must match the formatted files.
+	return generateSnippetMetadata(outdir, model, quickstarts)
 }
 
-func templatesProvider() language.TemplateProvider {
-	return func(name string) (string, error) {
-		name = filepath.ToSlash(name)
-		contents, err := dartTemplates.ReadFile(name)
-		if err != nil {
-			return "", err
-		}
-		return string(contents), nil
-	}
+// Templates returns the built-in templates for the Dart codec.
+func Templates() fs.FS {
+	return dartTemplates
 }
 
-func generatedFiles(model *api.API) []language.GeneratedFile {
+func generatedFiles(fsys fs.FS, model *api.API) []language.GeneratedFile {
 	codec := model.Codec.(*modelAnnotations)
 	mainFileNameWithExtension := codec.MainFileNameWithExtension
 
-	files := language.WalkTemplatesDir(dartTemplates, "templates")
+	files := language.WalkTemplatesDir(fsys, "templates")
 
 	for index, fileInfo := range files {
 		// Replace 'main.dart' with '{servicename}.dart'
@@ -81,3 +86,66 @@ func generatedFiles(model *api.API) []language.GeneratedFile {
 
 	return files
 }
+
+// generateQuickstarts generates a quickstart sample for each service with an
+// HTTP/JSON client. It returns the services and the path of their samples.
+func generateQuickstarts(outdir string, model *api.API, provider language.TemplateProvider) (map[*api.Service]string, error) {
+	codec := model.Codec.(*modelAnnotations)
+	if !codec.GenerateRest {
+		return nil, nil
+	}
+	quickstarts := map[*api.Service]string{}
+	for _, s := range model.Services {
+		generated := language.GeneratedFile{
+			TemplatePath: "templates/example/quickstart.mustache",
+			OutputPath:   filepath.Join("example", strcase.ToSnake(s.Name)+"_quickstart.dart"),
+		}
+		if err := language.GenerateService(outdir, s, provider, generated); err != nil {
+			return nil, err
+		}
+		quickstarts[s] = generated.OutputPath
+	}
+	return quickstarts, nil
+}
+
+// generateSnippetMetadata writes the snippet metadata for the quickstart
+// samples, using their region tags for the short segments.
+func generateSnippetMetadata(outdir string, model *api.API, quickstarts map[*api.Service]string) error {
+	var samples []*language.SnippetSample
+	for _, s := range model.Services {
+		file, ok := quickstarts[s]
+		if !ok {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(outdir, file))
+		if err != nil {
+			return err
+		}
+		annotations := s.Codec.(*serviceAnnotations)
+		segments := []*snippetmetadata.Segment{snippetmetadata.FullSegment(content)}
+		short := snippetmetadata.ShortSegment(content, "// [START "+annotations.RegionTag+"]", "// [END "+annotations.RegionTag+"]")
+		if short != nil {
+			segments = append(segments, short)
+		}
+		sample := &language.SnippetSample{
+			Service:    s,
+			ClientName: annotations.Name,
+			File:       filepath.ToSlash(file),
+			Segments:   segments,
+		}
+		if m := annotations.QuickstartMethod; m != nil {
+			ann := m.Codec.(*methodAnnotation)
+			sample.Method = m
+			sample.MethodName = ann.Name
+			sample.RequestType = ann.RequestType
+			sample.ResultType = ann.ReturnType
+		}
+		samples = append(samples, sample)
+	}
+	if len(samples) == 0 {
+		return nil
+	}
+	codec := model.Codec.(*modelAnnotations)
+	index := language.NewSnippetIndex(model, snippetmetadata.LanguageDart, codec.PackageName, codec.PackageVersion, samples)
+	return snippetmetadata.Write(outdir, model.PackageName, index)
+}
@@ -15,7 +15,9 @@
 package dart
 
 import (
+	"encoding/json"
 	"errors"
+	"flag"
 	"io/fs"
 	"maps"
 	"os"
@@ -25,14 +27,17 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/librarian/internal/config"
 	"github.com/googleapis/librarian/internal/sidekick/api"
 	"github.com/googleapis/librarian/internal/sidekick/parser"
+	"github.com/googleapis/librarian/internal/snippetmetadata"
 	"github.com/googleapis/librarian/internal/sources"
 )
 
 var (
 	testdataDir, _ = filepath.Abs("../testdata")
+	updateGolden   = flag.Bool("update", false, "update golden files")
 )
 
 func TestFromProtobuf(t *testing.T) {
@@ -83,6 +88,39 @@ func TestFromProtobuf(t *testing.T) {
 	}
 }
 
+func TestGenerate_ResourceNames(t *testing.T) {
+	outDir := t.TempDir()
+	secret := api.NewTestMessage("Secret").
+		WithResource(api.NewTestResource("test.googleapis.com/Secret").WithPatterns(
+			api.NewTestResourcePattern("projects", "project", "secrets", "secret"),
+		))
+	request := api.NewTestMessage("GetSecretRequest").
+		WithFields(api.NewTestField("name").WithType(api.TypezString).WithResourceReference("test.googleapis.com/Secret"))
+	model := api.NewTestAPI([]*api.Message{secret, request}, []*api.Enum{}, []*api.Service{})
+
+	options := maps.Clone(requiredConfig)
+	options["skip-format"] = "true"
+	if err := Generate(t.Context(), model, outDir, options); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(filepath.Join(outDir, "lib", "src", "api.g.dart"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"final class SecretName {",
+		"    final values = _parseResourceName(name, const ['projects', null, 'secrets', null]);",
+		"  static const wildcardParent = 'projects/-';",
+		"  String toString() => 'projects/$project/secrets/$secret';",
+		"  SecretName? get nameResource => SecretName.parse(name);",
+		"List<String>? _parseResourceName(String name, List<String?> pattern) {",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("missing %q in generated code", want)
+		}
+	}
+}
+
 func TestGeneratedFiles(t *testing.T) {
 	model := api.NewTestAPI([]*api.Message{}, []*api.Enum{}, []*api.Service{})
 	annotate := newAnnotateModel(model)
@@ -91,7 +129,7 @@ func TestGeneratedFiles(t *testing.T) {
 	maps.Copy(options, map[string]string{"package:google_cloud_rpc": "^1.2.3", "package:http": "^4.5.6"})
 
 	annotate.annotateModel(options)
-	files := generatedFiles(model)
+	files := generatedFiles(dartTemplates, model)
 	if len(files) == 0 {
 		t.Errorf("expected a non-empty list of template files from generatedFiles()")
 	}
@@ -132,3 +170,226 @@ func requireProtoc(t *testing.T) {
 		t.Skip("skipping test because protoc is not installed")
 	}
 }
+
+func TestGenerate_GrpcGolden(t *testing.T) {
+	outDir := t.TempDir()
+	model := api.NewTestEchoAPI()
+	if err := api.CrossReference(model); err != nil {
+		t.Fatal(err)
+	}
+	options := maps.Clone(requiredConfig)
+	maps.Copy(options, map[string]string{
+		"skip-format":  "true",
+		"transport":    "grpc",
+		"package:grpc": "^4.0.0",
+	})
+	if err := Generate(t.Context(), model, outDir, options); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(filepath.Join(outDir, "lib", "src", "api.g.dart"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join("testdata", "grpc", "api.g.dart.golden")
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Errorf("mismatch (-want +got), run with -update to refresh the golden files:\n%s", diff)
+	}
+}
+
+func TestGenerate_GrpcAndRest(t *testing.T) {
+	outDir := t.TempDir()
+	model := api.NewTestEchoAPI()
+	if err := api.CrossReference(model); err != nil {
+		t.Fatal(err)
+	}
+	options := maps.Clone(requiredConfig)
+	maps.Copy(options, map[string]string{
+		"skip-format":  "true",
+		"transport":    "grpc+rest",
+		"package:grpc": "^4.0.0",
+	})
+	if err := Generate(t.Context(), model, outDir, options); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(filepath.Join(outDir, "lib", "src", "api.g.dart"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"final class Echo {",
+		"base class FakeEcho implements Echo {",
+		"final class EchoGrpc {",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("missing %q in generated code", want)
+		}
+	}
+}
+
+func TestGenerate_FakeGolden(t *testing.T) {
+	outDir := t.TempDir()
+	request := api.NewTestMessage("EchoRequest").
+		WithFields(api.NewTestField("content").WithType(api.TypezString))
+	response := api.NewTestMessage("EchoResponse").
+		WithFields(api.NewTestField("content").WithType(api.TypezString))
+	empty := &api.Message{Name: "Empty", Package: "google.protobuf", ID: ".google.protobuf.Empty"}
+	newMethod := func(name, verb string, output *api.Message) *api.Method {
+		return api.NewTestMethod(name).
+			WithVerb(verb).
+			WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1").WithLiteral(strings.ToLower(name))).
+			WithInput(request).
+			WithOutput(output)
+	}
+	echo := newMethod("Echo", "POST", response)
+	expand := newMethod("Expand", "POST", response)
+	expand.ServerSideStreaming = true
+	forget := newMethod("Forget", "DELETE", empty)
+	forget.ReturnsEmpty = true
+	service := api.NewTestService("Echo").WithMethods(echo, expand, forget)
+	service.DefaultHost = "echo.googleapis.com"
+	model := api.NewTestAPI([]*api.Message{request, response}, []*api.Enum{}, []*api.Service{service})
+	model.AddMessage(empty)
+	if err := api.CrossReference(model); err != nil {
+		t.Fatal(err)
+	}
+	options := maps.Clone(requiredConfig)
+	options["skip-format"] = "true"
+	if err := Generate(t.Context(), model, outDir, options); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(filepath.Join(outDir, "lib", "src", "api.g.dart"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := strings.Index(string(content), "/// Testing fake for [Echo].")
+	if start == -1 {
+		t.Fatalf("missing FakeEcho in generated code:\n%s", content)
+	}
+	got := string(content[start:])
+	got = got[:strings.Index(got, "\n}\n")+3]
+	golden := filepath.Join("testdata", "fake", "fake_echo.dart.golden")
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(golden, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(want), got); diff != "" {
+		t.Errorf("mismatch (-want +got), run with -update to refresh the golden files:\n%s", diff)
+	}
+}
+
+func TestGenerate_SnippetMetadata(t *testing.T) {
+	outDir := t.TempDir()
+	model := api.NewTestEchoAPI()
+	if err := api.CrossReference(model); err != nil {
+		t.Fatal(err)
+	}
+	model.PackageName = "google.showcase.v1"
+	service := model.Services[0]
+	service.Package = model.PackageName
+	service.QuickstartMethod = service.Methods[0]
+	options := maps.Clone(requiredConfig)
+	maps.Copy(options, map[string]string{
+		"skip-format": "true",
+		"version":     "1.2.3",
+	})
+	if err := Generate(t.Context(), model, outDir, options); err != nil {
+		t.Fatal(err)
+	}
+	sample, err := os.ReadFile(filepath.Join(outDir, "example", "echo_quickstart.dart"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"// [START echo_v1_generated_Echo_Echo_async]",
+		"  final client = Echo.fromApiKey();",
+		"  final response = await client.echo(EchoRequest(/* set fields */));",
+		"// [END echo_v1_generated_Echo_Echo_async]",
+	} {
+		if !strings.Contains(string(sample), want) {
+			t.Errorf("missing %q in generated sample:\n%s", want, sample)
+		}
+	}
+
+	content, err := os.ReadFile(filepath.Join(outDir, "snippet_metadata_google.showcase.v1.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got snippetmetadata.Index
+	if err := json.Unmarshal(content, &got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("DART", got.ClientLibrary.Language); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff("1.2.3", got.ClientLibrary.Version); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if len(got.Snippets) != 1 {
+		t.Fatalf("expected a single snippet, got %d", len(got.Snippets))
+	}
+	snippet := got.Snippets[0]
+	if diff := cmp.Diff("echo_v1_generated_Echo_Echo_async", snippet.RegionTag); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff("example/echo_quickstart.dart", snippet.File); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff("google.showcase.v1.Echo.echo", snippet.ClientMethod.FullName); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	lines := strings.Count(string(sample), "\n")
+	wantSegments := []*snippetmetadata.Segment{
+		{Start: 1, End: lines, Type: snippetmetadata.SegmentFull},
+		{Start: lines - 8, End: lines - 1, Type: snippetmetadata.SegmentShort},
+	}
+	if diff := cmp.Diff(wantSegments, snippet.Segments); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerate_SnippetMetadata_GrpcOnly(t *testing.T) {
+	outDir := t.TempDir()
+	options := maps.Clone(requiredConfig)
+	maps.Copy(options, map[string]string{
+		"skip-format":  "true",
+		"transport":    "grpc",
+		"package:grpc": "^4.0.0",
+	})
+	model := api.NewTestEchoAPI()
+	if err := api.CrossReference(model); err != nil {
+		t.Fatal(err)
+	}
+	if err := Generate(t.Context(), model, outDir, options); err != nil {
+		t.Fatal(err)
+	}
+	matches, err := filepath.Glob(filepath.Join(outDir, "snippet_metadata_*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 0 {
+		t.Errorf("expected no snippet metadata for gRPC-only packages, got %v", matches)
+	}
+}
+
+// grpcTestModel returns a model with one method for each streaming shape,
+// and a method without HTTP annotations.
@@ -0,0 +1,72 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dart
+
+import "github.com/googleapis/librarian/internal/sidekick/language"
+
+// Options describes the codec options accepted by the Dart generator.
+var Options = &language.OptionSchema{
+	Codec: "dart",
+	Options: []language.Option{
+		{Name: "api-keys-environment-variables", Type: language.ListOption, Doc: "The environment variables searched for an API key. Required for packages with services."},
+		{Name: "copyright-year", Doc: "The year when the files were first generated."},
+		{Name: "dependencies", Type: language.ListOption, Doc: "Additional dependencies for `pubspec.yaml`, typically used by handwritten code."},
+		{Name: "dev-dependencies", Type: language.ListOption, Doc: "Additional dev dependencies for `pubspec.yaml`."},
+		{Name: "extra-exports", Doc: "Dart `export` statements appended after the imports, separated by `;`."},
+		{Name: "extra-imports", Doc: "Dart imports included in the generated file, separated by `;`."},
+		{Name: "issue-tracker-url", Doc: "A link to the issue tracker for the service. Required."},
+		{Name: "library-path-override", Doc: "The path of the generated library, relative to `lib/`."},
+		{Name: "not-for-publication", Type: language.BoolOption, Doc: "The package is not published."},
+		{Name: "package-name-override", Doc: "Overrides the default package name."},
+		{Name: "package:", Doc: "The version constraint for a package, such as `'package:http' = '^1.3.0'`."},
+		{Name: "part-file", Doc: "A `part` file included in the generated library."},
+		{Name: "prefix:", Doc: "The import prefix for a Protobuf package, such as `'prefix:google.protobuf' = 'protobuf'`."},
+		{Name: "proto:", Doc: "The Dart import for a Protobuf package, such as `'proto:google.protobuf' = 'package:google_cloud_protobuf/protobuf.dart'`."},
+		{Name: "readme-after-title-text", Doc: "Markdown inserted in `README.md` after the title."},
+		{Name: "readme-quickstart-text", Doc: "Markdown for the quickstart section of `README.md`."},
+		{Name: "repository-url", Doc: "The URL of the source repository."},
+		{Name: "skip-format", Type: language.BoolOption, Doc: "Skip running `dart format` on the generated code."},
+		{Name: "supports-sse", Type: language.BoolOption, Doc: "The service supports server-sent events for streaming methods."},
+		{Name: "template-overlay", Doc: "A directory with templates that shadow the built-in templates."},
+		{Name: "transport", Values: []string{"rest", "grpc", "grpc+rest"}, Doc: "The transports used by the generated clients, defaults to `rest`."},
+		{Name: "version", Doc: "The version of the generated package."},
+	},
+}
+
+// codecOptions holds the decoded [Options].
+type codecOptions struct {
+	APIKeysEnvironmentVariables []string          `option:"api-keys-environment-variables"`
+	CopyrightYear               string            `option:"copyright-year"`
+	Dependencies                []string          `option:"dependencies"`
+	DevDependencies             []string          `option:"dev-dependencies"`
+	ExtraExports                string            `option:"extra-exports"`
+	ExtraImports                string            `option:"extra-imports"`
+	IssueTrackerURL             string            `option:"issue-tracker-url"`
+	LibraryPathOverride         string            `option:"library-path-override"`
+	NotForPublication           bool              `option:"not-for-publication"`
+	PackageNameOverride         string            `option:"package-name-override"`
+	Packages                    map[string]string `option:"package:"`
+	PartFile                    string            `option:"part-file"`
+	Prefixes                    map[string]string `option:"prefix:"`
+	Protos                      map[string]string `option:"proto:"`
+	ReadMeAfterTitleText        string            `option:"readme-after-title-text"`
+	ReadMeQuickstartText        string            `option:"readme-quickstart-text"`
+	RepositoryURL               string            `option:"repository-url"`
+	SkipFormat                  bool              `option:"skip-format"`
+	SupportsSSE                 bool              `option:"supports-sse"`
+	TemplateOverlay             string            `option:"template-overlay"`
+	Transport                   string            `option:"transport"`
+	Version                     string            `option:"version"`
+}
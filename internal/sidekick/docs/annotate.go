@@ -0,0 +1,490 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docs
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+// The directories for each kind of page, relative to the output directory.
+const (
+	servicesDir = "services"
+	methodsDir  = "methods"
+	messagesDir = "messages"
+	enumsDir    = "enums"
+)
+
+var (
+	// Matches Protobuf cross-reference links, such as
+	// `[Secret][google.cloud.secretmanager.v1.Secret]`.
+	xrefLink = regexp.MustCompile(`\[[^\]]*\]\[([A-Za-z_][A-Za-z0-9_.]*)\]`)
+	// Matches implicit cross-reference links, such as `[Secret][]`.
+	implicitXrefLink = regexp.MustCompile(`\[([A-Za-z_][A-Za-z0-9_.]*)\]\[\]`)
+)
+
+var behaviorNames = map[api.FieldBehavior]string{
+	api.FieldBehaviorOptional:                 "Optional",
+	api.FieldBehaviorRequired:                 "Required",
+	api.FieldBehaviorOutputOnly:               "Output only",
+	api.FieldBehaviorInputOnly:                "Input only",
+	api.FieldBehaviorImmutable:                "Immutable",
+	api.FieldBehaviorUnorderedList:            "Unordered list",
+	api.FieldBehaviorUnorderedNonEmptyDefault: "Non-empty default",
+	api.FieldBehaviorIdentifier:               "Identifier",
+}
+
+type modelAnnotations struct {
+	Title       string
+	PackageName string
+	DocLines    []string
+	Services    []*api.Service
+	Messages    []*api.Message
+	Enums       []*api.Enum
+}
+
+// HasServices returns true if the model has services.
+func (m *modelAnnotations) HasServices() bool {
+	return len(m.Services) > 0
+}
+
+// HasMessages returns true if the model has messages.
+func (m *modelAnnotations) HasMessages() bool {
+	return len(m.Messages) > 0
+}
+
+// HasEnums returns true if the model has enums.
+func (m *modelAnnotations) HasEnums() bool {
+	return len(m.Enums) > 0
+}
+
+type serviceAnnotations struct {
+	// The path of the page, relative to the output directory.
+	Path     string
+	FullName string
+	DocLines []string
+}
+
+type methodAnnotations struct {
+	Path     string
+	FullName string
+	DocLines []string
+	// A Markdown link to the service page.
+	ServiceLink string
+	// Markdown links to the request and response messages.
+	RequestLink  string
+	ResponseLink string
+	// Markdown links to the response and metadata of long-running operations.
+	OperationResponseLink string
+	OperationMetadataLink string
+	// IsLRO is true if the method starts a long-running operation.
+	IsLRO    bool
+	Bindings []*bindingAnnotation
+}
+
+// HasBindings returns true if the method has HTTP bindings.
+func (m *methodAnnotations) HasBindings() bool {
+	return len(m.Bindings) > 0
+}
+
+type bindingAnnotation struct {
+	Verb string
+	Path string
+	Body string
+}
+
+type messageAnnotations struct {
+	Path     string
+	FullName string
+	DocLines []string
+	// The resource patterns, such as `projects/{project}/secrets/{secret}`.
+	ResourcePatterns []string
+	Fields           []*api.Field
+	// Markdown links to the nested messages and enums.
+	NestedLinks []string
+}
+
+// HasResourcePatterns returns true if the message is a resource with
+// patterns.
+func (m *messageAnnotations) HasResourcePatterns() bool {
+	return len(m.ResourcePatterns) > 0
+}
+
+// HasFields returns true if the message has fields.
+func (m *messageAnnotations) HasFields() bool {
+	return len(m.Fields) > 0
+}
+
+// HasNested returns true if the message has nested messages or enums.
+func (m *messageAnnotations) HasNested() bool {
+	return len(m.NestedLinks) > 0
+}
+
+type fieldAnnotations struct {
+	// The anchor for the field in the message page.
+	Anchor string
+	// The field type as Markdown, including a link if the type has a page.
+	Type      string
+	Behaviors string
+	OneOf     string
+	// The type of resource referenced by the field, if any.
+	ResourceReference string
+	DocLines          []string
+}
+
+type enumAnnotations struct {
+	Path     string
+	FullName string
+	DocLines []string
+}
+
+type enumValueAnnotations struct {
+	Anchor   string
+	DocLines []string
+}
+
+// annotateModel annotates the elements of model with the data used in the
+// templates.
+func annotateModel(model *api.API) {
+	messages := allMessages(model)
+	enums := allEnums(model, messages)
+	pages := newPages(model, messages, enums)
+
+	for _, s := range model.Services {
+		annotateService(s, pages)
+	}
+	for _, m := range messages {
+		annotateMessage(m, pages)
+	}
+	for _, e := range enums {
+		annotateEnum(e, pages)
+	}
+	title := model.Title
+	if title == "" {
+		title = model.Name
+	}
+	model.Codec = &modelAnnotations{
+		Title:       title,
+		PackageName: model.PackageName,
+		DocLines:    docLines(model.Description, nil, pages, ""),
+		Services:    model.Services,
+		Messages:    messages,
+		Enums:       enums,
+	}
+}
+
+func annotateService(s *api.Service, pages map[string]string) {
+	s.Codec = &serviceAnnotations{
+		Path:     pages[s.ID],
+		FullName: strings.TrimPrefix(s.ID, "."),
+		DocLines: docLines(s.Documentation, s.Scopes(), pages, "../"),
+	}
+	for _, m := range s.Methods {
+		annotateMethod(m, s, pages)
+	}
+}
+
+func annotateMethod(m *api.Method, s *api.Service, pages map[string]string) {
+	ann := &methodAnnotations{
+		Path:         pages[m.ID],
+		FullName:     strings.TrimPrefix(m.ID, "."),
+		DocLines:     docLines(m.Documentation, s.Scopes(), pages, "../"),
+		ServiceLink:  typeLink(s.ID, pages),
+		RequestLink:  typeLink(m.InputTypeID, pages),
+		ResponseLink: typeLink(m.OutputTypeID, pages),
+	}
+	if m.OperationInfo != nil {
+		ann.IsLRO = true
+		ann.OperationResponseLink = typeLink(m.OperationInfo.ResponseTypeID, pages)
+		ann.OperationMetadataLink = typeLink(m.OperationInfo.MetadataTypeID, pages)
+	}
+	if m.PathInfo != nil {
+		for _, b := range m.PathInfo.Bindings {
+			if b.PathTemplate == nil {
+				continue
+			}
+			ann.Bindings = append(ann.Bindings, &bindingAnnotation{
+				Verb: b.Verb,
+				Path: bindingPath(b.PathTemplate),
+				Body: m.PathInfo.BodyFieldPath,
+			})
+		}
+	}
+	m.Codec = ann
+}
+
+func annotateMessage(m *api.Message, pages map[string]string) {
+	ann := &messageAnnotations{
+		Path:     pages[m.ID],
+		FullName: strings.TrimPrefix(m.ID, "."),
+		DocLines: docLines(m.Documentation, m.Scopes(), pages, "../"),
+		Fields:   m.Fields,
+	}
+	if m.Resource != nil {
+		for _, p := range m.Resource.Patterns {
+			ann.ResourcePatterns = append(ann.ResourcePatterns, p.String())
+		}
+	}
+	for _, child := range m.Messages {
+		if !child.IsMap {
+			ann.NestedLinks = append(ann.NestedLinks, typeLink(child.ID, pages))
+		}
+	}
+	for _, e := range m.Enums {
+		ann.NestedLinks = append(ann.NestedLinks, typeLink(e.ID, pages))
+	}
+	for _, f := range m.Fields {
+		annotateField(f, m, pages)
+	}
+	m.Codec = ann
+}
+
+func annotateField(f *api.Field, m *api.Message, pages map[string]string) {
+	ann := &fieldAnnotations{
+		Anchor:   strings.ToLower(f.Name),
+		Type:     fieldType(f, pages),
+		DocLines: docLines(f.Documentation, m.Scopes(), pages, "../"),
+	}
+	var behaviors []string
+	for _, b := range f.Behavior {
+		if name, ok := behaviorNames[b]; ok {
+			behaviors = append(behaviors, name)
+		}
+	}
+	ann.Behaviors = strings.Join(behaviors, ", ")
+	if f.IsOneOf && f.Group != nil {
+		ann.OneOf = f.Group.Name
+	}
+	if r := f.ResourceReference; r != nil {
+		ann.ResourceReference = r.Type
+		if ann.ResourceReference == "" {
+			ann.ResourceReference = r.ChildType
+		}
+	}
+	f.Codec = ann
+}
+
+func annotateEnum(e *api.Enum, pages map[string]string) {
+	e.Codec = &enumAnnotations{
+		Path:     pages[e.ID],
+		FullName: strings.TrimPrefix(e.ID, "."),
+		DocLines: docLines(e.Documentation, e.Scopes(), pages, "../"),
+	}
+	for _, v := range e.Values {
+		v.Codec = &enumValueAnnotations{
+			Anchor:   strings.ToLower(v.Name),
+			DocLines: docLines(v.Documentation, e.Scopes(), pages, "../"),
+		}
+	}
+}
+
+// allMessages returns the messages in model, including nested messages, in
+// the order they are defined. Map entries and the placeholders for services
+// in OpenAPI specifications do not get their own page.
+func allMessages(model *api.API) []*api.Message {
+	var result []*api.Message
+	seen := map[string]bool{}
+	var visit func(m *api.Message)
+	visit = func(m *api.Message) {
+		if m.IsMap || m.ServicePlaceholder || seen[m.ID] {
+			return
+		}
+		seen[m.ID] = true
+		result = append(result, m)
+		for _, child := range m.Messages {
+			visit(child)
+		}
+	}
+	for _, m := range model.Messages {
+		visit(m)
+	}
+	return result
+}
+
+// allEnums returns the enums in model, including enums nested in messages.
+func allEnums(model *api.API, messages []*api.Message) []*api.Enum {
+	var result []*api.Enum
+	seen := map[string]bool{}
+	add := func(enums []*api.Enum) {
+		for _, e := range enums {
+			if !seen[e.ID] {
+				seen[e.ID] = true
+				result = append(result, e)
+			}
+		}
+	}
+	add(model.Enums)
+	for _, m := range messages {
+		add(m.Enums)
+	}
+	return result
+}
+
+// newPages returns the path of the page, or the page and anchor, for each
+// element ID that can be the target of a link.
+func newPages(model *api.API, messages []*api.Message, enums []*api.Enum) map[string]string {
+	pages := map[string]string{}
+	for _, s := range model.Services {
+		pages[s.ID] = pagePath(servicesDir, s.ID)
+		for _, m := range s.Methods {
+			pages[m.ID] = pagePath(methodsDir, m.ID)
+		}
+	}
+	for _, m := range messages {
+		path := pagePath(messagesDir, m.ID)
+		pages[m.ID] = path
+		for _, f := range m.Fields {
+			pages[m.ID+"."+f.Name] = path + "#" + strings.ToLower(f.Name)
+		}
+	}
+	for _, e := range enums {
+		path := pagePath(enumsDir, e.ID)
+		pages[e.ID] = path
+		for _, v := range e.Values {
+			pages[e.ID+"."+v.Name] = path + "#" + strings.ToLower(v.Name)
+		}
+	}
+	return pages
+}
+
+func pagePath(dir, id string) string {
+	return fmt.Sprintf("%s/%s.md", dir, strings.TrimPrefix(id, "."))
+}
+
+// docLines returns the documentation as Markdown lines, followed by the link
+// definitions for any cross-references that resolve to a page, and a blank
+// line.
+//
+// The paths in the link definitions are prefixed with root, which leads from
+// the page to the output directory.
+func docLines(documentation string, scopes []string, pages map[string]string, root string) []string {
+	documentation = strings.TrimSpace(documentation)
+	if documentation == "" {
+		return nil
+	}
+	lines := strings.Split(documentation, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	var definitions []string
+	for _, link := range xrefLinks(documentation) {
+		if path := resolveLink(link, scopes, pages); path != "" {
+			definitions = append(definitions, fmt.Sprintf("[%s]: %s%s", link, root, path))
+		}
+	}
+	if len(definitions) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, definitions...)
+	}
+	// The templates expect a blank line after the documentation.
+	return append(lines, "")
+}
+
+// xrefLinks returns the targets of the cross-reference links in
+// documentation, sorted and without duplicates.
+func xrefLinks(documentation string) []string {
+	var links []string
+	for _, re := range []*regexp.Regexp{xrefLink, implicitXrefLink} {
+		for _, match := range re.FindAllStringSubmatch(documentation, -1) {
+			links = append(links, match[1])
+		}
+	}
+	slices.Sort(links)
+	return slices.Compact(links)
+}
+
+// resolveLink returns the path for a cross-reference link.
+//
+// Links may be relative to the element where they appear, so the scopes of
+// the element are tried before the fully qualified name.
+func resolveLink(link string, scopes []string, pages map[string]string) string {
+	for _, s := range scopes {
+		if path, ok := pages[fmt.Sprintf(".%s.%s", s, link)]; ok {
+			return path
+		}
+	}
+	return pages["."+link]
+}
+
+// typeLink returns a Markdown link to the page for id, or the fully
+// qualified name as code if the element does not have a page.
+func typeLink(id string, pages map[string]string) string {
+	name := strings.TrimPrefix(id, ".")
+	path, ok := pages[id]
+	if !ok {
+		return fmt.Sprintf("`%s`", name)
+	}
+	short := name[strings.LastIndex(name, ".")+1:]
+	return fmt.Sprintf("[`%s`](../%s)", short, path)
+}
+
+func fieldType(f *api.Field, pages map[string]string) string {
+	if f.Map && f.MessageType != nil {
+		var key, value string
+		for _, entry := range f.MessageType.Fields {
+			switch entry.Name {
+			case "key":
+				key = scalarOrTypeLink(entry, pages)
+			case "value":
+				value = scalarOrTypeLink(entry, pages)
+			}
+		}
+		return fmt.Sprintf("map<%s, %s>", key, value)
+	}
+	t := scalarOrTypeLink(f, pages)
+	switch {
+	case f.Repeated:
+		return "repeated " + t
+	case f.Optional && f.Typez != api.TypezMessage:
+		return "optional " + t
+	}
+	return t
+}
+
+func scalarOrTypeLink(f *api.Field, pages map[string]string) string {
+	switch f.Typez {
+	case api.TypezMessage, api.TypezEnum:
+		return typeLink(f.TypezID, pages)
+	}
+	return fmt.Sprintf("`%s`", strings.ToLower(f.Typez.String()))
+}
+
+// bindingPath returns the path template in its canonical form, such as
+// `/v1/{name=projects/*/secrets/*}:addVersion`.
+func bindingPath(t *api.PathTemplate) string {
+	var segments []string
+	for _, s := range t.Segments {
+		switch {
+		case s.Literal != nil:
+			segments = append(segments, *s.Literal)
+		case s.Variable != nil:
+			name := strings.Join(s.Variable.FieldPath, ".")
+			if len(s.Variable.Segments) == 0 {
+				segments = append(segments, fmt.Sprintf("{%s}", name))
+				continue
+			}
+			segments = append(segments, fmt.Sprintf("{%s=%s}", name, strings.Join(s.Variable.Segments, "/")))
+		}
+	}
+	path := "/" + strings.Join(segments, "/")
+	if t.Verb != nil {
+		path += ":" + *t.Verb
+	}
+	return path
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package docs generates a language-neutral API reference from the sidekick
+// model.
+//
+// The reference is a set of Markdown pages: an index, plus one page per
+// service, method, message and enum. Cross-reference links in the
+// documentation are resolved to the corresponding pages. The output directory
+// includes a `docs.metadata.json` file, so it can be packaged with
+// [docuploader.CreateArchive].
+package docs
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/googleapis/librarian/internal/docuploader"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/language"
+	"github.com/googleapis/librarian/internal/snippetmetadata"
+)
+
+// Language is the value of the `language` field in the documentation
+// metadata. The reference does not target any programming language.
+const Language = "api"
+
+//go:embed all:templates
+var templates embed.FS
+
+// Generate generates the API reference for model in outdir. The codec options
+// are described in [Options].
+func Generate(ctx context.Context, model *api.API, outdir string, codec map[string]string) error {
+	opts := &codecOptions{}
+	if err := Options.Decode(codec, opts); err != nil {
+		return err
+	}
+	annotateModel(model)
+	fsys := language.OverlayTemplates(templates, opts.TemplateOverlay)
+	provider := language.TemplatesProvider(fsys)
+	if err := language.GenerateFromModel(outdir, model, provider, language.WalkTemplatesDir(fsys, "templates/package")); err != nil {
+		return err
+	}
+	ann := model.Codec.(*modelAnnotations)
+	for _, s := range ann.Services {
+		gen := language.GeneratedFile{
+			TemplatePath: "templates/pages/service.md.mustache",
+			OutputPath:   s.Codec.(*serviceAnnotations).Path,
+		}
+		if err := language.GenerateService(outdir, s, provider, gen); err != nil {
+			return err
+		}
+		for _, m := range s.Methods {
+			gen := language.GeneratedFile{
+				TemplatePath: "templates/pages/method.md.mustache",
+				OutputPath:   m.Codec.(*methodAnnotations).Path,
+			}
+			if err := language.GenerateMethod(outdir, m, provider, gen); err != nil {
+				return err
+			}
+		}
+	}
+	for _, m := range ann.Messages {
+		gen := language.GeneratedFile{
+			TemplatePath: "templates/pages/message.md.mustache",
+			OutputPath:   m.Codec.(*messageAnnotations).Path,
+		}
+		if err := language.GenerateMessage(outdir, m, provider, gen); err != nil {
+			return err
+		}
+	}
+	for _, e := range ann.Enums {
+		gen := language.GeneratedFile{
+			TemplatePath: "templates/pages/enum.md.mustache",
+			OutputPath:   e.Codec.(*enumAnnotations).Path,
+		}
+		if err := language.GenerateEnum(outdir, e, provider, gen); err != nil {
+			return err
+		}
+	}
+	return writeMetadata(outdir, model, opts)
+}
+
+// writeMetadata writes the `docs.metadata.json` file expected by
+// [docuploader.CreateArchive].
+func writeMetadata(outdir string, model *api.API, opts *codecOptions) error {
+	metadata := &docuploader.DocUploaderMetadata{
+		Language: Language,
+		Name:     docsetName(model, opts),
+		Version:  opts.Version,
+	}
+	if metadata.Version == "" {
+		metadata.Version = snippetmetadata.APIVersion(model.PackageName)
+	}
+	metadata.SetUpdateTime(time.Now())
+	content, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(outdir, "docs.metadata.json"), append(content, '\n'), 0644)
+}
+
+func docsetName(model *api.API, opts *codecOptions) string {
+	if name := opts.Name; name != "" {
+		return name
+	}
+	for _, s := range model.Services {
+		if s.DefaultHost != "" {
+			name, _, _ := strings.Cut(s.DefaultHost, ".")
+			return name
+		}
+	}
+	return model.Name
+}
@@ -0,0 +1,217 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docs
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/docuploader"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/language"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+const testPackage = "google.cloud.secretmanager.v1"
+
+func TestGenerateGolden(t *testing.T) {
+	outDir := t.TempDir()
+	if err := Generate(t.Context(), testModel(t), outDir, map[string]string{"version": "1.2.3"}); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	err := filepath.WalkDir(outDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(outDir, path)
+		if err != nil {
+			return err
+		}
+		got = append(got, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"docs.metadata.json",
+		"enums/google.cloud.secretmanager.v1.Secret.State.md",
+		"index.md",
+		"messages/google.cloud.secretmanager.v1.GetSecretRequest.md",
+		"messages/google.cloud.secretmanager.v1.Secret.md",
+		"methods/google.cloud.secretmanager.v1.SecretManagerService.GetSecret.md",
+		"services/google.cloud.secretmanager.v1.SecretManagerService.md",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("mismatch (-want +got):\n%s", diff)
+	}
+	for _, name := range want[1:] {
+		t.Run(name, func(t *testing.T) {
+			content, err := os.ReadFile(filepath.Join(outDir, name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			golden := filepath.Join("testdata", "golden", name)
+			if *updateGolden {
+				if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(golden, content, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(string(want), string(content)); diff != "" {
+				t.Errorf("mismatch (-want +got), run with -update to refresh the golden files:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateMetadata(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		codec map[string]string
+		want  *docuploader.DocUploaderMetadata
+	}{
+		{
+			name:  "defaults",
+			codec: map[string]string{},
+			want:  &docuploader.DocUploaderMetadata{Language: "api", Name: "secretmanager", Version: "v1"},
+		},
+		{
+			name:  "options",
+			codec: map[string]string{"name": "secrets", "version": "1.2.3"},
+			want:  &docuploader.DocUploaderMetadata{Language: "api", Name: "secrets", Version: "1.2.3"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			outDir := t.TempDir()
+			if err := Generate(t.Context(), testModel(t), outDir, test.codec); err != nil {
+				t.Fatal(err)
+			}
+			content, err := os.ReadFile(filepath.Join(outDir, "docs.metadata.json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := &docuploader.DocUploaderMetadata{}
+			if err := json.Unmarshal(content, got); err != nil {
+				t.Fatal(err)
+			}
+			if got.UpdateTime == "" {
+				t.Errorf("missing updateTime in %s", content)
+			}
+			got.UpdateTime = ""
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerate_UnknownOption(t *testing.T) {
+	err := Generate(t.Context(), testModel(t), t.TempDir(), map[string]string{"versoin": "1.2.3"})
+	if !errors.Is(err, language.ErrUnknownOption) {
+		t.Errorf("Generate() error = %v, want %v", err, language.ErrUnknownOption)
+	}
+}
+
+func TestResolveLink(t *testing.T) {
+	model := testModel(t)
+	messages := allMessages(model)
+	pages := newPages(model, messages, allEnums(model, messages))
+	scopes := model.Message(".google.cloud.secretmanager.v1.Secret").Scopes()
+	for _, test := range []struct {
+		link string
+		want string
+	}{
+		{"google.cloud.secretmanager.v1.Secret", "messages/google.cloud.secretmanager.v1.Secret.md"},
+		{"Secret", "messages/google.cloud.secretmanager.v1.Secret.md"},
+		{"Secret.name", "messages/google.cloud.secretmanager.v1.Secret.md#name"},
+		{"State.ENABLED", "enums/google.cloud.secretmanager.v1.Secret.State.md#enabled"},
+		{"SecretManagerService.GetSecret", "methods/google.cloud.secretmanager.v1.SecretManagerService.GetSecret.md"},
+		{"google.protobuf.Timestamp", ""},
+	} {
+		t.Run(test.link, func(t *testing.T) {
+			got := resolveLink(test.link, scopes, pages)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func testModel(t *testing.T) *api.API {
+	t.Helper()
+	state := &api.Enum{
+		Name:          "State",
+		ID:            ".google.cloud.secretmanager.v1.Secret.State",
+		Package:       testPackage,
+		Documentation: "The state of a [Secret][google.cloud.secretmanager.v1.Secret].",
+		Values: []*api.EnumValue{
+			{Name: "STATE_UNSPECIFIED", Number: 0, Documentation: "Not specified."},
+			{Name: "ENABLED", Number: 1, Documentation: "The secret can be used."},
+			{Name: "DISABLED", Number: 2, Deprecated: true},
+		},
+	}
+	secret := api.NewTestMessage("Secret").WithPackage(testPackage).
+		WithResource(api.NewTestResource("secretmanager.googleapis.com/Secret").WithPatterns(api.ResourcePattern{
+			*(&api.PathSegment{}).WithLiteral("projects"),
+			*(&api.PathSegment{}).WithVariable(api.NewPathVariable("project")),
+			*(&api.PathSegment{}).WithLiteral("secrets"),
+			*(&api.PathSegment{}).WithVariable(api.NewPathVariable("secret")),
+		})).
+		WithFields(
+			api.NewTestField("name").WithType(api.TypezString).WithBehavior(api.FieldBehaviorIdentifier),
+			api.NewTestField("labels").WithType(api.TypezString).WithRepeated().WithBehavior(api.FieldBehaviorOptional),
+			&api.Field{Name: "state", JSONName: "state", Typez: api.TypezEnum, TypezID: state.ID, EnumType: state,
+				Documentation: "Output only. The [State][] of the secret.", Behavior: []api.FieldBehavior{api.FieldBehaviorOutputOnly}},
+			&api.Field{Name: "create_time", JSONName: "createTime", Typez: api.TypezMessage, TypezID: ".google.protobuf.Timestamp", Deprecated: true},
+		)
+	secret.Documentation = "A secret, see [GetSecret][google.cloud.secretmanager.v1.SecretManagerService.GetSecret]."
+	request := api.NewTestMessage("GetSecretRequest").WithPackage(testPackage).
+		WithFields(api.NewTestField("name").WithType(api.TypezString).
+			WithBehavior(api.FieldBehaviorRequired).
+			WithResourceReference("secretmanager.googleapis.com/Secret"))
+	request.Documentation = "Request for [GetSecret][SecretManagerService.GetSecret]."
+	get := api.NewTestMethod("GetSecret").
+		WithVerb("GET").
+		WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1").WithVariable(api.NewPathVariable("name").
+			WithLiteral("projects").WithMatch().WithLiteral("secrets").WithMatch())).
+		WithInput(request).
+		WithOutput(secret)
+	get.Documentation = "Gets metadata for a given [Secret][google.cloud.secretmanager.v1.Secret]."
+	service := api.NewTestService("SecretManagerService").WithPackage(testPackage).WithMethods(get)
+	service.Documentation = "Manages secrets."
+	service.DefaultHost = "secretmanager.googleapis.com"
+	model := api.NewTestAPI([]*api.Message{secret, request}, []*api.Enum{state}, []*api.Service{service})
+	model.Title = "Secret Manager API"
+	model.Description = "Stores sensitive data such as API keys, passwords, and certificates."
+	if err := api.CrossReference(model); err != nil {
+		t.Fatal(err)
+	}
+	return model
+}
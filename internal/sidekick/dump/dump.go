@@ -0,0 +1,543 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dump converts a cross-referenced [api.API] into a stable,
+// cycle-free representation that can be written as JSON or YAML.
+//
+// The back-pointers in the model (such as `Parent`, `Model` and `Service`)
+// are replaced by the ID of the element they point to. Elements are listed
+// in the order they appear in the model, so the output is deterministic and
+// suitable as golden data in tests.
+package dump
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/yaml"
+)
+
+const (
+	// FormatJSON selects JSON output in [Marshal].
+	FormatJSON = "json"
+	// FormatYAML selects YAML output in [Marshal].
+	FormatYAML = "yaml"
+)
+
+var errUnknownFormat = errors.New("unknown dump format")
+
+// Model is the dump of an [api.API].
+type Model struct {
+	Name        string      `json:"name,omitempty" yaml:"name,omitempty"`
+	PackageName string      `json:"package_name,omitempty" yaml:"package_name,omitempty"`
+	Title       string      `json:"title,omitempty" yaml:"title,omitempty"`
+	Description string      `json:"description,omitempty" yaml:"description,omitempty"`
+	Revision    string      `json:"revision,omitempty" yaml:"revision,omitempty"`
+	Services    []*Service  `json:"services,omitempty" yaml:"services,omitempty"`
+	Messages    []*Message  `json:"messages,omitempty" yaml:"messages,omitempty"`
+	Enums       []*Enum     `json:"enums,omitempty" yaml:"enums,omitempty"`
+	Resources   []*Resource `json:"resources,omitempty" yaml:"resources,omitempty"`
+}
+
+// Service is the dump of an [api.Service].
+type Service struct {
+	ID            string    `json:"id" yaml:"id"`
+	Name          string    `json:"name" yaml:"name"`
+	Package       string    `json:"package,omitempty" yaml:"package,omitempty"`
+	DefaultHost   string    `json:"default_host,omitempty" yaml:"default_host,omitempty"`
+	Deprecated    bool      `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Documentation string    `json:"documentation,omitempty" yaml:"documentation,omitempty"`
+	Methods       []*Method `json:"methods,omitempty" yaml:"methods,omitempty"`
+}
+
+// Method is the dump of an [api.Method].
+type Method struct {
+	ID                  string         `json:"id" yaml:"id"`
+	Name                string         `json:"name" yaml:"name"`
+	Service             string         `json:"service,omitempty" yaml:"service,omitempty"`
+	SourceService       string         `json:"source_service,omitempty" yaml:"source_service,omitempty"`
+	InputType           string         `json:"input_type" yaml:"input_type"`
+	OutputType          string         `json:"output_type" yaml:"output_type"`
+	Deprecated          bool           `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	ReturnsEmpty        bool           `json:"returns_empty,omitempty" yaml:"returns_empty,omitempty"`
+	ClientSideStreaming bool           `json:"client_side_streaming,omitempty" yaml:"client_side_streaming,omitempty"`
+	ServerSideStreaming bool           `json:"server_side_streaming,omitempty" yaml:"server_side_streaming,omitempty"`
+	Standard            string         `json:"standard,omitempty" yaml:"standard,omitempty"`
+	APIVersion          string         `json:"api_version,omitempty" yaml:"api_version,omitempty"`
+	Body                string         `json:"body,omitempty" yaml:"body,omitempty"`
+	Bindings            []*Binding     `json:"bindings,omitempty" yaml:"bindings,omitempty"`
+	Pagination          string         `json:"pagination,omitempty" yaml:"pagination,omitempty"`
+	OperationInfo       *OperationInfo `json:"operation_info,omitempty" yaml:"operation_info,omitempty"`
+	DiscoveryLro        *DiscoveryLro  `json:"discovery_lro,omitempty" yaml:"discovery_lro,omitempty"`
+	Routing             []*Routing     `json:"routing,omitempty" yaml:"routing,omitempty"`
+	AutoPopulated       []string       `json:"auto_populated,omitempty" yaml:"auto_populated,omitempty"`
+	Documentation       string         `json:"documentation,omitempty" yaml:"documentation,omitempty"`
+}
+
+// Binding is the dump of an [api.PathBinding].
+type Binding struct {
+	Verb            string          `json:"verb" yaml:"verb"`
+	Path            string          `json:"path" yaml:"path"`
+	QueryParameters []string        `json:"query_parameters,omitempty" yaml:"query_parameters,omitempty"`
+	TargetResource  *TargetResource `json:"target_resource,omitempty" yaml:"target_resource,omitempty"`
+}
+
+// TargetResource is the dump of an [api.TargetResource].
+type TargetResource struct {
+	FieldPaths []string `json:"field_paths" yaml:"field_paths"`
+	Template   string   `json:"template,omitempty" yaml:"template,omitempty"`
+}
+
+// OperationInfo is the dump of an [api.OperationInfo].
+type OperationInfo struct {
+	MetadataType string `json:"metadata_type" yaml:"metadata_type"`
+	ResponseType string `json:"response_type" yaml:"response_type"`
+}
+
+// DiscoveryLro is the dump of an [api.DiscoveryLro].
+type DiscoveryLro struct {
+	PollingPathParameters []string `json:"polling_path_parameters" yaml:"polling_path_parameters"`
+}
+
+// Routing is the dump of an [api.RoutingInfo].
+type Routing struct {
+	Name     string   `json:"name" yaml:"name"`
+	Variants []string `json:"variants" yaml:"variants"`
+}
+
+// Message is the dump of an [api.Message].
+type Message struct {
+	ID                 string      `json:"id" yaml:"id"`
+	Name               string      `json:"name" yaml:"name"`
+	Package            string      `json:"package,omitempty" yaml:"package,omitempty"`
+	Parent             string      `json:"parent,omitempty" yaml:"parent,omitempty"`
+	Deprecated         bool        `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	IsMap              bool        `json:"is_map,omitempty" yaml:"is_map,omitempty"`
+	SyntheticRequest   bool        `json:"synthetic_request,omitempty" yaml:"synthetic_request,omitempty"`
+	ServicePlaceholder bool        `json:"service_placeholder,omitempty" yaml:"service_placeholder,omitempty"`
+	Resource           string      `json:"resource,omitempty" yaml:"resource,omitempty"`
+	Pagination         *Pagination `json:"pagination,omitempty" yaml:"pagination,omitempty"`
+	Documentation      string      `json:"documentation,omitempty" yaml:"documentation,omitempty"`
+	Fields             []*Field    `json:"fields,omitempty" yaml:"fields,omitempty"`
+	OneOfs             []*OneOf    `json:"oneofs,omitempty" yaml:"oneofs,omitempty"`
+	Messages           []*Message  `json:"messages,omitempty" yaml:"messages,omitempty"`
+	Enums              []*Enum     `json:"enums,omitempty" yaml:"enums,omitempty"`
+}
+
+// Pagination is the dump of an [api.PaginationInfo].
+type Pagination struct {
+	NextPageToken string `json:"next_page_token" yaml:"next_page_token"`
+	PageableItem  string `json:"pageable_item" yaml:"pageable_item"`
+}
+
+// Field is the dump of an [api.Field].
+type Field struct {
+	ID                string             `json:"id" yaml:"id"`
+	Name              string             `json:"name" yaml:"name"`
+	JSONName          string             `json:"json_name,omitempty" yaml:"json_name,omitempty"`
+	Type              string             `json:"type" yaml:"type"`
+	TypeID            string             `json:"type_id,omitempty" yaml:"type_id,omitempty"`
+	Optional          bool               `json:"optional,omitempty" yaml:"optional,omitempty"`
+	Repeated          bool               `json:"repeated,omitempty" yaml:"repeated,omitempty"`
+	Map               bool               `json:"map,omitempty" yaml:"map,omitempty"`
+	Deprecated        bool               `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Recursive         bool               `json:"recursive,omitempty" yaml:"recursive,omitempty"`
+	AutoPopulated     bool               `json:"auto_populated,omitempty" yaml:"auto_populated,omitempty"`
+	OneOf             string             `json:"oneof,omitempty" yaml:"oneof,omitempty"`
+	Behavior          []string           `json:"behavior,omitempty" yaml:"behavior,omitempty"`
+	ResourceReference *ResourceReference `json:"resource_reference,omitempty" yaml:"resource_reference,omitempty"`
+	Documentation     string             `json:"documentation,omitempty" yaml:"documentation,omitempty"`
+}
+
+// ResourceReference is the dump of an [api.ResourceReference].
+type ResourceReference struct {
+	Type      string `json:"type,omitempty" yaml:"type,omitempty"`
+	ChildType string `json:"child_type,omitempty" yaml:"child_type,omitempty"`
+}
+
+// OneOf is the dump of an [api.OneOf].
+type OneOf struct {
+	ID     string   `json:"id" yaml:"id"`
+	Name   string   `json:"name" yaml:"name"`
+	Fields []string `json:"fields" yaml:"fields"`
+}
+
+// Enum is the dump of an [api.Enum].
+type Enum struct {
+	ID            string       `json:"id" yaml:"id"`
+	Name          string       `json:"name" yaml:"name"`
+	Package       string       `json:"package,omitempty" yaml:"package,omitempty"`
+	Parent        string       `json:"parent,omitempty" yaml:"parent,omitempty"`
+	Deprecated    bool         `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Documentation string       `json:"documentation,omitempty" yaml:"documentation,omitempty"`
+	Values        []*EnumValue `json:"values,omitempty" yaml:"values,omitempty"`
+}
+
+// EnumValue is the dump of an [api.EnumValue].
+type EnumValue struct {
+	ID            string `json:"id" yaml:"id"`
+	Name          string `json:"name" yaml:"name"`
+	Number        int32  `json:"number" yaml:"number"`
+	Deprecated    bool   `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Documentation string `json:"documentation,omitempty" yaml:"documentation,omitempty"`
+}
+
+// Resource is the dump of an [api.Resource].
+type Resource struct {
+	Type     string   `json:"type" yaml:"type"`
+	Patterns []string `json:"patterns,omitempty" yaml:"patterns,omitempty"`
+	Plural   string   `json:"plural,omitempty" yaml:"plural,omitempty"`
+	Singular string   `json:"singular,omitempty" yaml:"singular,omitempty"`
+	Self     string   `json:"self,omitempty" yaml:"self,omitempty"`
+}
+
+// New returns the dump of `model`.
+//
+// If `filter` is not empty, only the elements whose ID matches one of the
+// entries are included. An entry matches an element if it is the element ID,
+// or the ID of any of its ancestors. Services, messages and enums are also
+// included if any of their descendants match, but then only the matching
+// descendants are included.
+func New(model *api.API, filter []string) *Model {
+	d := &Model{
+		Name:        model.Name,
+		PackageName: model.PackageName,
+		Title:       model.Title,
+		Description: model.Description,
+		Revision:    model.Revision,
+	}
+	for _, s := range model.Services {
+		if svc := newService(s, filter); svc != nil {
+			d.Services = append(d.Services, svc)
+		}
+	}
+	for _, m := range model.Messages {
+		if msg := newMessage(m, filter); msg != nil {
+			d.Messages = append(d.Messages, msg)
+		}
+	}
+	for _, e := range model.Enums {
+		if enum := newEnum(e, filter); enum != nil {
+			d.Enums = append(d.Enums, enum)
+		}
+	}
+	resources := map[string]*api.Resource{}
+	if model.State != nil {
+		maps.Copy(resources, model.State.ResourceByType)
+	}
+	for _, r := range model.ResourceDefinitions {
+		resources[r.Type] = r
+	}
+	for _, typ := range slices.Sorted(maps.Keys(resources)) {
+		r := resources[typ]
+		if len(filter) != 0 && (r.Self == nil || !matches(r.Self.ID, filter)) && !slices.Contains(filter, typ) {
+			continue
+		}
+		d.Resources = append(d.Resources, newResource(r))
+	}
+	return d
+}
+
+// matches returns true if `id` is selected by `filter`.
+func matches(id string, filter []string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(filter, func(f string) bool {
+		return id == f || strings.HasPrefix(id, f+".")
+	})
+}
+
+func newService(s *api.Service, filter []string) *Service {
+	svc := &Service{
+		ID:            s.ID,
+		Name:          s.Name,
+		Package:       s.Package,
+		DefaultHost:   s.DefaultHost,
+		Deprecated:    s.Deprecated,
+		Documentation: s.Documentation,
+	}
+	for _, m := range s.Methods {
+		if matches(m.ID, filter) {
+			svc.Methods = append(svc.Methods, newMethod(m))
+		}
+	}
+	if len(svc.Methods) == 0 && !matches(s.ID, filter) {
+		return nil
+	}
+	return svc
+}
+
+func newMethod(m *api.Method) *Method {
+	method := &Method{
+		ID:                  m.ID,
+		Name:                m.Name,
+		SourceService:       m.SourceServiceID,
+		InputType:           m.InputTypeID,
+		OutputType:          m.OutputTypeID,
+		Deprecated:          m.Deprecated,
+		ReturnsEmpty:        m.ReturnsEmpty,
+		ClientSideStreaming: m.ClientSideStreaming,
+		ServerSideStreaming: m.ServerSideStreaming,
+		Standard:            standard(m),
+		APIVersion:          m.APIVersion,
+		Documentation:       m.Documentation,
+	}
+	if m.Service != nil {
+		method.Service = m.Service.ID
+	}
+	if m.PathInfo != nil {
+		method.Body = m.PathInfo.BodyFieldPath
+		for _, b := range m.PathInfo.Bindings {
+			method.Bindings = append(method.Bindings, newBinding(b))
+		}
+	}
+	if m.Pagination != nil {
+		method.Pagination = m.Pagination.ID
+	}
+	if m.OperationInfo != nil {
+		method.OperationInfo = &OperationInfo{
+			MetadataType: m.OperationInfo.MetadataTypeID,
+			ResponseType: m.OperationInfo.ResponseTypeID,
+		}
+	}
+	if m.DiscoveryLro != nil {
+		method.DiscoveryLro = &DiscoveryLro{PollingPathParameters: m.DiscoveryLro.PollingPathParameters}
+	}
+	for _, r := range m.Routing {
+		routing := &Routing{Name: r.Name}
+		for _, v := range r.Variants {
+			routing.Variants = append(routing.Variants, v.FieldName()+"="+v.TemplateAsString())
+		}
+		method.Routing = append(method.Routing, routing)
+	}
+	for _, f := range m.AutoPopulated {
+		method.AutoPopulated = append(method.AutoPopulated, f.ID)
+	}
+	return method
+}
+
+func standard(m *api.Method) string {
+	switch {
+	case m.IsAIPStandardGet:
+		return "get"
+	case m.IsAIPStandardList:
+		return "list"
+	case m.IsAIPStandardCreate:
+		return "create"
+	case m.IsAIPStandardUpdate:
+		return "update"
+	case m.IsAIPStandardDelete:
+		return "delete"
+	case m.IsAIPStandardUndelete:
+		return "undelete"
+	default:
+		return ""
+	}
+}
+
+func newBinding(b *api.PathBinding) *Binding {
+	binding := &Binding{
+		Verb:            b.Verb,
+		QueryParameters: slices.Sorted(maps.Keys(b.QueryParameters)),
+	}
+	if b.PathTemplate != nil {
+		binding.Path = b.PathTemplate.FlatPath()
+		if b.PathTemplate.Verb != nil {
+			binding.Path += ":" + *b.PathTemplate.Verb
+		}
+	}
+	if b.TargetResource != nil {
+		target := &TargetResource{Template: api.ResourcePattern(b.TargetResource.Template).String()}
+		for _, p := range b.TargetResource.FieldPaths {
+			target.FieldPaths = append(target.FieldPaths, strings.Join(p, "."))
+		}
+		binding.TargetResource = target
+	}
+	return binding
+}
+
+// newMessage returns the dump of `m`, or nil if neither `m` nor any of its
+// descendants matches `filter`.
+func newMessage(m *api.Message, filter []string) *Message {
+	if matches(m.ID, filter) {
+		// All the descendants match too.
+		filter = nil
+	}
+	msg := &Message{
+		ID:                 m.ID,
+		Name:               m.Name,
+		Package:            m.Package,
+		Deprecated:         m.Deprecated,
+		IsMap:              m.IsMap,
+		SyntheticRequest:   m.SyntheticRequest,
+		ServicePlaceholder: m.ServicePlaceholder,
+		Documentation:      m.Documentation,
+	}
+	if m.Parent != nil {
+		msg.Parent = m.Parent.ID
+	}
+	if m.Resource != nil {
+		msg.Resource = m.Resource.Type
+	}
+	if m.Pagination != nil {
+		msg.Pagination = &Pagination{}
+		if m.Pagination.NextPageToken != nil {
+			msg.Pagination.NextPageToken = m.Pagination.NextPageToken.ID
+		}
+		if m.Pagination.PageableItem != nil {
+			msg.Pagination.PageableItem = m.Pagination.PageableItem.ID
+		}
+	}
+	for _, f := range m.Fields {
+		if matches(f.ID, filter) {
+			msg.Fields = append(msg.Fields, newField(f))
+		}
+	}
+	for _, o := range m.OneOfs {
+		oneof := &OneOf{ID: o.ID, Name: o.Name}
+		for _, f := range o.Fields {
+			oneof.Fields = append(oneof.Fields, f.ID)
+		}
+		if matches(o.ID, filter) {
+			msg.OneOfs = append(msg.OneOfs, oneof)
+		}
+	}
+	for _, child := range m.Messages {
+		if nested := newMessage(child, filter); nested != nil {
+			msg.Messages = append(msg.Messages, nested)
+		}
+	}
+	for _, e := range m.Enums {
+		if enum := newEnum(e, filter); enum != nil {
+			msg.Enums = append(msg.Enums, enum)
+		}
+	}
+	if len(filter) != 0 && len(msg.Fields) == 0 && len(msg.OneOfs) == 0 && len(msg.Messages) == 0 && len(msg.Enums) == 0 {
+		return nil
+	}
+	return msg
+}
+
+var behaviorNames = map[api.FieldBehavior]string{
+	api.FieldBehaviorUnspecified:              "UNSPECIFIED",
+	api.FieldBehaviorOptional:                 "OPTIONAL",
+	api.FieldBehaviorRequired:                 "REQUIRED",
+	api.FieldBehaviorOutputOnly:               "OUTPUT_ONLY",
+	api.FieldBehaviorInputOnly:                "INPUT_ONLY",
+	api.FieldBehaviorImmutable:                "IMMUTABLE",
+	api.FieldBehaviorUnorderedList:            "UNORDERED_LIST",
+	api.FieldBehaviorUnorderedNonEmptyDefault: "NON_EMPTY_DEFAULT",
+	api.FieldBehaviorIdentifier:               "IDENTIFIER",
+}
+
+func newField(f *api.Field) *Field {
+	field := &Field{
+		ID:            f.ID,
+		Name:          f.Name,
+		JSONName:      f.JSONName,
+		Type:          strings.ToLower(f.Typez.String()),
+		TypeID:        f.TypezID,
+		Optional:      f.Optional,
+		Repeated:      f.Repeated,
+		Map:           f.Map,
+		Deprecated:    f.Deprecated,
+		Recursive:     f.Recursive,
+		AutoPopulated: f.AutoPopulated,
+		Documentation: f.Documentation,
+	}
+	if f.IsOneOf && f.Group != nil {
+		field.OneOf = f.Group.ID
+	}
+	for _, b := range f.Behavior {
+		field.Behavior = append(field.Behavior, behaviorNames[b])
+	}
+	if f.ResourceReference != nil {
+		field.ResourceReference = &ResourceReference{
+			Type:      f.ResourceReference.Type,
+			ChildType: f.ResourceReference.ChildType,
+		}
+	}
+	return field
+}
+
+// newEnum returns the dump of `e`, or nil if neither `e` nor any of its
+// values matches `filter`.
+func newEnum(e *api.Enum, filter []string) *Enum {
+	if matches(e.ID, filter) {
+		filter = nil
+	}
+	enum := &Enum{
+		ID:            e.ID,
+		Name:          e.Name,
+		Package:       e.Package,
+		Deprecated:    e.Deprecated,
+		Documentation: e.Documentation,
+	}
+	if e.Parent != nil {
+		enum.Parent = e.Parent.ID
+	}
+	for _, v := range e.Values {
+		if !matches(v.ID, filter) {
+			continue
+		}
+		enum.Values = append(enum.Values, &EnumValue{
+			ID:            v.ID,
+			Name:          v.Name,
+			Number:        v.Number,
+			Deprecated:    v.Deprecated,
+			Documentation: v.Documentation,
+		})
+	}
+	if len(filter) != 0 && len(enum.Values) == 0 {
+		return nil
+	}
+	return enum
+}
+
+func newResource(r *api.Resource) *Resource {
+	resource := &Resource{
+		Type:     r.Type,
+		Plural:   r.Plural,
+		Singular: r.Singular,
+	}
+	for _, p := range r.Patterns {
+		resource.Patterns = append(resource.Patterns, p.String())
+	}
+	if r.Self != nil {
+		resource.Self = r.Self.ID
+	}
+	return resource
+}
+
+// Marshal returns the dump formatted as `json` or `yaml`.
+func Marshal(d *Model, format string) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		data, err := json.MarshalIndent(d, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case FormatYAML:
+		return yaml.Marshal(d)
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownFormat, format)
+	}
+}
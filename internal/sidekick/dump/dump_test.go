@@ -0,0 +1,300 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dump
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/yaml"
+)
+
+func testModel(t *testing.T) *api.API {
+	t.Helper()
+	secret := api.NewTestMessage("Secret").
+		WithFields(
+			api.NewTestField("name").WithType(api.TypezString).WithBehavior(api.FieldBehaviorIdentifier),
+		).
+		WithResource(api.NewTestResource("test.googleapis.com/Secret").
+			WithPatterns(api.ResourcePattern{
+				*(&api.PathSegment{}).WithLiteral("secrets"),
+				*(&api.PathSegment{}).WithVariable(api.NewPathVariable("secret").WithMatch()),
+			}))
+	request := api.NewTestMessage("GetSecretRequest").
+		WithFields(api.NewTestField("name").WithType(api.TypezString).WithResourceReference("test.googleapis.com/Secret"))
+	get := api.NewTestMethod("GetSecret").
+		WithVerb("GET").
+		WithInput(request).
+		WithOutput(secret).
+		WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1").WithVariableNamed("name"))
+	service := api.NewTestService("Service").WithMethods(get)
+	model := api.NewTestAPI([]*api.Message{secret, request}, []*api.Enum{}, []*api.Service{service})
+	if err := api.CrossReference(model); err != nil {
+		t.Fatal(err)
+	}
+	return model
+}
+
+func TestNew(t *testing.T) {
+	got := New(testModel(t), nil)
+	want := &Model{
+		Name:        "Test",
+		PackageName: "test",
+		Services: []*Service{
+			{
+				ID:      ".test.Service",
+				Name:    "Service",
+				Package: "test",
+				Methods: []*Method{
+					{
+						ID:         ".test.Service.GetSecret",
+						Name:       "GetSecret",
+						Service:    ".test.Service",
+						InputType:  ".test.GetSecretRequest",
+						OutputType: ".test.Secret",
+						Standard:   "get",
+						Bindings:   []*Binding{{Verb: "GET", Path: "v1/{name}"}},
+					},
+				},
+			},
+		},
+		Messages: []*Message{
+			{
+				ID:       ".test.Secret",
+				Name:     "Secret",
+				Package:  "test",
+				Resource: "test.googleapis.com/Secret",
+				Fields: []*Field{
+					{ID: ".test.Secret.name", Name: "name", JSONName: "name", Type: "string", Behavior: []string{"IDENTIFIER"}},
+				},
+			},
+			{
+				ID:      ".test.GetSecretRequest",
+				Name:    "GetSecretRequest",
+				Package: "test",
+				Fields: []*Field{
+					{
+						ID:                ".test.GetSecretRequest.name",
+						Name:              "name",
+						JSONName:          "name",
+						Type:              "string",
+						ResourceReference: &ResourceReference{Type: "test.googleapis.com/Secret"},
+					},
+				},
+			},
+		},
+		Resources: []*Resource{
+			{Type: "test.googleapis.com/Secret", Patterns: []string{"secrets/{secret}"}, Self: ".test.Secret"},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNew_Filter(t *testing.T) {
+	for _, test := range []struct {
+		name         string
+		filter       []string
+		wantServices []string
+		wantMessages []string
+	}{
+		{
+			name:         "message",
+			filter:       []string{".test.Secret"},
+			wantMessages: []string{".test.Secret"},
+		},
+		{
+			name:         "method",
+			filter:       []string{".test.Service.GetSecret"},
+			wantServices: []string{".test.Service"},
+		},
+		{
+			name:         "package",
+			filter:       []string{".test"},
+			wantServices: []string{".test.Service"},
+			wantMessages: []string{".test.Secret", ".test.GetSecretRequest"},
+		},
+		{
+			name:   "no match",
+			filter: []string{".other"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := New(testModel(t), test.filter)
+			var gotServices, gotMessages []string
+			for _, s := range got.Services {
+				gotServices = append(gotServices, s.ID)
+			}
+			for _, m := range got.Messages {
+				gotMessages = append(gotMessages, m.ID)
+			}
+			if diff := cmp.Diff(test.wantServices, gotServices); diff != "" {
+				t.Errorf("services mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.wantMessages, gotMessages); diff != "" {
+				t.Errorf("messages mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNew_FilterNested(t *testing.T) {
+	secret := api.NewTestMessage("Secret").
+		WithFields(
+			api.NewTestField("name").WithType(api.TypezString),
+			api.NewTestField("size").WithType(api.TypezInt32),
+		)
+	labels := &api.Message{
+		Name:    "Labels",
+		ID:      ".test.Secret.Labels",
+		Package: "test",
+		Parent:  secret,
+	}
+	labels.WithFields(api.NewTestField("key").WithType(api.TypezString))
+	state := &api.Enum{
+		Name:    "State",
+		ID:      ".test.Secret.State",
+		Package: "test",
+		Parent:  secret,
+		Values: []*api.EnumValue{
+			{Name: "STATE_UNSPECIFIED", ID: ".test.Secret.State.STATE_UNSPECIFIED", Number: 0},
+			{Name: "ENABLED", ID: ".test.Secret.State.ENABLED", Number: 1},
+		},
+	}
+	secret.Messages = []*api.Message{labels}
+	secret.Enums = []*api.Enum{state}
+	model := api.NewTestAPI([]*api.Message{secret}, []*api.Enum{}, []*api.Service{})
+
+	for _, test := range []struct {
+		name   string
+		filter []string
+		want   []*Message
+	}{
+		{
+			name:   "field",
+			filter: []string{".test.Secret.size"},
+			want: []*Message{
+				{
+					ID:      ".test.Secret",
+					Name:    "Secret",
+					Package: "test",
+					Fields: []*Field{
+						{ID: ".test.Secret.size", Name: "size", JSONName: "size", Type: "int32"},
+					},
+				},
+			},
+		},
+		{
+			name:   "nested message field",
+			filter: []string{".test.Secret.Labels.key"},
+			want: []*Message{
+				{
+					ID:      ".test.Secret",
+					Name:    "Secret",
+					Package: "test",
+					Messages: []*Message{
+						{
+							ID:      ".test.Secret.Labels",
+							Name:    "Labels",
+							Package: "test",
+							Parent:  ".test.Secret",
+							Fields: []*Field{
+								{ID: ".test.Secret.Labels.key", Name: "key", JSONName: "key", Type: "string"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "nested enum value",
+			filter: []string{".test.Secret.State.ENABLED"},
+			want: []*Message{
+				{
+					ID:      ".test.Secret",
+					Name:    "Secret",
+					Package: "test",
+					Enums: []*Enum{
+						{
+							ID:      ".test.Secret.State",
+							Name:    "State",
+							Package: "test",
+							Parent:  ".test.Secret",
+							Values: []*EnumValue{
+								{ID: ".test.Secret.State.ENABLED", Name: "ENABLED", Number: 1},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "no match",
+			filter: []string{".test.Secret.Other"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := New(model, test.filter)
+			if diff := cmp.Diff(test.want, got.Messages); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	want := New(testModel(t), nil)
+	for _, test := range []struct {
+		format    string
+		unmarshal func([]byte) (*Model, error)
+	}{
+		{
+			format: FormatJSON,
+			unmarshal: func(data []byte) (*Model, error) {
+				got := &Model{}
+				err := json.Unmarshal(data, got)
+				return got, err
+			},
+		},
+		{
+			format:    FormatYAML,
+			unmarshal: yaml.Unmarshal[Model],
+		},
+	} {
+		t.Run(test.format, func(t *testing.T) {
+			data, err := Marshal(want, test.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := test.unmarshal(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMarshal_Error(t *testing.T) {
+	if _, err := Marshal(&Model{}, "xml"); !errors.Is(err, errUnknownFormat) {
+		t.Errorf("Marshal() error = %v, want %v", err, errUnknownFormat)
+	}
+}
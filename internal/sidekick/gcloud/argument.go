@@ -66,6 +66,12 @@ type Argument struct {
 	// Spec defines the structure for complex argument types, such as key-value pairs for a map.
 	// Origin: Generated for `map` fields in a proto message.
 	Spec []ArgSpec
+	// Params lists the arguments of an argument group. If set, this argument
+	// is a group for a message field and its ArgName, APIField and Clearable
+	// apply to the message as a whole.
+	// Origin: Generated for the nested message fields of update commands, so that each sub-field
+	// gets its own flag and update mask path.
+	Params []Argument
 	// ResourceMethodParams maps API method parameters to resource attributes,
 	// used for non-standard resource name formats.
 	// Origin: Generated for resource reference arguments to map the parsed name correctly.
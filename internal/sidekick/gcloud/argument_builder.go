@@ -33,6 +33,11 @@ type argumentBuilder struct {
 	service   *api.Service
 	field     *api.Field
 	apiField  string
+	// argPrefix is the name of the enclosing argument group, if any.
+	argPrefix string
+	// messages holds the IDs of the enclosing group messages, to stop the
+	// expansion of recursive messages.
+	messages []string
 }
 
 // newArgumentBuilder constructs a new argumentBuilder.
@@ -54,9 +59,13 @@ func (b *argumentBuilder) build() (*Argument, error) {
 		return nil, nil
 	}
 
+	if b.isGroup() {
+		return b.buildGroup()
+	}
+
 	// TODO(https://github.com/googleapis/librarian/issues/3414): Abstract away casing logic in the model.
 	arg := &Argument{
-		ArgName:   strcase.ToKebab(b.field.Name),
+		ArgName:   b.argName(),
 		APIField:  b.apiField,
 		Required:  b.field.DocumentAsRequired(),
 		Repeated:  b.repeated(),
@@ -91,13 +100,66 @@ func (b *argumentBuilder) build() (*Argument, error) {
 	return arg, nil
 }
 
+func (b *argumentBuilder) argName() string {
+	name := strcase.ToKebab(b.field.Name)
+	if b.argPrefix == "" {
+		return name
+	}
+	return b.argPrefix + "-" + name
+}
+
+// isGroup reports whether the field is a nested message in an update command.
+// These become argument groups with one flag per sub-field, so that gcloud
+// derives an update mask path for each flag rather than replacing the whole
+// message. Well-known types and recursive messages are kept as a single flag.
+func (b *argumentBuilder) isGroup() bool {
+	msg := b.field.MessageType
+	if !provider.IsUpdate(b.method) || msg == nil || b.field.Map || b.field.Repeated || b.field.ResourceReference != nil {
+		return false
+	}
+	if msg.Package == "google.protobuf" || len(msg.Fields) == 0 {
+		return false
+	}
+	return !slices.Contains(b.messages, msg.ID)
+}
+
+// buildGroup creates an argument group for a nested message field. It returns
+// nil if all the sub-fields are ignored.
+func (b *argumentBuilder) buildGroup() (*Argument, error) {
+	group := &Argument{
+		ArgName:   b.argName(),
+		APIField:  b.apiField,
+		Required:  b.field.DocumentAsRequired(),
+		Clearable: true,
+		HelpText:  b.helpText(),
+	}
+	for _, f := range b.field.MessageType.Fields {
+		child := newArgumentBuilder(b.method, b.overrides, b.model, b.service, f, b.apiField+"."+f.JSONName)
+		child.argPrefix = group.ArgName
+		child.messages = append(slices.Clip(b.messages), b.field.MessageType.ID)
+		arg, err := child.build()
+		if err != nil {
+			return nil, err
+		}
+		if arg != nil {
+			group.Params = append(group.Params, *arg)
+		}
+	}
+	if len(group.Params) == 0 {
+		return nil, nil
+	}
+	return group, nil
+}
+
 func (b *argumentBuilder) isIgnored() bool {
-	if b.field.Name == "update_mask" {
+	// Discovery fields use camelCase names, such as pageToken.
+	name := strcase.ToSnake(b.field.Name)
+	if name == "update_mask" {
 		return true
 	}
 	if provider.IsList(b.method) {
-		switch b.field.Name {
-		case "page_size", "page_token", "filter", "order_by":
+		switch name {
+		case "page_size", "page_token", "filter", "order_by", "max_results":
 			return true
 		}
 	}
@@ -191,6 +253,61 @@ func (b *argumentBuilder) buildPrimaryResource(idField *api.Field) Argument {
 	return param
 }
 
+// buildDiscoveryResource creates the main resource argument for a command
+// generated from a Discovery document. Discovery documents have no resource
+// definitions, so the resource is derived from the flat path of the method
+// and its attributes are the path parameters. For insert methods, idField is
+// the name field of the request body, which holds the ID of the new resource.
+func (b *argumentBuilder) buildDiscoveryResource(idField *fieldWithPrefix) Argument {
+	segments := provider.DiscoveryResourceSegments(b.method)
+	if provider.IsCollectionMethod(b.method) && idField == nil {
+		segments = provider.DiscoveryParentSegments(b.method)
+	}
+
+	attributes := newAttributesFromSegments(segments)
+	for i := range attributes {
+		// Discovery path parameters are named after the attribute, for
+		// example `{zone}` rather than `{zonesId}`.
+		attributes[i].ParameterName = attributes[i].AttributeName
+	}
+
+	var helpText string
+	if idField != nil {
+		helpText = idField.field.Documentation
+	} else if n := len(attributes); n > 0 {
+		name := attributes[n-1].AttributeName
+		for _, f := range b.method.InputType.Fields {
+			if f.Name == name {
+				helpText = f.Documentation
+			}
+		}
+	}
+
+	hostParts := strings.Split(b.service.DefaultHost, ".")
+	shortServiceName := hostParts[0]
+	pluralName := provider.GetPluralFromSegments(segments)
+
+	param := Argument{
+		HelpText:          provider.CleanDocumentation(helpText),
+		IsPositional:      !provider.IsList(b.method),
+		IsPrimaryResource: true,
+		Required:          true,
+		ResourceSpec: &ResourceSpec{
+			Name:                  provider.GetSingularFromSegments(segments),
+			PluralName:            pluralName,
+			Collection:            fmt.Sprintf("%s.%s", shortServiceName, pluralName),
+			DisableAutoCompleters: provider.IsList(b.method),
+			Attributes:            attributes,
+		},
+	}
+
+	if idField != nil {
+		param.RequestIDField = idField.prefix
+	}
+
+	return param
+}
+
 // resourceReferenceSpec creates a ResourceSpec for a field that references
 // another resource type (e.g., a `--network` flag).
 func (b *argumentBuilder) resourceReferenceSpec() (*ResourceSpec, error) {
@@ -174,6 +174,140 @@ func TestNewArgument(t *testing.T) {
 	}
 }
 
+func TestNewArgument_Groups(t *testing.T) {
+	timestamp := api.NewTestMessage("Timestamp").WithPackage("google.protobuf").WithFields(
+		api.NewTestField("seconds").WithType(api.TypezInt64),
+	)
+	config := api.NewTestMessage("Config").WithFields(
+		api.NewTestField("mount_point").WithType(api.TypezString),
+		api.NewTestField("tags").WithType(api.TypezString).WithRepeated(),
+		api.NewTestField("uid").WithType(api.TypezString).WithBehavior(api.FieldBehaviorOutputOnly),
+		api.NewTestField("create_time").WithMessageType(timestamp),
+	)
+	node := api.NewTestMessage("Node")
+	node.WithFields(
+		api.NewTestField("value").WithType(api.TypezString),
+		api.NewTestField("child").WithMessageType(node),
+	)
+	outputOnly := api.NewTestMessage("Status").WithFields(
+		api.NewTestField("state").WithType(api.TypezString).WithBehavior(api.FieldBehaviorOutputOnly),
+	)
+	update := api.NewTestMethod("UpdateInstance").WithVerb("PATCH")
+	create := api.NewTestMethod("CreateInstance").WithVerb("POST")
+
+	for _, test := range []struct {
+		name     string
+		field    *api.Field
+		apiField string
+		method   *api.Method
+		want     *Argument
+	}{
+		{
+			name:     "nested message in update",
+			field:    api.NewTestField("config").WithMessageType(config),
+			apiField: "instance.config",
+			method:   update,
+			want: &Argument{
+				ArgName:   "config",
+				APIField:  "instance.config",
+				Clearable: true,
+				HelpText:  "Value for the `config` field.",
+				Params: []Argument{
+					{
+						ArgName:  "config-mount-point",
+						APIField: "instance.config.mountPoint",
+						Type:     "str",
+						HelpText: "Value for the `mount-point` field.",
+					},
+					{
+						ArgName:   "config-tags",
+						APIField:  "instance.config.tags",
+						Type:      "str",
+						Repeated:  true,
+						Clearable: true,
+						HelpText:  "Value for the `tags` field.",
+					},
+					{
+						ArgName:  "config-create-time",
+						APIField: "instance.config.createTime",
+						Type:     "arg_object",
+						HelpText: "Value for the `create-time` field.",
+					},
+				},
+			},
+		},
+		{
+			name:     "recursive message",
+			field:    api.NewTestField("root").WithMessageType(node),
+			apiField: "root",
+			method:   update,
+			want: &Argument{
+				ArgName:   "root",
+				APIField:  "root",
+				Clearable: true,
+				HelpText:  "Value for the `root` field.",
+				Params: []Argument{
+					{
+						ArgName:  "root-value",
+						APIField: "root.value",
+						Type:     "str",
+						HelpText: "Value for the `value` field.",
+					},
+					{
+						ArgName:  "root-child",
+						APIField: "root.child",
+						Type:     "arg_object",
+						HelpText: "Value for the `child` field.",
+					},
+				},
+			},
+		},
+		{
+			name:     "nested message in create",
+			field:    api.NewTestField("config").WithMessageType(config),
+			apiField: "config",
+			method:   create,
+			want: &Argument{
+				ArgName:  "config",
+				APIField: "config",
+				Type:     "arg_object",
+				HelpText: "Value for the `config` field.",
+			},
+		},
+		{
+			name:     "labels",
+			field:    api.NewTestField("labels").WithType(api.TypezMessage).WithMap(),
+			apiField: "instance.labels",
+			method:   update,
+			want: &Argument{
+				ArgName:   "labels",
+				APIField:  "instance.labels",
+				Repeated:  true,
+				Clearable: true,
+				HelpText:  "Value for the `labels` field.",
+				Spec:      []ArgSpec{{APIField: "key"}, {APIField: "value"}},
+			},
+		},
+		{
+			name:     "only output fields",
+			field:    api.NewTestField("status").WithMessageType(outputOnly),
+			apiField: "instance.status",
+			method:   update,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := newArgumentBuilder(test.method, &provider.Config{}, nil, nil, test.field, test.apiField).build()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestIsIgnored(t *testing.T) {
 	for _, test := range []struct {
 		name   string
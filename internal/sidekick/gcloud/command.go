@@ -96,7 +96,9 @@ type HelpText struct {
 	Examples string
 }
 
-// Async defines the details for handling long-running operations.
+// Async defines the details for handling long-running operations. gcloud
+// adds an --async flag to every command that has one, and otherwise polls the
+// operation until it completes.
 type Async struct {
 	// Collection is the API collection for the long-running operation resource.
 	// Origin: Hardcoded to the standard operations collection for the service.
@@ -104,4 +106,9 @@ type Async struct {
 
 	// ExtractResourceResult indicates whether to extract the resource result from the LRO.
 	ExtractResourceResult bool
+
+	// OperationGetMethodParams maps the parameters of the request that polls
+	// the operation to fields of the original request.
+	// Origin: The polling path parameters of Discovery methods.
+	OperationGetMethodParams map[string]string
 }
@@ -54,7 +54,9 @@ func (b *commandBuilder) build() (*Command, error) {
 		return nil, err
 	}
 
-	useUpdateMask := b.updateMask()
+	// Without '*' update masks, gcloud derives the mask from the api_field of
+	// each flag that is set.
+	useUpdateMask := b.updateMask() && provider.SupportsStarUpdateMasks(b.overrides)
 
 	return &Command{
 		Name:                 b.name(),
@@ -98,6 +100,9 @@ func (b *commandBuilder) outputFormat() string {
 
 // async creates the `Async` part of the command definition for long-running operations.
 func (b *commandBuilder) async() *Async {
+	if b.method.DiscoveryLro != nil {
+		return b.discoveryAsync()
+	}
 	if b.method.OperationInfo == nil {
 		return nil
 	}
@@ -106,6 +111,11 @@ func (b *commandBuilder) async() *Async {
 		Collection: b.collectionPath(true),
 	}
 
+	// The user asked to see the operation result instead of the resource.
+	if provider.DisplayOperationResult(b.overrides, strings.TrimPrefix(b.method.ID, ".")) {
+		return async
+	}
+
 	// Extract the resource result if the LRO response type matches the
 	// method's resource type.
 	resource := provider.GetResourceForMethod(b.method, b.model)
@@ -130,6 +140,36 @@ func (b *commandBuilder) async() *Async {
 	return async
 }
 
+// discoveryAsync creates the `Async` part of the command definition for a
+// Discovery method that returns an operation. The operations collection is
+// the service of the polling method the Discovery parser matched to the
+// method, and the parameters of the polling request are copied from the
+// original request.
+func (b *commandBuilder) discoveryAsync() *Async {
+	var poller *api.Method
+	for _, m := range b.service.Methods {
+		if provider.IsDiscoveryPoller(m) && m.SourceService != nil {
+			poller = m
+			break
+		}
+	}
+	if poller == nil {
+		return nil
+	}
+	params := map[string]string{}
+	for _, p := range b.method.DiscoveryLro.PollingPathParameters {
+		params[p] = p
+	}
+	return &Async{
+		Collection:               []string{fmt.Sprintf("%s.%s", b.shortServiceName(), poller.SourceService.Name)},
+		OperationGetMethodParams: params,
+	}
+}
+
+func (b *commandBuilder) shortServiceName() string {
+	return strings.Split(b.service.DefaultHost, ".")[0]
+}
+
 func (b *commandBuilder) hidden() bool {
 	if b.overrides != nil && len(b.overrides.APIs) > 0 {
 		return b.overrides.APIs[0].RootIsHidden
@@ -149,6 +189,11 @@ func (b *commandBuilder) helpText() HelpText {
 
 // requestMethod determines the API method name for the command execution.
 func (b *commandBuilder) requestMethod() string {
+	// Discovery methods are called by their own name, such as insert or
+	// aggregatedList, rather than the name implied by the command.
+	if provider.IsDiscoveryMethod(b.method) {
+		return b.method.Name
+	}
 	// For custom methods (AIP-136), the `method` field in the request configuration
 	// MUST match the custom verb defined in the HTTP binding (e.g., ":exportData" -> "exportData").
 	if b.method.PathInfo != nil && len(b.method.PathInfo.Bindings) > 0 && b.method.PathInfo.Bindings[0].PathTemplate.Verb != nil {
@@ -186,6 +231,11 @@ func (b *commandBuilder) newArguments() ([]Argument, error) {
 		return nil, err
 	}
 
+	if provider.IsDiscoveryMethod(b.method) {
+		arg := newArgumentBuilder(b.method, b.overrides, b.model, b.service, nil, "").buildDiscoveryResource(cf.resourceIdField)
+		args = append(args, arg)
+	}
+
 	if cf.primaryField != nil {
 		var idField *api.Field
 		if cf.resourceIdField != nil {
@@ -219,16 +269,32 @@ func (b *commandBuilder) categorizeFields() (classifiedFields, error) {
 		bodyFieldPath = b.method.PathInfo.BodyFieldPath
 	}
 
+	// The path parameters of Discovery methods are the attributes of the
+	// resource argument.
+	var pathParameters map[string]bool
+	if provider.IsDiscoveryMethod(b.method) {
+		pathParameters = provider.DiscoveryPathParameters(b.method)
+	}
+
 	var collected []fieldWithPrefix
 	for _, field := range b.method.InputType.Fields {
+		if pathParameters[field.Name] {
+			continue
+		}
 		isExpandableMessage := field.MessageType != nil && !field.Map
 		isBodyField := bodyFieldPath != "" && (bodyFieldPath == field.Name || bodyFieldPath == "*")
 
 		if isExpandableMessage && isBodyField {
+			bodyName := field.JSONName
+			if provider.IsDiscoveryMethod(b.method) {
+				// gcloud names the body field of a Discovery request after
+				// its type, not the synthetic field name.
+				bodyName = strcase.ToLowerCamel(field.MessageType.Name)
+			}
 			for _, f := range field.MessageType.Fields {
 				collected = append(collected, fieldWithPrefix{
 					field:  f,
-					prefix: fmt.Sprintf("%s.%s", field.JSONName, f.JSONName),
+					prefix: fmt.Sprintf("%s.%s", bodyName, f.JSONName),
 				})
 			}
 			continue
@@ -266,9 +332,15 @@ func (b *commandBuilder) categorizeFields() (classifiedFields, error) {
 // It follows AIP-127 and AIP-132 by extracting the collection structure directly from
 // the method's HTTP annotation (PathInfo).
 func (b *commandBuilder) collectionPath(isAsync bool) []string {
+	// Discovery collections are named after the collection in the flat
+	// path, for example compute.instances.
+	if !isAsync && provider.IsDiscoveryMethod(b.method) {
+		plural := provider.GetPluralResourceNameForMethod(b.method, b.model)
+		return []string{fmt.Sprintf("%s.%s", b.shortServiceName(), plural)}
+	}
+
 	var collections []string
-	hostParts := strings.Split(b.service.DefaultHost, ".")
-	shortServiceName := hostParts[0]
+	shortServiceName := b.shortServiceName()
 
 	// Iterate over all bindings (primary + additional) to support multitype resources (AIP-127).
 	for _, binding := range b.method.PathInfo.Bindings {
@@ -250,6 +250,37 @@ func TestAsync(t *testing.T) {
 	}
 }
 
+func TestAsync_DisplayOperationResult(t *testing.T) {
+	service := api.NewTestService("TestService").WithPackage("google.cloud.test.v1")
+	service.DefaultHost = "test.googleapis.com"
+	model := api.NewTestAPI([]*api.Message{}, nil, []*api.Service{service})
+	m := api.NewTestMethod("CreateThing").WithVerb("POST").WithPathTemplate(
+		(&api.PathTemplate{}).WithLiteral("v1").WithVariable(api.NewPathVariable("parent").WithLiteral("projects").WithMatch()).WithLiteral("things"),
+	).WithInput(
+		api.NewTestMessage("CreateRequest").WithFields(
+			api.NewTestField("thing").WithType(api.TypezMessage).WithMessageType(
+				api.NewTestMessage("Thing").WithResource(api.NewTestResource("test.googleapis.com/Thing")),
+			),
+		),
+	)
+	m.ID = ".google.cloud.test.v1.TestService.CreateThing"
+	m.OperationInfo = &api.OperationInfo{ResponseTypeID: "Thing"}
+	m.Service = service
+	overrides := &provider.Config{
+		APIs: []provider.API{{
+			CommandOperationsConfig: []*provider.CommandOperationsConfig{
+				{Selector: "google.cloud.test.v1.TestService.*", DisplayOperationResult: true},
+			},
+		}},
+	}
+
+	got := newCommandBuilder(m, overrides, model, service).async()
+	want := &Async{Collection: []string{"test.projects.operations"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("async() mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestCollectionPath(t *testing.T) {
 	service := &api.Service{
 		DefaultHost: "test.googleapis.com",
@@ -423,6 +454,35 @@ func TestNewCommand(t *testing.T) {
 				DisableAutoFieldMask: true,
 			},
 		},
+		{
+			name: "Update Command without star update masks",
+			method: func() *api.Method {
+				m := api.NewTestMethod("UpdateThing").
+					WithVerb("PATCH").
+					WithInput(api.NewTestMessage("UpdateThingRequest").WithFields(
+						api.NewTestField("thing").WithType(api.TypezMessage).WithMessageType(
+							api.NewTestMessage("Thing").WithFields(
+								api.NewTestField("name").WithType(api.TypezString),
+							).WithResource(api.NewTestResource("test.googleapis.com/Thing")),
+						),
+						api.NewTestField("update_mask").WithType(api.TypezMessage),
+					)).
+					WithPathTemplate((&api.PathTemplate{}).
+						WithLiteral("v1").
+						WithVariable(api.NewPathVariable("thing", "name").WithLiteral("projects").WithMatch().WithLiteral("things").WithMatch()))
+				m.ID = "google.cloud.test.v1.Service.UpdateThing"
+				return m
+			}(),
+			overrides: &provider.Config{
+				APIs: []provider.API{
+					{SupportsStarUpdateMasks: boolPtr(false)},
+				},
+			},
+			want: &Command{
+				Name:             "update",
+				ReadModifyUpdate: true,
+			},
+		},
 		{
 			name: "LRO Command",
 			method: func() *api.Method {
@@ -825,3 +885,189 @@ func TestCommandBuilderNewArgumentsResourceError(t *testing.T) {
 		t.Errorf("newArguments() error = %v, want error containing %q", err, "resource definition not found")
 	}
 }
+
+func TestNewCommand_Discovery(t *testing.T) {
+	project := Attribute{
+		AttributeName: "project",
+		ParameterName: "project",
+		Help:          "The project id of the {resource} resource.",
+		Property:      "core/project",
+	}
+	zone := Attribute{
+		AttributeName: "zone",
+		ParameterName: "zone",
+		Help:          "The zone id of the {resource} resource.",
+	}
+	instance := Attribute{
+		AttributeName: "instance",
+		ParameterName: "instance",
+		Help:          "The instance id of the {resource} resource.",
+	}
+	for _, test := range []struct {
+		name string
+		want *Command
+	}{
+		{
+			name: "insert",
+			want: &Command{
+				Name:       "create",
+				Hidden:     true,
+				Collection: []string{"compute.instances"},
+				Method:     "insert",
+				Arguments: []Argument{
+					{
+						HelpText:          "Name of the resource.",
+						IsPositional:      true,
+						IsPrimaryResource: true,
+						Required:          true,
+						RequestIDField:    "instance.name",
+						ResourceSpec: &ResourceSpec{
+							Name:       "instance",
+							PluralName: "instances",
+							Collection: "compute.instances",
+							Attributes: []Attribute{project, zone, instance},
+						},
+					},
+					{ArgName: "request-id", APIField: "requestId", Type: "str", HelpText: "An optional request ID."},
+					{ArgName: "description", APIField: "instance.description", Type: "str", HelpText: "An optional description."},
+				},
+				Async: &Async{
+					Collection:               []string{"compute.zoneOperations"},
+					OperationGetMethodParams: map[string]string{"project": "project", "zone": "zone"},
+				},
+			},
+		},
+		{
+			name: "list",
+			want: &Command{
+				Name:            "list",
+				Hidden:          true,
+				Collection:      []string{"compute.instances"},
+				Method:          "list",
+				ResponseIDField: "name",
+				Arguments: []Argument{
+					{
+						HelpText:          "The name of the zone.",
+						IsPrimaryResource: true,
+						Required:          true,
+						ResourceSpec: &ResourceSpec{
+							Name:                  "zone",
+							PluralName:            "zones",
+							Collection:            "compute.zones",
+							DisableAutoCompleters: true,
+							Attributes:            []Attribute{project, zone},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "get",
+			want: &Command{
+				Name:       "describe",
+				Hidden:     true,
+				Collection: []string{"compute.instances"},
+				Method:     "get",
+				Arguments: []Argument{
+					{
+						HelpText:          "The name of the instance.",
+						IsPositional:      true,
+						IsPrimaryResource: true,
+						Required:          true,
+						ResourceSpec: &ResourceSpec{
+							Name:       "instance",
+							PluralName: "instances",
+							Collection: "compute.instances",
+							Attributes: []Attribute{project, zone, instance},
+						},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			model, service := discoveryModel()
+			var method *api.Method
+			for _, m := range service.Methods {
+				if m.Name == test.name {
+					method = m
+				}
+			}
+
+			got, err := newCommandBuilder(method, &provider.Config{}, model, service).build()
+			if err != nil {
+				t.Fatal(err)
+			}
+			opts := cmpopts.IgnoreFields(Command{}, "HelpText", "OutputFormat")
+			if diff := cmp.Diff(test.want, got, opts); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// discoveryModel returns a model shaped like the output of the Discovery
+// parser: an instances service whose methods have synthetic requests, and a
+// copy of the zone operations poller added for the LRO in insert.
+func discoveryModel() (*api.API, *api.Service) {
+	collection := func() *api.PathTemplate {
+		return (&api.PathTemplate{}).
+			WithLiteral("compute").WithLiteral("v1").
+			WithLiteral("projects").WithVariableNamed("project").
+			WithLiteral("zones").WithVariableNamed("zone")
+	}
+	request := func(name string, fields ...*api.Field) *api.Message {
+		m := api.NewTestMessage(name).WithFields(append([]*api.Field{
+			api.NewTestField("project").WithType(api.TypezString),
+			api.NewTestField("zone").WithType(api.TypezString),
+		}, fields...)...)
+		m.Fields[1].Documentation = "The name of the zone."
+		m.SyntheticRequest = true
+		return m
+	}
+
+	insert := api.NewTestMethod("insert").
+		WithVerb("POST").
+		WithPathTemplate(collection().WithLiteral("instances")).
+		WithInput(request("insertRequest",
+			api.NewTestField("requestId").WithType(api.TypezString),
+			api.NewTestField("body").WithType(api.TypezMessage).WithMessageType(
+				api.NewTestMessage("Instance").WithFields(
+					api.NewTestField("name").WithType(api.TypezString),
+					api.NewTestField("description").WithType(api.TypezString),
+				),
+			),
+		))
+	insert.PathInfo.BodyFieldPath = "body"
+	insert.InputType.Fields[2].Documentation = "An optional request ID."
+	insert.InputType.Fields[3].MessageType.Fields[0].Documentation = "Name of the resource."
+	insert.InputType.Fields[3].MessageType.Fields[1].Documentation = "An optional description."
+	insert.DiscoveryLro = &api.DiscoveryLro{PollingPathParameters: []string{"project", "zone"}}
+
+	list := api.NewTestMethod("list").
+		WithVerb("GET").
+		WithPathTemplate(collection().WithLiteral("instances")).
+		WithInput(request("listRequest",
+			api.NewTestField("maxResults").WithType(api.TypezInt32),
+			api.NewTestField("pageToken").WithType(api.TypezString),
+		))
+
+	get := api.NewTestMethod("get").
+		WithVerb("GET").
+		WithPathTemplate(collection().WithLiteral("instances").WithVariableNamed("instance")).
+		WithInput(request("getRequest", api.NewTestField("instance").WithType(api.TypezString)))
+	get.InputType.Fields[2].Documentation = "The name of the instance."
+
+	operations := api.NewTestService("zoneOperations").WithPackage("compute")
+	getOperation := api.NewTestMethod("getOperation").
+		WithVerb("GET").
+		WithPathTemplate(collection().WithLiteral("operations").WithVariableNamed("operation")).
+		WithInput(request("getOperationRequest", api.NewTestField("operation").WithType(api.TypezString)))
+	getOperation.SourceService = operations
+	getOperation.SourceServiceID = operations.ID
+
+	service := api.NewTestService("instances").WithPackage("compute").WithMethods(insert, list, get, getOperation)
+	service.DefaultHost = "compute.googleapis.com"
+	model := api.NewTestAPI(nil, nil, []*api.Service{service, operations})
+	return model, service
+}
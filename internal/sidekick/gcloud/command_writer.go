@@ -143,8 +143,9 @@ func mapCommandToYAML(c *Command, track string) *declarative.Command {
 	}
 	if c.Async != nil {
 		y.Async = &declarative.Async{
-			Collection:            c.Async.Collection,
-			ExtractResourceResult: c.Async.ExtractResourceResult,
+			Collection:               c.Async.Collection,
+			ExtractResourceResult:    c.Async.ExtractResourceResult,
+			OperationGetMethodParams: c.Async.OperationGetMethodParams,
 		}
 	}
 	if c.ResponseIDField != "" {
@@ -169,6 +170,19 @@ func mapCommandToYAML(c *Command, track string) *declarative.Command {
 func mapArgumentsToYAML(args []Argument) []declarative.Argument {
 	var ya []declarative.Argument
 	for _, a := range args {
+		if len(a.Params) != 0 {
+			ya = append(ya, declarative.Argument{
+				Group: &declarative.ArgumentGroup{
+					APIField:  a.APIField,
+					ArgName:   a.ArgName,
+					Clearable: a.Clearable,
+					Required:  a.Required,
+					HelpText:  a.HelpText,
+					Params:    mapArgumentsToYAML(a.Params),
+				},
+			})
+			continue
+		}
 		t := a.Type
 		if t == "str" {
 			t = ""
@@ -259,3 +259,43 @@ func TestMapCommandToYAML(t *testing.T) {
 		t.Errorf("mismatch (-want +got):\n%s", diff)
 	}
 }
+
+func TestMapArgumentsToYAML_Group(t *testing.T) {
+	args := []Argument{
+		{
+			ArgName:   "config",
+			APIField:  "instance.config",
+			Clearable: true,
+			HelpText:  "Config.",
+			Params: []Argument{
+				{
+					ArgName:  "config-mount-point",
+					APIField: "instance.config.mountPoint",
+					Type:     "str",
+					HelpText: "Mount point.",
+				},
+			},
+		},
+	}
+	want := []declarative.Argument{
+		{
+			Group: &declarative.ArgumentGroup{
+				ArgName:   "config",
+				APIField:  "instance.config",
+				Clearable: true,
+				HelpText:  "Config.",
+				Params: []declarative.Argument{
+					{
+						ArgName:  "config-mount-point",
+						APIField: "instance.config.mountPoint",
+						HelpText: "Mount point.",
+					},
+				},
+			},
+		},
+	}
+	got := mapArgumentsToYAML(args)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -78,6 +78,8 @@ package declarative
 
 //go:generate go run -tags configdocgen ../../../../cmd/config_doc_generate.go -input . -output ../../../../doc/gcloud/gcloud-declarative-schema.md -root Command -root-title Command -title "gcloud Declarative YAML"
 
+import "gopkg.in/yaml.v3"
+
 // Command represents a single gcloud declarative YAML command definition.
 type Command struct {
 	// ReleaseTracks lists the gcloud release tracks the command is registered
@@ -184,6 +186,17 @@ func (d Default) MarshalYAML() (any, error) {
 	return *d.Value, nil
 }
 
+// UnmarshalYAML implements yaml.Unmarshaler so that any scalar, sequence or
+// mapping is accepted as the default value.
+func (d *Default) UnmarshalYAML(node *yaml.Node) error {
+	var v any
+	if err := node.Decode(&v); err != nil {
+		return err
+	}
+	d.Value = &v
+	return nil
+}
+
 // Arguments contains the argument definitions for a command.
 type Arguments struct {
 	// Params is the ordered list of arguments the command accepts. Each entry
@@ -193,6 +206,10 @@ type Arguments struct {
 
 // Argument describes a single command argument.
 type Argument struct {
+	// Group makes this entry an argument group rather than a single
+	// argument. When set, no other field of the Argument is emitted.
+	Group *ArgumentGroup `yaml:"group,omitempty"`
+
 	// ArgName is the name of the argument as it appears to the user, such as
 	// instance-id. For flags gcloud prepends --.
 	ArgName string `yaml:"arg_name,omitempty"`
@@ -257,6 +274,46 @@ type Argument struct {
 	ResourceMethodParams map[string]string `yaml:"resource_method_params,omitempty"`
 }
 
+// MarshalYAML implements yaml.Marshaler so that argument groups are emitted
+// as a single group key.
+func (a Argument) MarshalYAML() (any, error) {
+	if a.Group != nil {
+		return struct {
+			Group *ArgumentGroup `yaml:"group"`
+		}{a.Group}, nil
+	}
+	type plain Argument
+	return plain(a), nil
+}
+
+// ArgumentGroup describes a group of arguments that together set a message
+// field, such as the flags for each sub-field of a nested message.
+type ArgumentGroup struct {
+	// Mutex allows at most one of the arguments in the group to be set.
+	Mutex bool `yaml:"mutex,omitempty"`
+
+	// APIField is the dot-separated path into the API request message of the
+	// message field the group sets, for example instance.config.
+	APIField string `yaml:"api_field,omitempty"`
+
+	// ArgName is the name of the group. On update commands with Clearable
+	// set, gcloud generates a --clear-<arg_name> flag for it.
+	ArgName string `yaml:"arg_name,omitempty"`
+
+	// Clearable causes gcloud to generate a flag that clears the whole
+	// message on update commands.
+	Clearable bool `yaml:"clearable,omitempty"`
+
+	// Required makes at least one argument in the group mandatory.
+	Required bool `yaml:"required"`
+
+	// HelpText is the help text shown for the group in gcloud help.
+	HelpText string `yaml:"help_text,omitempty"`
+
+	// Params lists the arguments in the group.
+	Params []Argument `yaml:"params,omitempty"`
+}
+
 // ArgSpec describes an entry in an argument's spec list.
 type ArgSpec struct {
 	// APIField is the sub-field name within the structured argument value,
@@ -347,4 +404,9 @@ type Async struct {
 	// response field when the operation completes. Set when the LRO response
 	// type matches the resource being created or updated.
 	ExtractResourceResult bool `yaml:"extract_resource_result"`
+
+	// OperationGetMethodParams maps the parameters of the operation get
+	// request to fields of the original request, for example
+	// {zone: zone}. Used by Discovery based APIs.
+	OperationGetMethodParams map[string]string `yaml:"operation_get_method_params,omitempty"`
 }
@@ -16,17 +16,36 @@
 package gcloud
 
 import (
+	"path/filepath"
+
 	"github.com/googleapis/librarian/internal/sidekick/api"
 	"github.com/googleapis/librarian/internal/sidekick/gcloud/provider"
 )
 
+// testsDir is the directory, relative to the output, of the generated unit
+// tests. It mirrors the layout of the gcloud tests.
+var testsDir = filepath.Join("tests", "unit", "surface")
+
 // Generate builds a gcloud command tree from the parsed API model and
 // overrides and writes the resulting command groups into output under
-// baseModule.
+// baseModule. If the overrides enable it, a unit test module for each command
+// is written under tests/unit/surface in output.
 func Generate(model *api.API, overrides *provider.Config, output, baseModule string) error {
-	tree, err := newSurfaceBuilder(model, overrides).build()
+	tree, err := BuildSurface(model, overrides)
 	if err != nil {
 		return err
 	}
-	return writeSurface(output, baseModule, tree)
+	if err := writeSurface(output, baseModule, tree); err != nil {
+		return err
+	}
+	if overrides == nil || !overrides.GenerateTests {
+		return nil
+	}
+	return writeSurfaceTests(filepath.Join(output, testsDir), tree)
+}
+
+// BuildSurface builds the gcloud command tree for the parsed API model and
+// overrides without writing any files.
+func BuildSurface(model *api.API, overrides *provider.Config) (*CommandGroupsByTrack, error) {
+	return newSurfaceBuilder(model, overrides).build()
 }
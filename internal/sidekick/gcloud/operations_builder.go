@@ -0,0 +1,221 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloud
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/gcloud/provider"
+)
+
+// operationsBuilder builds the commands of the operations group from the
+// methods of the google.longrunning.Operations mixin. Unlike the methods of
+// the service itself, the mixin has no resource definition for operations,
+// so the resource is derived from the HTTP path of each method.
+type operationsBuilder struct {
+	*commandBuilder
+}
+
+func newOperationsBuilder(method *api.Method, overrides *provider.Config, model *api.API, service *api.Service) *operationsBuilder {
+	return &operationsBuilder{
+		commandBuilder: newCommandBuilder(method, overrides, model, service),
+	}
+}
+
+// build returns the commands for the mixin method. GetOperation produces
+// both describe and wait, since wait polls the same method. Methods without
+// a gcloud command, such as WaitOperation, produce none.
+func (b *operationsBuilder) build() []*Command {
+	switch b.method.Name {
+	case "GetOperation":
+		wait := b.command("wait", "The name of the operation resource to wait on.")
+		wait.Async = &Async{Collection: wait.Collection}
+		return []*Command{b.command("describe", ""), wait}
+	case "ListOperations":
+		return []*Command{b.command("list", "")}
+	case "DeleteOperation":
+		return []*Command{b.command("delete", "")}
+	case "CancelOperation":
+		return []*Command{b.command("cancel", "")}
+	default:
+		return nil
+	}
+}
+
+// command builds the command with the given name. If helpText is empty, the
+// documentation of the request's name field is used for the resource
+// argument.
+func (b *operationsBuilder) command(name, helpText string) *Command {
+	segments := b.operationSegments()
+	collection := b.collection(segments)
+
+	if helpText == "" {
+		helpText = b.nameFieldDocumentation()
+	}
+	arg := Argument{
+		HelpText:     helpText,
+		IsPositional: true,
+		Required:     true,
+		ResourceSpec: &ResourceSpec{
+			Name:       provider.GetSingularFromSegments(segments),
+			PluralName: provider.GetPluralFromSegments(segments),
+			Collection: collection,
+			Attributes: newAttributesFromSegments(segments),
+		},
+	}
+
+	cmd := &Command{
+		Name:       name,
+		Hidden:     b.hidden(),
+		HelpText:   b.operationsHelpText(name),
+		APIVersion: provider.APIVersion(b.overrides),
+		Collection: []string{collection},
+		Method:     b.requestMethod(),
+	}
+	if name == "list" {
+		// List commands take the parent of the operations as a flag.
+		parent := provider.GetParentFromSegments(segments)
+		arg.IsPositional = false
+		arg.ResourceSpec = &ResourceSpec{
+			Name:                  provider.GetSingularFromSegments(parent),
+			PluralName:            provider.GetPluralFromSegments(parent),
+			Collection:            b.collection(parent),
+			DisableAutoCompleters: true,
+			Attributes:            newAttributesFromSegments(parent),
+		}
+		cmd.ResponseIDField = "name"
+	}
+	cmd.Arguments = []Argument{arg}
+	return cmd
+}
+
+// operationSegments returns the resource pattern of a single operation,
+// such as projects/{project}/locations/{location}/operations/{operation},
+// derived from the HTTP path of the method. The path of ListOperations ends
+// with the operations collection, which is completed with an operation
+// variable.
+func (b *operationsBuilder) operationSegments() []api.PathSegment {
+	binding := provider.PrimaryBinding(b.method)
+	if binding == nil || binding.PathTemplate == nil {
+		return nil
+	}
+	var literals []string
+	for i, seg := range binding.PathTemplate.Segments {
+		switch {
+		case seg.Literal != nil:
+			// Skip the API version prefix.
+			if i == 0 && isVersion(*seg.Literal) {
+				continue
+			}
+			literals = append(literals, *seg.Literal)
+		case seg.Variable != nil:
+			for _, s := range seg.Variable.Segments {
+				if s != "*" && s != "**" {
+					literals = append(literals, s)
+				}
+			}
+		}
+	}
+
+	var segments []api.PathSegment
+	for _, lit := range literals {
+		segments = append(segments,
+			api.PathSegment{Literal: &lit},
+			api.PathSegment{Variable: api.NewPathVariable(b.singular(lit))},
+		)
+	}
+	return segments
+}
+
+// collection returns the gcloud collection for a resource pattern, for
+// example parallelstore.projects.locations.operations.
+func (b *operationsBuilder) collection(segments []api.PathSegment) string {
+	shortServiceName := strings.Split(b.service.DefaultHost, ".")[0]
+	return fmt.Sprintf("%s.%s", shortServiceName, provider.GetCollectionPathFromSegments(segments))
+}
+
+func (b *operationsBuilder) nameFieldDocumentation() string {
+	if b.method.InputType == nil {
+		return ""
+	}
+	for _, f := range b.method.InputType.Fields {
+		if f.Name == "name" {
+			return provider.CleanDocumentation(f.Documentation)
+		}
+	}
+	return ""
+}
+
+// operationsHelpText returns the help text for the command from the gcloud
+// config, or a generic help text for the operations command name.
+func (b *operationsBuilder) operationsHelpText(name string) HelpText {
+	if rule := provider.FindHelpTextRule(b.overrides, strings.TrimPrefix(b.method.ID, ".")); rule != nil && name != "wait" {
+		return b.helpText()
+	}
+	if name == "list" {
+		return HelpText{
+			Brief:       "List operations",
+			Description: "List operations",
+			Examples:    "To list all operations, run:\n\n    $ {command}",
+		}
+	}
+	return HelpText{
+		Brief:       fmt.Sprintf("%s operations", toTitleCase(name)),
+		Description: fmt.Sprintf("%s an operation", toTitleCase(name)),
+		Examples:    fmt.Sprintf("To %s the operation, run:\n\n    $ {command}", name),
+	}
+}
+
+// isVersion reports whether lit is an API version such as v1 or v2beta.
+func isVersion(lit string) bool {
+	return len(lit) > 1 && lit[0] == 'v' && lit[1] >= '0' && lit[1] <= '9'
+}
+
+// singular returns the singular form of a collection name in a path, such as
+// location for locations.
+//
+// Per AIP-122, the variable following the collection in a resource pattern
+// names a single resource, so the resources of the model are searched first.
+// Collections without a resource, such as operations, fall back to the
+// English plural rules.
+func (b *operationsBuilder) singular(plural string) string {
+	resources := slices.Clone(b.model.ResourceDefinitions)
+	for _, m := range b.model.Messages {
+		if m.Resource != nil {
+			resources = append(resources, m.Resource)
+		}
+	}
+	for _, r := range resources {
+		for _, pattern := range r.Patterns {
+			for i := 0; i+1 < len(pattern); i++ {
+				if pattern[i].Literal != nil && *pattern[i].Literal == plural && pattern[i+1].Variable != nil {
+					return provider.GetSingularFromSegments(pattern[:i+2])
+				}
+			}
+		}
+	}
+	switch {
+	case strings.HasSuffix(plural, "ies"):
+		return strings.TrimSuffix(plural, "ies") + "y"
+	case strings.HasSuffix(plural, "sses"), strings.HasSuffix(plural, "xes"),
+		strings.HasSuffix(plural, "ches"), strings.HasSuffix(plural, "shes"):
+		return strings.TrimSuffix(plural, "es")
+	default:
+		return strings.TrimSuffix(plural, "s")
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloud
+
+import (
+	"flag"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/gcloud/provider"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+func TestOperationsGolden(t *testing.T) {
+	root, err := BuildSurface(operationsModel(), &provider.Config{
+		APIs: []provider.API{{APIVersion: "v1"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, group := range []string{"resources", "operations"} {
+		commands := root.GA.Groups[group].Commands
+		for _, name := range slices.Sorted(maps.Keys(commands)) {
+			t.Run(group+"/"+name, func(t *testing.T) {
+				got, err := marshalCommand(commands[name], "GA")
+				if err != nil {
+					t.Fatal(err)
+				}
+				golden := filepath.Join("testdata", "operations", group, name+".yaml")
+				if *updateGolden {
+					if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(golden, got, 0644); err != nil {
+						t.Fatal(err)
+					}
+				}
+				want, err := os.ReadFile(golden)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(string(want), string(got)); diff != "" {
+					t.Errorf("mismatch (-want +got), run with -update to refresh the golden files:\n%s", diff)
+				}
+			})
+		}
+	}
+}
+
+func TestOperationsBuilder_Commands(t *testing.T) {
+	for _, test := range []struct {
+		method string
+		want   []string
+	}{
+		{method: "GetOperation", want: []string{"describe", "wait"}},
+		{method: "ListOperations", want: []string{"list"}},
+		{method: "DeleteOperation", want: []string{"delete"}},
+		{method: "CancelOperation", want: []string{"cancel"}},
+		{method: "WaitOperation", want: nil},
+	} {
+		t.Run(test.method, func(t *testing.T) {
+			method := operationsMethod(test.method, "POST", "v1/{name=projects/*/locations/*/operations/*}")
+			service := api.NewTestService("Service")
+			service.DefaultHost = "example.googleapis.com"
+			var got []string
+			for _, cmd := range newOperationsBuilder(method, &provider.Config{}, &api.API{}, service).build() {
+				got = append(got, cmd.Name)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOperationsBuilder_HelpTextRule(t *testing.T) {
+	method := operationsMethod("CancelOperation", "POST", "v1/{name=projects/*/locations/*/operations/*}:cancel")
+	method.ID = ".example.v1.Service.CancelOperation"
+	service := api.NewTestService("Service")
+	service.DefaultHost = "example.googleapis.com"
+	config := &provider.Config{
+		APIs: []provider.API{{
+			HelpText: &provider.HelpTextRules{
+				MethodRules: []*provider.HelpTextRule{{
+					Selector: "example.v1.Service.CancelOperation",
+					HelpText: &provider.HelpTextElement{Brief: "Stop an operation"},
+				}},
+			},
+		}},
+	}
+	cmds := newOperationsBuilder(method, config, &api.API{}, service).build()
+	if len(cmds) != 1 {
+		t.Fatalf("got %d commands, want 1", len(cmds))
+	}
+	want := HelpText{Brief: "Stop an operation"}
+	if diff := cmp.Diff(want, cmds[0].HelpText); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestOperationsBuilder_Singular(t *testing.T) {
+	resource := api.NewTestResource("example.googleapis.com/Address").WithPatterns(
+		(&api.PathTemplate{}).
+			WithLiteral("projects").WithVariableNamed("project").
+			WithLiteral("regions").WithVariableNamed("region").
+			WithLiteral("addresses").WithVariableNamed("address").
+			Segments,
+	)
+	model := &api.API{ResourceDefinitions: []*api.Resource{resource}}
+	b := newOperationsBuilder(api.NewTestMethod("GetOperation"), &provider.Config{}, model, api.NewTestService("Service"))
+	for _, test := range []struct {
+		plural string
+		want   string
+	}{
+		{"addresses", "address"},
+		{"regions", "region"},
+		{"operations", "operation"},
+		{"policies", "policy"},
+		{"classes", "class"},
+		{"boxes", "box"},
+		{"batches", "batch"},
+	} {
+		t.Run(test.plural, func(t *testing.T) {
+			if got := b.singular(test.plural); got != test.want {
+				t.Errorf("singular(%q) = %q, want %q", test.plural, got, test.want)
+			}
+		})
+	}
+}
+
+// operationsModel returns a service with a long-running create method and
+// the google.longrunning.Operations mixin methods.
+func operationsModel() *api.API {
+	resource := api.NewTestResource("example.googleapis.com/Resource").WithPatterns(
+		(&api.PathTemplate{}).
+			WithLiteral("projects").WithVariableNamed("project").
+			WithLiteral("locations").WithVariableNamed("location").
+			WithLiteral("resources").WithVariableNamed("resource").
+			Segments,
+	)
+	message := api.NewTestMessage("Resource").WithFields(
+		api.NewTestField("name").WithType(api.TypezString),
+	)
+	message.Resource = resource
+
+	parent := api.NewTestField("parent").WithType(api.TypezString)
+	parent.ResourceReference = &api.ResourceReference{ChildType: resource.Type}
+	create := api.NewTestMethod("CreateResource").
+		WithVerb("POST").
+		WithInput(api.NewTestMessage("CreateResourceRequest").WithFields(
+			parent,
+			api.NewTestField("resource_id").WithType(api.TypezString),
+			api.NewTestField("resource").WithMessageType(message),
+		)).
+		WithPathTemplate((&api.PathTemplate{}).
+			WithLiteral("v1").
+			WithVariable(api.NewPathVariable("parent").WithLiteral("projects").WithMatch().WithLiteral("locations").WithMatch()).
+			WithLiteral("resources"))
+	create.OperationInfo = &api.OperationInfo{
+		ResponseTypeID: ".example.v1.Resource",
+		MetadataTypeID: ".example.v1.OperationMetadata",
+	}
+
+	service := api.NewTestService("Example").WithPackage("example.v1").WithMethods(
+		create,
+		operationsMethod("GetOperation", "GET", "v1/{name=projects/*/locations/*/operations/*}"),
+		operationsMethod("ListOperations", "GET", "v1/{name=projects/*/locations/*}/operations"),
+		operationsMethod("DeleteOperation", "DELETE", "v1/{name=projects/*/locations/*/operations/*}"),
+		operationsMethod("CancelOperation", "POST", "v1/{name=projects/*/locations/*/operations/*}:cancel"),
+	)
+	service.DefaultHost = "example.googleapis.com"
+
+	model := api.NewTestAPI([]*api.Message{message}, nil, []*api.Service{service})
+	model.Name = "example"
+	model.PackageName = "example.v1"
+	model.ResourceDefinitions = []*api.Resource{resource}
+	return model
+}
+
+// operationsMethod returns a google.longrunning.Operations mixin method bound
+// to path.
+func operationsMethod(name, verb, path string) *api.Method {
+	docs := map[string]string{
+		"GetOperation":    "The name of the operation resource.",
+		"ListOperations":  "The name of the operation's parent resource.",
+		"DeleteOperation": "The name of the operation resource to be deleted.",
+		"CancelOperation": "The name of the operation resource to be cancelled.",
+		"WaitOperation":   "The name of the operation resource to wait on.",
+	}
+	field := api.NewTestField("name").WithType(api.TypezString)
+	field.Documentation = docs[name]
+	method := mockMethod(name, path)
+	method.PathInfo.Bindings[0].Verb = verb
+	method.InputType = api.NewTestMessage(name + "Request").WithFields(field)
+	method.SourceServiceID = ".google.longrunning.Operations"
+	return method
+}
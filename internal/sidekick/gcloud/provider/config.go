@@ -15,6 +15,8 @@
 // Package provider contains configuration types and helpers for surfer tools.
 package provider
 
+import "strings"
+
 //go:generate go run -tags configdocgen ../../../../cmd/config_doc_generate.go -input . -output ../../../../doc/gcloud/gcloud-yaml-schema.md -title "gcloud.yaml"
 
 // Config represents the top-level schema of a gcloud config YAML file.
@@ -26,6 +28,11 @@ type Config struct {
 	// GenerateOperations indicates whether to generate top-level operations commands.
 	GenerateOperations *bool `yaml:"generate_operations,omitempty"`
 
+	// GenerateTests indicates whether to generate a Python unit test module
+	// for each command. The tests are written under tests/unit/surface in the
+	// output directory.
+	GenerateTests bool `yaml:"generate_tests,omitempty"`
+
 	// APIs describes the APIs for which to generate a gcloud surface.
 	APIs []API `yaml:"apis,omitempty"`
 
@@ -33,6 +40,34 @@ type Config struct {
 	// descriptors, providing additional patterns that might be used for
 	// resource identification or command generation.
 	ResourcePatterns []ResourcePattern `yaml:"resource_patterns,omitempty"`
+
+	// Discovery configures the LRO polling methods of an API generated from a
+	// Discovery document.
+	Discovery *Discovery `yaml:"discovery,omitempty"`
+}
+
+// Discovery contains the LRO polling configuration for APIs generated from a
+// Discovery document.
+type Discovery struct {
+	// OperationID is the ID of the LRO operation type (e.g.,
+	// ".google.cloud.compute.v1.Operation").
+	OperationID string `yaml:"operation_id"`
+
+	// Pollers is a list of LRO polling configurations. The first poller whose
+	// prefix matches the path of a method is used.
+	Pollers []Poller `yaml:"pollers,omitempty"`
+}
+
+// Poller defines how to find the method that polls the LRO of a Discovery
+// method.
+type Poller struct {
+	// Prefix is an acceptable prefix for the URL path (e.g.,
+	// "compute/v1/projects/{project}/zones/{zone}").
+	Prefix string `yaml:"prefix"`
+
+	// MethodID is the corresponding method ID (e.g.,
+	// ".google.cloud.compute.v1.zoneOperations.get").
+	MethodID string `yaml:"method_id"`
 }
 
 // API describes an API to generate a surface for. This structure holds
@@ -48,7 +83,7 @@ type API struct {
 	// SupportsStarUpdateMasks indicates that this API supports '*' updateMasks
 	// in accordance with https://google.aip.dev/134#request-message. The
 	// default is assumed to be true for AIP compliant APIs.
-	SupportsStarUpdateMasks bool `yaml:"supports_star_update_masks,omitempty"`
+	SupportsStarUpdateMasks *bool `yaml:"supports_star_update_masks,omitempty"`
 
 	// RootIsHidden applies the gcloud 'hidden' flag to the root command group
 	// of the generated surface.  When true, the top-level command group for
@@ -280,3 +315,48 @@ func ShouldGenerateOperations(c *Config) bool {
 	}
 	return *c.GenerateOperations
 }
+
+// SupportsStarUpdateMasks returns true if update commands should send a '*'
+// update mask instead of the mask gcloud derives from the flags that are set.
+// It defaults to true if omitted from the config.
+func SupportsStarUpdateMasks(c *Config) bool {
+	if c == nil || len(c.APIs) == 0 || c.APIs[0].SupportsStarUpdateMasks == nil {
+		return true
+	}
+	return *c.APIs[0].SupportsStarUpdateMasks
+}
+
+// DisplayOperationResult returns true if the command for methodID should
+// print the result of its long-running operation rather than the resource the
+// operation acted on.
+func DisplayOperationResult(c *Config, methodID string) bool {
+	if c == nil {
+		return false
+	}
+	for _, api := range c.APIs {
+		for _, op := range api.CommandOperationsConfig {
+			if matchesSelector(op.Selector, methodID) {
+				return op.DisplayOperationResult
+			}
+		}
+	}
+	return false
+}
+
+// matchesSelector reports whether one of the comma-separated patterns in
+// selector matches id.
+func matchesSelector(selector, id string) bool {
+	for _, pattern := range strings.Split(selector, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(id, prefix) {
+				return true
+			}
+			continue
+		}
+		if pattern == id {
+			return true
+		}
+	}
+	return false
+}
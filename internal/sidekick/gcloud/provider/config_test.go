@@ -150,3 +150,57 @@ func TestAPIVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestSupportsStarUpdateMasks(t *testing.T) {
+	yes, no := true, false
+	for _, test := range []struct {
+		name      string
+		overrides *Config
+		want      bool
+	}{
+		{name: "nil config", overrides: nil, want: true},
+		{name: "no APIs", overrides: &Config{}, want: true},
+		{name: "omitted", overrides: &Config{APIs: []API{{}}}, want: true},
+		{name: "enabled", overrides: &Config{APIs: []API{{SupportsStarUpdateMasks: &yes}}}, want: true},
+		{name: "disabled", overrides: &Config{APIs: []API{{SupportsStarUpdateMasks: &no}}}, want: false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if got := SupportsStarUpdateMasks(test.overrides); got != test.want {
+				t.Errorf("SupportsStarUpdateMasks() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDisplayOperationResult(t *testing.T) {
+	overrides := &Config{
+		APIs: []API{{
+			CommandOperationsConfig: []*CommandOperationsConfig{
+				{Selector: "example.v1.Service.CreateThing, example.v1.Service.DeleteThing", DisplayOperationResult: true},
+				{Selector: "example.v1.Other.*", DisplayOperationResult: true},
+				{Selector: "example.v1.Service.*", DisplayOperationResult: false},
+			},
+		}},
+	}
+	for _, test := range []struct {
+		name      string
+		overrides *Config
+		methodID  string
+		want      bool
+	}{
+		{name: "nil config", overrides: nil, methodID: "example.v1.Service.CreateThing", want: false},
+		{name: "exact", overrides: overrides, methodID: "example.v1.Service.CreateThing", want: true},
+		{name: "list entry", overrides: overrides, methodID: "example.v1.Service.DeleteThing", want: true},
+		{name: "wildcard", overrides: overrides, methodID: "example.v1.Other.UpdateThing", want: true},
+		{name: "first match wins", overrides: overrides, methodID: "example.v1.Service.UpdateThing", want: false},
+		{name: "no match", overrides: overrides, methodID: "example.v2.Service.CreateThing", want: false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if got := DisplayOperationResult(test.overrides, test.methodID); got != test.want {
+				t.Errorf("DisplayOperationResult() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
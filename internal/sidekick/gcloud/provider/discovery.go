@@ -0,0 +1,124 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+// IsDiscoveryMethod determines if the method was parsed from a Discovery
+// document. The parser gives Discovery methods a synthetic request message
+// whose fields are the path and query parameters of the method.
+func IsDiscoveryMethod(m *api.Method) bool {
+	return m.InputType != nil && m.InputType.SyntheticRequest
+}
+
+// IsDiscoveryPoller determines if the method is a copy of an LRO polling
+// method that the Discovery parser adds to each service with long-running
+// methods. The poller is generated with its own service.
+func IsDiscoveryPoller(m *api.Method) bool {
+	return IsDiscoveryMethod(m) && m.Service != nil && m.SourceServiceID != "" && m.SourceServiceID != m.Service.ID
+}
+
+// DiscoveryPathSegments returns the resource pattern in the flat path of a
+// Discovery method. It drops the service path, such as compute/v1, and
+// literals that do not name a collection, such as global. A trailing
+// collection literal, as in the path of a list method, is kept.
+//
+// Example: `compute/v1/projects/{project}/global/networks/{network}` ->
+// `projects/{project}/networks/{network}`.
+func DiscoveryPathSegments(m *api.Method) []api.PathSegment {
+	binding := PrimaryBinding(m)
+	if binding == nil || binding.PathTemplate == nil {
+		return nil
+	}
+	raw := binding.PathTemplate.Segments
+	var segments []api.PathSegment
+	for i, seg := range raw {
+		if seg.Variable != nil {
+			if len(segments) > 0 {
+				segments = append(segments, seg)
+			}
+			continue
+		}
+		isLast := i == len(raw)-1
+		followedByVariable := !isLast && raw[i+1].Variable != nil
+		if followedByVariable || (isLast && len(segments) > 0) {
+			segments = append(segments, seg)
+		}
+	}
+	return segments
+}
+
+// DiscoveryResourceSegments returns the pattern of the resource a Discovery
+// method operates on, such as projects/{project}/zones/{zone}/instances/{instance}.
+// The flat path of a collection method, such as list or insert, ends with the
+// collection, so the pattern is taken from a method of the same service whose
+// path names a single resource in that collection. If there is none, the
+// pattern ends with the collection literal.
+func DiscoveryResourceSegments(m *api.Method) []api.PathSegment {
+	segments := DiscoveryPathSegments(m)
+	if len(segments) == 0 || segments[len(segments)-1].Variable != nil || m.Service == nil {
+		return segments
+	}
+	for _, other := range m.Service.Methods {
+		candidate := DiscoveryPathSegments(other)
+		if len(candidate) != len(segments)+1 || candidate[len(candidate)-1].Variable == nil {
+			continue
+		}
+		if sameLiterals(candidate[:len(segments)], segments) {
+			return candidate
+		}
+	}
+	return segments
+}
+
+// DiscoveryParentSegments returns the pattern of the parent of the resource a
+// Discovery method operates on, such as projects/{project}/zones/{zone}.
+func DiscoveryParentSegments(m *api.Method) []api.PathSegment {
+	segments := DiscoveryResourceSegments(m)
+	if len(segments) > 0 && segments[len(segments)-1].Literal != nil {
+		return segments[:len(segments)-1]
+	}
+	return GetParentFromSegments(segments)
+}
+
+func sameLiterals(a, b []api.PathSegment) bool {
+	for i := range a {
+		if (a[i].Literal == nil) != (b[i].Literal == nil) {
+			return false
+		}
+		if a[i].Literal != nil && *a[i].Literal != *b[i].Literal {
+			return false
+		}
+	}
+	return true
+}
+
+// DiscoveryPathParameters returns the names of the path parameters of a
+// Discovery method.
+func DiscoveryPathParameters(m *api.Method) map[string]bool {
+	params := map[string]bool{}
+	binding := PrimaryBinding(m)
+	if binding == nil || binding.PathTemplate == nil {
+		return params
+	}
+	for _, seg := range binding.PathTemplate.Segments {
+		if seg.Variable != nil && len(seg.Variable.FieldPath) > 0 {
+			params[seg.Variable.FieldPath[0]] = true
+		}
+	}
+	return params
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+func TestIsDiscoveryPoller(t *testing.T) {
+	service := api.NewTestService("instances").WithPackage("compute")
+	request := api.NewTestMessage("getOperationRequest")
+	request.SyntheticRequest = true
+
+	poller := api.NewTestMethod("getOperation").WithInput(request)
+	poller.SourceServiceID = ".compute.zoneOperations"
+	get := api.NewTestMethod("get").WithInput(request)
+	mixin := api.NewTestMethod("GetOperation")
+	mixin.SourceServiceID = ".google.longrunning.Operations"
+	service.WithMethods(poller, get, mixin)
+
+	for _, test := range []struct {
+		name   string
+		method *api.Method
+		want   bool
+	}{
+		{"Poller", poller, true},
+		{"Service method", get, false},
+		{"Protobuf mixin", mixin, false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			got := IsDiscoveryPoller(test.method)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiscoveryResourceSegments(t *testing.T) {
+	scope := func() *api.PathTemplate {
+		return (&api.PathTemplate{}).WithLiteral("projects").WithVariableNamed("project")
+	}
+	path := func() *api.PathTemplate {
+		return (&api.PathTemplate{}).WithLiteral("compute").WithLiteral("v1").
+			WithLiteral("projects").WithVariableNamed("project").
+			WithLiteral("global").WithLiteral("networks")
+	}
+	request := api.NewTestMessage("request")
+	request.SyntheticRequest = true
+	list := api.NewTestMethod("list").WithInput(request).WithPathTemplate(path())
+	get := api.NewTestMethod("get").WithInput(request).WithPathTemplate(path().WithVariableNamed("network"))
+	orphan := api.NewTestMethod("list").WithInput(request).WithPathTemplate(path())
+	api.NewTestService("networks").WithMethods(list, get)
+	api.NewTestService("other").WithMethods(orphan)
+
+	for _, test := range []struct {
+		name       string
+		method     *api.Method
+		wantPath   []api.PathSegment
+		wantSelf   []api.PathSegment
+		wantParent []api.PathSegment
+	}{
+		{
+			name:       "collection method",
+			method:     list,
+			wantPath:   scope().WithLiteral("networks").Segments,
+			wantSelf:   scope().WithLiteral("networks").WithVariableNamed("network").Segments,
+			wantParent: scope().Segments,
+		},
+		{
+			name:       "resource method",
+			method:     get,
+			wantPath:   scope().WithLiteral("networks").WithVariableNamed("network").Segments,
+			wantSelf:   scope().WithLiteral("networks").WithVariableNamed("network").Segments,
+			wantParent: scope().Segments,
+		},
+		{
+			name:       "no resource method",
+			method:     orphan,
+			wantPath:   scope().WithLiteral("networks").Segments,
+			wantSelf:   scope().WithLiteral("networks").Segments,
+			wantParent: scope().Segments,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(test.wantPath, DiscoveryPathSegments(test.method)); diff != "" {
+				t.Errorf("DiscoveryPathSegments() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.wantSelf, DiscoveryResourceSegments(test.method)); diff != "" {
+				t.Errorf("DiscoveryResourceSegments() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.wantParent, DiscoveryParentSegments(test.method)); diff != "" {
+				t.Errorf("DiscoveryParentSegments() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiscoveryPathParameters(t *testing.T) {
+	request := api.NewTestMessage("request")
+	request.SyntheticRequest = true
+	m := api.NewTestMethod("get").WithInput(request).WithPathTemplate((&api.PathTemplate{}).
+		WithLiteral("compute").WithLiteral("v1").
+		WithLiteral("projects").WithVariableNamed("project").
+		WithLiteral("zones").WithVariableNamed("zone").
+		WithLiteral("instances").WithVariableNamed("instance"))
+
+	got := DiscoveryPathParameters(m)
+	want := map[string]bool{"project": true, "zone": true, "instance": true}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
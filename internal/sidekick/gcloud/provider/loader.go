@@ -63,6 +63,42 @@ func CreateAPIModel(googleapisPath, includeList, serviceConfig, descriptorFiles,
 	return model, nil
 }
 
+// CreateDiscoveryAPIModel parses a Discovery document and creates the API
+// model. The pollers in discovery identify the methods used to poll
+// long-running operations.
+func CreateDiscoveryAPIModel(discoveryDoc, serviceConfig string, discovery *Discovery) (*api.API, error) {
+	parserConfig := &parser.ModelConfig{
+		SpecificationFormat: libconfig.SpecDiscovery,
+		SpecificationSource: discoveryDoc,
+		ServiceConfig:       serviceConfig,
+		Discovery:           discovery.apiDiscovery(),
+	}
+	model, err := parser.CreateModel(parserConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API model: %w", err)
+	}
+	return model, nil
+}
+
+// apiDiscovery converts the gcloud.yaml poller configuration to the form
+// used by the Discovery parser.
+func (d *Discovery) apiDiscovery() *api.Discovery {
+	if d == nil {
+		return nil
+	}
+	pollers := make([]*api.Poller, len(d.Pollers))
+	for i, poller := range d.Pollers {
+		pollers[i] = &api.Poller{
+			Prefix:   poller.Prefix,
+			MethodID: poller.MethodID,
+		}
+	}
+	return &api.Discovery{
+		OperationID: d.OperationID,
+		Pollers:     pollers,
+	}
+}
+
 // ReadGcloudConfig loads the gcloud configuration from a gcloud.yaml file.
 func ReadGcloudConfig(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
@@ -83,14 +119,21 @@ func ReadGcloudConfig(path string) (*Config, error) {
 }
 
 func validateConfig(cfg *Config) error {
+	if errs := validateResourcePatterns(cfg); len(errs) != 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+func validateResourcePatterns(cfg *Config) []error {
+	var errs []error
 	for i, p := range cfg.ResourcePatterns {
 		if p.Type == "" {
-			return fmt.Errorf("resource_patterns[%d].type is required", i)
+			errs = append(errs, fmt.Errorf("resource_patterns[%d].type is required", i))
 		}
 		if len(p.Patterns) == 0 {
-			return fmt.Errorf("resource_patterns[%d].patterns must not be empty", i)
+			errs = append(errs, fmt.Errorf("resource_patterns[%d].patterns must not be empty", i))
 		}
 	}
-
-	return nil
+	return errs
 }
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/sidekick/api"
 	"github.com/googleapis/librarian/internal/yaml"
 )
 
@@ -28,6 +29,7 @@ func TestReadGcloudConfig(t *testing.T) {
 	const validConfig = `
 service_name: "example.googleapis.com"
 generate_operations: true
+generate_tests: true
 apis:
 - name: "ExampleAPI"
   api_version: "v1"
@@ -51,6 +53,11 @@ resource_patterns:
 - type: "example.googleapis.com/Resource"
   patterns: ["projects/{project}/resources/{resource}"]
   api_version: "v1"
+discovery:
+  operation_id: ".example.v1.Operation"
+  pollers:
+  - prefix: "example/v1/projects/{project}/zones/{zone}"
+    method_id: ".example.v1.zoneOperations.get"
 `
 
 	tmpFile := filepath.Join(t.TempDir(), "gcloud.yaml")
@@ -78,6 +85,46 @@ resource_patterns:
 	}
 }
 
+func TestCreateDiscoveryAPIModel(t *testing.T) {
+	model, err := CreateDiscoveryAPIModel(
+		"../../../testdata/discovery/small-compute.v1.json",
+		"../../../testdata/googleapis/google/cloud/compute/v1/small-compute_v1.yaml",
+		&Discovery{OperationID: ".google.cloud.compute.v1.Operation"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, s := range model.Services {
+		got = append(got, s.Name)
+	}
+	want := []string{"AcceleratorTypes", "Addresses", "instances"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDiscovery_APIDiscovery(t *testing.T) {
+	d := &Discovery{
+		OperationID: ".example.v1.Operation",
+		Pollers: []Poller{
+			{Prefix: "example/v1/projects/{project}/zones/{zone}", MethodID: ".example.v1.zoneOperations.get"},
+		},
+	}
+	want := &api.Discovery{
+		OperationID: ".example.v1.Operation",
+		Pollers: []*api.Poller{
+			{Prefix: "example/v1/projects/{project}/zones/{zone}", MethodID: ".example.v1.zoneOperations.get"},
+		},
+	}
+	if diff := cmp.Diff(want, d.apiDiscovery()); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if got := (*Discovery)(nil).apiDiscovery(); got != nil {
+		t.Errorf("apiDiscovery() = %v, want nil", got)
+	}
+}
+
 func TestReadGcloudConfig_Invalid(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -53,9 +53,10 @@ func GetCommandName(method *api.Method) (string, error) {
 	}
 }
 
-// IsCreate determines if the method is a standard Create method (AIP-133).
+// IsCreate determines if the method is a standard Create method (AIP-133)
+// or the insert method of a Discovery resource.
 func IsCreate(m *api.Method) bool {
-	if !strings.HasPrefix(m.Name, "Create") {
+	if !strings.HasPrefix(m.Name, "Create") && m.Name != "insert" {
 		return false
 	}
 	if verb := GetHTTPVerb(m); verb != "" {
@@ -64,14 +65,15 @@ func IsCreate(m *api.Method) bool {
 	return true
 }
 
-// IsGet determines if the method is a standard Get method (AIP-131).
+// IsGet determines if the method is a standard Get method (AIP-131) or the
+// get method of a Discovery resource.
 func IsGet(m *api.Method) bool {
 	// Use sidekick's robust AIP check if available.
 	if m.IsAIPStandardGet {
 		return true
 	}
 	// Fallback heuristic
-	if !strings.HasPrefix(m.Name, "Get") {
+	if !strings.HasPrefix(m.Name, "Get") && m.Name != "get" {
 		return false
 	}
 	if verb := GetHTTPVerb(m); verb != "" {
@@ -80,9 +82,10 @@ func IsGet(m *api.Method) bool {
 	return true
 }
 
-// IsList determines if the method is a standard List method (AIP-132).
+// IsList determines if the method is a standard List method (AIP-132) or the
+// list method of a Discovery resource.
 func IsList(m *api.Method) bool {
-	if !strings.HasPrefix(m.Name, "List") {
+	if !strings.HasPrefix(m.Name, "List") && m.Name != "list" {
 		return false
 	}
 	if verb := GetHTTPVerb(m); verb != "" {
@@ -91,9 +94,10 @@ func IsList(m *api.Method) bool {
 	return true
 }
 
-// IsUpdate determines if the method is a standard Update method (AIP-134).
+// IsUpdate determines if the method is a standard Update method (AIP-134) or
+// the patch or update method of a Discovery resource.
 func IsUpdate(m *api.Method) bool {
-	if !strings.HasPrefix(m.Name, "Update") {
+	if !strings.HasPrefix(m.Name, "Update") && m.Name != "update" && m.Name != "patch" {
 		return false
 	}
 	if verb := GetHTTPVerb(m); verb != "" {
@@ -102,14 +106,15 @@ func IsUpdate(m *api.Method) bool {
 	return true
 }
 
-// IsDelete determines if the method is a standard Delete method (AIP-135).
+// IsDelete determines if the method is a standard Delete method (AIP-135) or
+// the delete method of a Discovery resource.
 func IsDelete(m *api.Method) bool {
 	// Use sidekick's robust AIP check if available.
 	if m.IsAIPStandardDelete {
 		return true
 	}
 	// Fallback heuristic
-	if !strings.HasPrefix(m.Name, "Delete") {
+	if !strings.HasPrefix(m.Name, "Delete") && m.Name != "delete" {
 		return false
 	}
 	if verb := GetHTTPVerb(m); verb != "" {
@@ -132,6 +137,12 @@ func PrimaryBinding(m *api.Method) *api.PathBinding {
 	return m.PathInfo.Bindings[0]
 }
 
+// IsOperationsMixin determines if the method comes from the
+// google.longrunning.Operations mixin rather than from the service itself.
+func IsOperationsMixin(m *api.Method) bool {
+	return m.SourceServiceID == ".google.longrunning.Operations"
+}
+
 // GetHTTPVerb returns the HTTP verb from the primary binding, or an empty string if not available.
 func GetHTTPVerb(m *api.Method) string {
 	if b := PrimaryBinding(m); b != nil {
@@ -32,6 +32,7 @@ func TestIsCreate(t *testing.T) {
 		{"Name Mismatch", &api.Method{Name: "GetInstance"}, false},
 		{"Verb Match", api.NewTestMethod("CreateInstance").WithVerb("POST"), true},
 		{"Verb Mismatch", api.NewTestMethod("CreateInstance").WithVerb("GET"), false},
+		{"Discovery Insert", api.NewTestMethod("insert").WithVerb("POST"), true},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			t.Parallel()
@@ -53,6 +54,7 @@ func TestIsGet(t *testing.T) {
 		{"Name Mismatch", &api.Method{Name: "CreateInstance"}, false},
 		{"Verb Match", api.NewTestMethod("GetInstance").WithVerb("GET"), true},
 		{"Verb Mismatch", api.NewTestMethod("GetInstance").WithVerb("POST"), false},
+		{"Discovery Get", api.NewTestMethod("get").WithVerb("GET"), true},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			t.Parallel()
@@ -74,6 +76,7 @@ func TestIsList(t *testing.T) {
 		{"Name Mismatch", &api.Method{Name: "GetInstance"}, false},
 		{"Verb Match", api.NewTestMethod("ListInstances").WithVerb("GET"), true},
 		{"Verb Mismatch", api.NewTestMethod("ListInstances").WithVerb("POST"), false},
+		{"Discovery List", api.NewTestMethod("list").WithVerb("GET"), true},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			t.Parallel()
@@ -96,6 +99,8 @@ func TestIsUpdate(t *testing.T) {
 		{"Verb Match PATCH", api.NewTestMethod("UpdateInstance").WithVerb("PATCH"), true},
 		{"Verb Match PUT", api.NewTestMethod("UpdateInstance").WithVerb("PUT"), true},
 		{"Verb Mismatch", api.NewTestMethod("UpdateInstance").WithVerb("GET"), false},
+		{"Discovery Patch", api.NewTestMethod("patch").WithVerb("PATCH"), true},
+		{"Discovery Update", api.NewTestMethod("update").WithVerb("PUT"), true},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			t.Parallel()
@@ -117,6 +122,7 @@ func TestIsDelete(t *testing.T) {
 		{"Name Mismatch", &api.Method{Name: "GetInstance"}, false},
 		{"Verb Match", api.NewTestMethod("DeleteInstance").WithVerb("DELETE"), true},
 		{"Verb Mismatch", api.NewTestMethod("DeleteInstance").WithVerb("GET"), false},
+		{"Discovery Delete", api.NewTestMethod("delete").WithVerb("DELETE"), true},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			t.Parallel()
@@ -283,6 +289,27 @@ func TestIsCollectionMethod(t *testing.T) {
 	}
 }
 
+func TestIsOperationsMixin(t *testing.T) {
+	mixin := api.NewTestMethod("GetOperation").WithVerb("GET")
+	mixin.SourceServiceID = ".google.longrunning.Operations"
+	for _, test := range []struct {
+		name   string
+		method *api.Method
+		want   bool
+	}{
+		{"Mixin", mixin, true},
+		{"Service method", api.NewTestMethod("GetOperation").WithVerb("GET"), false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			got := IsOperationsMixin(test.method)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestIsStandardMethod(t *testing.T) {
 	for _, test := range []struct {
 		name   string
@@ -90,7 +90,9 @@ func GetCollectionPathFromSegments(segments []api.PathSegment) string {
 
 // IsPrimaryResourceField determines if a field represents the primary resource of a method.
 func IsPrimaryResourceField(field *api.Field, method *api.Method) bool {
-	if method.InputType == nil {
+	// The resource of a Discovery method is named by its path parameters, not
+	// by a single field.
+	if method.InputType == nil || IsDiscoveryMethod(method) {
 		return false
 	}
 
@@ -112,6 +114,11 @@ func IsResourceIdField(field *api.Field, method *api.Method, model *api.API) boo
 	if !IsCreate(method) {
 		return false
 	}
+	// Discovery insert methods take the ID as the name of the new resource
+	// in the request body.
+	if IsDiscoveryMethod(method) {
+		return field.Name == "name"
+	}
 	resource := GetResourceForMethod(method, model)
 	if resource == nil {
 		return false
@@ -227,7 +234,8 @@ func isSingletonResource(resource *api.Resource) bool {
 
 // GetPluralResourceNameForMethod determines the plural name of a resource. It follows a clear
 // hierarchy of truth: first, the explicit `plural` field in the resource
-// definition, and second, inference from the resource pattern.
+// definition, second, inference from the resource pattern, and third, for
+// Discovery methods, inference from the flat path.
 func GetPluralResourceNameForMethod(method *api.Method, model *api.API) string {
 	resource := GetResourceForMethod(method, model)
 	if resource != nil {
@@ -242,12 +250,22 @@ func GetPluralResourceNameForMethod(method *api.Method, model *api.API) string {
 			return GetPluralFromSegments(resource.Patterns[0])
 		}
 	}
+	// Discovery documents have no resource definitions, so we fall back to
+	// the flat path of the method.
+	if IsDiscoveryMethod(method) {
+		segments := DiscoveryResourceSegments(method)
+		if n := len(segments); n > 0 && segments[n-1].Literal != nil {
+			return *segments[n-1].Literal
+		}
+		return GetPluralFromSegments(segments)
+	}
 	return ""
 }
 
 // GetSingularResourceNameForMethod determines the singular name of a resource. It follows a clear
 // hierarchy of truth: first, the explicit `singular` field in the resource
-// definition, and second, inference from the resource pattern.
+// definition, second, inference from the resource pattern, and third, for
+// Discovery methods, inference from the flat path.
 func GetSingularResourceNameForMethod(method *api.Method, model *api.API) string {
 	resource := GetResourceForMethod(method, model)
 	if resource != nil {
@@ -258,6 +276,9 @@ func GetSingularResourceNameForMethod(method *api.Method, model *api.API) string
 			return GetSingularFromSegments(resource.Patterns[0])
 		}
 	}
+	if IsDiscoveryMethod(method) {
+		return GetSingularFromSegments(DiscoveryResourceSegments(method))
+	}
 	return ""
 }
 
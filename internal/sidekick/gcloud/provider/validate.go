@@ -0,0 +1,149 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+// selectorKind is the kind of API element a selector refers to.
+type selectorKind int
+
+const (
+	serviceSelector selectorKind = iota
+	messageSelector
+	methodSelector
+	fieldSelector
+)
+
+// Validate returns the problems found in cfg: invalid resource patterns,
+// malformed selectors and, if model is not nil, selectors that match no
+// element of the API. Unlike [ReadGcloudConfig] it reports every problem
+// rather than only the first one.
+func Validate(cfg *Config, model *api.API) []error {
+	errs := validateResourcePatterns(cfg)
+	var ids map[selectorKind]map[string]bool
+	if model != nil {
+		ids = elementIDs(model)
+	}
+	check := func(location, selector string, kind selectorKind, single bool) {
+		if err := checkSelector(selector, single); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", location, err))
+			return
+		}
+		if ids == nil {
+			return
+		}
+		for _, pattern := range strings.Split(selector, ",") {
+			if !matchesAny(strings.TrimSpace(pattern), ids[kind]) {
+				errs = append(errs, fmt.Errorf("%s: %q matches no element in the API", location, pattern))
+			}
+		}
+	}
+	for i, a := range cfg.APIs {
+		prefix := fmt.Sprintf("apis[%d]", i)
+		if a.HelpText != nil {
+			for _, rules := range []struct {
+				name  string
+				kind  selectorKind
+				rules []*HelpTextRule
+			}{
+				{"service_rules", serviceSelector, a.HelpText.ServiceRules},
+				{"message_rules", messageSelector, a.HelpText.MessageRules},
+				{"method_rules", methodSelector, a.HelpText.MethodRules},
+				{"field_rules", fieldSelector, a.HelpText.FieldRules},
+			} {
+				for j, rule := range rules.rules {
+					check(fmt.Sprintf("%s.help_text.%s[%d].selector", prefix, rules.name, j), rule.Selector, rules.kind, false)
+				}
+			}
+		}
+		for j, f := range a.OutputFormatting {
+			check(fmt.Sprintf("%s.output_formatting[%d].selector", prefix, j), f.Selector, methodSelector, true)
+		}
+		for j, c := range a.CommandOperationsConfig {
+			check(fmt.Sprintf("%s.command_operations_config[%d].selector", prefix, j), c.Selector, methodSelector, false)
+		}
+	}
+	return errs
+}
+
+// checkSelector verifies that selector is a comma-separated list of qualified
+// names, each optionally ending in a "*" wildcard component. If single is
+// true, the selector must be a single name without wildcards.
+func checkSelector(selector string, single bool) error {
+	if selector == "" {
+		return fmt.Errorf("selector is required")
+	}
+	patterns := strings.Split(selector, ",")
+	if single && len(patterns) != 1 {
+		return fmt.Errorf("selector %q must name a single element", selector)
+	}
+	for _, pattern := range patterns {
+		components := strings.Split(strings.TrimSpace(pattern), ".")
+		for i, c := range components {
+			switch {
+			case c == "*" && single:
+				return fmt.Errorf("selector %q must not use wildcards", selector)
+			case c == "*" && i != len(components)-1:
+				return fmt.Errorf("selector %q has a wildcard before the last component", selector)
+			case c == "*":
+			case c == "" || strings.Contains(c, "*"):
+				return fmt.Errorf("selector %q has an invalid component %q", selector, c)
+			}
+		}
+	}
+	return nil
+}
+
+// matchesAny reports whether pattern matches one of ids.
+func matchesAny(pattern string, ids map[string]bool) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		for id := range ids {
+			if strings.HasPrefix(id, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+	return ids[pattern]
+}
+
+// elementIDs returns the qualified names, without the leading ".", of the
+// services, messages, methods and fields in model.
+func elementIDs(model *api.API) map[selectorKind]map[string]bool {
+	ids := map[selectorKind]map[string]bool{
+		serviceSelector: {},
+		messageSelector: {},
+		methodSelector:  {},
+		fieldSelector:   {},
+	}
+	for id := range model.State.ServiceByID {
+		ids[serviceSelector][strings.TrimPrefix(id, ".")] = true
+	}
+	for id := range model.State.MethodByID {
+		ids[methodSelector][strings.TrimPrefix(id, ".")] = true
+	}
+	for id, m := range model.State.MessageByID {
+		ids[messageSelector][strings.TrimPrefix(id, ".")] = true
+		for _, f := range m.Fields {
+			ids[fieldSelector][strings.TrimPrefix(f.ID, ".")] = true
+		}
+	}
+	return ids
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+func TestValidate(t *testing.T) {
+	instance := &api.Message{
+		Name:    "Instance",
+		ID:      ".example.v1.Instance",
+		Package: "example.v1",
+		Fields: []*api.Field{
+			{Name: "name", ID: ".example.v1.Instance.name"},
+		},
+	}
+	service := &api.Service{
+		Name:    "Service",
+		ID:      ".example.v1.Service",
+		Package: "example.v1",
+		Methods: []*api.Method{
+			{Name: "GetInstance", ID: ".example.v1.Service.GetInstance"},
+			{Name: "ListInstances", ID: ".example.v1.Service.ListInstances"},
+		},
+	}
+	model := api.NewTestAPI([]*api.Message{instance}, nil, []*api.Service{service})
+
+	for _, test := range []struct {
+		name  string
+		cfg   *Config
+		model *api.API
+		want  []string
+	}{
+		{
+			name: "valid",
+			cfg: &Config{APIs: []API{{
+				HelpText: &HelpTextRules{
+					ServiceRules: []*HelpTextRule{{Selector: "example.v1.Service"}},
+					MessageRules: []*HelpTextRule{{Selector: "example.v1.Instance"}},
+					MethodRules:  []*HelpTextRule{{Selector: "example.v1.Service.GetInstance, example.v1.Service.ListInstances"}},
+					FieldRules:   []*HelpTextRule{{Selector: "example.v1.Instance.*"}},
+				},
+				OutputFormatting:        []*OutputFormatting{{Selector: "example.v1.Service.ListInstances", Format: "table(name)"}},
+				CommandOperationsConfig: []*CommandOperationsConfig{{Selector: "example.v1.Service.*"}},
+			}}},
+			model: model,
+		},
+		{
+			name: "syntax only without a model",
+			cfg: &Config{APIs: []API{{
+				HelpText: &HelpTextRules{
+					MethodRules: []*HelpTextRule{{Selector: "example.v1.Service.DeleteInstance"}},
+				},
+			}}},
+		},
+		{
+			name: "invalid resource patterns",
+			cfg:  &Config{ResourcePatterns: []ResourcePattern{{}}},
+			want: []string{
+				"resource_patterns[0].type is required",
+				"resource_patterns[0].patterns must not be empty",
+			},
+		},
+		{
+			name: "malformed selectors",
+			cfg: &Config{APIs: []API{{
+				HelpText: &HelpTextRules{
+					MethodRules: []*HelpTextRule{
+						{Selector: ""},
+						{Selector: "example.*.Service"},
+						{Selector: "example.v1.Serv*"},
+						{Selector: "example..Service"},
+					},
+				},
+				OutputFormatting: []*OutputFormatting{
+					{Selector: "example.v1.Service.*"},
+					{Selector: "example.v1.Service.GetInstance,example.v1.Service.ListInstances"},
+				},
+			}}},
+			want: []string{
+				`apis[0].help_text.method_rules[0].selector: selector is required`,
+				`apis[0].help_text.method_rules[1].selector: selector "example.*.Service" has a wildcard before the last component`,
+				`apis[0].help_text.method_rules[2].selector: selector "example.v1.Serv*" has an invalid component "Serv*"`,
+				`apis[0].help_text.method_rules[3].selector: selector "example..Service" has an invalid component ""`,
+				`apis[0].output_formatting[0].selector: selector "example.v1.Service.*" must not use wildcards`,
+				`apis[0].output_formatting[1].selector: selector "example.v1.Service.GetInstance,example.v1.Service.ListInstances" must name a single element`,
+			},
+		},
+		{
+			name: "selectors matching nothing",
+			cfg: &Config{APIs: []API{{
+				HelpText: &HelpTextRules{
+					MethodRules: []*HelpTextRule{{Selector: "example.v1.Service.DeleteInstance"}},
+					FieldRules:  []*HelpTextRule{{Selector: "example.v1.Instance.labels"}},
+					// A message name is not a method.
+					ServiceRules: []*HelpTextRule{{Selector: "example.v1.Instance"}},
+				},
+				CommandOperationsConfig: []*CommandOperationsConfig{{Selector: "example.v2.*"}},
+			}}},
+			model: model,
+			want: []string{
+				`apis[0].help_text.service_rules[0].selector: "example.v1.Instance" matches no element in the API`,
+				`apis[0].help_text.method_rules[0].selector: "example.v1.Service.DeleteInstance" matches no element in the API`,
+				`apis[0].help_text.field_rules[0].selector: "example.v1.Instance.labels" matches no element in the API`,
+				`apis[0].command_operations_config[0].selector: "example.v2.*" matches no element in the API`,
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var got []string
+			for _, err := range Validate(test.cfg, test.model) {
+				got = append(got, err.Error())
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -64,12 +64,25 @@ func (b *surfaceBuilder) insert(root *CommandGroup, gb *groupBuilder, method *ap
 		return nil
 	}
 
+	if provider.IsDiscoveryPoller(method) {
+		return nil
+	}
+
 	binding := provider.PrimaryBinding(method)
 	if binding == nil {
 		return nil
 	}
 
 	segments := provider.GetLiteralSegments(binding.PathTemplate.Segments)
+	if provider.IsDiscoveryMethod(method) {
+		// Discovery resources are flat collections, such as compute
+		// instances. The scopes in their paths, such as zones, are
+		// attributes of the resource argument rather than command groups.
+		segments = nil
+		if plural := provider.GetPluralResourceNameForMethod(method, b.model); plural != "" {
+			segments = []string{plural}
+		}
+	}
 	if len(segments) == 0 {
 		return nil
 	}
@@ -90,6 +103,13 @@ func (b *surfaceBuilder) insert(root *CommandGroup, gb *groupBuilder, method *ap
 		curr = curr.Groups[seg]
 	}
 
+	if provider.IsOperationsMixin(method) {
+		for _, cmd := range newOperationsBuilder(method, b.config, b.model, gb.service).build() {
+			curr.Commands[cmd.Name] = cmd
+		}
+		return nil
+	}
+
 	cmd, err := newCommandBuilder(method, b.config, b.model, gb.service).build()
 	if err != nil {
 		return err
@@ -65,6 +65,28 @@ func TestSurfaceBuilder_Build_Structure(t *testing.T) {
 	}
 }
 
+func TestSurfaceBuilder_Build_Discovery(t *testing.T) {
+	model, _ := discoveryModel()
+	model.Name = "compute"
+	model.Title = "Compute Engine API"
+
+	root, err := newSurfaceBuilder(model, &provider.Config{}).build()
+	if err != nil {
+		t.Fatalf("build() failed: %v", err)
+	}
+
+	got := flattenTree(root.GA)
+	want := []string{
+		"compute/instances/create",
+		"compute/instances/describe",
+		"compute/instances/list",
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("flattenTree() mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestSurfaceBuilder_Build_Operations_Disabled(t *testing.T) {
 	service := mockService("parallelstore.googleapis.com", mockMethod("GetOperation", "v1/{name=projects/*/locations/*/operations/*}"))
 
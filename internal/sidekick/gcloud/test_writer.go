@@ -0,0 +1,501 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloud
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/iancoleman/strcase"
+)
+
+// testProject is the project configured by the gcloud test harness.
+const testProject = "fake-project"
+
+// standardRequestMethods maps the names of standard commands to the API
+// method gcloud calls when the command does not name one.
+var standardRequestMethods = map[string]string{
+	"create":   "create",
+	"delete":   "delete",
+	"describe": "get",
+	"list":     "list",
+	"update":   "patch",
+}
+
+// writeSurfaceTests writes a Python unit test module for each command in the
+// tree. The modules are written to outputDir, mirroring the command group
+// directories of the surface. Each test mocks the API client and checks the
+// request built from the resource argument and flags of the command.
+func writeSurfaceTests(outputDir string, tree *CommandGroupsByTrack) error {
+	return writeGroupTests(outputDir, groupTracks{ga: tree.GA, beta: tree.BETA, alpha: tree.ALPHA})
+}
+
+func writeGroupTests(outputDir string, b groupTracks) error {
+	name := groupName(b)
+	if name == "" {
+		return nil
+	}
+
+	groupDir := filepath.Join(outputDir, strcase.ToSnake(name))
+	tracks := []struct {
+		name  string
+		group *CommandGroup
+	}{{"GA", b.ga}, {"BETA", b.beta}, {"ALPHA", b.alpha}}
+
+	verbs := map[string][]trackTest{}
+	subNames := map[string]bool{}
+	for _, t := range tracks {
+		if t.group == nil {
+			continue
+		}
+		for verb, cmd := range t.group.Commands {
+			if test := newTrackTest(t.name, t.group.Path, cmd); test != nil {
+				verbs[verb] = append(verbs[verb], *test)
+			}
+		}
+		for sub := range t.group.Groups {
+			subNames[sub] = true
+		}
+	}
+
+	if len(verbs) > 0 {
+		if err := os.MkdirAll(groupDir, 0755); err != nil {
+			return err
+		}
+		if err := writeTestPackage(groupDir); err != nil {
+			return err
+		}
+	}
+	for verb, tests := range verbs {
+		if err := writeCommandTest(groupDir, verb, tests); err != nil {
+			return err
+		}
+	}
+
+	for sub := range subNames {
+		subBundle := groupTracks{}
+		if b.ga != nil {
+			subBundle.ga = b.ga.Groups[sub]
+		}
+		if b.beta != nil {
+			subBundle.beta = b.beta.Groups[sub]
+		}
+		if b.alpha != nil {
+			subBundle.alpha = b.alpha.Groups[sub]
+		}
+		if err := writeGroupTests(groupDir, subBundle); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeTestPackage makes dir a Python package, so the test runner can import
+// the test modules in it.
+func writeTestPackage(dir string) error {
+	var buf bytes.Buffer
+	if err := testPackageTemplate.Execute(&buf, map[string]int{"Year": time.Now().Year()}); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "__init__.py"), buf.Bytes(), 0644)
+}
+
+func writeCommandTest(groupDir, verb string, tests []trackTest) error {
+	view := commandTestView{
+		Year:      time.Now().Year(),
+		ClassName: strcase.ToCamel(verb),
+		Command:   tests[0].Command,
+		Tracks:    tests,
+	}
+	var buf bytes.Buffer
+	if err := commandTestTemplate.Execute(&buf, view); err != nil {
+		return fmt.Errorf("failed to render test for %q: %w", verb, err)
+	}
+	path := filepath.Join(groupDir, fmt.Sprintf("%s_test.py", verb))
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// commandTestView is the data for a command's test module.
+type commandTestView struct {
+	Year      int
+	ClassName string
+	Command   string
+	Tracks    []trackTest
+}
+
+// trackTest describes the test of a command in a single release track.
+type trackTest struct {
+	// Track is the release track, for example GA.
+	Track string
+	// Command is the command line, without the release track prefix.
+	Command string
+	// Args are the arguments that follow the command.
+	Args string
+	// APIName and APIVersion select the mocked API client.
+	APIName    string
+	APIVersion string
+	// Service is the attribute of the API client for the collection, for
+	// example projects_locations_instances.
+	Service string
+	// Method is the API method, for example Get.
+	Method string
+	// Request is the expected request as a Python dict.
+	Request string
+	// Response sets fields of the mocked response as a Python dict.
+	Response string
+	// Get is the expected request of the read in a read-modify-update.
+	Get string
+}
+
+// Prefix returns the command line prefix of the release track.
+func (t trackTest) Prefix() string {
+	if t.Track == "GA" {
+		return ""
+	}
+	return strings.ToLower(t.Track) + " "
+}
+
+// newTrackTest builds the test of cmd. It returns nil for commands the test
+// cannot describe: commands without a primary resource argument, and wait
+// commands, which poll until an operation is done.
+func newTrackTest(track string, groupPath []string, cmd *Command) *trackTest {
+	if cmd.Name == "wait" || len(cmd.Collection) == 0 {
+		return nil
+	}
+	var primary *Argument
+	for i := range cmd.Arguments {
+		if cmd.Arguments[i].IsPrimaryResource && cmd.Arguments[i].ResourceSpec != nil {
+			primary = &cmd.Arguments[i]
+			break
+		}
+	}
+	if primary == nil {
+		return nil
+	}
+
+	var words []string
+	for _, p := range groupPath {
+		words = append(words, strcase.ToKebab(p))
+	}
+	words = append(words, strcase.ToKebab(cmd.Name))
+
+	apiName, collection, _ := strings.Cut(cmd.Collection[0], ".")
+	method := cmd.Method
+	if method == "" {
+		method = standardRequestMethods[cmd.Name]
+	}
+
+	request := pyDict{}
+	var args []string
+	values := map[string]string{}
+	attrs := primary.ResourceSpec.Attributes
+	for i, attr := range attrs {
+		value := attributeValue(attr)
+		switch {
+		case attr.Property != "":
+			// The value comes from the property set by the test harness.
+		case i == len(attrs)-1 && primary.IsPositional:
+			args = slices.Insert(args, 0, value)
+		default:
+			args = append(args, fmt.Sprintf("--%s=%s", strcase.ToKebab(attr.AttributeName), value))
+		}
+		values[resourceLiteral(attr)] = value
+	}
+	addResourceFields(request, cmd, primary)
+
+	// A read-modify-update first reads the resource the command updates.
+	var get string
+	if cmd.ReadModifyUpdate {
+		get = request.String()
+	}
+
+	var maskPaths []string
+	for _, arg := range flagArguments(cmd.Arguments) {
+		flag, value := flagValue(arg)
+		args = append(args, flag)
+		request.set(arg.APIField, value)
+		if _, path, ok := strings.Cut(arg.APIField, "."); ok {
+			maskPaths = append(maskPaths, path)
+		}
+	}
+
+	if cmd.ReadModifyUpdate {
+		switch {
+		case cmd.StarUpdateMask:
+			request["updateMask"] = "*"
+		case len(maskPaths) > 0:
+			slices.Sort(maskPaths)
+			request["updateMask"] = strings.Join(maskPaths, ",")
+		}
+	}
+
+	response := pyDict{}
+	if cmd.Async != nil {
+		args = append(args, "--async")
+		response["name"] = operationName(cmd.Async, values)
+	}
+
+	return &trackTest{
+		Track:      track,
+		Command:    strings.Join(words, " "),
+		Args:       strings.Join(args, " "),
+		APIName:    apiName,
+		APIVersion: cmd.APIVersion,
+		Service:    strings.ReplaceAll(collection, ".", "_"),
+		Method:     strcase.ToCamel(method),
+		Request:    request.String(),
+		Response:   response.String(),
+		Get:        get,
+	}
+}
+
+// addResourceFields sets the request fields for the primary resource. For
+// Discovery APIs, each attribute is a request field. Otherwise the resource
+// is sent as a relative name in the name field, or the parent field for
+// collection commands.
+func addResourceFields(request pyDict, cmd *Command, primary *Argument) {
+	attrs := primary.ResourceSpec.Attributes
+	if isDiscoveryResource(primary.ResourceSpec) {
+		n := len(attrs)
+		if primary.RequestIDField != "" {
+			n--
+		}
+		for _, attr := range attrs[:n] {
+			request[attr.ParameterName] = attributeValue(attr)
+		}
+		if primary.RequestIDField != "" {
+			request.set(primary.RequestIDField, attributeValue(attrs[n]))
+		}
+		return
+	}
+
+	var parts []string
+	for _, attr := range attrs {
+		parts = append(parts, resourceLiteral(attr), attributeValue(attr))
+	}
+	switch {
+	case primary.RequestIDField != "" && len(parts) >= 2:
+		request["parent"] = strings.Join(parts[:len(parts)-2], "/")
+		request.set(primary.RequestIDField, parts[len(parts)-1])
+	case cmd.Name == "list" || cmd.Name == "create":
+		request["parent"] = strings.Join(parts, "/")
+	default:
+		request["name"] = strings.Join(parts, "/")
+	}
+}
+
+// isDiscoveryResource reports whether the resource is addressed by separate
+// path parameters, as in Discovery APIs, rather than by a relative name.
+func isDiscoveryResource(spec *ResourceSpec) bool {
+	for _, attr := range spec.Attributes {
+		if attr.ParameterName != attr.AttributeName {
+			return false
+		}
+	}
+	return len(spec.Attributes) > 0
+}
+
+// resourceLiteral returns the collection literal that precedes the
+// attribute in a relative name, for example projects for projectsId.
+func resourceLiteral(attr Attribute) string {
+	return strings.TrimSuffix(attr.ParameterName, "Id")
+}
+
+func attributeValue(attr Attribute) string {
+	if attr.Property == "core/project" {
+		return testProject
+	}
+	return testValue(attr.AttributeName)
+}
+
+func testValue(name string) string {
+	return "my-" + strcase.ToKebab(name)
+}
+
+// flagArguments returns the scalar flags of a command, including those in
+// argument groups. Flags with choices, maps, lists or resource references are
+// left out of the baseline test.
+func flagArguments(args []Argument) []Argument {
+	var flags []Argument
+	for _, arg := range args {
+		if len(arg.Params) > 0 {
+			flags = append(flags, flagArguments(arg.Params)...)
+			continue
+		}
+		if arg.IsPrimaryResource || arg.IsPositional || arg.ResourceSpec != nil || arg.Repeated || arg.APIField == "" {
+			continue
+		}
+		switch arg.Type {
+		case "str", "int", "long", "bool":
+			if len(arg.Choices) == 0 {
+				flags = append(flags, arg)
+			}
+		}
+	}
+	return flags
+}
+
+// flagValue returns the command line flag for arg and the value gcloud sets
+// in the request.
+func flagValue(arg Argument) (string, any) {
+	switch arg.Type {
+	case "bool":
+		return "--" + arg.ArgName, true
+	case "int", "long":
+		return "--" + arg.ArgName + "=1", 1
+	default:
+		value := testValue(arg.ArgName)
+		return fmt.Sprintf("--%s=%s", arg.ArgName, value), value
+	}
+}
+
+// operationName returns the name of the operation returned by an async
+// command. For relative names, the parent of the operation is taken from the
+// attributes of the resource, keyed by collection literal.
+func operationName(async *Async, values map[string]string) string {
+	if len(async.OperationGetMethodParams) > 0 || len(async.Collection) == 0 {
+		return "my-operation"
+	}
+	literals := strings.Split(async.Collection[0], ".")[1:]
+	var parts []string
+	for _, lit := range literals {
+		value, ok := values[lit]
+		if !ok {
+			value = testValue(strings.TrimSuffix(lit, "s"))
+		}
+		parts = append(parts, lit, value)
+	}
+	return strings.Join(parts, "/")
+}
+
+// pyDict is a JSON-like value rendered as a Python dict literal. Nested
+// messages are nested pyDicts.
+type pyDict map[string]any
+
+// set sets the value at a dotted field path, creating nested dicts as needed.
+func (d pyDict) set(path string, value any) {
+	head, rest, nested := strings.Cut(path, ".")
+	if !nested {
+		d[head] = value
+		return
+	}
+	child, ok := d[head].(pyDict)
+	if !ok {
+		child = pyDict{}
+		d[head] = child
+	}
+	child.set(rest, value)
+}
+
+// String renders d with sorted keys, so the generated tests are stable.
+func (d pyDict) String() string {
+	var sb strings.Builder
+	sb.WriteString("{")
+	for i, key := range slices.Sorted(maps.Keys(d)) {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "%s: %s", pyString(key), pyValue(d[key]))
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+func pyValue(v any) string {
+	switch v := v.(type) {
+	case pyDict:
+		return v.String()
+	case string:
+		return pyString(v)
+	case bool:
+		if v {
+			return "True"
+		}
+		return "False"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+var pyStringReplacer = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+func pyString(s string) string {
+	return "'" + pyStringReplacer.Replace(s) + "'"
+}
+
+var testPackageTemplate = template.Must(template.New("__init__.py").Parse(licenseTemplate))
+
+var commandTestTemplate = template.Must(template.New("test.py").Parse(autogenTemplate + `"""Tests for the generated ` + "`gcloud {{.Command}}`" + ` command."""
+
+from __future__ import absolute_import
+from __future__ import division
+from __future__ import unicode_literals
+
+from apitools.base.py import encoding
+from apitools.base.py.testing import mock
+from googlecloudsdk.api_lib.util import apis
+from tests.lib import cli_test_base
+from tests.lib import sdk_test_base
+from tests.lib import test_case
+
+
+class _{{.ClassName}}TestBase(sdk_test_base.WithFakeAuth, cli_test_base.CliTestBase):
+  """Mocks the API client and expects the requests built by the command."""
+
+  def MockClient(self, api_name, api_version):
+    if not api_version:
+      api_version = apis.ResolveVersion(api_name)
+    self.client = mock.Client(
+        client_class=apis.GetClientClass(api_name, api_version))
+    self.client.Mock()
+    self.addCleanup(self.client.Unmock)
+    self.messages = apis.GetMessagesModule(api_name, api_version)
+
+  def Expect(self, service, method, request, response=None):
+    client_service = getattr(self.client, service)
+    config = client_service.GetMethodConfig(method)
+    request_type = getattr(self.messages, config.request_type_name)
+    response_type = getattr(self.messages, config.response_type_name)
+    getattr(client_service, method).Expect(
+        encoding.PyValueToMessage(request_type, request),
+        encoding.PyValueToMessage(response_type, response or {}))
+{{range .Tracks}}
+
+class {{$.ClassName}}Test{{.Track}}(_{{$.ClassName}}TestBase):
+
+  def SetUp(self):
+    self.MockClient('{{.APIName}}', '{{.APIVersion}}')
+
+  def test{{$.ClassName}}(self):
+{{- if .Get}}
+    self.Expect('{{.Service}}', 'Get', {{.Get}})
+{{- end}}
+    self.Expect(
+        '{{.Service}}', '{{.Method}}',
+        {{.Request}},
+        {{.Response}})
+    self.Run('{{.Prefix}}{{.Command}} {{.Args}}')
+{{end}}
+
+if __name__ == '__main__':
+  test_case.main()
+`))
@@ -0,0 +1,264 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcloud
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func testInstanceArgument(positional bool, attrs ...Attribute) Argument {
+	return Argument{
+		IsPositional:      positional,
+		IsPrimaryResource: true,
+		Required:          true,
+		ResourceSpec: &ResourceSpec{
+			Name:       "instance",
+			PluralName: "instances",
+			Collection: "test.projects.locations.instances",
+			Attributes: attrs,
+		},
+	}
+}
+
+func TestNewTrackTest(t *testing.T) {
+	project := Attribute{AttributeName: "project", ParameterName: "projectsId", Property: "core/project"}
+	location := Attribute{AttributeName: "location", ParameterName: "locationsId"}
+	instance := Attribute{AttributeName: "instance", ParameterName: "instancesId"}
+	groupPath := []string{"test", "instances"}
+
+	for _, test := range []struct {
+		name string
+		cmd  *Command
+		want *trackTest
+	}{
+		{
+			name: "describe",
+			cmd: &Command{
+				Name:       "describe",
+				APIVersion: "v1",
+				Collection: []string{"test.projects.locations.instances"},
+				Arguments:  []Argument{testInstanceArgument(true, project, location, instance)},
+			},
+			want: &trackTest{
+				Track:      "GA",
+				Command:    "test instances describe",
+				Args:       "my-instance --location=my-location",
+				APIName:    "test",
+				APIVersion: "v1",
+				Service:    "projects_locations_instances",
+				Method:     "Get",
+				Request:    "{'name': 'projects/fake-project/locations/my-location/instances/my-instance'}",
+				Response:   "{}",
+			},
+		},
+		{
+			name: "list",
+			cmd: &Command{
+				Name:       "list",
+				Collection: []string{"test.projects.locations.instances"},
+				Arguments:  []Argument{testInstanceArgument(false, project, location)},
+			},
+			want: &trackTest{
+				Track:    "GA",
+				Command:  "test instances list",
+				Args:     "--location=my-location",
+				APIName:  "test",
+				Service:  "projects_locations_instances",
+				Method:   "List",
+				Request:  "{'parent': 'projects/fake-project/locations/my-location'}",
+				Response: "{}",
+			},
+		},
+		{
+			name: "async create",
+			cmd: &Command{
+				Name:       "create",
+				Collection: []string{"test.projects.locations.instances"},
+				Arguments: []Argument{
+					func() Argument {
+						arg := testInstanceArgument(true, project, location, instance)
+						arg.RequestIDField = "instanceId"
+						return arg
+					}(),
+					{ArgName: "description", APIField: "instance.description", Type: "str"},
+					{ArgName: "labels", APIField: "instance.labels", Repeated: true, Spec: []ArgSpec{{APIField: "key"}, {APIField: "value"}}},
+				},
+				Async: &Async{Collection: []string{"test.projects.locations.operations"}},
+			},
+			want: &trackTest{
+				Track:    "GA",
+				Command:  "test instances create",
+				Args:     "my-instance --location=my-location --description=my-description --async",
+				APIName:  "test",
+				Service:  "projects_locations_instances",
+				Method:   "Create",
+				Request:  "{'instance': {'description': 'my-description'}, 'instanceId': 'my-instance', 'parent': 'projects/fake-project/locations/my-location'}",
+				Response: "{'name': 'projects/fake-project/locations/my-location/operations/my-operation'}",
+			},
+		},
+		{
+			name: "read modify update",
+			cmd: &Command{
+				Name:       "update",
+				Collection: []string{"test.projects.locations.instances"},
+				Arguments: []Argument{
+					testInstanceArgument(true, project, location, instance),
+					{
+						ArgName: "network",
+						Params: []Argument{
+							{ArgName: "network-enabled", APIField: "instance.network.enabled", Type: "bool", Action: "store_true_false"},
+						},
+					},
+					{ArgName: "capacity-gib", APIField: "instance.capacityGib", Type: "long"},
+				},
+				ReadModifyUpdate: true,
+			},
+			want: &trackTest{
+				Track:    "GA",
+				Command:  "test instances update",
+				Args:     "my-instance --location=my-location --network-enabled --capacity-gib=1",
+				APIName:  "test",
+				Service:  "projects_locations_instances",
+				Method:   "Patch",
+				Request:  "{'instance': {'capacityGib': 1, 'network': {'enabled': True}}, 'name': 'projects/fake-project/locations/my-location/instances/my-instance', 'updateMask': 'capacityGib,network.enabled'}",
+				Response: "{}",
+				Get:      "{'name': 'projects/fake-project/locations/my-location/instances/my-instance'}",
+			},
+		},
+		{
+			name: "discovery insert",
+			cmd: &Command{
+				Name:       "create",
+				Method:     "insert",
+				Collection: []string{"compute.instances"},
+				Arguments: []Argument{
+					func() Argument {
+						arg := testInstanceArgument(true,
+							Attribute{AttributeName: "project", ParameterName: "project", Property: "core/project"},
+							Attribute{AttributeName: "zone", ParameterName: "zone"},
+							Attribute{AttributeName: "instance", ParameterName: "instance"},
+						)
+						arg.RequestIDField = "instance.name"
+						return arg
+					}(),
+				},
+				Async: &Async{
+					Collection:               []string{"compute.zoneOperations"},
+					OperationGetMethodParams: map[string]string{"project": "project", "zone": "zone"},
+				},
+			},
+			want: &trackTest{
+				Track:    "GA",
+				Command:  "test instances create",
+				Args:     "my-instance --zone=my-zone --async",
+				APIName:  "compute",
+				Service:  "instances",
+				Method:   "Insert",
+				Request:  "{'instance': {'name': 'my-instance'}, 'project': 'fake-project', 'zone': 'my-zone'}",
+				Response: "{'name': 'my-operation'}",
+			},
+		},
+		{
+			name: "wait",
+			cmd: &Command{
+				Name:       "wait",
+				Collection: []string{"test.projects.locations.operations"},
+				Arguments:  []Argument{testInstanceArgument(true, project, location, instance)},
+			},
+		},
+		{
+			name: "no primary resource",
+			cmd: &Command{
+				Name:       "describe",
+				Collection: []string{"test.projects.locations.instances"},
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := newTrackTest("GA", groupPath, test.cmd)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWriteSurfaceTests(t *testing.T) {
+	describe := &Command{
+		Name:       "describe",
+		Collection: []string{"test.projects.instances"},
+		Arguments: []Argument{testInstanceArgument(true,
+			Attribute{AttributeName: "project", ParameterName: "projectsId", Property: "core/project"},
+			Attribute{AttributeName: "instance", ParameterName: "instancesId"},
+		)},
+	}
+	group := func() *CommandGroup {
+		return &CommandGroup{
+			Name: "test",
+			Path: []string{"test"},
+			Groups: map[string]*CommandGroup{
+				"instances": {
+					Name:     "instances",
+					Path:     []string{"test", "instances"},
+					Commands: map[string]*Command{"describe": describe},
+				},
+			},
+		}
+	}
+	tree := &CommandGroupsByTrack{GA: group(), BETA: group()}
+
+	dir := t.TempDir()
+	if err := writeSurfaceTests(dir, tree); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "test", "instances", "__init__.py")); err != nil {
+		t.Error(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "test", "__init__.py")); err == nil {
+		t.Error("wrote __init__.py for a group without commands")
+	}
+	got, err := os.ReadFile(filepath.Join(dir, "test", "instances", "describe_test.py"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"class DescribeTestGA(_DescribeTestBase):",
+		"class DescribeTestBETA(_DescribeTestBase):",
+		"self.Run('test instances describe my-instance')",
+		"self.Run('beta test instances describe my-instance')",
+		"{'name': 'projects/fake-project/instances/my-instance'}",
+	} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("describe_test.py does not contain %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestPyDict(t *testing.T) {
+	d := pyDict{}
+	d.set("b.c", "it's")
+	d.set("b.d", true)
+	d.set("a", 1)
+	want := `{'a': 1, 'b': {'c': 'it\'s', 'd': True}}`
+	if diff := cmp.Diff(want, d.String()); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -67,6 +67,23 @@ func TestGenerateService(t *testing.T) {
 	verifyElementOutput(t, outDir)
 }
 
+func TestGenerateMethod(t *testing.T) {
+	method := &api.Method{
+		Name: "ExpectedName",
+	}
+	outDir := t.TempDir()
+
+	gen := GeneratedFile{
+		TemplatePath: "testTemplates/test002.mustache",
+		OutputPath:   "test002.txt",
+	}
+	err := GenerateMethod(outDir, method, provider, gen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifyElementOutput(t, outDir)
+}
+
 func TestGenerateMessage(t *testing.T) {
 	message := &api.Message{
 		Name: "ExpectedName",
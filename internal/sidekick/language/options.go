@@ -0,0 +1,291 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate go run -tags codecoptionsdocgen ../../../cmd/codec_options_doc_generate.go -output ../../../doc/codec-options.md
+
+package language
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrUnknownOption is returned when a codec option is not part of the codec's schema.
+	ErrUnknownOption = errors.New("unknown codec option")
+	// ErrInvalidOptionValue is returned when the value of a codec option does not match its type.
+	ErrInvalidOptionValue = errors.New("invalid codec option value")
+)
+
+// OptionType is the type of the value for a codec option.
+type OptionType int
+
+const (
+	// StringOption accepts any value.
+	StringOption OptionType = iota
+	// BoolOption accepts the values accepted by [strconv.ParseBool].
+	BoolOption
+	// ListOption accepts a comma-separated list of values.
+	ListOption
+)
+
+// String returns the name of the type, as used in the reference documentation.
+func (t OptionType) String() string {
+	switch t {
+	case BoolOption:
+		return "bool"
+	case ListOption:
+		return "list"
+	default:
+		return "string"
+	}
+}
+
+// Option describes a single codec option.
+type Option struct {
+	// Name is the option key, such as `copyright-year`.
+	//
+	// Names ending in `:`, such as `package:`, are prefixes. They match any key
+	// starting with the prefix, such as `package:tokio`.
+	Name string
+	// Type is the type of the option value.
+	Type OptionType
+	// Values, if not empty, lists the allowed values for the option.
+	Values []string
+	// Doc is a short description of the option, used in the reference
+	// documentation.
+	Doc string
+}
+
+// IsPrefix returns true if the option matches all the keys with a given prefix.
+func (o *Option) IsPrefix() bool {
+	return strings.HasSuffix(o.Name, ":")
+}
+
+func (o *Option) matches(key string) bool {
+	if o.IsPrefix() {
+		return strings.HasPrefix(key, o.Name) && len(key) > len(o.Name)
+	}
+	return o.Name == key
+}
+
+func (o *Option) check(value string) error {
+	if o.Type == BoolOption {
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("expected a boolean, got %q", value)
+		}
+	}
+	if len(o.Values) != 0 && !slices.Contains(o.Values, value) {
+		return fmt.Errorf("expected one of %s, got %q", strings.Join(o.Values, ", "), value)
+	}
+	return nil
+}
+
+// OptionSchema describes the options accepted by a codec.
+type OptionSchema struct {
+	// Codec is the name of the codec, such as `rust`.
+	Codec string
+	// Options are the options accepted by the codec.
+	Options []Option
+	// Extends lists other schemas whose options are also accepted by the
+	// codec. For example, `rust_prost` receives the same options as `rust`.
+	Extends []*OptionSchema
+}
+
+// Lookup returns the option matching key, or nil if there is no such option.
+func (s *OptionSchema) Lookup(key string) *Option {
+	for i := range s.Options {
+		if s.Options[i].matches(key) {
+			return &s.Options[i]
+		}
+	}
+	for _, parent := range s.Extends {
+		if o := parent.Lookup(key); o != nil {
+			return o
+		}
+	}
+	return nil
+}
+
+// Validate returns an error if options contains keys not in the schema, or
+// values that do not match the type of their option.
+//
+// The error for unknown keys suggests the closest known option, if any.
+func (s *OptionSchema) Validate(options map[string]string) error {
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(options)) {
+		o := s.Lookup(key)
+		if o == nil {
+			if suggestion := s.suggest(key); suggestion != "" {
+				errs = append(errs, fmt.Errorf("%w %q in %s codec, did you mean %q?", ErrUnknownOption, key, s.Codec, suggestion))
+			} else {
+				errs = append(errs, fmt.Errorf("%w %q in %s codec", ErrUnknownOption, key, s.Codec))
+			}
+			continue
+		}
+		if err := o.check(options[key]); err != nil {
+			errs = append(errs, fmt.Errorf("%w for %q in %s codec: %w", ErrInvalidOptionValue, key, s.Codec, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Decode validates options and stores their values in the struct pointed to
+// by dst.
+//
+// Fields are bound to options with an `option` struct tag, such as
+// `option:"copyright-year"`. String options decode into `string` fields,
+// boolean options into `bool` fields, and list options into `[]string`
+// fields. Prefix options, such as `package:`, decode into `map[string]string`
+// fields keyed by the remainder of the key.
+//
+// Fields for absent options keep their value, so callers initialize dst with
+// the defaults. Every option in the schema must have a field in dst, while the
+// options from the schemas it extends are only decoded if dst has a field for
+// them.
+func (s *OptionSchema) Decode(options map[string]string, dst any) error {
+	if err := s.Validate(options); err != nil {
+		return err
+	}
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("cannot decode %s codec options into %T, expected a pointer to a struct", s.Codec, dst)
+	}
+	v = v.Elem()
+	fields := map[string]reflect.Value{}
+	for i := range v.NumField() {
+		if name, ok := v.Type().Field(i).Tag.Lookup("option"); ok {
+			fields[name] = v.Field(i)
+		}
+	}
+	for _, o := range s.Options {
+		if _, ok := fields[o.Name]; !ok {
+			return fmt.Errorf("no field for option %q in %T", o.Name, dst)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(options)) {
+		o := s.Lookup(key)
+		field, ok := fields[o.Name]
+		if !ok {
+			continue
+		}
+		if err := o.decode(key, options[key], field); err != nil {
+			return fmt.Errorf("cannot decode %q in %s codec: %w", key, s.Codec, err)
+		}
+	}
+	return nil
+}
+
+func (o *Option) decode(key, value string, field reflect.Value) error {
+	var want reflect.Type
+	var got reflect.Value
+	switch {
+	case o.IsPrefix():
+		want = reflect.TypeFor[map[string]string]()
+		if field.Type() == want && field.IsNil() {
+			field.Set(reflect.MakeMap(want))
+		}
+	case o.Type == BoolOption:
+		// Validate already checked the value.
+		b, _ := strconv.ParseBool(value)
+		want, got = reflect.TypeFor[bool](), reflect.ValueOf(b)
+	case o.Type == ListOption:
+		want, got = reflect.TypeFor[[]string](), reflect.ValueOf(splitList(value))
+	default:
+		want, got = reflect.TypeFor[string](), reflect.ValueOf(value)
+	}
+	if field.Type() != want {
+		return fmt.Errorf("field has type %s, want %s", field.Type(), want)
+	}
+	if o.IsPrefix() {
+		field.SetMapIndex(reflect.ValueOf(strings.TrimPrefix(key, o.Name)), reflect.ValueOf(value))
+		return nil
+	}
+	field.Set(got)
+	return nil
+}
+
+// splitList returns the elements of a comma-separated list option.
+func splitList(value string) []string {
+	if value == "" {
+		return []string{}
+	}
+	list := strings.Split(value, ",")
+	for i := range list {
+		list[i] = strings.TrimSpace(list[i])
+	}
+	return list
+}
+
+// names returns the names of all the options in the schema, including the
+// options from the schemas it extends.
+func (s *OptionSchema) names() []string {
+	var names []string
+	for _, o := range s.Options {
+		names = append(names, o.Name)
+	}
+	for _, parent := range s.Extends {
+		names = append(names, parent.names()...)
+	}
+	return names
+}
+
+// suggest returns the option name closest to key, or an empty string if no
+// option is close enough to be a likely typo.
+func (s *OptionSchema) suggest(key string) string {
+	best, bestDistance := "", 0
+	for _, name := range s.names() {
+		candidate := key
+		if strings.HasSuffix(name, ":") {
+			// Only compare the prefix part of keys such as `pakage:tokio`.
+			if i := strings.Index(key, ":"); i != -1 {
+				candidate = key[:i+1]
+			}
+		}
+		d := editDistance(candidate, name)
+		if best == "" || d < bestDistance {
+			best, bestDistance = name, d
+		}
+	}
+	if best == "" || bestDistance > max(2, len(key)/3) {
+		return ""
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
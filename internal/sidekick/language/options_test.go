@@ -0,0 +1,191 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package language
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var testSchema = &OptionSchema{
+	Codec: "test",
+	Options: []Option{
+		{Name: "copyright-year", Type: StringOption},
+		{Name: "skip-format", Type: BoolOption},
+		{Name: "extra-modules", Type: ListOption},
+		{Name: "transport", Type: StringOption, Values: []string{"rest", "grpc"}},
+		{Name: "package:", Type: StringOption},
+	},
+	Extends: []*OptionSchema{
+		{Codec: "base", Options: []Option{{Name: "root-name", Type: StringOption}}},
+	},
+}
+
+func TestOptionSchema_Validate(t *testing.T) {
+	err := testSchema.Validate(map[string]string{
+		"copyright-year": "2026",
+		"skip-format":    "true",
+		"extra-modules":  "a,b",
+		"transport":      "grpc",
+		"package:tokio":  "version=1",
+		"root-name":      "googleapis",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOptionSchema_Validate_Error(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		options map[string]string
+		wantErr error
+		wantMsg string
+	}{
+		{"typo", map[string]string{"copyright-yaer": "2026"}, ErrUnknownOption, `did you mean "copyright-year"?`},
+		{"prefix typo", map[string]string{"pakage:tokio": "1"}, ErrUnknownOption, `did you mean "package:"?`},
+		{"empty prefix", map[string]string{"package:": "1"}, ErrUnknownOption, `"package:"`},
+		{"no suggestion", map[string]string{"completely-unrelated": "x"}, ErrUnknownOption, `"completely-unrelated" in test codec`},
+		{"bad bool", map[string]string{"skip-format": "maybe"}, ErrInvalidOptionValue, "expected a boolean"},
+		{"bad value", map[string]string{"transport": "http"}, ErrInvalidOptionValue, "expected one of rest, grpc"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := testSchema.Validate(test.options)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("Validate() error = %v, want %v", err, test.wantErr)
+			}
+			if !strings.Contains(err.Error(), test.wantMsg) {
+				t.Errorf("Validate() error = %q, want it to contain %q", err, test.wantMsg)
+			}
+		})
+	}
+}
+
+func TestOptionSchema_Validate_NoSuggestion(t *testing.T) {
+	err := testSchema.Validate(map[string]string{"completely-unrelated": "x"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if strings.Contains(err.Error(), "did you mean") {
+		t.Errorf("unexpected suggestion in %q", err)
+	}
+}
+
+type testOptions struct {
+	CopyrightYear string            `option:"copyright-year"`
+	SkipFormat    bool              `option:"skip-format"`
+	ExtraModules  []string          `option:"extra-modules"`
+	Transport     string            `option:"transport"`
+	Packages      map[string]string `option:"package:"`
+}
+
+func TestOptionSchema_Decode(t *testing.T) {
+	got := &testOptions{CopyrightYear: "2025", Transport: "rest"}
+	err := testSchema.Decode(map[string]string{
+		"skip-format":   "true",
+		"extra-modules": "a, b",
+		"package:tokio": "version=1",
+		"root-name":     "googleapis",
+	}, got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &testOptions{
+		CopyrightYear: "2025",
+		SkipFormat:    true,
+		ExtraModules:  []string{"a", "b"},
+		Transport:     "rest",
+		Packages:      map[string]string{"tokio": "version=1"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestOptionSchema_Decode_Error(t *testing.T) {
+	type missingField struct {
+		CopyrightYear string `option:"copyright-year"`
+	}
+	type wrongType struct {
+		CopyrightYear string            `option:"copyright-year"`
+		SkipFormat    string            `option:"skip-format"`
+		ExtraModules  []string          `option:"extra-modules"`
+		Transport     string            `option:"transport"`
+		Packages      map[string]string `option:"package:"`
+	}
+	for _, test := range []struct {
+		name    string
+		options map[string]string
+		dst     any
+		wantMsg string
+	}{
+		{"invalid option", map[string]string{"skip-format": "maybe"}, &testOptions{}, "expected a boolean"},
+		{"not a pointer", map[string]string{}, testOptions{}, "expected a pointer to a struct"},
+		{"missing field", map[string]string{}, &missingField{}, `no field for option "skip-format"`},
+		{"wrong type", map[string]string{"skip-format": "true"}, &wrongType{}, "field has type string, want bool"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := testSchema.Decode(test.options, test.dst)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), test.wantMsg) {
+				t.Errorf("Decode() error = %q, want it to contain %q", err, test.wantMsg)
+			}
+		})
+	}
+}
+
+func TestOptionSchema_Lookup(t *testing.T) {
+	for _, test := range []struct {
+		key  string
+		want string
+	}{
+		{"copyright-year", "copyright-year"},
+		{"package:tokio", "package:"},
+		{"root-name", "root-name"},
+		{"unknown", ""},
+	} {
+		t.Run(test.key, func(t *testing.T) {
+			got := ""
+			if o := testSchema.Lookup(test.key); o != nil {
+				got = o.Name
+			}
+			if got != test.want {
+				t.Errorf("Lookup(%q) = %q, want %q", test.key, got, test.want)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	for _, test := range []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"copyright-yaer", "copyright-year", 2},
+	} {
+		if got := editDistance(test.a, test.b); got != test.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", test.a, test.b, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package language
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// TemplatesRoot is the directory containing the embedded templates in each
+// codec.
+const TemplatesRoot = "templates"
+
+// OverlayTemplates returns a filesystem where the files in dir shadow the
+// templates embedded in base.
+//
+// The overlay directory mirrors the layout of the embedded `templates`
+// directory. For example, `$dir/crate/src/lib.rs.mustache` replaces
+// `templates/crate/src/lib.rs.mustache`. Files that only exist in the overlay
+// are added to the embedded templates, so [WalkTemplatesDir] includes them in
+// the generated files.
+//
+// If dir is empty, OverlayTemplates returns base.
+func OverlayTemplates(base fs.FS, dir string) fs.FS {
+	if dir == "" {
+		return base
+	}
+	return &overlayFS{base: base, overlay: os.DirFS(dir)}
+}
+
+// TemplatesProvider returns a [TemplateProvider] reading templates from fsys.
+func TemplatesProvider(fsys fs.FS) TemplateProvider {
+	return func(name string) (string, error) {
+		contents, err := fs.ReadFile(fsys, filepath.ToSlash(name))
+		if err != nil {
+			return "", err
+		}
+		return string(contents), nil
+	}
+}
+
+type overlayFS struct {
+	base    fs.FS
+	overlay fs.FS
+}
+
+// overlayPath returns the path of name in the overlay directory, if name is
+// in the templates directory.
+func overlayPath(name string) (string, bool) {
+	if name == TemplatesRoot {
+		return ".", true
+	}
+	return strings.CutPrefix(name, TemplatesRoot+"/")
+}
+
+// Open opens the file in the overlay, if it exists, and the embedded file
+// otherwise. Directories are always opened from the embedded templates when
+// possible, use [fs.ReadDir] to list the merged contents.
+func (o *overlayFS) Open(name string) (fs.File, error) {
+	if path, ok := overlayPath(name); ok {
+		f, err := o.overlay.Open(path)
+		if err == nil {
+			info, err := f.Stat()
+			if err == nil && !info.IsDir() {
+				return f, nil
+			}
+			f.Close()
+		}
+	}
+	f, err := o.base.Open(name)
+	if err == nil || !errors.Is(err, fs.ErrNotExist) {
+		return f, err
+	}
+	if path, ok := overlayPath(name); ok {
+		return o.overlay.Open(path)
+	}
+	return nil, err
+}
+
+// ReadDir returns the union of the embedded and overlay entries in name,
+// sorted by filename. Overlay entries replace embedded entries with the same
+// name.
+func (o *overlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries, err := fs.ReadDir(o.base, name)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	path, ok := overlayPath(name)
+	if !ok {
+		return entries, err
+	}
+	overlay, oerr := fs.ReadDir(o.overlay, path)
+	if oerr != nil {
+		if errors.Is(oerr, fs.ErrNotExist) {
+			return entries, err
+		}
+		return nil, oerr
+	}
+	for _, entry := range overlay {
+		index := slices.IndexFunc(entries, func(e fs.DirEntry) bool { return e.Name() == entry.Name() })
+		if index == -1 {
+			entries = append(entries, entry)
+		} else if !entry.IsDir() {
+			entries[index] = entry
+		}
+	}
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int { return strings.Compare(a.Name(), b.Name()) })
+	return entries, nil
+}
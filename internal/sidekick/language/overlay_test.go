@@ -0,0 +1,98 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package language
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOverlayTemplates(t *testing.T) {
+	base := fstest.MapFS{
+		"templates/crate/Cargo.toml.mustache":  {Data: []byte("base cargo")},
+		"templates/crate/src/lib.rs.mustache":  {Data: []byte("base lib {{> partial}}")},
+		"templates/crate/src/partial.mustache": {Data: []byte("base partial")},
+	}
+	dir := t.TempDir()
+	for name, contents := range map[string]string{
+		"crate/src/partial.mustache":      "overlay partial",
+		"crate/src/extra.rs.mustache":     "overlay extra",
+		"crate/examples/main.rs.mustache": "overlay example",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fsys := OverlayTemplates(base, dir)
+
+	provider := TemplatesProvider(fsys)
+	for _, test := range []struct {
+		name string
+		want string
+	}{
+		{"templates/crate/Cargo.toml.mustache", "base cargo"},
+		{"templates/crate/src/partial.mustache", "overlay partial"},
+		{"templates/crate/src/extra.rs.mustache", "overlay extra"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := provider(test.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("provider(%q) = %q, want %q", test.name, got, test.want)
+			}
+		})
+	}
+
+	got := WalkTemplatesDir(fsys, "templates/crate")
+	want := []GeneratedFile{
+		{TemplatePath: "templates/crate/Cargo.toml.mustache", OutputPath: "/Cargo.toml"},
+		{TemplatePath: "templates/crate/examples/main.rs.mustache", OutputPath: "/examples/main.rs"},
+		{TemplatePath: "templates/crate/src/extra.rs.mustache", OutputPath: "/src/extra.rs"},
+		{TemplatePath: "templates/crate/src/lib.rs.mustache", OutputPath: "/src/lib.rs"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestOverlayTemplates_NoOverlay(t *testing.T) {
+	base := fstest.MapFS{
+		"templates/readme/README.md.mustache": {Data: []byte("readme")},
+	}
+	got := OverlayTemplates(base, "")
+	if diff := cmp.Diff(base, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestOverlayTemplates_Missing(t *testing.T) {
+	base := fstest.MapFS{
+		"templates/readme/README.md.mustache": {Data: []byte("readme")},
+	}
+	fsys := OverlayTemplates(base, t.TempDir())
+	if _, err := TemplatesProvider(fsys)("templates/readme/missing.mustache"); err == nil {
+		t.Errorf("expected an error for a missing template")
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package language
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/iancoleman/strcase"
+)
+
+// ResourceName describes a resource name helper type.
+//
+// The codecs generate one helper type per resource. Resources with a single
+// pattern map to a struct (or class), resources with multiple patterns map to
+// an enum (or sealed class) with one case per pattern.
+type ResourceName struct {
+	// Resource is the resource described by the helper.
+	Resource *api.Resource
+	// Name is the name of the helper type, such as `SecretName`.
+	Name string
+	// Patterns are the patterns supported by the helper, in the same order as
+	// they appear in the resource definition.
+	Patterns []*ResourceNamePattern
+}
+
+// IsMultiPattern returns true if the resource has more than one pattern.
+func (r *ResourceName) IsMultiPattern() bool {
+	return len(r.Patterns) > 1
+}
+
+// ResourceNamePattern is a single pattern in a resource name helper.
+type ResourceNamePattern struct {
+	// Template is the pattern in its canonical form, such as
+	// `projects/{project}/secrets/{secret}`.
+	Template string
+	// Name distinguishes this pattern from other patterns of the same
+	// resource, such as `ProjectLocationSecret`.
+	Name string
+	// Segments are the segments in the pattern.
+	Segments []ResourceNameSegment
+	// Variables are the names of the variables in the pattern, in order.
+	Variables []string
+	// WildcardParent is the parent collection with all the variables replaced
+	// by `-`, such as `projects/-`. Empty if the pattern has no parent.
+	WildcardParent string
+}
+
+// ResourceNameSegment is a single segment in a resource name pattern. Exactly
+// one of the fields is set.
+type ResourceNameSegment struct {
+	Literal  string
+	Variable string
+}
+
+// ResourceNames returns the resource name helpers for a model.
+//
+// Only resources defined by messages in the model's package get helpers.
+// Resources defined by other packages, or only through
+// `google.api.resource_definition`, are owned by some other library.
+// Patterns that cannot be parsed and formatted one segment at a time, such as
+// patterns with `**` matchers, are skipped. Resources without any usable
+// pattern are skipped too.
+//
+// The helper names avoid any conflicts with the top-level messages and enums
+// in the model.
+func ResourceNames(model *api.API) []*ResourceName {
+	taken := map[string]bool{}
+	for _, m := range model.Messages {
+		taken[m.Name] = true
+	}
+	for _, e := range model.Enums {
+		taken[e.Name] = true
+	}
+	var resources []*api.Resource
+	var collect func(m *api.Message)
+	collect = func(m *api.Message) {
+		if m.Resource != nil && m.Package == model.PackageName {
+			resources = append(resources, m.Resource)
+		}
+		for _, child := range m.Messages {
+			collect(child)
+		}
+	}
+	for _, m := range model.Messages {
+		collect(m)
+	}
+	slices.SortFunc(resources, func(a, b *api.Resource) int { return strings.Compare(a.Type, b.Type) })
+	resources = slices.CompactFunc(resources, func(a, b *api.Resource) bool { return a.Type == b.Type })
+
+	var names []*ResourceName
+	for _, r := range resources {
+		patterns := resourceNamePatterns(r)
+		if len(patterns) == 0 {
+			continue
+		}
+		name := resourceNameTypeName(r, taken)
+		taken[name] = true
+		names = append(names, &ResourceName{Resource: r, Name: name, Patterns: patterns})
+	}
+	return names
+}
+
+// ResourceNameFor returns the helper for a resource type, or nil if there is
+// no helper.
+func ResourceNameFor(names []*ResourceName, typ string) *ResourceName {
+	idx := slices.IndexFunc(names, func(n *ResourceName) bool { return n.Resource.Type == typ })
+	if idx == -1 {
+		return nil
+	}
+	return names[idx]
+}
+
+func resourceNameTypeName(r *api.Resource, taken map[string]bool) string {
+	base := r.Singular
+	if base == "" {
+		base = r.Type[strings.LastIndex(r.Type, "/")+1:]
+	}
+	base = strcase.ToCamel(base)
+	for _, candidate := range []string{base + "Name", base + "ResourceName"} {
+		if !taken[candidate] {
+			return candidate
+		}
+	}
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%sResourceName%d", base, i)
+		if !taken[candidate] {
+			return candidate
+		}
+	}
+}
+
+func resourceNamePatterns(r *api.Resource) []*ResourceNamePattern {
+	var patterns []*ResourceNamePattern
+	names := map[string]bool{}
+	for _, p := range r.Patterns {
+		pattern := newResourceNamePattern(p)
+		if pattern == nil {
+			continue
+		}
+		name := pattern.Name
+		for i := 2; names[pattern.Name]; i++ {
+			pattern.Name = fmt.Sprintf("%s%d", name, i)
+		}
+		names[pattern.Name] = true
+		patterns = append(patterns, pattern)
+	}
+	return patterns
+}
+
+func newResourceNamePattern(p api.ResourcePattern) *ResourceNamePattern {
+	if len(p) == 0 {
+		return nil
+	}
+	pattern := &ResourceNamePattern{Template: p.String()}
+	for _, s := range p {
+		switch {
+		case s.Literal != nil:
+			pattern.Segments = append(pattern.Segments, ResourceNameSegment{Literal: *s.Literal})
+		case s.Variable != nil:
+			v := s.Variable
+			if len(v.FieldPath) != 1 || !slices.Equal(v.Segments, []string{api.SingleSegmentWildcard}) {
+				return nil
+			}
+			pattern.Segments = append(pattern.Segments, ResourceNameSegment{Variable: v.FieldPath[0]})
+			pattern.Variables = append(pattern.Variables, v.FieldPath[0])
+		default:
+			return nil
+		}
+	}
+	pattern.Name = resourceNamePatternName(pattern)
+	pattern.WildcardParent = wildcardParent(pattern.Segments)
+	return pattern
+}
+
+// resourceNamePatternName returns a name based on the variables, such as
+// `ProjectLocationSecret`. Patterns without variables use the literals.
+func resourceNamePatternName(p *ResourceNamePattern) string {
+	parts := p.Variables
+	if len(parts) == 0 {
+		for _, s := range p.Segments {
+			parts = append(parts, s.Literal)
+		}
+	}
+	var name strings.Builder
+	for _, part := range parts {
+		name.WriteString(strcase.ToCamel(part))
+	}
+	return name.String()
+}
+
+// wildcardParent returns the segments before the last collection, with all
+// variables replaced by `-`. For `projects/{project}/secrets/{secret}` this
+// is `projects/-`.
+func wildcardParent(segments []ResourceNameSegment) string {
+	last := len(segments) - 1
+	if last < 0 || segments[last].Variable == "" {
+		return ""
+	}
+	parent := segments[:last]
+	if len(parent) != 0 && parent[len(parent)-1].Literal != "" {
+		parent = parent[:len(parent)-1]
+	}
+	if len(parent) == 0 {
+		return ""
+	}
+	var parts []string
+	for _, s := range parent {
+		if s.Variable != "" {
+			parts = append(parts, "-")
+			continue
+		}
+		parts = append(parts, s.Literal)
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package language
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+func TestResourceNames(t *testing.T) {
+	secret := api.NewTestMessage("Secret").
+		WithResource(api.NewTestResource("test.googleapis.com/Secret").WithPatterns(
+			api.NewTestResourcePattern("projects", "project", "secrets", "secret"),
+			api.NewTestResourcePattern("projects", "project", "locations", "location", "secrets", "secret"),
+		))
+	project := api.NewTestMessage("Project").
+		WithResource(api.NewTestResource("test.googleapis.com/Project").WithPatterns(
+			api.NewTestResourcePattern("projects", "project"),
+		))
+	// Conflicts with the `Project` resource helper name.
+	projectName := api.NewTestMessage("ProjectName")
+	model := api.NewTestAPI([]*api.Message{secret, project, projectName}, []*api.Enum{}, []*api.Service{})
+
+	got := ResourceNames(model)
+	want := []*ResourceName{
+		{
+			Resource: project.Resource,
+			Name:     "ProjectResourceName",
+			Patterns: []*ResourceNamePattern{
+				{
+					Template:  "projects/{project}",
+					Name:      "Project",
+					Segments:  []ResourceNameSegment{{Literal: "projects"}, {Variable: "project"}},
+					Variables: []string{"project"},
+				},
+			},
+		},
+		{
+			Resource: secret.Resource,
+			Name:     "SecretName",
+			Patterns: []*ResourceNamePattern{
+				{
+					Template: "projects/{project}/secrets/{secret}",
+					Name:     "ProjectSecret",
+					Segments: []ResourceNameSegment{
+						{Literal: "projects"}, {Variable: "project"},
+						{Literal: "secrets"}, {Variable: "secret"},
+					},
+					Variables:      []string{"project", "secret"},
+					WildcardParent: "projects/-",
+				},
+				{
+					Template: "projects/{project}/locations/{location}/secrets/{secret}",
+					Name:     "ProjectLocationSecret",
+					Segments: []ResourceNameSegment{
+						{Literal: "projects"}, {Variable: "project"},
+						{Literal: "locations"}, {Variable: "location"},
+						{Literal: "secrets"}, {Variable: "secret"},
+					},
+					Variables:      []string{"project", "location", "secret"},
+					WildcardParent: "projects/-/locations/-",
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(ResourceName{}, "Resource")); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if got := ResourceNameFor(got, "test.googleapis.com/Secret"); got == nil || got.Resource != secret.Resource {
+		t.Errorf("ResourceNameFor() = %v, want %v", got, secret.Resource)
+	}
+	if got := ResourceNameFor(got, "test.googleapis.com/Missing"); got != nil {
+		t.Errorf("ResourceNameFor() = %v, want nil", got)
+	}
+}
+
+func TestResourceNames_Skipped(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		message *api.Message
+	}{
+		{
+			name:    "no patterns",
+			message: api.NewTestMessage("Widget").WithResource(api.NewTestResource("test.googleapis.com/Widget")),
+		},
+		{
+			name: "recursive match",
+			message: api.NewTestMessage("Widget").WithResource(api.NewTestResource("test.googleapis.com/Widget").WithPatterns(
+				api.ResourcePattern{
+					*(&api.PathSegment{}).WithLiteral("widgets"),
+					*(&api.PathSegment{}).WithVariable(api.NewPathVariable("widget").WithMatchRecursive()),
+				},
+			)),
+		},
+		{
+			name: "other package",
+			message: api.NewTestMessage("Widget").WithPackage("other").
+				WithResource(api.NewTestResource("other.googleapis.com/Widget").WithPatterns(
+					api.NewTestResourcePattern("widgets", "widget"),
+				)),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			model := api.NewTestAPI([]*api.Message{test.message}, []*api.Enum{}, []*api.Service{})
+			model.PackageName = "test"
+			if got := ResourceNames(model); len(got) != 0 {
+				t.Errorf("expected no resource names, got %v", got)
+			}
+		})
+	}
+}
+
+func TestWildcardParent(t *testing.T) {
+	for _, test := range []struct {
+		pattern api.ResourcePattern
+		want    string
+	}{
+		{api.NewTestResourcePattern("projects", "project"), ""},
+		{api.NewTestResourcePattern("projects", "project", "secrets", "secret"), "projects/-"},
+		{api.NewTestResourcePattern("projects", "project", "secrets", "secret", "versions", "version"), "projects/-/secrets/-"},
+		{api.ResourcePattern{*(&api.PathSegment{}).WithLiteral("settings")}, ""},
+	} {
+		t.Run(test.pattern.String(), func(t *testing.T) {
+			p := newResourceNamePattern(test.pattern)
+			if p.WildcardParent != test.want {
+				t.Errorf("wildcardParent(%q) = %q, want %q", test.pattern.String(), p.WildcardParent, test.want)
+			}
+		})
+	}
+}
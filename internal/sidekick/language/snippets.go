@@ -0,0 +1,109 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package language
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/snippetmetadata"
+)
+
+// SnippetSample describes a generated sample, used to create the snippet
+// metadata for a package.
+type SnippetSample struct {
+	Service *api.Service
+	// Method is nil for samples that demonstrate a service, such as
+	// quickstarts.
+	Method *api.Method
+	// ClientName is the name of the client type in the target language.
+	ClientName string
+	// MethodName is the name of the client method in the target language.
+	MethodName string
+	// RequestType is the type of the request parameter in the target
+	// language. It is empty if the client method has no request parameter.
+	RequestType string
+	// ResultType is the type returned by the client method in the target
+	// language.
+	ResultType string
+	// File is the path of the sample, relative to the package root.
+	File     string
+	Segments []*snippetmetadata.Segment
+}
+
+// NewSnippetIndex returns the snippet metadata for the samples generated from
+// model, in a library with the given name and version.
+func NewSnippetIndex(model *api.API, lang, name, version string, samples []*SnippetSample) *snippetmetadata.Index {
+	index := &snippetmetadata.Index{
+		ClientLibrary: &snippetmetadata.ClientLibrary{
+			APIs: []*snippetmetadata.API{
+				{ID: model.PackageName, Version: snippetmetadata.APIVersion(model.PackageName)},
+			},
+			Language: lang,
+			Name:     name,
+			Version:  version,
+		},
+		Snippets: []*snippetmetadata.Snippet{},
+	}
+	for _, s := range samples {
+		index.Snippets = append(index.Snippets, s.snippet(lang))
+	}
+	return index
+}
+
+func (s *SnippetSample) snippet(lang string) *snippetmetadata.Snippet {
+	service := s.Service
+	shortName, _, _ := strings.Cut(service.DefaultHost, ".")
+	var methodName string
+	if s.Method != nil {
+		methodName = s.Method.Name
+	}
+	regionTag := snippetmetadata.RegionTag(service.DefaultHost, service.Package, service.Name, methodName)
+	snippet := snippetmetadata.NewSnippet(lang, regionTag, s.File)
+	snippet.Segments = s.Segments
+	if s.Method == nil {
+		snippet.Title = fmt.Sprintf("%s %s Quickstart", shortName, service.Name)
+		snippet.Description = service.Documentation
+		return snippet
+	}
+	snippet.Title = fmt.Sprintf("%s %s Sample", shortName, s.Method.Name)
+	snippet.Description = s.Method.Documentation
+	clientFullName := service.Package + "." + s.ClientName
+	snippet.ClientMethod = &snippetmetadata.ClientMethod{
+		Async: true,
+		Client: &snippetmetadata.Client{
+			FullName:  clientFullName,
+			ShortName: s.ClientName,
+		},
+		FullName: clientFullName + "." + s.MethodName,
+		Method: &snippetmetadata.Method{
+			FullName: strings.TrimPrefix(s.Method.ID, "."),
+			Service: &snippetmetadata.Service{
+				FullName:  strings.TrimPrefix(service.ID, "."),
+				ShortName: service.Name,
+			},
+			ShortName: s.Method.Name,
+		},
+		ResultType: s.ResultType,
+		ShortName:  s.MethodName,
+	}
+	if s.RequestType != "" {
+		snippet.ClientMethod.Parameters = []*snippetmetadata.Parameter{
+			{Name: "request", Type: s.RequestType},
+		}
+	}
+	return snippet
+}
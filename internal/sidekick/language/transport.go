@@ -0,0 +1,62 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package language
+
+import (
+	"fmt"
+
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+// Transports describes the transports a codec generates clients for.
+type Transports struct {
+	// GRPC is true if the codec generates gRPC clients.
+	GRPC bool
+	// REST is true if the codec generates HTTP/JSON clients.
+	REST bool
+}
+
+// ParseTransport parses the value of the `transport` codec option.
+//
+// The values match the transports in the service config: `grpc`, `rest` and
+// `grpc+rest`. An empty value selects the HTTP/JSON clients only.
+func ParseTransport(value string) (Transports, error) {
+	switch value {
+	case "", "rest":
+		return Transports{REST: true}, nil
+	case "grpc":
+		return Transports{GRPC: true}, nil
+	case "grpc+rest":
+		return Transports{GRPC: true, REST: true}, nil
+	default:
+		return Transports{}, fmt.Errorf("unknown transport %q, expected one of grpc, rest, or grpc+rest", value)
+	}
+}
+
+// GrpcPath returns the gRPC path for a method, such as
+// `/google.cloud.secretmanager.v1.SecretManagerService/GetSecret`.
+//
+// Methods from mixins use the path of the service that defines them, not the
+// service that includes them.
+func GrpcPath(m *api.Method) string {
+	service := m.SourceService
+	if service == nil {
+		service = m.Service
+	}
+	if service == nil {
+		return fmt.Sprintf("/%s", m.Name)
+	}
+	return fmt.Sprintf("/%s.%s/%s", service.Package, service.Name, m.Name)
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package language
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+func TestParseTransport(t *testing.T) {
+	for _, test := range []struct {
+		value string
+		want  Transports
+	}{
+		{"", Transports{REST: true}},
+		{"rest", Transports{REST: true}},
+		{"grpc", Transports{GRPC: true}},
+		{"grpc+rest", Transports{GRPC: true, REST: true}},
+	} {
+		t.Run(test.value, func(t *testing.T) {
+			got, err := ParseTransport(test.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseTransport_Error(t *testing.T) {
+	if got, err := ParseTransport("carrier-pigeon"); err == nil {
+		t.Errorf("expected an error, got %v", got)
+	}
+}
+
+func TestGrpcPath(t *testing.T) {
+	service := &api.Service{Name: "SecretManagerService", Package: "google.cloud.secretmanager.v1"}
+	mixin := &api.Service{Name: "Locations", Package: "google.cloud.location"}
+	for _, test := range []struct {
+		name   string
+		method *api.Method
+		want   string
+	}{
+		{
+			name:   "service",
+			method: &api.Method{Name: "GetSecret", Service: service},
+			want:   "/google.cloud.secretmanager.v1.SecretManagerService/GetSecret",
+		},
+		{
+			name:   "mixin",
+			method: &api.Method{Name: "ListLocations", Service: service, SourceService: mixin},
+			want:   "/google.cloud.location.Locations/ListLocations",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := GrpcPath(test.method); got != test.want {
+				t.Errorf("GrpcPath() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -16,15 +16,22 @@ package parser
 
 import (
 	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 
 	"cloud.google.com/go/longrunning/autogen/longrunningpb"
 
+	"github.com/googleapis/librarian/internal/config"
 	"github.com/googleapis/librarian/internal/serviceconfig"
 	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sources"
 	locationpb "google.golang.org/genproto/googleapis/cloud/location"
 	statuspb "google.golang.org/genproto/googleapis/rpc/status"
 	exprpb "google.golang.org/genproto/googleapis/type/expr"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protodesc"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/descriptorpb"
@@ -45,8 +52,22 @@ const (
 
 type mixinMethods map[string]bool
 
+// mixinConfig configures how mixin APIs are resolved and which of their
+// methods are added to the generated services.
+type mixinConfig struct {
+	// Source locates the protos for mixins that are not built into sidekick.
+	Source *sources.SourceConfig
+	// Filter selects the mixin methods added to each service.
+	Filter *config.Mixins
+}
+
 // loadMixins loads file descriptors for configured mixins.
-func loadMixins(serviceConfig *serviceconfig.Service, withLongrunning bool) (mixinMethods, []*descriptorpb.FileDescriptorProto) {
+//
+// The locations, IAM, and longrunning mixins are built into sidekick. Any
+// other API in the service config `apis:` list is resolved from `protoFiles`,
+// or from the source roots in `cfg`. APIs defined in `sourceFiles` are the
+// services being generated and are not mixins.
+func loadMixins(serviceConfig *serviceconfig.Service, withLongrunning bool, sourceFiles, protoFiles []*descriptorpb.FileDescriptorProto, cfg *mixinConfig) (mixinMethods, []*descriptorpb.FileDescriptorProto, error) {
 	var files []*descriptorpb.FileDescriptorProto
 	var enabledMixinMethods mixinMethods = make(map[string]bool)
 	var apiNames []string
@@ -63,17 +84,19 @@ func loadMixins(serviceConfig *serviceconfig.Service, withLongrunning bool) (mix
 		apiNames = append(apiNames, longrunningService)
 	}
 	if len(apiNames) < 2 {
-		return enabledMixinMethods, files
+		return enabledMixinMethods, files, nil
 	}
 	known := map[string]bool{}
-	appendIfNew := func(desc protoreflect.FileDescriptor) {
-		file := protodesc.ToFileDescriptorProto(desc)
+	appendProtoIfNew := func(file *descriptorpb.FileDescriptorProto) {
 		if _, ok := known[file.GetName()]; ok {
 			return
 		}
 		known[file.GetName()] = true
 		files = append(files, file)
 	}
+	appendIfNew := func(desc protoreflect.FileDescriptor) {
+		appendProtoIfNew(protodesc.ToFileDescriptorProto(desc))
+	}
 	for _, apiName := range apiNames {
 		switch apiName {
 		case locationService:
@@ -91,16 +114,165 @@ func loadMixins(serviceConfig *serviceconfig.Service, withLongrunning bool) (mix
 			appendIfNew(emptypb.File_google_protobuf_empty_proto)
 			appendIfNew(statuspb.File_google_rpc_status_proto)
 			appendIfNew(longrunningpb.File_google_longrunning_operations_proto)
+		default:
+			if findServiceFile(apiName, sourceFiles) != nil {
+				continue
+			}
+			resolved, err := resolveMixin(apiName, protoFiles, cfg.source())
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, f := range resolved {
+				appendProtoIfNew(f)
+			}
 		}
 	}
 	enabledMixinMethods = loadMixinMethods(serviceConfig)
+	enabledMixinMethods.filter(cfg.filter())
 	if withLongrunning {
 		// We prefer using the `http.rules` section from the service config, but
 		// if we must implement the longrunning mixin, we must also implement
-		// the GetOperation method.
+		// the GetOperation method. This method is required even if the
+		// library configuration denies it.
 		enabledMixinMethods[".google.longrunning.Operations.GetOperation"] = true
 	}
-	return enabledMixinMethods, files
+	return enabledMixinMethods, files, nil
+}
+
+func (c *mixinConfig) source() *sources.SourceConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Source
+}
+
+func (c *mixinConfig) filter() *config.Mixins {
+	if c == nil {
+		return nil
+	}
+	return c.Filter
+}
+
+// filter removes the methods rejected by `f`.
+func (m mixinMethods) filter(f *config.Mixins) {
+	if f == nil {
+		return
+	}
+	for id := range m {
+		if len(f.Allow) != 0 && !matchesMixin(id, f.Allow) {
+			delete(m, id)
+			continue
+		}
+		if matchesMixin(id, f.Deny) {
+			delete(m, id)
+		}
+	}
+}
+
+// matchesMixin returns true if the method `id` matches one of the mixin
+// service or method names in `list`.
+func matchesMixin(id string, list []string) bool {
+	for _, name := range list {
+		if !strings.HasPrefix(name, ".") {
+			name = "." + name
+		}
+		if id == name || strings.HasPrefix(id, name+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveMixin returns the file descriptors needed to use the `apiName`
+// service as a mixin.
+//
+// If the service is defined in `protoFiles`, typically because the API
+// imports it, that file is used. Otherwise the proto file is located in the
+// source roots and compiled with `protoc`. Returns no files if the service
+// cannot be found.
+func resolveMixin(apiName string, protoFiles []*descriptorpb.FileDescriptorProto, source *sources.SourceConfig) ([]*descriptorpb.FileDescriptorProto, error) {
+	if f := findServiceFile(apiName, protoFiles); f != nil {
+		return []*descriptorpb.FileDescriptorProto{f}, nil
+	}
+	filename, err := findMixinProto(apiName, source)
+	if err != nil {
+		return nil, err
+	}
+	if filename == "" {
+		slog.Warn("cannot find mixin in source roots, skipping", "api", apiName)
+		return nil, nil
+	}
+	contents, err := runProtoc([]string{filename}, source)
+	if err != nil {
+		return nil, fmt.Errorf("cannot compile mixin %s: %w", apiName, err)
+	}
+	descriptors := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(contents, descriptors); err != nil {
+		return nil, err
+	}
+	loaded := map[string]bool{}
+	for _, f := range protoFiles {
+		loaded[f.GetName()] = true
+	}
+	var files []*descriptorpb.FileDescriptorProto
+	for _, f := range descriptors.File {
+		// Files already loaded with the API need not be loaded again.
+		// The file defining the mixin is always needed, as its services
+		// are not processed otherwise.
+		if loaded[f.GetName()] && findServiceFile(apiName, []*descriptorpb.FileDescriptorProto{f}) == nil {
+			continue
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// findServiceFile returns the file in `files` defining the service named
+// `apiName`, or nil if there is none.
+func findServiceFile(apiName string, files []*descriptorpb.FileDescriptorProto) *descriptorpb.FileDescriptorProto {
+	for _, f := range files {
+		for _, s := range f.GetService() {
+			if f.GetPackage()+"."+s.GetName() == apiName {
+				return f
+			}
+		}
+	}
+	return nil
+}
+
+// findMixinProto returns the path of the proto file defining the `apiName`
+// service. Following the googleapis conventions, the file must be in the
+// directory matching the package name, in one of the source roots. Returns
+// the empty string if there is no such file.
+func findMixinProto(apiName string, source *sources.SourceConfig) (string, error) {
+	idx := strings.LastIndex(apiName, ".")
+	if idx == -1 || source == nil {
+		return "", nil
+	}
+	packagez, service := apiName[:idx], apiName[idx+1:]
+	dir := filepath.FromSlash(strings.ReplaceAll(packagez, ".", "/"))
+	serviceRE := regexp.MustCompile(`(?m)^\s*service\s+` + regexp.QuoteMeta(service) + `\s*\{`)
+	packageRE := regexp.MustCompile(`(?m)^\s*package\s+` + regexp.QuoteMeta(packagez) + `\s*;`)
+	for _, root := range source.ActiveRoots {
+		rootPath := source.Root(root)
+		if rootPath == "" {
+			continue
+		}
+		candidates, err := filepath.Glob(filepath.Join(rootPath, dir, "*.proto"))
+		if err != nil {
+			return "", err
+		}
+		for _, candidate := range candidates {
+			contents, err := os.ReadFile(candidate)
+			if err != nil {
+				return "", err
+			}
+			if packageRE.Match(contents) && serviceRE.Match(contents) {
+				return candidate, nil
+			}
+		}
+	}
+	return "", nil
 }
 
 // loadMixinMethods determines which mixins methods should be generated.
@@ -15,11 +15,18 @@
 package parser
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/config"
 	"github.com/googleapis/librarian/internal/sample"
+	"github.com/googleapis/librarian/internal/serviceconfig"
+	"github.com/googleapis/librarian/internal/sources"
 	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/apipb"
 )
 
 func TestProtobuf_ForceLongrunning(t *testing.T) {
@@ -45,7 +52,10 @@ func TestProtobuf_ForceLongrunning(t *testing.T) {
 		".google.longrunning.Operations.GetOperation":    true,
 		".google.longrunning.Operations.CancelOperation": true,
 	}
-	gotMethods, gotDescriptors := loadMixins(sc, true)
+	gotMethods, gotDescriptors, err := loadMixins(sc, true, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if diff := cmp.Diff(wantMethods, gotMethods); diff != "" {
 		t.Errorf("mismatched operations (-want, +got):\n%s", diff)
 	}
@@ -66,7 +76,10 @@ func TestProtobuf_ForceLongrunningNoRules(t *testing.T) {
 	wantMethods := mixinMethods{
 		".google.longrunning.Operations.GetOperation": true,
 	}
-	gotMethods, gotDescriptors := loadMixins(sc, true)
+	gotMethods, gotDescriptors, err := loadMixins(sc, true, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if diff := cmp.Diff(wantMethods, gotMethods); diff != "" {
 		t.Errorf("mismatched operations (-want, +got):\n%s", diff)
 	}
@@ -79,3 +92,158 @@ func TestProtobuf_ForceLongrunningNoRules(t *testing.T) {
 		t.Errorf("Missing longrunning descriptor in %v", gotDescriptors)
 	}
 }
+
+func TestMixinMethodsFilter(t *testing.T) {
+	all := func() mixinMethods {
+		return mixinMethods{
+			".google.cloud.location.Locations.GetLocation":   true,
+			".google.cloud.location.Locations.ListLocations": true,
+			".google.iam.v1.IAMPolicy.GetIamPolicy":          true,
+			".google.iam.v1.IAMPolicy.SetIamPolicy":          true,
+		}
+	}
+	for _, test := range []struct {
+		name   string
+		filter *config.Mixins
+		want   mixinMethods
+	}{
+		{
+			name: "nil filter",
+			want: all(),
+		},
+		{
+			name:   "allow service",
+			filter: &config.Mixins{Allow: []string{"google.cloud.location.Locations"}},
+			want: mixinMethods{
+				".google.cloud.location.Locations.GetLocation":   true,
+				".google.cloud.location.Locations.ListLocations": true,
+			},
+		},
+		{
+			name:   "allow method",
+			filter: &config.Mixins{Allow: []string{".google.iam.v1.IAMPolicy.GetIamPolicy"}},
+			want: mixinMethods{
+				".google.iam.v1.IAMPolicy.GetIamPolicy": true,
+			},
+		},
+		{
+			name:   "deny service",
+			filter: &config.Mixins{Deny: []string{"google.iam.v1.IAMPolicy"}},
+			want: mixinMethods{
+				".google.cloud.location.Locations.GetLocation":   true,
+				".google.cloud.location.Locations.ListLocations": true,
+			},
+		},
+		{
+			name: "deny takes precedence",
+			filter: &config.Mixins{
+				Allow: []string{"google.cloud.location.Locations"},
+				Deny:  []string{"google.cloud.location.Locations.ListLocations"},
+			},
+			want: mixinMethods{
+				".google.cloud.location.Locations.GetLocation": true,
+			},
+		},
+		{
+			name:   "prefix is not a service",
+			filter: &config.Mixins{Deny: []string{"google.iam.v1.IAM"}},
+			want:   all(),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := all()
+			got.filter(test.filter)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadMixins_Resolved(t *testing.T) {
+	sourceFile := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test/v1/service.proto"),
+		Package: proto.String("test.v1"),
+		Service: []*descriptorpb.ServiceDescriptorProto{{Name: proto.String("Service")}},
+	}
+	mixinFile := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test/mixins/frobber.proto"),
+		Package: proto.String("test.mixins"),
+		Service: []*descriptorpb.ServiceDescriptorProto{{Name: proto.String("Frobber")}},
+	}
+	sc := &serviceconfig.Service{
+		Apis: []*apipb.Api{
+			{Name: "test.v1.Service"},
+			{Name: "test.mixins.Frobber"},
+		},
+		Http: &annotations.Http{
+			Rules: []*httpRule{
+				{
+					Selector: "test.mixins.Frobber.Frob",
+					Pattern:  &httpRulePost{Post: "/v1/{name=projects/*/frobs/*}:frob"},
+				},
+				{
+					Selector: "test.mixins.Frobber.Unfrob",
+					Pattern:  &httpRulePost{Post: "/v1/{name=projects/*/frobs/*}:unfrob"},
+				},
+			},
+		},
+	}
+	cfg := &mixinConfig{Filter: &config.Mixins{Deny: []string{"test.mixins.Frobber.Unfrob"}}}
+	gotMethods, gotFiles, err := loadMixins(sc, false, []*descriptorpb.FileDescriptorProto{sourceFile}, []*descriptorpb.FileDescriptorProto{sourceFile, mixinFile}, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantMethods := mixinMethods{".test.mixins.Frobber.Frob": true}
+	if diff := cmp.Diff(wantMethods, gotMethods); diff != "" {
+		t.Errorf("methods mismatch (-want +got):\n%s", diff)
+	}
+	var gotNames []string
+	for _, f := range gotFiles {
+		gotNames = append(gotNames, f.GetName())
+	}
+	wantNames := []string{"test/mixins/frobber.proto"}
+	if diff := cmp.Diff(wantNames, gotNames); diff != "" {
+		t.Errorf("files mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFindMixinProto(t *testing.T) {
+	src := &sources.SourceConfig{
+		Sources:     &sources.Sources{ProtobufSrc: "testdata"},
+		ActiveRoots: []string{"protobuf-src"},
+	}
+	for _, test := range []struct {
+		name    string
+		apiName string
+		want    string
+	}{
+		{
+			name:    "found",
+			apiName: "test.mixins.Frobber",
+			want:    filepath.Join("testdata", "test", "mixins", "frobber.proto"),
+		},
+		{
+			name:    "unknown service",
+			apiName: "test.mixins.Unknown",
+		},
+		{
+			name:    "unknown package",
+			apiName: "test.unknown.Frobber",
+		},
+		{
+			name:    "no package",
+			apiName: "Frobber",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := findMixinProto(test.apiName, src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("findMixinProto(%q) = %q, want %q", test.apiName, got, test.want)
+			}
+		})
+	}
+}
@@ -44,6 +44,9 @@ type ModelConfig struct {
 	// Service config
 	ServiceConfig string
 
+	// Mixins selects the mixin methods added to each service.
+	Mixins *config.Mixins
+
 	// Codec configuration
 	Codec map[string]string
 
@@ -61,7 +61,7 @@ func ParseProtobuf(cfg *ModelConfig) (*api.API, error) {
 	if err != nil {
 		return nil, err
 	}
-	return makeAPIForProtobuf(serviceConfig, request)
+	return makeAPIForProtobuf(serviceConfig, request, &mixinConfig{Source: cfg.Source, Filter: cfg.Mixins})
 }
 
 func codeGeneratorRequestFromDescriptors(descriptorFiles, generateFiles string) (*pluginpb.CodeGeneratorRequest, error) {
@@ -258,17 +258,18 @@ const (
 	enumDescriptorValue = 2
 )
 
-func makeAPIForProtobuf(serviceConfig *serviceconfig.Service, req *pluginpb.CodeGeneratorRequest) (*api.API, error) {
+func makeAPIForProtobuf(serviceConfig *serviceconfig.Service, req *pluginpb.CodeGeneratorRequest, mixins *mixinConfig) (*api.API, error) {
 	var (
 		mixinFileDesc       []*descriptorpb.FileDescriptorProto
 		enabledMixinMethods mixinMethods = make(map[string]bool)
 	)
 	state := &api.APIState{
-		ServiceByID:    make(map[string]*api.Service),
-		MethodByID:     make(map[string]*api.Method),
-		MessageByID:    make(map[string]*api.Message),
-		EnumByID:       make(map[string]*api.Enum),
-		ResourceByType: make(map[string]*api.Resource),
+		ServiceByID:        make(map[string]*api.Service),
+		MethodByID:         make(map[string]*api.Method),
+		MessageByID:        make(map[string]*api.Message),
+		EnumByID:           make(map[string]*api.Enum),
+		ResourceByType:     make(map[string]*api.Resource),
+		SourceLocationByID: make(map[string]*api.SourceLocation),
 	}
 	result := &api.API{
 		State: state,
@@ -279,7 +280,11 @@ func makeAPIForProtobuf(serviceConfig *serviceconfig.Service, req *pluginpb.Code
 			result.Description = serviceConfig.Documentation.Summary
 		}
 		withLongrunning := requiresLongrunningMixin(req)
-		enabledMixinMethods, mixinFileDesc = loadMixins(serviceConfig, withLongrunning)
+		var err error
+		enabledMixinMethods, mixinFileDesc, err = loadMixins(serviceConfig, withLongrunning, req.GetSourceFileDescriptors(), req.GetProtoFile(), mixins)
+		if err != nil {
+			return nil, err
+		}
 		names := svcconfig.ExtractPackageName(serviceConfig)
 		if names != nil {
 			result.PackageName = names.PackageName
@@ -396,6 +401,7 @@ func makeAPIForProtobuf(serviceConfig *serviceconfig.Service, req *pluginpb.Code
 				slog.Warn("dropped unknown documentation type", "loc", p, "docs", loc)
 			}
 		}
+		addSourceLocations(result, f)
 		result.Services = append(result.Services, fileServices...)
 	}
 
@@ -619,6 +625,7 @@ func processMessage(model *api.API, m *descriptorpb.DescriptorProto, mFQN, packa
 			Name:          mf.GetName(),
 			ID:            mFQN + "." + mf.GetName(),
 			JSONName:      mf.GetJsonName(),
+			Number:        mf.GetNumber(),
 			Deprecated:    mf.GetOptions().GetDeprecated(),
 			Optional:      isProtoOptional,
 			IsOneOf:       mf.OneofIndex != nil && !isProtoOptional,
@@ -841,6 +848,69 @@ func addEnumDocumentation(model *api.API, p []int32, doc string, eFQN string) {
 	}
 }
 
+// addSourceLocations records the file and line of each service, method,
+// message, field, and enum defined in `f`.
+func addSourceLocations(model *api.API, f *descriptorpb.FileDescriptorProto) {
+	fFQN := "." + f.GetPackage()
+	for _, loc := range f.GetSourceCodeInfo().GetLocation() {
+		if len(loc.GetSpan()) == 0 {
+			continue
+		}
+		id := sourceLocationID(f, loc.GetPath(), fFQN)
+		if id == "" {
+			continue
+		}
+		model.State.SourceLocationByID[id] = &api.SourceLocation{
+			File: f.GetName(),
+			Line: int(loc.GetSpan()[0]) + 1,
+		}
+	}
+}
+
+// sourceLocationID returns the ID of the element at path `p`, or the empty
+// string if the path does not refer to an element in the model.
+func sourceLocationID(f *descriptorpb.FileDescriptorProto, p []int32, fFQN string) string {
+	if len(p) < 2 {
+		return ""
+	}
+	switch p[0] {
+	case fileDescriptorMessageType:
+		m := f.GetMessageType()[p[1]]
+		return messageSourceLocationID(m, p[2:], fFQN+"."+m.GetName())
+	case fileDescriptorEnumType:
+		if len(p) == 2 {
+			return fFQN + "." + f.GetEnumType()[p[1]].GetName()
+		}
+	case fileDescriptorService:
+		s := f.GetService()[p[1]]
+		sFQN := fFQN + "." + s.GetName()
+		switch {
+		case len(p) == 2:
+			return sFQN
+		case len(p) == 4 && p[2] == serviceDescriptorProtoMethod:
+			return sFQN + "." + s.GetMethod()[p[3]].GetName()
+		}
+	}
+	return ""
+}
+
+func messageSourceLocationID(m *descriptorpb.DescriptorProto, p []int32, mFQN string) string {
+	switch {
+	case len(p) == 0:
+		return mFQN
+	case len(p) < 2:
+		return ""
+	case p[0] == messageDescriptorNestedType:
+		nmsg := m.GetNestedType()[p[1]]
+		return messageSourceLocationID(nmsg, p[2:], mFQN+"."+nmsg.GetName())
+	case p[0] == messageDescriptorField && len(p) == 2:
+		return mFQN + "." + m.GetField()[p[1]].GetName()
+	case p[0] == messageDescriptorEnum && len(p) == 2:
+		return mFQN + "." + m.GetEnumType()[p[1]].GetName()
+	}
+	return ""
+}
+
 func parseResourcePatterns(patterns []string) ([]api.ResourcePattern, error) {
 	var parsedPatterns []api.ResourcePattern
 	for _, p := range patterns {
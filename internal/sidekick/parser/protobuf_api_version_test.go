@@ -29,7 +29,7 @@ func TestProtobuf_ApiVersion(t *testing.T) {
 		Title: "A test-only API",
 	}
 
-	model, err := makeAPIForProtobuf(serviceConfig, newTestCodeGeneratorRequest(t, "api_version.proto"))
+	model, err := makeAPIForProtobuf(serviceConfig, newTestCodeGeneratorRequest(t, "api_version.proto"), nil)
 	if err != nil {
 		t.Fatalf("Failed to make API for Protobuf %v", err)
 	}
@@ -17,9 +17,11 @@ package parser
 import (
 	"testing"
 
+	"github.com/googleapis/librarian/internal/config"
 	"github.com/googleapis/librarian/internal/serviceconfig"
 	"github.com/googleapis/librarian/internal/sidekick/api"
 	"github.com/googleapis/librarian/internal/sidekick/api/apitest"
+	"github.com/googleapis/librarian/internal/sources"
 	"google.golang.org/genproto/googleapis/api/annotations"
 	"google.golang.org/protobuf/types/known/apipb"
 )
@@ -52,7 +54,7 @@ func TestProtobuf_LocationMixin(t *testing.T) {
 			},
 		},
 	}
-	test, err := makeAPIForProtobuf(serviceConfig, newTestCodeGeneratorRequest(t, "test_service.proto"))
+	test, err := makeAPIForProtobuf(serviceConfig, newTestCodeGeneratorRequest(t, "test_service.proto"), nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -123,7 +125,7 @@ func TestProtobuf_IAMMixin(t *testing.T) {
 			},
 		},
 	}
-	test, err := makeAPIForProtobuf(serviceConfig, newTestCodeGeneratorRequest(t, "test_service.proto"))
+	test, err := makeAPIForProtobuf(serviceConfig, newTestCodeGeneratorRequest(t, "test_service.proto"), nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -200,7 +202,7 @@ func TestProtobuf_OperationMixin(t *testing.T) {
 			},
 		},
 	}
-	test, err := makeAPIForProtobuf(serviceConfig, newTestCodeGeneratorRequest(t, "test_service.proto"))
+	test, err := makeAPIForProtobuf(serviceConfig, newTestCodeGeneratorRequest(t, "test_service.proto"), nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -287,7 +289,7 @@ func TestProtobuf_OperationMixinNoEmpty(t *testing.T) {
 			},
 		},
 	}
-	test, err := makeAPIForProtobuf(serviceConfig, newTestCodeGeneratorRequest(t, "test_noempty_mixin.proto"))
+	test, err := makeAPIForProtobuf(serviceConfig, newTestCodeGeneratorRequest(t, "test_noempty_mixin.proto"), nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -373,7 +375,7 @@ func TestProtobuf_DuplicateMixin(t *testing.T) {
 			},
 		},
 	}
-	test, err := makeAPIForProtobuf(serviceConfig, newTestCodeGeneratorRequest(t, "test_duplicate_mixin.proto"))
+	test, err := makeAPIForProtobuf(serviceConfig, newTestCodeGeneratorRequest(t, "test_duplicate_mixin.proto"), nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -412,3 +414,79 @@ func TestProtobuf_DuplicateMixin(t *testing.T) {
 		},
 	})
 }
+
+func TestProtobuf_ResolvedMixin(t *testing.T) {
+	requireProtoc(t)
+	serviceConfig := &serviceconfig.Service{
+		Name:  "test.googleapis.com",
+		Title: "Test API",
+		Apis: []*apipb.Api{
+			{Name: "test.TestService"},
+			{Name: "test.mixins.Frobber"},
+		},
+		Http: &annotations.Http{
+			Rules: []*httpRule{
+				{
+					Selector: "test.mixins.Frobber.Frob",
+					Pattern: &httpRulePost{
+						Post: "/v1/{name=projects/*/frobs/*}:frob",
+					},
+					Body: "*",
+				},
+				{
+					Selector: "test.mixins.Frobber.Unfrob",
+					Pattern: &httpRulePost{
+						Post: "/v1/{name=projects/*/frobs/*}:unfrob",
+					},
+					Body: "*",
+				},
+			},
+		},
+	}
+	mixins := &mixinConfig{
+		Source: &sources.SourceConfig{
+			Sources: &sources.Sources{
+				Googleapis:  "../../testdata/googleapis",
+				ProtobufSrc: "testdata",
+			},
+			ActiveRoots: []string{"googleapis", "protobuf-src"},
+		},
+		Filter: &config.Mixins{Deny: []string{"test.mixins.Frobber.Unfrob"}},
+	}
+	test, err := makeAPIForProtobuf(serviceConfig, newTestCodeGeneratorRequest(t, "test_service.proto"), mixins)
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := test.Service(".test.TestService")
+	if service == nil {
+		t.Fatalf("Cannot find service %s in API State", ".test.TestService")
+	}
+	if test.Method(".test.TestService.Unfrob") != nil {
+		t.Errorf("denied mixin method .test.TestService.Unfrob should not be generated")
+	}
+	apitest.CheckMethod(t, service, "Frob", &api.Method{
+		Documentation:   "Provides the [Frobber][test.mixins.Frobber] service functionality in this service.",
+		Name:            "Frob",
+		ID:              ".test.TestService.Frob",
+		SourceServiceID: ".test.mixins.Frobber",
+		InputTypeID:     ".test.mixins.FrobRequest",
+		OutputTypeID:    ".test.mixins.FrobResponse",
+		PathInfo: &api.PathInfo{
+			BodyFieldPath: "*",
+			Bindings: []*api.PathBinding{
+				{
+					Verb: "POST",
+					PathTemplate: (&api.PathTemplate{}).
+						WithLiteral("v1").
+						WithVariable(api.NewPathVariable("name").
+							WithLiteral("projects").
+							WithMatch().
+							WithLiteral("frobs").
+							WithMatch()).
+						WithVerb("frob"),
+					QueryParameters: map[string]bool{},
+				},
+			},
+		},
+	})
+}
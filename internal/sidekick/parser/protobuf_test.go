@@ -29,6 +29,8 @@ import (
 	"github.com/googleapis/librarian/internal/sidekick/api/apitest"
 	"github.com/googleapis/librarian/internal/sources"
 	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
 	"google.golang.org/protobuf/types/known/apipb"
 	"google.golang.org/protobuf/types/pluginpb"
 )
@@ -36,7 +38,7 @@ import (
 func TestProtobuf_Info(t *testing.T) {
 	requireProtoc(t)
 	sc := sample.ServiceConfig()
-	got, err := makeAPIForProtobuf(sc, newTestCodeGeneratorRequest(t, "scalar.proto"))
+	got, err := makeAPIForProtobuf(sc, newTestCodeGeneratorRequest(t, "scalar.proto"), nil)
 	if err != nil {
 		t.Fatalf("Failed to make API for Protobuf %v", err)
 	}
@@ -58,7 +60,7 @@ func TestProtobuf_PartialInfo(t *testing.T) {
 		Title: "Secret Manager API",
 	}
 
-	got, err := makeAPIForProtobuf(serviceConfig, newTestCodeGeneratorRequest(t, "scalar.proto"))
+	got, err := makeAPIForProtobuf(serviceConfig, newTestCodeGeneratorRequest(t, "scalar.proto"), nil)
 	if err != nil {
 		t.Fatalf("Failed to make API for Protobuf %v", err)
 	}
@@ -75,7 +77,7 @@ func TestProtobuf_PartialInfo(t *testing.T) {
 
 func TestProtobuf_Scalar(t *testing.T) {
 	requireProtoc(t)
-	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "scalar.proto"))
+	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "scalar.proto"), nil)
 	if err != nil {
 		t.Fatalf("Failed to make API for Protobuf %v", err)
 	}
@@ -93,6 +95,7 @@ func TestProtobuf_Scalar(t *testing.T) {
 				Documentation: "A singular field tag = 1",
 				Name:          "f_double",
 				JSONName:      "fDouble",
+				Number:        1,
 				ID:            ".test.Fake.f_double",
 				Typez:         api.TypezDouble,
 			},
@@ -100,6 +103,7 @@ func TestProtobuf_Scalar(t *testing.T) {
 				Documentation: "A singular field tag = 2",
 				Name:          "f_float",
 				JSONName:      "fFloat",
+				Number:        2,
 				ID:            ".test.Fake.f_float",
 				Typez:         api.TypezFloat,
 			},
@@ -107,6 +111,7 @@ func TestProtobuf_Scalar(t *testing.T) {
 				Documentation: "A singular field tag = 3",
 				Name:          "f_int64",
 				JSONName:      "fInt64",
+				Number:        3,
 				ID:            ".test.Fake.f_int64",
 				Typez:         api.TypezInt64,
 			},
@@ -114,6 +119,7 @@ func TestProtobuf_Scalar(t *testing.T) {
 				Documentation: "A singular field tag = 4",
 				Name:          "f_uint64",
 				JSONName:      "fUint64",
+				Number:        4,
 				ID:            ".test.Fake.f_uint64",
 				Typez:         api.TypezUint64,
 			},
@@ -121,6 +127,7 @@ func TestProtobuf_Scalar(t *testing.T) {
 				Documentation: "A singular field tag = 5",
 				Name:          "f_int32",
 				JSONName:      "fInt32",
+				Number:        5,
 				ID:            ".test.Fake.f_int32",
 				Typez:         api.TypezInt32,
 			},
@@ -128,6 +135,7 @@ func TestProtobuf_Scalar(t *testing.T) {
 				Documentation: "A singular field tag = 6",
 				Name:          "f_fixed64",
 				JSONName:      "fFixed64",
+				Number:        6,
 				ID:            ".test.Fake.f_fixed64",
 				Typez:         api.TypezFixed64,
 			},
@@ -135,6 +143,7 @@ func TestProtobuf_Scalar(t *testing.T) {
 				Documentation: "A singular field tag = 7",
 				Name:          "f_fixed32",
 				JSONName:      "fFixed32",
+				Number:        7,
 				ID:            ".test.Fake.f_fixed32",
 				Typez:         api.TypezFixed32,
 			},
@@ -142,6 +151,7 @@ func TestProtobuf_Scalar(t *testing.T) {
 				Documentation: "A singular field tag = 8",
 				Name:          "f_bool",
 				JSONName:      "fBool",
+				Number:        8,
 				ID:            ".test.Fake.f_bool",
 				Typez:         api.TypezBool,
 			},
@@ -149,6 +159,7 @@ func TestProtobuf_Scalar(t *testing.T) {
 				Documentation: "A singular field tag = 9",
 				Name:          "f_string",
 				JSONName:      "fString",
+				Number:        9,
 				ID:            ".test.Fake.f_string",
 				Typez:         api.TypezString,
 			},
@@ -156,6 +167,7 @@ func TestProtobuf_Scalar(t *testing.T) {
 				Documentation: "A singular field tag = 12",
 				Name:          "f_bytes",
 				JSONName:      "fBytes",
+				Number:        12,
 				ID:            ".test.Fake.f_bytes",
 				Typez:         api.TypezBytes,
 			},
@@ -163,6 +175,7 @@ func TestProtobuf_Scalar(t *testing.T) {
 				Documentation: "A singular field tag = 13",
 				Name:          "f_uint32",
 				JSONName:      "fUint32",
+				Number:        13,
 				ID:            ".test.Fake.f_uint32",
 				Typez:         api.TypezUint32,
 			},
@@ -170,6 +183,7 @@ func TestProtobuf_Scalar(t *testing.T) {
 				Documentation: "A singular field tag = 15",
 				Name:          "f_sfixed32",
 				JSONName:      "fSfixed32",
+				Number:        15,
 				ID:            ".test.Fake.f_sfixed32",
 				Typez:         api.TypezSfixed32,
 			},
@@ -177,6 +191,7 @@ func TestProtobuf_Scalar(t *testing.T) {
 				Documentation: "A singular field tag = 16",
 				Name:          "f_sfixed64",
 				JSONName:      "fSfixed64",
+				Number:        16,
 				ID:            ".test.Fake.f_sfixed64",
 				Typez:         api.TypezSfixed64,
 			},
@@ -184,6 +199,7 @@ func TestProtobuf_Scalar(t *testing.T) {
 				Documentation: "A singular field tag = 17",
 				Name:          "f_sint32",
 				JSONName:      "fSint32",
+				Number:        17,
 				ID:            ".test.Fake.f_sint32",
 				Typez:         api.TypezSint32,
 			},
@@ -191,6 +207,7 @@ func TestProtobuf_Scalar(t *testing.T) {
 				Documentation: "A singular field tag = 18",
 				Name:          "f_sint64",
 				JSONName:      "fSint64",
+				Number:        18,
 				ID:            ".test.Fake.f_sint64",
 				Typez:         api.TypezSint64,
 			},
@@ -200,7 +217,7 @@ func TestProtobuf_Scalar(t *testing.T) {
 
 func TestProtobuf_ScalarArray(t *testing.T) {
 	requireProtoc(t)
-	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "scalar_array.proto"))
+	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "scalar_array.proto"), nil)
 	if err != nil {
 		t.Fatalf("Failed to make API for Protobuf %v", err)
 	}
@@ -219,6 +236,7 @@ func TestProtobuf_ScalarArray(t *testing.T) {
 				Documentation: "A repeated field tag = 1",
 				Name:          "f_double",
 				JSONName:      "fDouble",
+				Number:        1,
 				ID:            ".test.Fake.f_double",
 				Typez:         api.TypezDouble,
 			},
@@ -227,6 +245,7 @@ func TestProtobuf_ScalarArray(t *testing.T) {
 				Documentation: "A repeated field tag = 3",
 				Name:          "f_int64",
 				JSONName:      "fInt64",
+				Number:        3,
 				ID:            ".test.Fake.f_int64",
 				Typez:         api.TypezInt64,
 			},
@@ -235,6 +254,7 @@ func TestProtobuf_ScalarArray(t *testing.T) {
 				Documentation: "A repeated field tag = 9",
 				Name:          "f_string",
 				JSONName:      "fString",
+				Number:        9,
 				ID:            ".test.Fake.f_string",
 				Typez:         api.TypezString,
 			},
@@ -243,6 +263,7 @@ func TestProtobuf_ScalarArray(t *testing.T) {
 				Documentation: "A repeated field tag = 12",
 				Name:          "f_bytes",
 				JSONName:      "fBytes",
+				Number:        12,
 				ID:            ".test.Fake.f_bytes",
 				Typez:         api.TypezBytes,
 			},
@@ -252,7 +273,7 @@ func TestProtobuf_ScalarArray(t *testing.T) {
 
 func TestProtobuf_ScalarOptional(t *testing.T) {
 	requireProtoc(t)
-	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "scalar_optional.proto"))
+	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "scalar_optional.proto"), nil)
 	if err != nil {
 		t.Fatalf("Failed to make API for Protobuf %v", err)
 	}
@@ -271,6 +292,7 @@ func TestProtobuf_ScalarOptional(t *testing.T) {
 				Documentation: "An optional field tag = 1",
 				Name:          "f_double",
 				JSONName:      "fDouble",
+				Number:        1,
 				ID:            ".test.Fake.f_double",
 				Typez:         api.TypezDouble,
 			},
@@ -279,6 +301,7 @@ func TestProtobuf_ScalarOptional(t *testing.T) {
 				Documentation: "An optional field tag = 3",
 				Name:          "f_int64",
 				JSONName:      "fInt64",
+				Number:        3,
 				ID:            ".test.Fake.f_int64",
 				Typez:         api.TypezInt64,
 			},
@@ -287,6 +310,7 @@ func TestProtobuf_ScalarOptional(t *testing.T) {
 				Documentation: "An optional field tag = 9",
 				Name:          "f_string",
 				JSONName:      "fString",
+				Number:        9,
 				ID:            ".test.Fake.f_string",
 				Typez:         api.TypezString,
 			},
@@ -295,6 +319,7 @@ func TestProtobuf_ScalarOptional(t *testing.T) {
 				Documentation: "An optional field tag = 12",
 				Name:          "f_bytes",
 				JSONName:      "fBytes",
+				Number:        12,
 				ID:            ".test.Fake.f_bytes",
 				Typez:         api.TypezBytes,
 			},
@@ -304,7 +329,7 @@ func TestProtobuf_ScalarOptional(t *testing.T) {
 
 func TestProtobuf_SkipExternalMessages(t *testing.T) {
 	requireProtoc(t)
-	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "with_import.proto"))
+	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "with_import.proto"), nil)
 	if err != nil {
 		t.Fatalf("Failed to make API for Protobuf %v", err)
 	}
@@ -325,6 +350,7 @@ func TestProtobuf_SkipExternalMessages(t *testing.T) {
 			{
 				Name:          "payload",
 				JSONName:      "payload",
+				Number:        1,
 				ID:            ".test.LocalMessage.payload",
 				Documentation: "This field uses an imported message.",
 				Typez:         api.TypezMessage,
@@ -334,6 +360,7 @@ func TestProtobuf_SkipExternalMessages(t *testing.T) {
 			{
 				Name:          "value",
 				JSONName:      "value",
+				Number:        2,
 				ID:            ".test.LocalMessage.value",
 				Documentation: "This field uses an imported enum.",
 				Typez:         api.TypezEnum,
@@ -352,7 +379,7 @@ func TestProtobuf_SkipExternalMessages(t *testing.T) {
 
 func TestProtobuf_SkipExternaEnums(t *testing.T) {
 	requireProtoc(t)
-	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "with_import.proto"))
+	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "with_import.proto"), nil)
 	if err != nil {
 		t.Fatalf("Failed to make API for Protobuf %v", err)
 	}
@@ -394,7 +421,7 @@ func TestProtobuf_SkipExternaEnums(t *testing.T) {
 
 func TestProtobuf_Comments(t *testing.T) {
 	requireProtoc(t)
-	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "comments.proto"))
+	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "comments.proto"), nil)
 	if err != nil {
 		t.Fatalf("Failed to make API for Protobuf %v", err)
 	}
@@ -412,6 +439,7 @@ func TestProtobuf_Comments(t *testing.T) {
 				Name:          "parent",
 				Documentation: "A field.\n\nWith a longer description.",
 				JSONName:      "parent",
+				Number:        1,
 				ID:            ".test.Request.parent",
 				Typez:         api.TypezString,
 			},
@@ -432,6 +460,7 @@ func TestProtobuf_Comments(t *testing.T) {
 				Name:          "path",
 				Documentation: "Field in a nested message.\n\n* Bullet 1\n  Bullet 1 continued\n* Bullet 2\n  Bullet 2 continued",
 				JSONName:      "path",
+				Number:        1,
 				ID:            ".test.Response.Nested.path",
 				Typez:         api.TypezString,
 			},
@@ -498,9 +527,83 @@ func TestProtobuf_Comments(t *testing.T) {
 	})
 }
 
+func TestProtobuf_SourceLocations(t *testing.T) {
+	requireProtoc(t)
+	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "comments.proto"), nil)
+	if err != nil {
+		t.Fatalf("Failed to make API for Protobuf %v", err)
+	}
+	for _, want := range []struct {
+		id   string
+		line int
+	}{
+		{".test.Request", 31},
+		{".test.Request.parent", 35},
+		{".test.Response.Status", 47},
+		{".test.Response.Nested", 61},
+		{".test.Response.Nested.path", 68},
+		{".test.Service", 75},
+		{".test.Service.Create", 83},
+	} {
+		got := test.SourceLocation(want.id)
+		if diff := cmp.Diff(&api.SourceLocation{File: "comments.proto", Line: want.line}, got); diff != "" {
+			t.Errorf("mismatch for %s (-want +got):\n%s", want.id, diff)
+		}
+	}
+	if got := test.SourceLocation(".test.Undefined"); got != nil {
+		t.Errorf("SourceLocation(.test.Undefined) = %v, want nil", got)
+	}
+}
+
+func TestAddSourceLocations(t *testing.T) {
+	loc := func(line int32, path ...int32) *descriptorpb.SourceCodeInfo_Location {
+		return &descriptorpb.SourceCodeInfo_Location{Path: path, Span: []int32{line, 0, 10}}
+	}
+	f := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test/v1/test.proto"),
+		Package: proto.String("test.v1"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name:       proto.String("Secret"),
+				Field:      []*descriptorpb.FieldDescriptorProto{{Name: proto.String("name")}},
+				NestedType: []*descriptorpb.DescriptorProto{{Name: proto.String("Nested")}},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{
+			{
+				Name:   proto.String("Service"),
+				Method: []*descriptorpb.MethodDescriptorProto{{Name: proto.String("GetSecret")}},
+			},
+		},
+		SourceCodeInfo: &descriptorpb.SourceCodeInfo{
+			Location: []*descriptorpb.SourceCodeInfo_Location{
+				loc(0, fileDescriptorPackage),
+				loc(10, fileDescriptorMessageType, 0),
+				loc(11, fileDescriptorMessageType, 0, messageDescriptorField, 0),
+				loc(12, fileDescriptorMessageType, 0, messageDescriptorField, 0, 1),
+				loc(14, fileDescriptorMessageType, 0, messageDescriptorNestedType, 0),
+				loc(20, fileDescriptorService, 0),
+				loc(21, fileDescriptorService, 0, serviceDescriptorProtoMethod, 0),
+			},
+		},
+	}
+	model := &api.API{State: &api.APIState{SourceLocationByID: map[string]*api.SourceLocation{}}}
+	addSourceLocations(model, f)
+	want := map[string]*api.SourceLocation{
+		".test.v1.Secret":            {File: "test/v1/test.proto", Line: 11},
+		".test.v1.Secret.name":       {File: "test/v1/test.proto", Line: 12},
+		".test.v1.Secret.Nested":     {File: "test/v1/test.proto", Line: 15},
+		".test.v1.Service":           {File: "test/v1/test.proto", Line: 21},
+		".test.v1.Service.GetSecret": {File: "test/v1/test.proto", Line: 22},
+	}
+	if diff := cmp.Diff(want, model.State.SourceLocationByID); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestProtobuf_UniqueEnumValues(t *testing.T) {
 	requireProtoc(t)
-	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "enum_values.proto"))
+	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "enum_values.proto"), nil)
 	if err != nil {
 		t.Fatalf("Failed to make API for Protobuf %v", err)
 	}
@@ -557,7 +660,7 @@ func TestProtobuf_UniqueEnumValues(t *testing.T) {
 
 func TestProtobuf_OneOfs(t *testing.T) {
 	requireProtoc(t)
-	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "oneofs.proto"))
+	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "oneofs.proto"), nil)
 	if err != nil {
 		t.Fatalf("Failed to make API for Protobuf %v", err)
 	}
@@ -575,6 +678,7 @@ func TestProtobuf_OneOfs(t *testing.T) {
 				Name:          "field_one",
 				Documentation: "A string choice",
 				JSONName:      "fieldOne",
+				Number:        1,
 				ID:            ".test.Fake.field_one",
 				Typez:         api.TypezString,
 				IsOneOf:       true,
@@ -585,6 +689,7 @@ func TestProtobuf_OneOfs(t *testing.T) {
 				ID:            ".test.Fake.field_two",
 				Typez:         api.TypezInt64,
 				JSONName:      "fieldTwo",
+				Number:        2,
 				IsOneOf:       true,
 			},
 			{
@@ -593,6 +698,7 @@ func TestProtobuf_OneOfs(t *testing.T) {
 				ID:            ".test.Fake.field_three",
 				Typez:         api.TypezString,
 				JSONName:      "fieldThree",
+				Number:        3,
 				Optional:      true,
 			},
 			{
@@ -601,6 +707,7 @@ func TestProtobuf_OneOfs(t *testing.T) {
 				ID:            ".test.Fake.field_four",
 				Typez:         api.TypezInt32,
 				JSONName:      "fieldFour",
+				Number:        4,
 			},
 		},
 		OneOfs: []*api.OneOf{
@@ -614,6 +721,7 @@ func TestProtobuf_OneOfs(t *testing.T) {
 						ID:            ".test.Fake.field_one",
 						Typez:         api.TypezString,
 						JSONName:      "fieldOne",
+						Number:        1,
 						IsOneOf:       true,
 					},
 					{
@@ -622,6 +730,7 @@ func TestProtobuf_OneOfs(t *testing.T) {
 						ID:            ".test.Fake.field_two",
 						Typez:         api.TypezInt64,
 						JSONName:      "fieldTwo",
+						Number:        2,
 						IsOneOf:       true,
 					},
 				},
@@ -632,7 +741,7 @@ func TestProtobuf_OneOfs(t *testing.T) {
 
 func TestProtobuf_ObjectFields(t *testing.T) {
 	requireProtoc(t)
-	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "object_fields.proto"))
+	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "object_fields.proto"), nil)
 	if err != nil {
 		t.Fatalf("Failed to make API for Protobuf %v", err)
 	}
@@ -650,6 +759,7 @@ func TestProtobuf_ObjectFields(t *testing.T) {
 				Optional: true,
 				Name:     "singular_object",
 				JSONName: "singularObject",
+				Number:   1,
 				ID:       ".test.Fake.singular_object",
 				Typez:    api.TypezMessage,
 				TypezID:  ".test.Other",
@@ -659,6 +769,7 @@ func TestProtobuf_ObjectFields(t *testing.T) {
 				Optional: false,
 				Name:     "repeated_object",
 				JSONName: "repeatedObject",
+				Number:   2,
 				ID:       ".test.Fake.repeated_object",
 				Typez:    api.TypezMessage,
 				TypezID:  ".test.Other",
@@ -669,7 +780,7 @@ func TestProtobuf_ObjectFields(t *testing.T) {
 
 func TestProtobuf_WellKnownTypeFields(t *testing.T) {
 	requireProtoc(t)
-	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "wkt_fields.proto"))
+	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "wkt_fields.proto"), nil)
 	if err != nil {
 		t.Fatalf("Failed to make API for Protobuf %v", err)
 	}
@@ -685,6 +796,7 @@ func TestProtobuf_WellKnownTypeFields(t *testing.T) {
 			{
 				Name:     "field_mask",
 				JSONName: "fieldMask",
+				Number:   2,
 				ID:       ".test.Fake.field_mask",
 				Typez:    api.TypezMessage,
 				TypezID:  ".google.protobuf.FieldMask",
@@ -693,6 +805,7 @@ func TestProtobuf_WellKnownTypeFields(t *testing.T) {
 			{
 				Name:     "timestamp",
 				JSONName: "timestamp",
+				Number:   3,
 				ID:       ".test.Fake.timestamp",
 				Typez:    api.TypezMessage,
 				TypezID:  ".google.protobuf.Timestamp",
@@ -701,6 +814,7 @@ func TestProtobuf_WellKnownTypeFields(t *testing.T) {
 			{
 				Name:     "any",
 				JSONName: "any",
+				Number:   1,
 				ID:       ".test.Fake.any",
 				Typez:    api.TypezMessage,
 				TypezID:  ".google.protobuf.Any",
@@ -709,6 +823,7 @@ func TestProtobuf_WellKnownTypeFields(t *testing.T) {
 			{
 				Name:     "repeated_field_mask",
 				JSONName: "repeatedFieldMask",
+				Number:   5,
 				ID:       ".test.Fake.repeated_field_mask",
 				Typez:    api.TypezMessage,
 				TypezID:  ".google.protobuf.FieldMask",
@@ -717,6 +832,7 @@ func TestProtobuf_WellKnownTypeFields(t *testing.T) {
 			{
 				Name:     "repeated_timestamp",
 				JSONName: "repeatedTimestamp",
+				Number:   6,
 				ID:       ".test.Fake.repeated_timestamp",
 				Typez:    api.TypezMessage,
 				TypezID:  ".google.protobuf.Timestamp",
@@ -725,6 +841,7 @@ func TestProtobuf_WellKnownTypeFields(t *testing.T) {
 			{
 				Name:     "repeated_any",
 				JSONName: "repeatedAny",
+				Number:   4,
 				ID:       ".test.Fake.repeated_any",
 				Typez:    api.TypezMessage,
 				TypezID:  ".google.protobuf.Any",
@@ -736,7 +853,7 @@ func TestProtobuf_WellKnownTypeFields(t *testing.T) {
 
 func TestProtobuf_JsonName(t *testing.T) {
 	requireProtoc(t)
-	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "json_name.proto"))
+	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "json_name.proto"), nil)
 	if err != nil {
 		t.Fatalf("Failed to make API for Protobuf %v", err)
 	}
@@ -753,18 +870,21 @@ func TestProtobuf_JsonName(t *testing.T) {
 			{
 				Name:     "parent",
 				JSONName: "parent",
+				Number:   1,
 				ID:       ".test.Request.parent",
 				Typez:    api.TypezString,
 			},
 			{
 				Name:     "public_key",
 				JSONName: "public_key",
+				Number:   2,
 				ID:       ".test.Request.public_key",
 				Typez:    api.TypezString,
 			},
 			{
 				Name:     "read_time",
 				JSONName: "readTime",
+				Number:   3,
 				ID:       ".test.Request.read_time",
 				Typez:    api.TypezInt32,
 			},
@@ -774,7 +894,7 @@ func TestProtobuf_JsonName(t *testing.T) {
 
 func TestProtobuf_MapFields(t *testing.T) {
 	requireProtoc(t)
-	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "map_fields.proto"))
+	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "map_fields.proto"), nil)
 	if err != nil {
 		t.Fatalf("Failed to make API for Protobuf %v", err)
 	}
@@ -793,6 +913,7 @@ func TestProtobuf_MapFields(t *testing.T) {
 				Map:      true,
 				Name:     "singular_map",
 				JSONName: "singularMap",
+				Number:   1,
 				ID:       ".test.Fake.singular_map",
 				Typez:    api.TypezMessage,
 				TypezID:  ".test.Fake.SingularMapEntry",
@@ -803,6 +924,7 @@ func TestProtobuf_MapFields(t *testing.T) {
 				Map:      true,
 				Name:     "enum_value",
 				JSONName: "enumValue",
+				Number:   2,
 				ID:       ".test.Fake.enum_value",
 				Typez:    api.TypezMessage,
 				TypezID:  ".test.Fake.EnumValueEntry",
@@ -829,6 +951,7 @@ func TestProtobuf_MapFields(t *testing.T) {
 				Optional: false,
 				Name:     "key",
 				JSONName: "key",
+				Number:   1,
 				ID:       ".test.Fake.SingularMapEntry.key",
 				Typez:    api.TypezString,
 			},
@@ -837,6 +960,7 @@ func TestProtobuf_MapFields(t *testing.T) {
 				Optional: false,
 				Name:     "value",
 				JSONName: "value",
+				Number:   2,
 				ID:       ".test.Fake.SingularMapEntry.value",
 				Typez:    api.TypezInt32,
 			},
@@ -858,6 +982,7 @@ func TestProtobuf_MapFields(t *testing.T) {
 				Optional: false,
 				Name:     "key",
 				JSONName: "key",
+				Number:   1,
 				ID:       ".test.Fake.EnumValueEntry.key",
 				Typez:    api.TypezString,
 			},
@@ -866,6 +991,7 @@ func TestProtobuf_MapFields(t *testing.T) {
 				Optional: false,
 				Name:     "value",
 				JSONName: "value",
+				Number:   2,
 				ID:       ".test.Fake.EnumValueEntry.value",
 				Typez:    api.TypezEnum,
 				TypezID:  ".test.TestEnum",
@@ -876,7 +1002,7 @@ func TestProtobuf_MapFields(t *testing.T) {
 
 func TestProtobuf_Service(t *testing.T) {
 	requireProtoc(t)
-	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "test_service.proto"))
+	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "test_service.proto"), nil)
 	if err != nil {
 		t.Fatalf("Failed to make API for Protobuf %v", err)
 	}
@@ -1012,7 +1138,7 @@ func TestProtobuf_Service(t *testing.T) {
 
 func TestProtobuf_QueryParameters(t *testing.T) {
 	requireProtoc(t)
-	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "query_parameters.proto"))
+	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "query_parameters.proto"), nil)
 	if err != nil {
 		t.Fatalf("Failed to make API for Protobuf %v", err)
 	}
@@ -1081,7 +1207,7 @@ func TestProtobuf_QueryParameters(t *testing.T) {
 
 func TestProtobuf_Enum(t *testing.T) {
 	requireProtoc(t)
-	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "enum.proto"))
+	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "enum.proto"), nil)
 	if err != nil {
 		t.Fatalf("Failed to make API for Protobuf %v", err)
 	}
@@ -1134,7 +1260,7 @@ func TestProtobuf_TrimLeadingSpacesInDocumentation(t *testing.T) {
 
 func TestProtobuf_Pagination(t *testing.T) {
 	requireProtoc(t)
-	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "pagination.proto"))
+	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "pagination.proto"), nil)
 	if err != nil {
 		t.Fatalf("Failed to make API for Protobuf %v", err)
 	}
@@ -1174,6 +1300,7 @@ func TestProtobuf_Pagination(t *testing.T) {
 					ID:       ".test.ListFooRequest.page_token",
 					Typez:    9,
 					JSONName: "pageToken",
+					Number:   3,
 					Behavior: []api.FieldBehavior{api.FieldBehaviorOptional},
 				},
 			},
@@ -1202,6 +1329,7 @@ func TestProtobuf_Pagination(t *testing.T) {
 					ID:       ".test.ListFooMaxResultsInt32Request.page_token",
 					Typez:    9,
 					JSONName: "pageToken",
+					Number:   3,
 					Behavior: []api.FieldBehavior{api.FieldBehaviorOptional},
 				},
 			},
@@ -1230,6 +1358,7 @@ func TestProtobuf_Pagination(t *testing.T) {
 					ID:       ".test.ListFooMaxResultsUInt32Request.page_token",
 					Typez:    9,
 					JSONName: "pageToken",
+					Number:   3,
 					Behavior: []api.FieldBehavior{api.FieldBehaviorOptional},
 				},
 			},
@@ -1258,6 +1387,7 @@ func TestProtobuf_Pagination(t *testing.T) {
 					ID:       ".test.ListFooMaxResultsUInt32ValueRequest.page_token",
 					Typez:    9,
 					JSONName: "pageToken",
+					Number:   3,
 					Behavior: []api.FieldBehavior{api.FieldBehaviorOptional},
 				},
 			},
@@ -1286,6 +1416,7 @@ func TestProtobuf_Pagination(t *testing.T) {
 					ID:       ".test.ListFooMaxResultsInt32ValueRequest.page_token",
 					Typez:    9,
 					JSONName: "pageToken",
+					Number:   3,
 					Behavior: []api.FieldBehavior{api.FieldBehaviorOptional},
 				},
 			},
@@ -1412,6 +1543,7 @@ func TestProtobuf_Pagination(t *testing.T) {
 				ID:       ".test.ListFooResponse.next_page_token",
 				Typez:    9,
 				JSONName: "nextPageToken",
+				Number:   2,
 			},
 			{
 				Name:     "foos",
@@ -1419,6 +1551,7 @@ func TestProtobuf_Pagination(t *testing.T) {
 				Typez:    11,
 				TypezID:  ".test.Foo",
 				JSONName: "foos",
+				Number:   1,
 				Repeated: true,
 			},
 			{
@@ -1426,6 +1559,7 @@ func TestProtobuf_Pagination(t *testing.T) {
 				ID:       ".test.ListFooResponse.total_size",
 				Typez:    5,
 				JSONName: "totalSize",
+				Number:   3,
 			},
 		},
 		Pagination: &api.PaginationInfo{
@@ -1434,6 +1568,7 @@ func TestProtobuf_Pagination(t *testing.T) {
 				ID:       ".test.ListFooResponse.next_page_token",
 				Typez:    9,
 				JSONName: "nextPageToken",
+				Number:   2,
 			},
 			PageableItem: &api.Field{
 				Name:     "foos",
@@ -1441,6 +1576,7 @@ func TestProtobuf_Pagination(t *testing.T) {
 				Typez:    11,
 				TypezID:  ".test.Foo",
 				JSONName: "foos",
+				Number:   1,
 				Repeated: true,
 			},
 		},
@@ -1482,7 +1618,7 @@ func TestProtobuf_OperationInfo(t *testing.T) {
 			},
 		},
 	}
-	test, err := makeAPIForProtobuf(serviceConfig, newTestCodeGeneratorRequest(t, "test_operation_info.proto"))
+	test, err := makeAPIForProtobuf(serviceConfig, newTestCodeGeneratorRequest(t, "test_operation_info.proto"), nil)
 	if err != nil {
 		t.Fatalf("Failed to make API for Protobuf %v", err)
 	}
@@ -1609,7 +1745,7 @@ func TestProtobuf_AutoPopulated(t *testing.T) {
 			},
 		},
 	}
-	test, err := makeAPIForProtobuf(serviceConfig, newTestCodeGeneratorRequest(t, "auto_populated.proto"))
+	test, err := makeAPIForProtobuf(serviceConfig, newTestCodeGeneratorRequest(t, "auto_populated.proto"), nil)
 	if err != nil {
 		t.Fatalf("Failed to make API for Protobuf %v", err)
 	}
@@ -1625,6 +1761,7 @@ func TestProtobuf_AutoPopulated(t *testing.T) {
 	request_id := &api.Field{
 		Name:     "request_id",
 		JSONName: "requestId",
+		Number:   4,
 		ID:       ".test.CreateFooRequest.request_id",
 		Documentation: "This is an auto-populated field. The remaining fields almost meet the\n" +
 			"requirements to be auto-populated, but fail for the reasons implied by\n" +
@@ -1637,6 +1774,7 @@ func TestProtobuf_AutoPopulated(t *testing.T) {
 		ID:            ".test.CreateFooRequest.request_id_optional",
 		Typez:         api.TypezString,
 		JSONName:      "requestIdOptional",
+		Number:        5,
 		Optional:      true,
 		AutoPopulated: true,
 	}
@@ -1645,6 +1783,7 @@ func TestProtobuf_AutoPopulated(t *testing.T) {
 		ID:            ".test.CreateFooRequest.request_id_with_field_behavior",
 		Typez:         api.TypezString,
 		JSONName:      "requestIdWithFieldBehavior",
+		Number:        6,
 		AutoPopulated: true,
 		Behavior:      []api.FieldBehavior{api.FieldBehaviorOptional, api.FieldBehaviorInputOnly},
 	}
@@ -1657,6 +1796,7 @@ func TestProtobuf_AutoPopulated(t *testing.T) {
 			{
 				Name:              "parent",
 				JSONName:          "parent",
+				Number:            1,
 				ID:                ".test.CreateFooRequest.parent",
 				Documentation:     "Required. The resource name of the project.",
 				Typez:             api.TypezString,
@@ -1666,6 +1806,7 @@ func TestProtobuf_AutoPopulated(t *testing.T) {
 			{
 				Name:          "foo_id",
 				JSONName:      "fooId",
+				Number:        2,
 				ID:            ".test.CreateFooRequest.foo_id",
 				Documentation: "Required. This must be unique within the project.",
 				Typez:         api.TypezString,
@@ -1674,6 +1815,7 @@ func TestProtobuf_AutoPopulated(t *testing.T) {
 			{
 				Name:          "foo",
 				JSONName:      "foo",
+				Number:        3,
 				ID:            ".test.CreateFooRequest.foo",
 				Documentation: "Required. A [Foo][test.Foo] with initial field values.",
 				Typez:         api.TypezMessage,
@@ -1689,12 +1831,14 @@ func TestProtobuf_AutoPopulated(t *testing.T) {
 				ID:       ".test.CreateFooRequest.not_request_id_bad_type",
 				Typez:    api.TypezBytes,
 				JSONName: "notRequestIdBadType",
+				Number:   7,
 			},
 			{
 				Name:     "not_request_id_required",
 				ID:       ".test.CreateFooRequest.not_request_id_required",
 				Typez:    api.TypezString,
 				JSONName: "notRequestIdRequired",
+				Number:   8,
 				Behavior: []api.FieldBehavior{api.FieldBehaviorRequired},
 			},
 			{
@@ -1702,6 +1846,7 @@ func TestProtobuf_AutoPopulated(t *testing.T) {
 				ID:       ".test.CreateFooRequest.not_request_id_required_with_other_field_behavior",
 				Typez:    api.TypezString,
 				JSONName: "notRequestIdRequiredWithOtherFieldBehavior",
+				Number:   9,
 				Behavior: []api.FieldBehavior{api.FieldBehaviorInputOnly, api.FieldBehaviorRequired},
 			},
 			{
@@ -1709,24 +1854,28 @@ func TestProtobuf_AutoPopulated(t *testing.T) {
 				ID:       ".test.CreateFooRequest.not_request_id_missing_field_info",
 				Typez:    api.TypezString,
 				JSONName: "notRequestIdMissingFieldInfo",
+				Number:   10,
 			},
 			{
 				Name:     "not_request_id_missing_field_info_format",
 				ID:       ".test.CreateFooRequest.not_request_id_missing_field_info_format",
 				Typez:    api.TypezString,
 				JSONName: "notRequestIdMissingFieldInfoFormat",
+				Number:   11,
 			},
 			{
 				Name:     "not_request_id_bad_field_info_format",
 				ID:       ".test.CreateFooRequest.not_request_id_bad_field_info_format",
 				Typez:    api.TypezString,
 				JSONName: "notRequestIdBadFieldInfoFormat",
+				Number:   12,
 			},
 			{
 				Name:     "not_request_id_missing_service_config",
 				ID:       ".test.CreateFooRequest.not_request_id_missing_service_config",
 				Typez:    api.TypezString,
 				JSONName: "notRequestIdMissingServiceConfig",
+				Number:   13,
 				// This just denotes that the field is eligible
 				// to be auto-populated
 				AutoPopulated: true,
@@ -1746,7 +1895,7 @@ func TestProtobuf_AutoPopulated(t *testing.T) {
 
 func TestProtobuf_Deprecated(t *testing.T) {
 	requireProtoc(t)
-	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "deprecated.proto"))
+	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "deprecated.proto"), nil)
 	if err != nil {
 		t.Fatalf("Failed to make API for Protobuf %v", err)
 	}
@@ -1796,12 +1945,14 @@ func TestProtobuf_Deprecated(t *testing.T) {
 			{
 				Name:     "name",
 				JSONName: "name",
+				Number:   1,
 				ID:       ".test.Request.name",
 				Typez:    api.TypezString,
 			},
 			{
 				Name:       "other",
 				JSONName:   "other",
+				Number:     2,
 				ID:         ".test.Request.other",
 				Typez:      api.TypezString,
 				Deprecated: true,
@@ -1869,7 +2020,7 @@ func TestProtobuf_Deprecated(t *testing.T) {
 
 func TestProtobuf_ResourceAnnotations(t *testing.T) {
 	requireProtoc(t)
-	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "resource_annotations.proto"))
+	test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "resource_annotations.proto"), nil)
 	if err != nil {
 		t.Fatalf("Failed to make API for Protobuf %v", err)
 	}
@@ -1993,6 +2144,7 @@ func TestProtobuf_ResourceAnnotations(t *testing.T) {
 				{
 					Name:     "name",
 					JSONName: "name",
+					Number:   1,
 					ID:       ".test.Book.name",
 					Typez:    api.TypezString,
 				},
@@ -2014,6 +2166,7 @@ func TestProtobuf_ResourceAnnotations(t *testing.T) {
 				{
 					Name:     "parent",
 					JSONName: "parent",
+					Number:   1,
 					ID:       ".test.CreateBookRequest.parent",
 					Typez:    api.TypezString,
 					ResourceReference: &api.ResourceReference{
@@ -2023,12 +2176,14 @@ func TestProtobuf_ResourceAnnotations(t *testing.T) {
 				{
 					Name:     "book_id",
 					JSONName: "bookId",
+					Number:   2,
 					ID:       ".test.CreateBookRequest.book_id",
 					Typez:    api.TypezString,
 				},
 				{
 					Name:     "book",
 					JSONName: "book",
+					Number:   3,
 					ID:       ".test.CreateBookRequest.book",
 					Typez:    api.TypezMessage,
 					TypezID:  ".test.Book",
@@ -2052,6 +2207,7 @@ func TestProtobuf_ResourceAnnotations(t *testing.T) {
 				{
 					Name:     "parent",
 					JSONName: "parent",
+					Number:   1,
 					ID:       ".test.ListBooksRequest.parent",
 					Typez:    api.TypezString,
 					ResourceReference: &api.ResourceReference{
@@ -2061,12 +2217,14 @@ func TestProtobuf_ResourceAnnotations(t *testing.T) {
 				{
 					Name:     "page_size",
 					JSONName: "pageSize",
+					Number:   2,
 					ID:       ".test.ListBooksRequest.page_size",
 					Typez:    api.TypezInt32,
 				},
 				{
 					Name:     "page_token",
 					JSONName: "pageToken",
+					Number:   3,
 					ID:       ".test.ListBooksRequest.page_token",
 					Typez:    api.TypezString,
 				},
@@ -2102,7 +2260,7 @@ func TestProtobuf_ResourceCoverage(t *testing.T) {
 	requireProtoc(t)
 
 	t.Run("Deduplication", func(t *testing.T) {
-		test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "resource_coverage.proto"))
+		test, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "resource_coverage.proto"), nil)
 		if err != nil {
 			t.Fatalf("Failed to make API for Protobuf %v", err)
 		}
@@ -2123,7 +2281,7 @@ func TestProtobuf_ResourceCoverage(t *testing.T) {
 	})
 
 	t.Run("InvalidPattern", func(t *testing.T) {
-		_, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "resource_invalid.proto"))
+		_, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "resource_invalid.proto"), nil)
 		if err == nil {
 			t.Errorf("Expected error for invalid resource pattern, got nil")
 		}
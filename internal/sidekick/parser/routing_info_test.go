@@ -242,7 +242,7 @@ func TestExamples(t *testing.T) {
 			},
 		},
 	} {
-		api, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "routing_info.proto"))
+		api, err := makeAPIForProtobuf(nil, newTestCodeGeneratorRequest(t, "routing_info.proto"), nil)
 		if err != nil {
 			t.Fatalf("Failed to make API for Protobuf %v", err)
 		}
@@ -0,0 +1,1029 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protofile
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/googleapis/librarian/internal/license"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/snippetmetadata"
+	"github.com/iancoleman/strcase"
+)
+
+var (
+	errMissingPackage = errors.New("the model has no package name")
+	errUnknownType    = errors.New("no known .proto file defines the type")
+	errNameConflict   = errors.New("cannot find a unique message name")
+)
+
+// The `.proto` files imported by the generated file for annotations.
+const (
+	annotationsProto        = "google/api/annotations.proto"
+	clientProto             = "google/api/client.proto"
+	fieldBehaviorProto      = "google/api/field_behavior.proto"
+	resourceProto           = "google/api/resource.proto"
+	extendedOperationsProto = "google/cloud/extended_operations.proto"
+	operationsProto         = "google/longrunning/operations.proto"
+)
+
+// The largest field number allowed by Protobuf, and the range of numbers
+// reserved for the Protobuf implementation.
+const (
+	maxFieldNumber      = 1<<29 - 1
+	firstReservedNumber = 19000
+	lastReservedNumber  = 19999
+)
+
+const indentUnit = "  "
+
+// importFiles maps the types defined outside the model to the `.proto` file
+// that defines them.
+var importFiles = map[string]string{
+	".google.protobuf.Any":          "google/protobuf/any.proto",
+	".google.protobuf.Duration":     "google/protobuf/duration.proto",
+	".google.protobuf.Empty":        "google/protobuf/empty.proto",
+	".google.protobuf.FieldMask":    "google/protobuf/field_mask.proto",
+	".google.protobuf.ListValue":    "google/protobuf/struct.proto",
+	".google.protobuf.NullValue":    "google/protobuf/struct.proto",
+	".google.protobuf.Struct":       "google/protobuf/struct.proto",
+	".google.protobuf.Value":        "google/protobuf/struct.proto",
+	".google.protobuf.Timestamp":    "google/protobuf/timestamp.proto",
+	".google.protobuf.BoolValue":    "google/protobuf/wrappers.proto",
+	".google.protobuf.BytesValue":   "google/protobuf/wrappers.proto",
+	".google.protobuf.DoubleValue":  "google/protobuf/wrappers.proto",
+	".google.protobuf.FloatValue":   "google/protobuf/wrappers.proto",
+	".google.protobuf.Int32Value":   "google/protobuf/wrappers.proto",
+	".google.protobuf.Int64Value":   "google/protobuf/wrappers.proto",
+	".google.protobuf.StringValue":  "google/protobuf/wrappers.proto",
+	".google.protobuf.UInt32Value":  "google/protobuf/wrappers.proto",
+	".google.protobuf.UInt64Value":  "google/protobuf/wrappers.proto",
+	".google.longrunning.Operation": operationsProto,
+	".google.rpc.Status":            "google/rpc/status.proto",
+	".google.type.Color":            "google/type/color.proto",
+	".google.type.Date":             "google/type/date.proto",
+	".google.type.DateTime":         "google/type/datetime.proto",
+	".google.type.TimeZone":         "google/type/datetime.proto",
+	".google.type.DayOfWeek":        "google/type/dayofweek.proto",
+	".google.type.Decimal":          "google/type/decimal.proto",
+	".google.type.Expr":             "google/type/expr.proto",
+	".google.type.Interval":         "google/type/interval.proto",
+	".google.type.LatLng":           "google/type/latlng.proto",
+	".google.type.LocalizedText":    "google/type/localized_text.proto",
+	".google.type.Money":            "google/type/money.proto",
+	".google.type.Month":            "google/type/month.proto",
+	".google.type.PhoneNumber":      "google/type/phone_number.proto",
+	".google.type.PostalAddress":    "google/type/postal_address.proto",
+	".google.type.TimeOfDay":        "google/type/timeofday.proto",
+}
+
+var scalarNames = map[api.Typez]string{
+	api.TypezDouble:   "double",
+	api.TypezFloat:    "float",
+	api.TypezInt64:    "int64",
+	api.TypezUint64:   "uint64",
+	api.TypezInt32:    "int32",
+	api.TypezFixed64:  "fixed64",
+	api.TypezFixed32:  "fixed32",
+	api.TypezBool:     "bool",
+	api.TypezString:   "string",
+	api.TypezBytes:    "bytes",
+	api.TypezUint32:   "uint32",
+	api.TypezSfixed32: "sfixed32",
+	api.TypezSfixed64: "sfixed64",
+	api.TypezSint32:   "sint32",
+	api.TypezSint64:   "sint64",
+}
+
+var behaviorNames = map[api.FieldBehavior]string{
+	api.FieldBehaviorOptional:                 "OPTIONAL",
+	api.FieldBehaviorRequired:                 "REQUIRED",
+	api.FieldBehaviorOutputOnly:               "OUTPUT_ONLY",
+	api.FieldBehaviorInputOnly:                "INPUT_ONLY",
+	api.FieldBehaviorImmutable:                "IMMUTABLE",
+	api.FieldBehaviorUnorderedList:            "UNORDERED_LIST",
+	api.FieldBehaviorUnorderedNonEmptyDefault: "NON_EMPTY_DEFAULT",
+	api.FieldBehaviorIdentifier:               "IDENTIFIER",
+}
+
+// The HTTP verbs with a dedicated field in `google.api.HttpRule`.
+var httpRuleVerbs = []string{"get", "put", "post", "delete", "patch"}
+
+var invalidIdentifierChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+type modelAnnotations struct {
+	CopyrightYear string
+	BoilerPlate   []string
+	Package       string
+	Imports       []string
+	// File-level options, such as `option java_package = "com.example";`.
+	Options  []string
+	Services []*api.Service
+	Messages []*api.Message
+	Enums    []*api.Enum
+	// The path of the `.proto` file, relative to the output directory.
+	FilePath string
+	// The path of the service config, relative to the output directory.
+	ServiceConfigPath string
+	// The fully qualified names of the services, as listed in the service
+	// config.
+	ServiceNames []string
+}
+
+type serviceAnnotations struct {
+	Name     string
+	DocLines []string
+	Options  []string
+	Methods  []*methodAnnotations
+}
+
+type methodAnnotations struct {
+	DocLines []string
+	// The method declaration, such as `rpc GetSecret(GetSecretRequest) returns (Secret)`.
+	Signature string
+	Options   []string
+}
+
+// HasOptions returns true if the method declaration needs a body.
+func (m *methodAnnotations) HasOptions() bool {
+	return len(m.Options) > 0
+}
+
+type messageAnnotations struct {
+	Name     string
+	Indent   string
+	DocLines []string
+	Options  []string
+	Messages []*api.Message
+	Enums    []*api.Enum
+	// The fields and oneofs, in the order of the fields in the model.
+	Items []*messageItem
+}
+
+// messageItem is either a field or a oneof group.
+type messageItem struct {
+	Field *fieldAnnotations
+	OneOf *oneOfAnnotations
+}
+
+type oneOfAnnotations struct {
+	Name     string
+	Indent   string
+	DocLines []string
+	Fields   []*fieldAnnotations
+}
+
+type fieldAnnotations struct {
+	DocLines []string
+	// The field declaration. Declarations with several options span multiple
+	// lines.
+	Lines []string
+}
+
+type enumAnnotations struct {
+	Name     string
+	Indent   string
+	DocLines []string
+	Options  []string
+	Values   []*enumValueAnnotations
+}
+
+type enumValueAnnotations struct {
+	DocLines []string
+	Decl     string
+}
+
+type annotator struct {
+	model       *api.API
+	hashNumbers bool
+	// The name of each message and enum, relative to the package. Messages
+	// hoisted out of the service placeholders get a new name.
+	names map[string]string
+	// The names in `names`, to resolve relative references.
+	defined map[string]bool
+	// The files imported by the generated file.
+	imports map[string]bool
+	// The enum value names used in each scope, keyed by the ID of the
+	// enclosing message, or the empty string for the package.
+	enumValueNames map[string]map[string]bool
+	// The methods used to poll long-running operations in Discovery-based
+	// APIs.
+	pollingMethods map[*api.Method]bool
+}
+
+func newAnnotator(model *api.API, opts *codecOptions) *annotator {
+	return &annotator{
+		model:          model,
+		hashNumbers:    opts.FieldNumbers == fieldNumbersHash,
+		names:          map[string]string{},
+		defined:        map[string]bool{},
+		imports:        map[string]bool{},
+		enumValueNames: map[string]map[string]bool{},
+		pollingMethods: map[*api.Method]bool{},
+	}
+}
+
+func annotateModel(model *api.API, opts *codecOptions) (*modelAnnotations, error) {
+	if model.PackageName == "" {
+		return nil, errMissingPackage
+	}
+	a := newAnnotator(model, opts)
+	messages, err := a.topLevelMessages()
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range messages {
+		if err := a.annotateMessage(m, 0); err != nil {
+			return nil, err
+		}
+	}
+	for _, e := range model.Enums {
+		a.annotateEnum(e, 0)
+	}
+	pollers := map[*api.Service]*api.Method{}
+	for _, s := range model.Services {
+		if poller := a.operationPoller(s); poller != nil {
+			pollers[s] = poller
+			a.pollingMethods[poller] = true
+		}
+	}
+	var serviceNames []string
+	for _, s := range model.Services {
+		if err := a.annotateService(s, pollers[s]); err != nil {
+			return nil, err
+		}
+		serviceNames = append(serviceNames, model.PackageName+"."+s.Codec.(*serviceAnnotations).Name)
+	}
+
+	year := opts.CopyrightYear
+	if year == "" {
+		year = strconv.Itoa(time.Now().Year())
+	}
+	dir := strings.ReplaceAll(model.PackageName, ".", "/")
+	name := shortName(model)
+	fileName := opts.FileName
+	if fileName == "" {
+		fileName = name + ".proto"
+	}
+	configName := name + ".yaml"
+	if version := snippetmetadata.APIVersion(model.PackageName); version != "" {
+		configName = fmt.Sprintf("%s_%s.yaml", name, version)
+	}
+	ann := &modelAnnotations{
+		CopyrightYear: year,
+		BoilerPlate: append(license.HeaderBulk(),
+			"",
+			" Code generated by sidekick. DO NOT EDIT."),
+		Package:           model.PackageName,
+		Imports:           slices.Sorted(maps.Keys(a.imports)),
+		Options:           fileOptions(opts.FileOptions),
+		Services:          model.Services,
+		Messages:          messages,
+		Enums:             model.Enums,
+		FilePath:          path.Join(dir, fileName),
+		ServiceConfigPath: path.Join(dir, configName),
+		ServiceNames:      serviceNames,
+	}
+	model.Codec = ann
+	return ann, nil
+}
+
+// topLevelMessages returns the messages at the top level of the `.proto`
+// file, and assigns a name to each message and enum.
+//
+// The OpenAPI and Discovery parsers nest the synthetic request messages in a
+// placeholder message with the same name as the service. The placeholders are
+// not emitted: their messages are hoisted to the top level.
+func (a *annotator) topLevelMessages() ([]*api.Message, error) {
+	var messages, hoisted []*api.Message
+	used := map[string]bool{}
+	for _, m := range a.model.Messages {
+		if m.ServicePlaceholder {
+			hoisted = append(hoisted, m.Messages...)
+			continue
+		}
+		messages = append(messages, m)
+		a.addNames(m, m.Name)
+		used[m.Name] = true
+	}
+	for _, e := range a.model.Enums {
+		a.addName(e.ID, e.Name)
+		used[e.Name] = true
+	}
+	// Several services may have a method with the same name, such as `get`.
+	// None of the requests for these methods gets the short name.
+	shortNames := map[string]int{}
+	for _, m := range hoisted {
+		shortNames[strcase.ToCamel(m.Name)]++
+	}
+	for _, m := range hoisted {
+		name := strcase.ToCamel(m.Name)
+		if used[name] || shortNames[name] > 1 {
+			name = qualifiedName(m)
+		}
+		if used[name] {
+			return nil, fmt.Errorf("%w for %s", errNameConflict, m.ID)
+		}
+		used[name] = true
+		a.addNames(m, name)
+		messages = append(messages, m)
+	}
+	return messages, nil
+}
+
+// qualifiedName returns a name for a message hoisted out of a service
+// placeholder that includes the service name, such as
+// `InsertInstancesRequest`.
+func qualifiedName(m *api.Message) string {
+	method := strings.TrimSuffix(m.Name, "Request")
+	service := ""
+	if m.Parent != nil {
+		service = m.Parent.Name
+	}
+	return strcase.ToCamel(method) + strcase.ToCamel(service) + "Request"
+}
+
+func (a *annotator) addNames(m *api.Message, name string) {
+	a.addName(m.ID, name)
+	for _, child := range m.Messages {
+		a.addNames(child, name+"."+child.Name)
+	}
+	for _, e := range m.Enums {
+		a.addName(e.ID, name+"."+e.Name)
+	}
+}
+
+func (a *annotator) addName(id, name string) {
+	a.names[id] = name
+	a.defined[name] = true
+}
+
+// shortName returns the short name of the API, such as `secretmanager`.
+func shortName(model *api.API) string {
+	for _, s := range model.Services {
+		if s.DefaultHost != "" {
+			name, _, _ := strings.Cut(s.DefaultHost, ".")
+			return name
+		}
+	}
+	if model.Name != "" {
+		return model.Name
+	}
+	return model.PackageName[strings.LastIndex(model.PackageName, ".")+1:]
+}
+
+// fileOptions returns the file-level options set with `option:<name>` codec
+// options, keyed by name. Values other than `true` and `false` are quoted.
+func fileOptions(values map[string]string) []string {
+	var options []string
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		value := values[name]
+		if value != "true" && value != "false" {
+			value = strconv.Quote(value)
+		}
+		options = append(options, fmt.Sprintf("option %s = %s;", name, value))
+	}
+	return options
+}
+
+func (a *annotator) annotateService(s *api.Service, poller *api.Method) error {
+	ann := &serviceAnnotations{
+		Name:     strcase.ToCamel(s.Name),
+		DocLines: docLines(s.Documentation, ""),
+	}
+	if s.DefaultHost != "" {
+		a.imports[clientProto] = true
+		ann.Options = append(ann.Options, fmt.Sprintf("%soption (google.api.default_host) = %q;", indentUnit, s.DefaultHost))
+	}
+	if s.Deprecated {
+		ann.Options = append(ann.Options, indentUnit+"option deprecated = true;")
+	}
+	var operationService string
+	if poller != nil {
+		operationService = strcase.ToCamel(poller.Service.Name)
+	}
+	for _, m := range s.Methods {
+		if m.SourceService != nil && m.SourceService != s {
+			continue
+		}
+		if poller != nil && m != poller && m.PathInfo == poller.PathInfo {
+			// The Discovery parser adds a copy of the polling method to
+			// each service with long-running operations.
+			continue
+		}
+		method, err := a.annotateMethod(m, operationService)
+		if err != nil {
+			return err
+		}
+		ann.Methods = append(ann.Methods, method)
+	}
+	s.Codec = ann
+	return nil
+}
+
+// operationPoller returns the method used to poll the long-running operations
+// started by s, if any.
+//
+// The Discovery parser adds a `getOperation` method to each service with
+// long-running operations. The method is a copy of the polling method from
+// another service, and shares its `PathInfo`.
+func (a *annotator) operationPoller(s *api.Service) *api.Method {
+	idx := slices.IndexFunc(s.Methods, func(m *api.Method) bool { return m.ID == s.ID+".getOperation" })
+	if idx == -1 {
+		return nil
+	}
+	mixin := s.Methods[idx]
+	for _, other := range a.model.Services {
+		if other == s {
+			continue
+		}
+		for _, m := range other.Methods {
+			if m.PathInfo == mixin.PathInfo {
+				return m
+			}
+		}
+	}
+	return nil
+}
+
+func (a *annotator) annotateMethod(m *api.Method, operationService string) (*methodAnnotations, error) {
+	input, err := a.typeRef(m.InputTypeID)
+	if err != nil {
+		return nil, err
+	}
+	output, err := a.typeRef(m.OutputTypeID)
+	if err != nil {
+		return nil, err
+	}
+	if m.ClientSideStreaming {
+		input = "stream " + input
+	}
+	if m.ServerSideStreaming {
+		output = "stream " + output
+	}
+	indent := indentUnit + indentUnit
+	ann := &methodAnnotations{
+		DocLines:  docLines(m.Documentation, indentUnit),
+		Signature: fmt.Sprintf("rpc %s(%s) returns (%s)", strcase.ToCamel(m.Name), input, output),
+	}
+	if m.PathInfo != nil && len(m.PathInfo.Bindings) > 0 {
+		a.imports[annotationsProto] = true
+		ann.Options = append(ann.Options, httpOption(m.PathInfo, indent)...)
+	}
+	if info := m.OperationInfo; info != nil {
+		a.imports[operationsProto] = true
+		ann.Options = append(ann.Options,
+			indent+"option (google.longrunning.operation_info) = {",
+			fmt.Sprintf("%s%sresponse_type: %q", indent, indentUnit, a.typeName(info.ResponseTypeID)),
+			fmt.Sprintf("%s%smetadata_type: %q", indent, indentUnit, a.typeName(info.MetadataTypeID)),
+			indent+"};")
+	}
+	if m.DiscoveryLro != nil && operationService != "" {
+		a.imports[extendedOperationsProto] = true
+		ann.Options = append(ann.Options, fmt.Sprintf("%soption (google.cloud.operation_service) = %q;", indent, operationService))
+	}
+	if a.pollingMethods[m] {
+		a.imports[extendedOperationsProto] = true
+		ann.Options = append(ann.Options, indent+"option (google.cloud.operation_polling_method) = true;")
+	}
+	if m.Deprecated {
+		ann.Options = append(ann.Options, indent+"option deprecated = true;")
+	}
+	return ann, nil
+}
+
+// httpOption returns the `google.api.http` option for a method. The first
+// binding is the main rule, any other bindings are additional bindings.
+func httpOption(info *api.PathInfo, indent string) []string {
+	lines := []string{indent + "option (google.api.http) = {"}
+	for i, binding := range info.Bindings {
+		inner := indent + indentUnit
+		if i != 0 {
+			lines = append(lines, inner+"additional_bindings {")
+			inner += indentUnit
+		}
+		lines = append(lines, httpRule(binding, info.BodyFieldPath, inner)...)
+		if i != 0 {
+			lines = append(lines, indent+indentUnit+"}")
+		}
+	}
+	return append(lines, indent+"};")
+}
+
+func httpRule(binding *api.PathBinding, body, indent string) []string {
+	var lines []string
+	path := httpPath(binding.PathTemplate)
+	verb := strings.ToLower(binding.Verb)
+	if slices.Contains(httpRuleVerbs, verb) {
+		lines = append(lines, fmt.Sprintf("%s%s: %q", indent, verb, path))
+	} else {
+		lines = append(lines,
+			indent+"custom {",
+			fmt.Sprintf("%s%skind: %q", indent, indentUnit, binding.Verb),
+			fmt.Sprintf("%s%spath: %q", indent, indentUnit, path),
+			indent+"}")
+	}
+	switch body {
+	case "":
+	case "*":
+		lines = append(lines, indent+`body: "*"`)
+	default:
+		lines = append(lines, fmt.Sprintf("%sbody: %q", indent, strcase.ToSnake(body)))
+	}
+	return lines
+}
+
+// httpPath returns the path template in the `google.api.http` syntax, such as
+// `/v1/{name=projects/*/secrets/*}:addVersion`. The field names are converted
+// to `snake_case`, to match the field names in the generated messages.
+func httpPath(t *api.PathTemplate) string {
+	var segments []string
+	for _, s := range t.Segments {
+		switch {
+		case s.Literal != nil:
+			segments = append(segments, *s.Literal)
+		case s.Variable != nil:
+			var names []string
+			for _, name := range s.Variable.FieldPath {
+				names = append(names, strcase.ToSnake(name))
+			}
+			name := strings.Join(names, ".")
+			if len(s.Variable.Segments) == 0 || slices.Equal(s.Variable.Segments, []string{"*"}) {
+				segments = append(segments, fmt.Sprintf("{%s}", name))
+				continue
+			}
+			segments = append(segments, fmt.Sprintf("{%s=%s}", name, strings.Join(s.Variable.Segments, "/")))
+		}
+	}
+	path := "/" + strings.Join(segments, "/")
+	if t.Verb != nil {
+		path += ":" + *t.Verb
+	}
+	return path
+}
+
+func (a *annotator) annotateMessage(m *api.Message, depth int) error {
+	indent := strings.Repeat(indentUnit, depth)
+	inner := indent + indentUnit
+	ann := &messageAnnotations{
+		Name:     localName(a.names[m.ID]),
+		Indent:   indent,
+		DocLines: docLines(m.Documentation, indent),
+	}
+	if m.Resource != nil {
+		a.imports[resourceProto] = true
+		ann.Options = append(ann.Options, resourceOption(m.Resource, inner)...)
+	}
+	if m.Deprecated {
+		ann.Options = append(ann.Options, inner+"option deprecated = true;")
+	}
+	for _, e := range m.Enums {
+		a.annotateEnum(e, depth+1)
+		ann.Enums = append(ann.Enums, e)
+	}
+	for _, child := range m.Messages {
+		if child.IsMap {
+			continue
+		}
+		if err := a.annotateMessage(child, depth+1); err != nil {
+			return err
+		}
+		ann.Messages = append(ann.Messages, child)
+	}
+	numbers := a.fieldNumbers(m)
+	groups := map[*api.OneOf]bool{}
+	for _, f := range m.Fields {
+		if f.IsOneOf && f.Group != nil {
+			if groups[f.Group] {
+				continue
+			}
+			groups[f.Group] = true
+			oneOf := &oneOfAnnotations{
+				Name:     strcase.ToSnake(f.Group.Name),
+				Indent:   inner,
+				DocLines: docLines(f.Group.Documentation, inner),
+			}
+			for _, member := range f.Group.Fields {
+				field, err := a.annotateField(member, numbers[member], inner+indentUnit)
+				if err != nil {
+					return err
+				}
+				oneOf.Fields = append(oneOf.Fields, field)
+			}
+			ann.Items = append(ann.Items, &messageItem{OneOf: oneOf})
+			continue
+		}
+		field, err := a.annotateField(f, numbers[f], inner)
+		if err != nil {
+			return err
+		}
+		ann.Items = append(ann.Items, &messageItem{Field: field})
+	}
+	m.Codec = ann
+	return nil
+}
+
+func resourceOption(r *api.Resource, indent string) []string {
+	lines := []string{
+		indent + "option (google.api.resource) = {",
+		fmt.Sprintf("%s%stype: %q", indent, indentUnit, r.Type),
+	}
+	for _, pattern := range r.Patterns {
+		lines = append(lines, fmt.Sprintf("%s%spattern: %q", indent, indentUnit, pattern.String()))
+	}
+	if r.Plural != "" {
+		lines = append(lines, fmt.Sprintf("%s%splural: %q", indent, indentUnit, r.Plural))
+	}
+	if r.Singular != "" {
+		lines = append(lines, fmt.Sprintf("%s%ssingular: %q", indent, indentUnit, r.Singular))
+	}
+	return append(lines, indent+"};")
+}
+
+// fieldNumbers returns the field number for each field in m.
+//
+// Fields parsed from protobuf keep their numbers. OpenAPI and Discovery
+// documents do not have field numbers, so these fields get new numbers that do
+// not collide with any existing number. By default the new numbers are
+// assigned in order. With `field-numbers = hash` the numbers are derived from
+// the field names, so they do not change when fields are added or reordered in
+// later revisions of the API.
+//
+// Hash collisions are resolved by rehashing the name with an increasing salt.
+// The fields are visited in name order, so the result does not depend on the
+// order of the fields in the document.
+func (a *annotator) fieldNumbers(m *api.Message) map[*api.Field]int {
+	numbers := map[*api.Field]int{}
+	used := map[int]bool{}
+	var unnumbered []*api.Field
+	for _, f := range m.Fields {
+		if f.Number == 0 {
+			unnumbered = append(unnumbered, f)
+			continue
+		}
+		numbers[f] = int(f.Number)
+		used[int(f.Number)] = true
+	}
+	available := func(number int) bool {
+		return !used[number] && (number < firstReservedNumber || number > lastReservedNumber)
+	}
+	if !a.hashNumbers {
+		next := 1
+		for _, f := range unnumbered {
+			for !available(next) {
+				next++
+			}
+			numbers[f] = next
+			used[next] = true
+		}
+		return numbers
+	}
+	slices.SortFunc(unnumbered, func(a, b *api.Field) int {
+		return strings.Compare(fieldName(a), fieldName(b))
+	})
+	for _, f := range unnumbered {
+		var number int
+		for salt := 0; ; salt++ {
+			number = hashFieldNumber(fieldName(f), salt)
+			if available(number) {
+				break
+			}
+		}
+		used[number] = true
+		numbers[f] = number
+	}
+	return numbers
+}
+
+// hashFieldNumber returns a field number in [1, maxFieldNumber] derived from
+// name. Non-zero values of salt yield different numbers for the same name.
+func hashFieldNumber(name string, salt int) int {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	if salt != 0 {
+		fmt.Fprintf(h, "#%d", salt)
+	}
+	return int(h.Sum32()%maxFieldNumber) + 1
+}
+
+func (a *annotator) annotateField(f *api.Field, number int, indent string) (*fieldAnnotations, error) {
+	typ, err := a.fieldType(f)
+	if err != nil {
+		return nil, err
+	}
+	name := fieldName(f)
+	var options []string
+	if f.JSONName != "" && f.JSONName != jsonName(name) {
+		options = append(options, fmt.Sprintf("json_name = %q", f.JSONName))
+	}
+	for _, b := range f.Behavior {
+		if behavior, ok := behaviorNames[b]; ok {
+			a.imports[fieldBehaviorProto] = true
+			options = append(options, "(google.api.field_behavior) = "+behavior)
+		}
+	}
+	if r := f.ResourceReference; r != nil {
+		a.imports[resourceProto] = true
+		if r.Type != "" {
+			options = append(options, fmt.Sprintf("(google.api.resource_reference) = { type: %q }", r.Type))
+		} else {
+			options = append(options, fmt.Sprintf("(google.api.resource_reference) = { child_type: %q }", r.ChildType))
+		}
+	}
+	if f.Deprecated {
+		options = append(options, "deprecated = true")
+	}
+	decl := fmt.Sprintf("%s%s %s = %d", indent, typ, name, number)
+	ann := &fieldAnnotations{DocLines: docLines(f.Documentation, indent)}
+	switch len(options) {
+	case 0:
+		ann.Lines = []string{decl + ";"}
+	case 1:
+		ann.Lines = []string{fmt.Sprintf("%s [%s];", decl, options[0])}
+	default:
+		ann.Lines = []string{decl + " ["}
+		for i, option := range options {
+			if i != len(options)-1 {
+				option += ","
+			}
+			ann.Lines = append(ann.Lines, indent+indentUnit+option)
+		}
+		ann.Lines = append(ann.Lines, indent+"];")
+	}
+	f.Codec = ann
+	return ann, nil
+}
+
+// fieldType returns the type of the field, including the `repeated` and
+// `optional` labels.
+//
+// The OpenAPI parser marks most fields as optional. Message fields always
+// track presence, so they do not need the label.
+func (a *annotator) fieldType(f *api.Field) (string, error) {
+	scope := ""
+	if f.Parent != nil {
+		scope = a.names[f.Parent.ID]
+	}
+	if f.Map {
+		return a.mapType(f, scope)
+	}
+	typ, err := a.elementType(f, scope)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case f.Repeated:
+		return "repeated " + typ, nil
+	case f.Optional && !f.IsOneOf && f.Typez != api.TypezMessage:
+		return "optional " + typ, nil
+	}
+	return typ, nil
+}
+
+func (a *annotator) mapType(f *api.Field, scope string) (string, error) {
+	entry := a.model.Message(f.TypezID)
+	if entry == nil {
+		return "", fmt.Errorf("%w: %s", errUnknownType, f.TypezID)
+	}
+	var key, value string
+	for _, field := range entry.Fields {
+		typ, err := a.elementType(field, scope)
+		if err != nil {
+			return "", err
+		}
+		switch field.Name {
+		case "key":
+			key = typ
+		case "value":
+			value = typ
+		}
+	}
+	if key == "" || value == "" {
+		return "", fmt.Errorf("map entry %s must have a key and a value field", f.TypezID)
+	}
+	return fmt.Sprintf("map<%s, %s>", key, value), nil
+}
+
+func (a *annotator) elementType(f *api.Field, scope string) (string, error) {
+	if name, ok := scalarNames[f.Typez]; ok {
+		return name, nil
+	}
+	switch f.Typez {
+	case api.TypezMessage, api.TypezEnum:
+		name, err := a.typeRef(f.TypezID)
+		if err != nil {
+			return "", err
+		}
+		return a.relativeName(name, scope), nil
+	}
+	return "", fmt.Errorf("unsupported type %s for field %s", f.Typez, f.ID)
+}
+
+// typeRef returns the name used to refer to a message or enum from the
+// generated file, and records any import needed to use it.
+func (a *annotator) typeRef(id string) (string, error) {
+	if name, ok := a.names[id]; ok {
+		return name, nil
+	}
+	file, ok := importFiles[id]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", errUnknownType, id)
+	}
+	a.imports[file] = true
+	return strings.TrimPrefix(id, "."), nil
+}
+
+// relativeName returns the shortest name that refers to name from the
+// message named scope, such as `State` for `Secret.State` in `Secret`.
+//
+// protoc resolves the first component of a name starting from the innermost
+// scope, so a shorter name is used only if no inner scope shadows it.
+func (a *annotator) relativeName(name, scope string) string {
+	for s := scope; s != ""; s = parentScope(s) {
+		rest, ok := strings.CutPrefix(name, s+".")
+		if !ok {
+			continue
+		}
+		if a.resolve(rest, scope) == name {
+			return rest
+		}
+		break
+	}
+	if !a.defined[name] || a.resolve(name, scope) == name {
+		return name
+	}
+	return "." + a.model.PackageName + "." + name
+}
+
+// resolve returns the message or enum that name refers to from scope, using
+// the protoc rules.
+func (a *annotator) resolve(name, scope string) string {
+	first, _, _ := strings.Cut(name, ".")
+	for s := scope; s != ""; s = parentScope(s) {
+		if a.defined[s+"."+first] {
+			return s + "." + name
+		}
+	}
+	return name
+}
+
+func parentScope(scope string) string {
+	idx := strings.LastIndex(scope, ".")
+	if idx == -1 {
+		return ""
+	}
+	return scope[:idx]
+}
+
+// typeName returns the name of a message, as used in string options such as
+// `google.longrunning.operation_info`.
+func (a *annotator) typeName(id string) string {
+	if name, ok := a.names[id]; ok {
+		return name
+	}
+	return strings.TrimPrefix(id, ".")
+}
+
+func (a *annotator) annotateEnum(e *api.Enum, depth int) {
+	indent := strings.Repeat(indentUnit, depth)
+	inner := indent + indentUnit
+	ann := &enumAnnotations{
+		Name:     e.Name,
+		Indent:   indent,
+		DocLines: docLines(e.Documentation, indent),
+	}
+	scope := ""
+	if e.Parent != nil {
+		scope = e.Parent.ID
+	}
+	used := a.enumValueNames[scope]
+	if used == nil {
+		used = map[string]bool{}
+		a.enumValueNames[scope] = used
+	}
+	prefix := strcase.ToScreamingSnake(e.Name) + "_"
+	uniqueName := func(name string) string {
+		if used[name] {
+			name = prefix + name
+		}
+		used[name] = true
+		return name
+	}
+
+	// In proto3 the first value must be zero.
+	var values []*api.EnumValue
+	for _, v := range e.Values {
+		if v.Number == 0 {
+			values = append(values, v)
+		}
+	}
+	for _, v := range e.Values {
+		if v.Number != 0 {
+			values = append(values, v)
+		}
+	}
+	if len(values) == 0 || values[0].Number != 0 {
+		ann.Values = append(ann.Values, &enumValueAnnotations{
+			DocLines: docLines("The value is not set.", inner),
+			Decl:     fmt.Sprintf("%s%s = 0;", inner, uniqueName(prefix+"UNSPECIFIED")),
+		})
+	}
+	numbers := map[int32]bool{}
+	for _, v := range values {
+		if numbers[v.Number] {
+			ann.Options = []string{inner + "option allow_alias = true;"}
+		}
+		numbers[v.Number] = true
+		name := uniqueName(enumValueName(v.Name))
+		lines := docLines(v.Documentation, inner)
+		if name != v.Name {
+			lines = append(lines, fmt.Sprintf("%s// The wire value is %q.", inner, v.Name))
+		}
+		decl := fmt.Sprintf("%s%s = %d", inner, name, v.Number)
+		if v.Deprecated {
+			decl += " [deprecated = true]"
+		}
+		ann.Values = append(ann.Values, &enumValueAnnotations{DocLines: lines, Decl: decl + ";"})
+	}
+	if e.Deprecated {
+		ann.Options = append(ann.Options, inner+"option deprecated = true;")
+	}
+	e.Codec = ann
+}
+
+// enumValueName returns a valid Protobuf identifier for an enum value.
+// Discovery documents allow any string as an enum value, such as `IPV4-ONLY`.
+func enumValueName(name string) string {
+	name = invalidIdentifierChars.ReplaceAllString(name, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return "VALUE_" + name
+	}
+	return name
+}
+
+// fieldName returns the name of the field in `snake_case`.
+func fieldName(f *api.Field) string {
+	return strcase.ToSnake(f.Name)
+}
+
+// jsonName returns the JSON name that protoc assigns to a field by default.
+func jsonName(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range name {
+		switch {
+		case r == '_':
+			upper = true
+		case upper:
+			b.WriteString(strings.ToUpper(string(r)))
+			upper = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// localName returns the last component of a (possibly nested) message name.
+func localName(name string) string {
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// docLines formats the documentation as `//` comments.
+func docLines(documentation, indent string) []string {
+	documentation = strings.TrimSpace(documentation)
+	if documentation == "" {
+		return nil
+	}
+	var lines []string
+	for _, line := range strings.Split(documentation, "\n") {
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			lines = append(lines, indent+"//")
+			continue
+		}
+		lines = append(lines, indent+"// "+line)
+	}
+	return lines
+}
@@ -0,0 +1,47 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protofile
+
+import "github.com/googleapis/librarian/internal/sidekick/language"
+
+const (
+	fieldNumbersSequential = "sequential"
+	fieldNumbersHash       = "hash"
+)
+
+// Options describes the codec options accepted by the proto file generator.
+var Options = &language.OptionSchema{
+	Codec: "protofile",
+	Options: []language.Option{
+		{Name: "copyright-year", Doc: "The year in the license header of the generated files, defaults to the current year."},
+		{
+			Name:   "field-numbers",
+			Values: []string{fieldNumbersSequential, fieldNumbersHash},
+			Doc:    "How to number fields that do not have a field number. `sequential` numbers the fields in order, `hash` derives each number from the field name, so numbers are stable when fields are added or reordered. Defaults to `sequential`.",
+		},
+		{Name: "file-name", Doc: "The name of the generated `.proto` file, defaults to the first label of the default host."},
+		{Name: "option:", Doc: "A file-level option, such as `'option:java_package' = 'com.google.cloud.compute.v1'`."},
+		{Name: "template-overlay", Doc: "A directory with templates that shadow the built-in templates."},
+	},
+}
+
+// codecOptions holds the decoded [Options].
+type codecOptions struct {
+	CopyrightYear   string            `option:"copyright-year"`
+	FieldNumbers    string            `option:"field-numbers"`
+	FileName        string            `option:"file-name"`
+	FileOptions     map[string]string `option:"option:"`
+	TemplateOverlay string            `option:"template-overlay"`
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package protofile converts the sidekick model into a `.proto` file and a
+// service config.
+//
+// The main use is to onboard APIs described by OpenAPI or Discovery documents
+// into tools that consume Protobuf specifications. The generated file includes
+// the HTTP, field behavior, resource and long-running operation annotations
+// found in the model.
+package protofile
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/license"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/language"
+	"github.com/googleapis/librarian/internal/yaml"
+)
+
+//go:embed all:templates
+var templates embed.FS
+
+// serviceConfig is the subset of `google.api.Service` written by the
+// generator.
+type serviceConfig struct {
+	Type          string                `yaml:"type"`
+	ConfigVersion int                   `yaml:"config_version"`
+	Name          string                `yaml:"name,omitempty"`
+	Title         string                `yaml:"title,omitempty"`
+	APIs          []serviceConfigAPI    `yaml:"apis,omitempty"`
+	Documentation *serviceConfigSummary `yaml:"documentation,omitempty"`
+}
+
+type serviceConfigAPI struct {
+	Name string `yaml:"name"`
+}
+
+type serviceConfigSummary struct {
+	Summary string `yaml:"summary"`
+}
+
+// Generate writes a `.proto` file and a service config for model in outdir.
+// The files are placed in the directory matching the package name, such as
+// `google/cloud/compute/v1/compute.proto`. The codec options are described in
+// [Options].
+func Generate(ctx context.Context, model *api.API, outdir string, codec map[string]string) error {
+	opts := &codecOptions{}
+	if err := Options.Decode(codec, opts); err != nil {
+		return err
+	}
+	ann, err := annotateModel(model, opts)
+	if err != nil {
+		return err
+	}
+	fsys := language.OverlayTemplates(templates, opts.TemplateOverlay)
+	provider := language.TemplatesProvider(fsys)
+	gen := language.GeneratedFile{
+		TemplatePath: "templates/proto/api.proto.mustache",
+		OutputPath:   ann.FilePath,
+	}
+	if err := language.GenerateFromModel(outdir, model, provider, []language.GeneratedFile{gen}); err != nil {
+		return err
+	}
+	if err := tidyFile(filepath.Join(outdir, ann.FilePath)); err != nil {
+		return err
+	}
+	return writeServiceConfig(filepath.Join(outdir, ann.ServiceConfigPath), model, ann)
+}
+
+// tidyFile removes the redundant blank lines produced by the templates.
+func tidyFile(name string) error {
+	contents, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, tidy(contents), 0666)
+}
+
+// tidy collapses consecutive blank lines, and removes the blank lines after
+// an opening brace, before a closing brace, and at the end of the file.
+//
+// The templates separate each element with a blank line, which is simpler
+// than tracking whether the element is the first or last in its block.
+func tidy(contents []byte) []byte {
+	var lines []string
+	blank := false
+	for _, line := range strings.Split(string(contents), "\n") {
+		if strings.TrimSpace(line) == "" {
+			blank = true
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		if blank && len(lines) > 0 && !strings.HasSuffix(lines[len(lines)-1], "{") && !strings.HasPrefix(trimmed, "}") {
+			lines = append(lines, "")
+		}
+		blank = false
+		lines = append(lines, line)
+	}
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+func writeServiceConfig(name string, model *api.API, ann *modelAnnotations) error {
+	config := &serviceConfig{
+		Type:          "google.api.Service",
+		ConfigVersion: 3,
+		Title:         model.Title,
+	}
+	for _, s := range model.Services {
+		if s.DefaultHost != "" {
+			config.Name = s.DefaultHost
+			break
+		}
+	}
+	for _, name := range ann.ServiceNames {
+		config.APIs = append(config.APIs, serviceConfigAPI{Name: name})
+	}
+	if summary := strings.TrimSpace(model.Description); summary != "" {
+		config.Documentation = &serviceConfigSummary{Summary: summary}
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	for _, line := range license.Header(ann.CopyrightYear) {
+		fmt.Fprintf(&buf, "#%s\n", line)
+	}
+	buf.WriteString("#\n# Code generated by sidekick. DO NOT EDIT.\n")
+	buf.Write(data)
+	return os.WriteFile(name, buf.Bytes(), 0666)
+}
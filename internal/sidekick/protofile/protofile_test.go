@@ -0,0 +1,568 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protofile
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	libconfig "github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/serviceconfig"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/language"
+	"github.com/googleapis/librarian/internal/sidekick/parser"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+const (
+	testPackage = "google.cloud.example.v1"
+	testdataDir = "../../testdata"
+)
+
+func TestGenerateGolden(t *testing.T) {
+	outDir := t.TempDir()
+	codec := map[string]string{
+		"copyright-year":          "2038",
+		"option:java_package":     "com.google.cloud.example.v1",
+		"option:cc_enable_arenas": "true",
+	}
+	if err := Generate(t.Context(), testModel(t), outDir, codec); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{
+		"google/cloud/example/v1/example.proto",
+		"google/cloud/example/v1/example_v1.yaml",
+	} {
+		t.Run(name, func(t *testing.T) {
+			content, err := os.ReadFile(filepath.Join(outDir, name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			golden := filepath.Join("testdata", "golden", filepath.Base(name))
+			if *updateGolden {
+				if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(golden, content, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(string(want), string(content)); diff != "" {
+				t.Errorf("mismatch (-want +got), run with -update to refresh the golden files:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateServiceConfig(t *testing.T) {
+	outDir := t.TempDir()
+	if err := Generate(t.Context(), testModel(t), outDir, map[string]string{}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := serviceconfig.Read(filepath.Join(outDir, "google/cloud/example/v1/example_v1.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.GetName() != "example.googleapis.com" {
+		t.Errorf("got name %q, want %q", got.GetName(), "example.googleapis.com")
+	}
+	if got.GetTitle() != "Example API" {
+		t.Errorf("got title %q, want %q", got.GetTitle(), "Example API")
+	}
+	var apis []string
+	for _, a := range got.GetApis() {
+		apis = append(apis, a.GetName())
+	}
+	if diff := cmp.Diff([]string{"google.cloud.example.v1.SecretService"}, apis); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateFromDiscovery(t *testing.T) {
+	cfg := &parser.ModelConfig{
+		SpecificationFormat: libconfig.SpecDiscovery,
+		ServiceConfig:       filepath.Join(testdataDir, "googleapis/google/cloud/compute/v1/small-compute_v1.yaml"),
+		SpecificationSource: filepath.Join(testdataDir, "discovery/small-compute.v1.json"),
+	}
+	model, err := parser.CreateModel(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	if err := Generate(t.Context(), model, outDir, map[string]string{"file-name": "small.proto"}); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(filepath.Join(outDir, "google/cloud/compute/v1/small.proto"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(content)
+	for _, want := range []string{
+		"package google.cloud.compute.v1;",
+		"service Instances {",
+		"rpc Get(GetRequest) returns (google.protobuf.Empty) {",
+		`get: "/compute/v1/p/{project}/z/{zone}/instances/{instance}"`,
+		"message GetRequest {",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in generated file:\n%s", want, got)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "google/cloud/compute/v1/compute_v1.yaml")); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGenerateDiscoveryLro(t *testing.T) {
+	operation := api.NewTestMessage("Operation").WithPackage(testPackage).
+		WithFields(api.NewTestField("name").WithType(api.TypezString))
+	request := api.NewTestMessage("InsertRequest").WithPackage(testPackage).
+		WithFields(api.NewTestField("project").WithType(api.TypezString))
+	pollRequest := api.NewTestMessage("GetOperationRequest").WithPackage(testPackage).
+		WithFields(api.NewTestField("operation").WithType(api.TypezString))
+	poll := api.NewTestMethod("get").
+		WithVerb("GET").
+		WithPathTemplate((&api.PathTemplate{}).WithLiteral("operations").WithVariableNamed("operation")).
+		WithInput(pollRequest).
+		WithOutput(operation)
+	poll.ID = ".google.cloud.example.v1.ZoneOperations.get"
+	insert := api.NewTestMethod("insert").
+		WithVerb("POST").
+		WithPathTemplate((&api.PathTemplate{}).WithLiteral("instances")).
+		WithInput(request).
+		WithOutput(operation)
+	insert.ID = ".google.cloud.example.v1.Instances.insert"
+	insert.DiscoveryLro = &api.DiscoveryLro{PollingPathParameters: []string{"project"}}
+	mixin := &api.Method{
+		Name:         "getOperation",
+		ID:           ".google.cloud.example.v1.Instances.getOperation",
+		InputTypeID:  poll.InputTypeID,
+		OutputTypeID: poll.OutputTypeID,
+		PathInfo:     poll.PathInfo,
+	}
+	operations := api.NewTestService("ZoneOperations").WithPackage(testPackage).WithMethods(poll)
+	instances := api.NewTestService("Instances").WithPackage(testPackage).WithMethods(insert, mixin)
+	model := api.NewTestAPI([]*api.Message{operation, request, pollRequest}, []*api.Enum{}, []*api.Service{operations, instances})
+	if err := api.CrossReference(model); err != nil {
+		t.Fatal(err)
+	}
+	outDir := t.TempDir()
+	if err := Generate(t.Context(), model, outDir, map[string]string{}); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(filepath.Join(outDir, "google/cloud/example/v1/Test.proto"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(content)
+	for _, want := range []string{
+		`import "google/cloud/extended_operations.proto";`,
+		`option (google.cloud.operation_service) = "ZoneOperations";`,
+		"option (google.cloud.operation_polling_method) = true;",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in generated file:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "rpc GetOperation") {
+		t.Errorf("the polling method should not be repeated in each service:\n%s", got)
+	}
+}
+
+func TestFieldNumbers(t *testing.T) {
+	fields := func(names ...string) *api.Message {
+		m := api.NewTestMessage("Message").WithPackage(testPackage)
+		for _, name := range names {
+			m = m.WithFields(api.NewTestField(name).WithType(api.TypezString))
+		}
+		return m
+	}
+	numbers := func(a *annotator, m *api.Message) map[string]int {
+		got := map[string]int{}
+		for f, n := range a.fieldNumbers(m) {
+			got[f.Name] = n
+		}
+		return got
+	}
+
+	sequential := newAnnotator(nil, &codecOptions{})
+	got := numbers(sequential, fields("b", "a"))
+	if diff := cmp.Diff(map[string]int{"b": 1, "a": 2}, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	hash := newAnnotator(nil, &codecOptions{FieldNumbers: fieldNumbersHash})
+	before := numbers(hash, fields("name", "etag"))
+	after := numbers(hash, fields("create_time", "etag", "name"))
+	for _, name := range []string{"name", "etag"} {
+		if before[name] != after[name] {
+			t.Errorf("the number for %q changed from %d to %d", name, before[name], after[name])
+		}
+		if n := after[name]; n < 1 || n > maxFieldNumber || (n >= firstReservedNumber && n <= lastReservedNumber) {
+			t.Errorf("invalid number %d for %q", n, name)
+		}
+	}
+
+	// These names have the same hash.
+	if hashFieldNumber("field_107872", 0) != hashFieldNumber("field_148820", 0) {
+		t.Fatalf("expected a collision between the test field names")
+	}
+	forward := numbers(hash, fields("field_107872", "field_148820"))
+	backward := numbers(hash, fields("field_148820", "field_107872"))
+	if diff := cmp.Diff(forward, backward); diff != "" {
+		t.Errorf("the numbers depend on the field order (-forward +backward):\n%s", diff)
+	}
+	if forward["field_107872"] == forward["field_148820"] {
+		t.Errorf("colliding fields got the same number %d", forward["field_107872"])
+	}
+
+	// Existing numbers are kept, and new numbers do not collide with them.
+	numbered := func(name string, number int32) *api.Field {
+		f := api.NewTestField(name).WithType(api.TypezString)
+		f.Number = number
+		return f
+	}
+	m := api.NewTestMessage("Message").WithPackage(testPackage).WithFields(
+		numbered("b", 0),
+		numbered("a", 1),
+		numbered("c", 0),
+		numbered("d", 3),
+	)
+	got = numbers(sequential, m)
+	if diff := cmp.Diff(map[string]int{"b": 2, "a": 1, "c": 4, "d": 3}, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	colliding := hashFieldNumber("field_107872", 0)
+	m = api.NewTestMessage("Message").WithPackage(testPackage).WithFields(
+		numbered("field_107872", 0),
+		numbered("existing", int32(colliding)),
+	)
+	got = numbers(hash, m)
+	if got["existing"] != colliding {
+		t.Errorf("the existing number changed from %d to %d", colliding, got["existing"])
+	}
+	if got["field_107872"] == colliding {
+		t.Errorf("the new number %d collides with an existing number", colliding)
+	}
+}
+
+func TestRelativeName(t *testing.T) {
+	a := newAnnotator(&api.API{PackageName: testPackage}, &codecOptions{})
+	for _, name := range []string{"State", "Secret", "Secret.State", "Secret.Replica", "Secret.Replica.State"} {
+		a.defined[name] = true
+	}
+	for _, test := range []struct {
+		name  string
+		scope string
+		want  string
+	}{
+		{"Secret.State", "Secret", "State"},
+		{"Secret.Replica.State", "Secret.Replica", "State"},
+		{"Secret.Replica", "Secret.Replica", "Replica"},
+		{"Secret.State", "Secret.Replica", "Secret.State"},
+		{"State", "", "State"},
+		{"State", "Secret", ".google.cloud.example.v1.State"},
+		{"google.protobuf.Timestamp", "Secret", "google.protobuf.Timestamp"},
+	} {
+		t.Run(test.name+" in "+test.scope, func(t *testing.T) {
+			if got := a.relativeName(test.name, test.scope); got != test.want {
+				t.Errorf("relativeName(%q, %q) = %q, want %q", test.name, test.scope, got, test.want)
+			}
+		})
+	}
+}
+
+func TestTidy(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "consecutive blank lines",
+			input: "a\n\n\n\nb\n",
+			want:  "a\n\nb\n",
+		},
+		{
+			name:  "braces",
+			input: "message A {\n\n  int32 a = 1;\n\n}\n\n",
+			want:  "message A {\n  int32 a = 1;\n}\n",
+		},
+		{
+			name:  "leading blank lines",
+			input: "\n\nsyntax = \"proto3\";\n",
+			want:  "syntax = \"proto3\";\n",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := string(tidy([]byte(test.input)))
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEnumValueName(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		want string
+	}{
+		{"ACTIVE", "ACTIVE"},
+		{"IPV4-ONLY", "IPV4_ONLY"},
+		{"3D", "VALUE_3D"},
+		{"", "VALUE_"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := enumValueName(test.name); got != test.want {
+				t.Errorf("enumValueName(%q) = %q, want %q", test.name, got, test.want)
+			}
+		})
+	}
+}
+
+func TestJSONName(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		want string
+	}{
+		{"name", "name"},
+		{"create_time", "createTime"},
+		{"data_crc32c", "dataCrc32c"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := jsonName(test.name); got != test.want {
+				t.Errorf("jsonName(%q) = %q, want %q", test.name, got, test.want)
+			}
+		})
+	}
+}
+
+func TestGenerate_UnknownOption(t *testing.T) {
+	err := Generate(t.Context(), testModel(t), t.TempDir(), map[string]string{"file-nmae": "example.proto"})
+	if !errors.Is(err, language.ErrUnknownOption) {
+		t.Errorf("Generate() error = %v, want %v", err, language.ErrUnknownOption)
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		modify func(*api.API)
+		want   error
+	}{
+		{
+			name:   "missing package",
+			modify: func(model *api.API) { model.PackageName = "" },
+			want:   errMissingPackage,
+		},
+		{
+			name: "unknown type",
+			modify: func(model *api.API) {
+				secret := model.Message(".google.cloud.example.v1.Secret")
+				secret.Fields[0].Typez = api.TypezMessage
+				secret.Fields[0].TypezID = ".google.example.Unknown"
+			},
+			want: errUnknownType,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			model := testModel(t)
+			test.modify(model)
+			err := Generate(t.Context(), model, t.TempDir(), map[string]string{})
+			if !errors.Is(err, test.want) {
+				t.Errorf("Generate() error = %v, want %v", err, test.want)
+			}
+		})
+	}
+}
+
+func TestHoistedNames(t *testing.T) {
+	placeholder := func(service string, methods ...string) *api.Message {
+		m := api.NewTestMessage(service).WithPackage(testPackage)
+		m.ServicePlaceholder = true
+		for _, method := range methods {
+			request := api.NewTestMessage(method + "Request").WithID(m.ID + "." + method + "Request")
+			request.SyntheticRequest = true
+			request.Parent = m
+			m.Messages = append(m.Messages, request)
+		}
+		return m
+	}
+	model := api.NewTestAPI([]*api.Message{
+		api.NewTestMessage("DeleteRequest").WithPackage(testPackage),
+		placeholder("instances", "get", "insert", "delete"),
+		placeholder("disks", "get"),
+	}, []*api.Enum{}, []*api.Service{})
+	a := newAnnotator(model, &codecOptions{})
+	if _, err := a.topLevelMessages(); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		".google.cloud.example.v1.DeleteRequest":           "DeleteRequest",
+		".google.cloud.example.v1.instances.getRequest":    "GetInstancesRequest",
+		".google.cloud.example.v1.instances.insertRequest": "InsertRequest",
+		".google.cloud.example.v1.instances.deleteRequest": "DeleteInstancesRequest",
+		".google.cloud.example.v1.disks.getRequest":        "GetDisksRequest",
+	}
+	if diff := cmp.Diff(want, a.names); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func testModel(t *testing.T) *api.API {
+	t.Helper()
+	state := &api.Enum{
+		Name:          "State",
+		ID:            ".google.cloud.example.v1.Secret.State",
+		Package:       testPackage,
+		Documentation: "The state of a secret.",
+		Values: []*api.EnumValue{
+			{Name: "STATE_UNSPECIFIED", Number: 0, Documentation: "Not specified."},
+			{Name: "ENABLED", Number: 1, Documentation: "The secret can be used."},
+			{Name: "ACTIVE", Number: 1, Deprecated: true},
+		},
+	}
+	shade := &api.Enum{
+		Name:          "Shade",
+		ID:            ".google.cloud.example.v1.Shade",
+		Package:       testPackage,
+		Documentation: "Values from a Discovery document.",
+		Values: []*api.EnumValue{
+			{Name: "LIGHT", Number: 1},
+			{Name: "dark-grey", Number: 2, Documentation: "Between light and black."},
+			{Name: "3D", Number: 3},
+		},
+	}
+	labels := api.NewTestMessage("LabelsEntry").WithID(".google.cloud.example.v1.Secret.LabelsEntry").
+		WithFields(
+			api.NewTestField("key").WithType(api.TypezString),
+			api.NewTestField("value").WithType(api.TypezString),
+		)
+	labels.Package = testPackage
+	labels.IsMap = true
+	replica := api.NewTestMessage("Replica").WithID(".google.cloud.example.v1.Secret.Replica").
+		WithFields(api.NewTestField("location").WithType(api.TypezString))
+	replica.Package = testPackage
+	replica.Documentation = "Where the secret is stored."
+
+	expireTime := &api.Field{Name: "expire_time", JSONName: "expireTime", Typez: api.TypezMessage, TypezID: ".google.protobuf.Timestamp", IsOneOf: true}
+	ttl := &api.Field{Name: "ttl", JSONName: "ttl", Typez: api.TypezMessage, TypezID: ".google.protobuf.Duration", IsOneOf: true,
+		Documentation: "Input only. The TTL for the secret."}
+	expiration := &api.OneOf{
+		Name:          "expiration",
+		ID:            ".google.cloud.example.v1.Secret.expiration",
+		Documentation: "When the secret expires.",
+		Fields:        []*api.Field{expireTime, ttl},
+	}
+	expireTime.Group = expiration
+	ttl.Group = expiration
+	secret := api.NewTestMessage("Secret").WithPackage(testPackage).
+		WithResource(api.NewTestResource("example.googleapis.com/Secret").WithPatterns(api.ResourcePattern{
+			*(&api.PathSegment{}).WithLiteral("projects"),
+			*(&api.PathSegment{}).WithVariable(api.NewPathVariable("project")),
+			*(&api.PathSegment{}).WithLiteral("secrets"),
+			*(&api.PathSegment{}).WithVariable(api.NewPathVariable("secret")),
+		}).WithSingular("secret")).
+		WithFields(
+			api.NewTestField("name").WithType(api.TypezString).WithBehavior(api.FieldBehaviorIdentifier),
+			&api.Field{Name: "labels", JSONName: "labels", Typez: api.TypezMessage, TypezID: labels.ID, Map: true,
+				Documentation: "The labels assigned to this secret."},
+			&api.Field{Name: "state", JSONName: "state", Typez: api.TypezEnum, TypezID: state.ID,
+				Behavior: []api.FieldBehavior{api.FieldBehaviorOutputOnly}},
+			expireTime,
+			ttl,
+			&api.Field{Name: "displayName", JSONName: "displayName", Typez: api.TypezString, Optional: true},
+			&api.Field{Name: "kms_key_name", JSONName: "kmsKey", Typez: api.TypezString},
+			api.NewTestField("tags").WithType(api.TypezString).WithRepeated().
+				WithBehavior(api.FieldBehaviorOptional, api.FieldBehaviorUnorderedList),
+			&api.Field{Name: "replicas", JSONName: "replicas", Typez: api.TypezMessage, TypezID: replica.ID, Repeated: true},
+			&api.Field{Name: "shade", JSONName: "shade", Typez: api.TypezEnum, TypezID: shade.ID},
+			&api.Field{Name: "etag", JSONName: "etag", Typez: api.TypezString, Deprecated: true},
+		)
+	secret.OneOfs = []*api.OneOf{expiration}
+	secret.Documentation = "A secret.\n\nSecrets have versions."
+
+	getRequest := api.NewTestMessage("GetSecretRequest").WithPackage(testPackage).
+		WithFields(api.NewTestField("name").WithType(api.TypezString).
+			WithBehavior(api.FieldBehaviorRequired).
+			WithResourceReference("example.googleapis.com/Secret"))
+	createRequest := api.NewTestMessage("CreateSecretRequest").WithPackage(testPackage).
+		WithFields(
+			api.NewTestField("parent").WithType(api.TypezString).WithChildTypeReference("example.googleapis.com/Secret"),
+			api.NewTestField("secret").WithMessageType(secret),
+		)
+	metadata := api.NewTestMessage("OperationMetadata").WithPackage(testPackage).
+		WithFields(&api.Field{Name: "create_time", JSONName: "createTime", Typez: api.TypezMessage, TypezID: ".google.protobuf.Timestamp"})
+	metadata.Deprecated = true
+	operation := api.NewTestMessage("Operation").WithPackage("google.longrunning")
+
+	get := api.NewTestMethod("GetSecret").
+		WithVerb("GET").
+		WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1").WithVariable(api.NewPathVariable("name").
+			WithLiteral("projects").WithMatch().WithLiteral("secrets").WithMatch())).
+		WithInput(getRequest).
+		WithOutput(secret)
+	get.ID = ".google.cloud.example.v1.SecretService.GetSecret"
+	get.Documentation = "Gets a secret."
+	get.PathInfo.Bindings = append(get.PathInfo.Bindings, &api.PathBinding{
+		Verb: "HEAD",
+		PathTemplate: (&api.PathTemplate{}).WithLiteral("v1").WithVariable(api.NewPathVariable("name").
+			WithLiteral("projects").WithMatch().WithLiteral("locations").WithMatch().WithLiteral("secrets").WithMatch()),
+	})
+	create := api.NewTestMethod("CreateSecret").
+		WithVerb("POST").
+		WithPathTemplate((&api.PathTemplate{}).WithLiteral("v1").WithVariable(api.NewPathVariable("parent").
+			WithLiteral("projects").WithMatch()).WithLiteral("secrets")).
+		WithInput(createRequest).
+		WithOutput(operation)
+	create.ID = ".google.cloud.example.v1.SecretService.CreateSecret"
+	create.PathInfo.BodyFieldPath = "secret"
+	create.OperationInfo = &api.OperationInfo{ResponseTypeID: secret.ID, MetadataTypeID: metadata.ID}
+	watch := api.NewTestMethod("WatchSecrets").WithInput(getRequest).WithOutput(secret)
+	watch.ID = ".google.cloud.example.v1.SecretService.WatchSecrets"
+	watch.PathInfo = nil
+	watch.ServerSideStreaming = true
+	watch.Deprecated = true
+
+	service := api.NewTestService("SecretService").WithPackage(testPackage).WithMethods(get, create, watch)
+	service.Documentation = "Manages secrets."
+	service.DefaultHost = "example.googleapis.com"
+	model := api.NewTestAPI(
+		[]*api.Message{secret, labels, replica, getRequest, createRequest, metadata},
+		[]*api.Enum{state, shade},
+		[]*api.Service{service})
+	model.State.MessageByID[operation.ID] = operation
+	// The parsers only list the top-level elements in the model.
+	model.Messages = []*api.Message{secret, getRequest, createRequest, metadata}
+	model.Enums = []*api.Enum{shade}
+	model.Title = "Example API"
+	model.Description = "An API to test the generator."
+	if err := api.CrossReference(model); err != nil {
+		t.Fatal(err)
+	}
+	return model
+}
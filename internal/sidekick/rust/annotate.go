@@ -36,6 +36,9 @@ type modelAnnotations struct {
 	RequiredPackages []string
 	ExternPackages   []string
 	HasLROs          bool
+	// The Cargo feature that enables the streaming methods. Only set if the
+	// package generates at least one streaming method.
+	StreamingFeature string
 	CopyrightYear    string
 	BoilerPlate      []string
 	DefaultHost      string
@@ -78,6 +81,13 @@ type modelAnnotations struct {
 	// selected at the model level will be skipped for Rust generation
 	// so we need to choose a different one.
 	QuickstartService *api.Service
+	// The resource name helpers defined in this package.
+	ResourceNames []*resourceNameAnnotations
+}
+
+// HasResourceNames returns true if the package defines resource name helpers.
+func (m *modelAnnotations) HasResourceNames() bool {
+	return len(m.ResourceNames) != 0
 }
 
 // IsWktCrate returns true when bootstrapping the well-known types crate the templates add some
@@ -269,6 +279,34 @@ type methodAnnotation struct {
 	HasResourceNameGeneration bool
 	ResourceNameTemplateGrpc  string
 	GrpcResourceNameArgs      []string
+	// The request type in the stub traits. For client-side and bidirectional
+	// streaming methods this is a stream of requests.
+	StubRequestType string
+	// The response type in the stub traits. For server-side and bidirectional
+	// streaming methods this is a stream of responses.
+	StubReturnType string
+	// Exactly one of these is true for streaming methods.
+	ServerStreaming bool
+	ClientStreaming bool
+	BidiStreaming   bool
+	// The Cargo feature required to use the method. Only set for streaming
+	// methods.
+	StreamingFeature string
+}
+
+// streamingFeature is the Cargo feature that enables the streaming methods.
+// These methods depend on `google-cloud-gax` APIs that are not stable yet, so
+// the generated crates do not enable the feature by default.
+const streamingFeature = "unstable-streaming"
+
+// StreamingRequest returns true if the method receives a stream of requests.
+func (m *methodAnnotation) StreamingRequest() bool {
+	return m.ClientStreaming || m.BidiStreaming
+}
+
+// StreamingResponse returns true if the method returns a stream of responses.
+func (m *methodAnnotation) StreamingResponse() bool {
+	return m.ServerStreaming || m.BidiStreaming
 }
 
 // HasGrpcResourceNameArgs returns true if the method has gRPC resource name arguments.
@@ -547,6 +585,49 @@ type fieldAnnotations struct {
 	// If this field is part of a oneof group, this will contain the other fields
 	// in the group.
 	OtherFieldsInGroup []*api.Field
+	// For fields referencing a resource, the fully qualified name of the
+	// resource name helper, such as `crate::model::SecretName`.
+	ResourceName string
+}
+
+type resourceNameAnnotations struct {
+	// The name of the struct or enum, such as `SecretName`.
+	Name           string
+	Type           string
+	IsMultiPattern bool
+	Patterns       []*resourcePatternAnnotations
+}
+
+type resourcePatternAnnotations struct {
+	Template string
+	// The enum variant, only used for multi-pattern resources.
+	VariantName string
+	// The prefix for the wildcard parent constant, only used for
+	// multi-pattern resources.
+	ConstantPrefix string
+	Variables      []*resourceVariableAnnotations
+	// The parameters for `new()`, such as
+	// `project: impl std::convert::Into<std::string::String>`.
+	Parameters string
+	// The pattern to match in `parse()`, such as `Some("projects"), None`.
+	Matcher string
+	// The name for the parsed values, `_` if the pattern has no variables.
+	Binding string
+	// The format string to display the name, such as `projects/{}`.
+	FormatString   string
+	WildcardParent string
+}
+
+// HasVariables returns true if the pattern has any variables.
+func (p *resourcePatternAnnotations) HasVariables() bool {
+	return len(p.Variables) != 0
+}
+
+type resourceVariableAnnotations struct {
+	// The variable name in the pattern.
+	Name      string
+	FieldName string
+	Index     int
 }
 
 // SkipIfIsEmpty returns true if the field should be skipped if it is empty.
@@ -615,6 +696,12 @@ type enumValueAnnotation struct {
 // [Template.Services] field.
 func annotateModel(model *api.API, codec *codec) (*modelAnnotations, error) {
 	codec.hasServices = len(model.Services) > 0
+	// The field annotations need the resource name helpers.
+	codec.resourceNames = language.ResourceNames(model)
+	var resourceNames []*resourceNameAnnotations
+	for _, r := range codec.resourceNames {
+		resourceNames = append(resourceNames, annotateResourceName(r))
+	}
 
 	resolveUsedPackages(model, codec.extraPackages)
 	// Annotate enums and messages that we intend to generate. In the
@@ -650,6 +737,7 @@ func annotateModel(model *api.API, codec *codec) (*modelAnnotations, error) {
 		}
 	}
 	hasLROs := false
+	var streamingFeatureName string
 	for _, s := range model.Services {
 		for _, m := range s.Methods {
 			if m.OperationInfo != nil || m.DiscoveryLro != nil {
@@ -658,6 +746,9 @@ func annotateModel(model *api.API, codec *codec) (*modelAnnotations, error) {
 			if !codec.generateMethod(m) {
 				continue
 			}
+			if m.ClientSideStreaming || m.ServerSideStreaming {
+				streamingFeatureName = streamingFeature
+			}
 			if _, err := codec.annotateMethod(m); err != nil {
 				return nil, err
 			}
@@ -727,6 +818,7 @@ func annotateModel(model *api.API, codec *codec) (*modelAnnotations, error) {
 		RequiredPackages: requiredPackages(codec.extraPackages),
 		ExternPackages:   externPackages(codec.extraPackages),
 		HasLROs:          hasLROs,
+		StreamingFeature: streamingFeatureName,
 		CopyrightYear:    codec.generationYear,
 		BoilerPlate: append(license.HeaderBulk(),
 			"",
@@ -748,6 +840,7 @@ func annotateModel(model *api.API, codec *codec) (*modelAnnotations, error) {
 		DetailedTracingAttributes: codec.detailedTracingAttributes,
 		InternalBuilders:          codec.internalBuilders,
 		QuickstartService:         quickstartService,
+		ResourceNames:             resourceNames,
 	}
 
 	codec.addFeatureAnnotations(model, ann)
@@ -972,6 +1065,17 @@ func (c *codec) annotateMethod(m *api.Method) (*methodAnnotation, error) {
 	if m.ReturnsEmpty {
 		returnType = "()"
 	}
+	requestType, err := c.methodInOutTypeName(m.InputTypeID, m.Model, m.Model.PackageName)
+	if err != nil {
+		return nil, err
+	}
+	stubRequestType, stubReturnType := requestType, returnType
+	if m.ClientSideStreaming {
+		stubRequestType = fmt.Sprintf("google_cloud_gax::streaming::RequestStream<%s>", requestType)
+	}
+	if m.ServerSideStreaming {
+		stubReturnType = fmt.Sprintf("google_cloud_gax::streaming::Streaming<%s>", returnType)
+	}
 	serviceName := c.ServiceName(m.Service)
 	systemParameters := slices.Clone(c.systemParameters)
 	if m.APIVersion != "" {
@@ -1000,6 +1104,11 @@ func (c *codec) annotateMethod(m *api.Method) (*methodAnnotation, error) {
 		RoutingRequired:           c.routingRequired,
 		DetailedTracingAttributes: c.detailedTracingAttributes,
 		InternalBuilders:          c.internalBuilders,
+		StubRequestType:           stubRequestType,
+		StubReturnType:            stubReturnType,
+		ServerStreaming:           m.ServerSideStreaming && !m.ClientSideStreaming,
+		ClientStreaming:           m.ClientSideStreaming && !m.ServerSideStreaming,
+		BidiStreaming:             m.ClientSideStreaming && m.ServerSideStreaming,
 	}
 
 	if err := c.annotateResourceNameGeneration(m, annotation); err != nil {
@@ -1010,6 +1119,12 @@ func (c *codec) annotateMethod(m *api.Method) (*methodAnnotation, error) {
 		// a recommendation that is not applicable to generated code.
 		annotation.Attributes = []string{"#[allow(clippy::should_implement_trait)]"}
 	}
+	if m.ClientSideStreaming || m.ServerSideStreaming {
+		annotation.StreamingFeature = streamingFeature
+		annotation.Attributes = append(annotation.Attributes,
+			fmt.Sprintf(`#[cfg(feature = "%s")]`, streamingFeature),
+			fmt.Sprintf(`#[cfg_attr(docsrs, doc(cfg(feature = "%s")))]`, streamingFeature))
+	}
 	if m.OperationInfo != nil {
 		metadataType, err := c.methodInOutTypeName(m.OperationInfo.MetadataTypeID, m.Model, m.Model.PackageName)
 		if err != nil {
@@ -1323,6 +1438,7 @@ func (c *codec) annotateField(field *api.Field, message *api.Message, model *api
 		return nil, err
 	}
 	ann := &fieldAnnotations{
+		ResourceName:       c.resourceNameFor(field),
 		FieldName:          toSnake(field.Name),
 		SetterName:         toSnakeNoMangling(field.Name),
 		FQMessageName:      fqMessageName,
@@ -1623,3 +1739,63 @@ func mapToBoxed(field *api.Field, message *api.Message, model *api.API) bool {
 	visited := make(map[string]bool)
 	return check(field.TypezID, message.ID, visited)
 }
+
+// resourceNameFor returns the resource name helper for fields referencing a
+// resource, or an empty string if there is no helper.
+//
+// Only singular, non-optional string fields use the helpers. The setters for
+// these fields accept the helper because it converts to `String`.
+func (c *codec) resourceNameFor(field *api.Field) string {
+	if field.ResourceReference == nil || field.Typez != api.TypezString || field.IsOneOf {
+		return ""
+	}
+	if field.Repeated || field.Map || field.Optional {
+		return ""
+	}
+	name := language.ResourceNameFor(c.resourceNames, field.ResourceReference.Type)
+	if name == nil {
+		return ""
+	}
+	return c.modulePath + "::" + name.Name
+}
+
+func annotateResourceName(r *language.ResourceName) *resourceNameAnnotations {
+	ann := &resourceNameAnnotations{
+		Name:           toPascal(r.Name),
+		Type:           r.Resource.Type,
+		IsMultiPattern: r.IsMultiPattern(),
+	}
+	for _, p := range r.Patterns {
+		ann.Patterns = append(ann.Patterns, annotateResourcePattern(p))
+	}
+	r.Resource.Codec = ann
+	return ann
+}
+
+func annotateResourcePattern(p *language.ResourceNamePattern) *resourcePatternAnnotations {
+	ann := &resourcePatternAnnotations{
+		Template:       p.Template,
+		VariantName:    toPascal(p.Name),
+		ConstantPrefix: toScreamingSnake(p.Name) + "_",
+		Binding:        "_",
+		WildcardParent: p.WildcardParent,
+	}
+	var parameters, matcher, format []string
+	for _, s := range p.Segments {
+		if s.Variable == "" {
+			matcher = append(matcher, fmt.Sprintf("std::option::Option::Some(%q)", s.Literal))
+			format = append(format, s.Literal)
+			continue
+		}
+		v := &resourceVariableAnnotations{Name: s.Variable, FieldName: toSnake(s.Variable), Index: len(ann.Variables)}
+		ann.Variables = append(ann.Variables, v)
+		parameters = append(parameters, fmt.Sprintf("%s: impl std::convert::Into<std::string::String>", v.FieldName))
+		matcher = append(matcher, "std::option::Option::None")
+		format = append(format, "{}")
+		ann.Binding = "values"
+	}
+	ann.Parameters = strings.Join(parameters, ", ")
+	ann.Matcher = strings.Join(matcher, ", ")
+	ann.FormatString = strings.Join(format, "/")
+	return ann
+}
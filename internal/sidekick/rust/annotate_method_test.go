@@ -52,6 +52,8 @@ func TestAnnotateMethodNames(t *testing.T) {
 				ServiceNameToCamel:  "resourceService",
 				ServiceNameToSnake:  "resource_service",
 				ReturnType:          "crate::model::Response",
+				StubRequestType:     "crate::model::Request",
+				StubReturnType:      "crate::model::Response",
 			},
 		},
 		{
@@ -65,6 +67,8 @@ func TestAnnotateMethodNames(t *testing.T) {
 				ServiceNameToCamel:  "resourceService",
 				ServiceNameToSnake:  "resource_service",
 				ReturnType:          "()",
+				StubRequestType:     "crate::model::Request",
+				StubReturnType:      "()",
 			},
 		},
 		{
@@ -78,6 +82,8 @@ func TestAnnotateMethodNames(t *testing.T) {
 				ServiceNameToCamel:  "resourceService",
 				ServiceNameToSnake:  "resource_service",
 				ReturnType:          "crate::model::Response",
+				StubRequestType:     "crate::model::Request",
+				StubReturnType:      "crate::model::Response",
 			},
 		},
 	} {
@@ -415,7 +421,7 @@ func TestAnnotateMethodResourceNameTemplate(t *testing.T) {
 				"SystemParameters", "ReturnType", "PathInfo", "Attributes",
 				"RoutingRequired", "DetailedTracingAttributes",
 				"ResourceNameTemplateGrpc", "GrpcResourceNameArgs",
-				"InternalBuilders")); diff != "" {
+				"InternalBuilders", "StubRequestType", "StubReturnType")); diff != "" {
 				t.Errorf("mismatch (-want, +got):\n%s", diff)
 			}
 
@@ -0,0 +1,158 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rust
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	libconfig "github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+func TestAnnotateResourceNames(t *testing.T) {
+	secret := api.NewTestMessage("Secret").
+		WithResource(api.NewTestResource("test.googleapis.com/Secret").WithPatterns(
+			api.NewTestResourcePattern("projects", "project", "secrets", "secret"),
+			api.NewTestResourcePattern("projects", "project", "locations", "location", "secrets", "secret"),
+		))
+	model := api.NewTestAPI([]*api.Message{secret}, []*api.Enum{}, []*api.Service{})
+	codec := newTestCodec(t, libconfig.SpecProtobuf, "", map[string]string{})
+	got, err := annotateModel(model, codec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*resourceNameAnnotations{
+		{
+			Name:           "SecretName",
+			Type:           "test.googleapis.com/Secret",
+			IsMultiPattern: true,
+			Patterns: []*resourcePatternAnnotations{
+				{
+					Template:       "projects/{project}/secrets/{secret}",
+					VariantName:    "ProjectSecret",
+					ConstantPrefix: "PROJECT_SECRET_",
+					Variables: []*resourceVariableAnnotations{
+						{Name: "project", FieldName: "project", Index: 0},
+						{Name: "secret", FieldName: "secret", Index: 1},
+					},
+					Parameters:     "project: impl std::convert::Into<std::string::String>, secret: impl std::convert::Into<std::string::String>",
+					Matcher:        `std::option::Option::Some("projects"), std::option::Option::None, std::option::Option::Some("secrets"), std::option::Option::None`,
+					Binding:        "values",
+					FormatString:   "projects/{}/secrets/{}",
+					WildcardParent: "projects/-",
+				},
+				{
+					Template:       "projects/{project}/locations/{location}/secrets/{secret}",
+					VariantName:    "ProjectLocationSecret",
+					ConstantPrefix: "PROJECT_LOCATION_SECRET_",
+					Variables: []*resourceVariableAnnotations{
+						{Name: "project", FieldName: "project", Index: 0},
+						{Name: "location", FieldName: "location", Index: 1},
+						{Name: "secret", FieldName: "secret", Index: 2},
+					},
+					Parameters:     "project: impl std::convert::Into<std::string::String>, location: impl std::convert::Into<std::string::String>, secret: impl std::convert::Into<std::string::String>",
+					Matcher:        `std::option::Option::Some("projects"), std::option::Option::None, std::option::Option::Some("locations"), std::option::Option::None, std::option::Option::Some("secrets"), std::option::Option::None`,
+					Binding:        "values",
+					FormatString:   "projects/{}/locations/{}/secrets/{}",
+					WildcardParent: "projects/-/locations/-",
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got.ResourceNames); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if !got.HasResourceNames() {
+		t.Errorf("expected HasResourceNames() to be true")
+	}
+}
+
+func TestAnnotateResourcePattern_NoVariables(t *testing.T) {
+	settings := api.NewTestMessage("Settings").
+		WithResource(api.NewTestResource("test.googleapis.com/Settings").WithPatterns(
+			api.ResourcePattern{*(&api.PathSegment{}).WithLiteral("settings")},
+		))
+	model := api.NewTestAPI([]*api.Message{settings}, []*api.Enum{}, []*api.Service{})
+	codec := newTestCodec(t, libconfig.SpecProtobuf, "", map[string]string{})
+	got, err := annotateModel(model, codec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &resourcePatternAnnotations{
+		Template:       "settings",
+		VariantName:    "Settings",
+		ConstantPrefix: "SETTINGS_",
+		Matcher:        `std::option::Option::Some("settings")`,
+		Binding:        "_",
+		FormatString:   "settings",
+	}
+	if len(got.ResourceNames) != 1 || len(got.ResourceNames[0].Patterns) != 1 {
+		t.Fatalf("expected a single resource name with a single pattern, got %v", got.ResourceNames)
+	}
+	if diff := cmp.Diff(want, got.ResourceNames[0].Patterns[0]); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if got.ResourceNames[0].Patterns[0].HasVariables() {
+		t.Errorf("expected HasVariables() to be false")
+	}
+}
+
+func TestAnnotateField_ResourceReference(t *testing.T) {
+	secret := api.NewTestMessage("Secret").
+		WithResource(api.NewTestResource("test.googleapis.com/Secret").WithPatterns(
+			api.NewTestResourcePattern("projects", "project", "secrets", "secret"),
+		))
+	const reference = "test.googleapis.com/Secret"
+	for _, test := range []struct {
+		name  string
+		field *api.Field
+		want  string
+	}{
+		{
+			name:  "singular",
+			field: api.NewTestField("name").WithType(api.TypezString).WithResourceReference(reference),
+			want:  "crate::model::SecretName",
+		},
+		{
+			name:  "repeated",
+			field: api.NewTestField("names").WithType(api.TypezString).WithResourceReference(reference).WithRepeated(),
+			want:  "",
+		},
+		{
+			name:  "unknown",
+			field: api.NewTestField("name").WithType(api.TypezString).WithResourceReference("test.googleapis.com/Unknown"),
+			want:  "",
+		},
+		{
+			name:  "no reference",
+			field: api.NewTestField("name").WithType(api.TypezString),
+			want:  "",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			request := api.NewTestMessage("GetSecretRequest").WithFields(test.field)
+			model := api.NewTestAPI([]*api.Message{secret, request}, []*api.Enum{}, []*api.Service{})
+			codec := newTestCodec(t, libconfig.SpecProtobuf, "", map[string]string{})
+			if _, err := annotateModel(model, codec); err != nil {
+				t.Fatal(err)
+			}
+			got := test.field.Codec.(*fieldAnnotations).ResourceName
+			if got != test.want {
+				t.Errorf("ResourceName = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
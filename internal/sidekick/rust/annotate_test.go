@@ -209,6 +209,8 @@ func TestServiceAnnotations(t *testing.T) {
 		ServiceNameToCamel:  "resourceService",
 		ServiceNameToSnake:  "resource_service",
 		ReturnType:          "crate::model::Response",
+		StubRequestType:     "crate::model::Request",
+		StubReturnType:      "crate::model::Response",
 	}
 	if diff := cmp.Diff(wantMethod, method.Codec); diff != "" {
 		t.Errorf("mismatch in method annotations (-want, +got)\n:%s", diff)
@@ -227,6 +229,8 @@ func TestServiceAnnotations(t *testing.T) {
 		ServiceNameToCamel:  "resourceService",
 		ServiceNameToSnake:  "resource_service",
 		ReturnType:          "()",
+		StubRequestType:     "crate::model::Request",
+		StubReturnType:      "()",
 	}
 	if diff := cmp.Diff(wantMethod, emptyMethod.Codec); diff != "" {
 		t.Errorf("mismatch in method annotations (-want, +got)\n:%s", diff)
@@ -506,7 +510,7 @@ func TestServiceAnnotationsNameOverrides(t *testing.T) {
 		t.Errorf("mismatch in service annotations (-want, +got)\n:%s", diff)
 	}
 
-	methodFilter := cmpopts.IgnoreFields(methodAnnotation{}, "Name", "NameNoMangling", "BuilderName", "Body", "PathInfo", "SystemParameters", "ReturnType")
+	methodFilter := cmpopts.IgnoreFields(methodAnnotation{}, "Name", "NameNoMangling", "BuilderName", "Body", "PathInfo", "SystemParameters", "ReturnType", "StubRequestType", "StubReturnType")
 	wantMethod := &methodAnnotation{
 		ServiceNameToPascal: "Renamed",
 		ServiceNameToCamel:  "renamed",
@@ -67,140 +67,73 @@ func newCodec(specificationFormat string, options map[string]string) (*codec, er
 	}
 
 	year, _, _ := time.Now().Date()
+	opts := &codecOptions{
+		CopyrightYear:           fmt.Sprintf("%04d", year),
+		ModulePath:              "crate::model",
+		Version:                 "0.0.0",
+		ReleaseLevel:            "preview",
+		IncludeStreamingMethods: true,
+	}
+	if err := Options.Decode(options, opts); err != nil {
+		return nil, err
+	}
 	codec := &codec{
-		generationYear:          fmt.Sprintf("%04d", year),
-		modulePath:              "crate::model",
-		extraPackages:           []*packagez{},
-		packageMapping:          map[string]*packagez{},
-		version:                 "0.0.0",
-		releaseLevel:            "preview",
-		systemParameters:        sysParams,
-		serializeEnumsAsStrings: specificationFormat != libconfig.SpecProtobuf,
-		bytesUseUrlSafeAlphabet: specificationFormat == libconfig.SpecDiscovery,
-	}
-
-	for key, definition := range options {
-		switch {
-		case key == "package-name-override":
-			codec.packageNameOverride = definition
-		case key == "name-overrides":
-			codec.nameOverrides = make(map[string]string)
-			for _, override := range strings.Split(definition, ",") {
-				tokens := strings.Split(override, "=")
-				if len(tokens) != 2 {
-					return nil, fmt.Errorf("cannot parse `name-overrides`. Expected input in the form of: 'n1=r1,n2=r2': %q", definition)
-				}
-				codec.nameOverrides[tokens[0]] = tokens[1]
-			}
-		case key == "module-path":
-			codec.modulePath = definition
-		case key == "copyright-year":
-			codec.generationYear = definition
-		case key == "not-for-publication":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `not-for-publication` value %q to boolean: %w", definition, err)
-			}
-			codec.doNotPublish = value
-		case key == "version":
-			codec.version = definition
-		case key == "release-level":
-			codec.releaseLevel = definition
-		case strings.HasPrefix(key, "package:"):
-			pkgOption, err := parsePackageOption(key, definition)
-			if err != nil {
-				return nil, err
-			}
-			codec.extraPackages = append(codec.extraPackages, pkgOption.pkg)
-			for _, source := range pkgOption.otherNames {
-				codec.packageMapping[source] = pkgOption.pkg
-			}
-		case key == "disabled-rustdoc-warnings":
-			codec.disabledRustdocWarnings = splitOption(definition)
-		case key == "disabled-clippy-warnings":
-			codec.disabledClippyWarnings = splitOption(definition)
-		case key == "template-override":
-			codec.templateOverride = definition
-		case key == "include-grpc-only-methods":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `include-grpc-only-methods` value %q to boolean: %w", definition, err)
-			}
-			codec.includeGrpcOnlyMethods = value
-		case key == "include-streaming-methods":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `include-streaming-methods` value %q to boolean: %w", definition, err)
-			}
-			codec.includeStreamingMethods = value
-		case key == "per-service-features":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `per-service-features` value %q to boolean: %w", definition, err)
-			}
-			codec.perServiceFeatures = value
-		case key == "default-features":
-			codec.defaultFeatures = splitOption(definition)
-		case key == "detailed-tracing-attributes":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `detailed-tracing-attributes` value %q to boolean: %w", definition, err)
-			}
-			codec.detailedTracingAttributes = value
-		case key == "has-veneer":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `has-veneer` value %q to boolean: %w", definition, err)
-			}
-			codec.hasVeneer = value
-		case key == "extra-modules":
-			codec.extraModules = splitOption(definition)
-		case key == "internal-types":
-			codec.internalTypes = splitOption(definition)
-		case key == "routing-required":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `routing-required` value %q to boolean: %w", definition, err)
-			}
-			codec.routingRequired = value
-		case key == "extend-grpc-transport":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `extend-grpc-transport` value %q to boolean: %w", definition, err)
-			}
-			codec.extendGrpcTransport = value
-		case key == "generate-setter-samples":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `generate-setter-samples` value %q to boolean: %w", definition, err)
-			}
-			codec.generateSetterSamples = value
-		case key == "generate-rpc-samples":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `generate-rpc-samples` value %q to boolean: %w", definition, err)
-			}
-			codec.generateRpcSamples = value
-		case key == "internal-builders":
-			value, err := strconv.ParseBool(definition)
-			if err != nil {
-				return nil, fmt.Errorf("cannot convert `internal-builders` value %q to boolean: %w", definition, err)
+		generationYear:            opts.CopyrightYear,
+		packageNameOverride:       opts.PackageNameOverride,
+		modulePath:                opts.ModulePath,
+		extraPackages:             []*packagez{},
+		packageMapping:            map[string]*packagez{},
+		version:                   opts.Version,
+		releaseLevel:              opts.ReleaseLevel,
+		doNotPublish:              opts.NotForPublication,
+		systemParameters:          sysParams,
+		serializeEnumsAsStrings:   specificationFormat != libconfig.SpecProtobuf,
+		bytesUseUrlSafeAlphabet:   specificationFormat == libconfig.SpecDiscovery,
+		disabledRustdocWarnings:   opts.DisabledRustdocWarnings,
+		disabledClippyWarnings:    opts.DisabledClippyWarnings,
+		templateOverride:          opts.TemplateOverride,
+		templateOverlay:           opts.TemplateOverlay,
+		includeGrpcOnlyMethods:    opts.IncludeGrpcOnlyMethods,
+		includeStreamingMethods:   opts.IncludeStreamingMethods,
+		perServiceFeatures:        opts.PerServiceFeatures,
+		defaultFeatures:           opts.DefaultFeatures,
+		detailedTracingAttributes: opts.DetailedTracingAttributes,
+		hasVeneer:                 opts.HasVeneer,
+		extraModules:              opts.ExtraModules,
+		internalTypes:             opts.InternalTypes,
+		routingRequired:           opts.RoutingRequired,
+		extendGrpcTransport:       opts.ExtendGrpcTransport,
+		generateSetterSamples:     opts.GenerateSetterSamples,
+		generateRpcSamples:        opts.GenerateRpcSamples,
+		internalBuilders:          opts.InternalBuilders,
+		quickstartServiceOverride: opts.QuickstartServiceOverride,
+	}
+	transports, err := language.ParseTransport(opts.Transport)
+	if err != nil {
+		return nil, err
+	}
+	codec.transports = transports
+	if opts.NameOverrides != "" {
+		codec.nameOverrides = make(map[string]string)
+		for _, override := range strings.Split(opts.NameOverrides, ",") {
+			tokens := strings.Split(override, "=")
+			if len(tokens) != 2 {
+				return nil, fmt.Errorf("cannot parse `name-overrides`. Expected input in the form of: 'n1=r1,n2=r2': %q", opts.NameOverrides)
 			}
-			codec.internalBuilders = value
-		case key == "quickstart-service-override":
-			codec.quickstartServiceOverride = definition
-		default:
-			return nil, fmt.Errorf("unknown Rust codec option %q", key)
+			codec.nameOverrides[tokens[0]] = tokens[1]
 		}
 	}
-	return codec, nil
-}
-
-func splitOption(definition string) []string {
-	if definition == "" {
-		return []string{}
+	for _, name := range slices.Sorted(maps.Keys(opts.Packages)) {
+		pkgOption, err := parsePackageOption("package:"+name, opts.Packages[name])
+		if err != nil {
+			return nil, err
+		}
+		codec.extraPackages = append(codec.extraPackages, pkgOption.pkg)
+		for _, source := range pkgOption.otherNames {
+			codec.packageMapping[source] = pkgOption.pkg
+		}
 	}
-	return strings.Split(definition, ",")
+	return codec, nil
 }
 
 type packageOption struct {
@@ -260,6 +193,8 @@ type codec struct {
 	nameOverrides map[string]string
 	// The year when the files were first generated.
 	generationYear string
+	// The resource name helpers defined in this package.
+	resourceNames []*language.ResourceName
 	// The full path of the generated module within the crate. This defaults to
 	// `model`. When generating only a module within a larger crate (see
 	// `GenerateModule`), this overrides the path for elements within the crate.
@@ -296,11 +231,16 @@ type codec struct {
 	bytesUseUrlSafeAlphabet bool
 	// Overrides the template subdirectory.
 	templateOverride string
+	// A directory with templates that shadow the built-in templates.
+	templateOverlay string
 	// If true, this includes gRPC-only methods, such as methods without HTTP
 	// annotations.
 	includeGrpcOnlyMethods bool
-	// If true, this includes gRPC streaming methods.
+	// If true, this includes streaming methods. Client-side and bidirectional
+	// streaming methods are only generated for gRPC transports.
 	includeStreamingMethods bool
+	// The transport used by the generated clients. Defaults to REST.
+	transports language.Transports
 	// If true, the generator will produce per-client features.
 	perServiceFeatures bool
 	// If not empty, and if `perServiceFeatures` is true, the default features
@@ -1593,8 +1533,12 @@ func (c *codec) generateMethod(m *api.Method) bool {
 	// RPCs for them.
 	// TODO(#499) - switch to explicitly excluding such functions. Easier to
 	//     find them and fix them that way.
-	if m.ClientSideStreaming || m.ServerSideStreaming {
-		return c.includeStreamingMethods
+	if m.ClientSideStreaming {
+		// There is no HTTP mapping for client-side streaming.
+		return c.includeStreamingMethods && c.grpcTransport()
+	}
+	if m.ServerSideStreaming && !c.includeStreamingMethods {
+		return false
 	}
 	if c.includeGrpcOnlyMethods {
 		return true
@@ -1605,6 +1549,11 @@ func (c *codec) generateMethod(m *api.Method) bool {
 	return m.PathInfo.Bindings[0].PathTemplate != nil
 }
 
+// grpcTransport returns true if the generated clients use gRPC.
+func (c *codec) grpcTransport() bool {
+	return c.transports.GRPC
+}
+
 // escapeKeyword is the list of Rust keywords and reserved words can be found
 // at https://doc.rust-lang.org/reference/keywords.html.
 func escapeKeyword(symbol string) string {
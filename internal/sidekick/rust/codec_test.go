@@ -225,6 +225,15 @@ func TestParseOptions(t *testing.T) {
 				c.includeStreamingMethods = true
 			},
 		},
+		{
+			Format: libconfig.SpecProtobuf,
+			Options: map[string]string{
+				"transport": "grpc",
+			},
+			Update: func(c *codec) {
+				c.transports = language.Transports{GRPC: true}
+			},
+		},
 		{
 			Format: libconfig.SpecProtobuf,
 			Options: map[string]string{
@@ -2289,53 +2298,92 @@ func TestParseOptionsGenerateRpcSamples(t *testing.T) {
 }
 
 func TestGenerateMethod_Streaming(t *testing.T) {
+	binding := &api.PathInfo{
+		Bindings: []*api.PathBinding{{PathTemplate: &api.PathTemplate{}}},
+	}
 	for _, test := range []struct {
 		name                    string
 		includeStreamingMethods bool
+		transports              language.Transports
 		method                  *api.Method
 		want                    bool
 	}{
 		{
-			name:                    "skips client-side streaming by default",
-			includeStreamingMethods: false,
+			name:                    "skips client-side streaming over HTTP",
+			includeStreamingMethods: true,
 			method: &api.Method{
-				Name:                "ClientStreaming",
+				Name:                "Collect",
 				ClientSideStreaming: true,
+				PathInfo:            binding,
 			},
 			want: false,
 		},
 		{
-			name:                    "includes client-side streaming when enabled",
+			name:                    "includes client-side streaming over gRPC",
 			includeStreamingMethods: true,
+			transports:              language.Transports{GRPC: true},
 			method: &api.Method{
-				Name:                "ClientStreaming",
+				Name:                "Collect",
 				ClientSideStreaming: true,
 			},
 			want: true,
 		},
 		{
-			name:                    "skips server-side streaming by default",
+			name:                    "skips client-side streaming when disabled",
 			includeStreamingMethods: false,
+			transports:              language.Transports{GRPC: true},
 			method: &api.Method{
-				Name:                "ServerStreaming",
+				Name:                "Collect",
+				ClientSideStreaming: true,
+			},
+			want: false,
+		},
+		{
+			name:                    "skips server-side streaming when disabled",
+			includeStreamingMethods: false,
+			method: &api.Method{
+				Name:                "Expand",
 				ServerSideStreaming: true,
+				PathInfo:            binding,
 			},
 			want: false,
 		},
 		{
-			name:                    "includes server-side streaming when enabled",
+			name:                    "includes server-side streaming over HTTP",
 			includeStreamingMethods: true,
 			method: &api.Method{
-				Name:                "ServerStreaming",
+				Name:                "Expand",
 				ServerSideStreaming: true,
+				PathInfo:            binding,
 			},
 			want: true,
 		},
 		{
-			name:                    "includes bidirectional streaming when enabled",
+			name:                    "skips server-side streaming without HTTP bindings",
+			includeStreamingMethods: true,
+			method: &api.Method{
+				Name:                "Expand",
+				ServerSideStreaming: true,
+			},
+			want: false,
+		},
+		{
+			name:                    "skips bidirectional streaming over HTTP",
 			includeStreamingMethods: true,
 			method: &api.Method{
-				Name:                "BidiStreaming",
+				Name:                "Chat",
+				ClientSideStreaming: true,
+				ServerSideStreaming: true,
+				PathInfo:            binding,
+			},
+			want: false,
+		},
+		{
+			name:                    "includes bidirectional streaming over gRPC",
+			includeStreamingMethods: true,
+			transports:              language.Transports{GRPC: true},
+			method: &api.Method{
+				Name:                "Chat",
 				ClientSideStreaming: true,
 				ServerSideStreaming: true,
 			},
@@ -2345,6 +2393,7 @@ func TestGenerateMethod_Streaming(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			c := &codec{
 				includeStreamingMethods: test.includeStreamingMethods,
+				transports:              test.transports,
 			}
 			if got := c.generateMethod(test.method); got != test.want {
 				t.Errorf("generateMethod() = %v, want %v", got, test.want)
@@ -2352,3 +2401,13 @@ func TestGenerateMethod_Streaming(t *testing.T) {
 		})
 	}
 }
+
+func TestNewCodec_StreamingDefault(t *testing.T) {
+	c, err := newCodec(libconfig.SpecProtobuf, map[string]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.includeStreamingMethods {
+		t.Errorf("streaming methods should be included by default")
+	}
+}
@@ -17,7 +17,7 @@ package rust
 import (
 	"context"
 	"embed"
-	"path/filepath"
+	"io/fs"
 
 	"github.com/googleapis/librarian/internal/sidekick/api"
 	"github.com/googleapis/librarian/internal/sidekick/language"
@@ -37,9 +37,12 @@ func Generate(ctx context.Context, model *api.API, outdir string, cfg *parser.Mo
 	if err != nil {
 		return err
 	}
-	provider := templatesProvider()
+	provider := language.TemplatesProvider(c.templatesFS())
 	generatedFiles := c.generatedFiles(annotations.HasServices())
-	return language.GenerateFromModel(outdir, model, provider, generatedFiles)
+	if err := language.GenerateFromModel(outdir, model, provider, generatedFiles); err != nil {
+		return err
+	}
+	return c.generateSnippetMetadata(outdir, model, annotations, generatedFiles)
 }
 
 // GenerateStorage generates Rust code for the storage service.
@@ -65,8 +68,9 @@ func GenerateStorage(ctx context.Context, outdir string, storageModel *api.API,
 			Control: controlModel,
 		},
 	}
-	provider := templatesProvider()
-	generatedFiles := language.WalkTemplatesDir(templates, "templates/storage")
+	fsys := storageCodec.templatesFS()
+	provider := language.TemplatesProvider(fsys)
+	generatedFiles := language.WalkTemplatesDir(fsys, "templates/storage")
 	return language.GenerateFromModel(outdir, model, provider, generatedFiles)
 }
 
@@ -75,19 +79,21 @@ type storageAnnotations struct {
 	Control *api.API
 }
 
-func templatesProvider() language.TemplateProvider {
-	return func(name string) (string, error) {
-		contents, err := templates.ReadFile(filepath.ToSlash(name))
-		if err != nil {
-			return "", err
-		}
-		return string(contents), nil
-	}
+// Templates returns the built-in templates for the Rust codec.
+func Templates() fs.FS {
+	return templates
+}
+
+// templatesFS returns the built-in templates, shadowed by the codec's
+// template overlay, if any.
+func (c *codec) templatesFS() fs.FS {
+	return language.OverlayTemplates(templates, c.templateOverlay)
 }
 
 func (c *codec) generatedFiles(hasServices bool) []language.GeneratedFile {
+	fsys := c.templatesFS()
 	if c.templateOverride != "" {
-		return language.WalkTemplatesDir(templates, c.templateOverride)
+		return language.WalkTemplatesDir(fsys, c.templateOverride)
 	}
 	var root string
 	switch {
@@ -96,5 +102,5 @@ func (c *codec) generatedFiles(hasServices bool) []language.GeneratedFile {
 	default:
 		root = "templates/crate"
 	}
-	return language.WalkTemplatesDir(templates, root)
+	return language.WalkTemplatesDir(fsys, root)
 }
@@ -0,0 +1,82 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rust
+
+import "github.com/googleapis/librarian/internal/sidekick/language"
+
+// Options describes the codec options accepted by the Rust generator.
+var Options = &language.OptionSchema{
+	Codec: "rust",
+	Options: []language.Option{
+		{Name: "copyright-year", Doc: "The year when the files were first generated."},
+		{Name: "default-features", Type: language.ListOption, Doc: "The default features when `per-service-features` is enabled."},
+		{Name: "detailed-tracing-attributes", Type: language.BoolOption, Doc: "Include detailed tracing attributes on HTTP requests."},
+		{Name: "disabled-clippy-warnings", Type: language.ListOption, Doc: "The `clippy` warnings to disable."},
+		{Name: "disabled-rustdoc-warnings", Type: language.ListOption, Doc: "The `rustdoc` warnings to disable."},
+		{Name: "extend-grpc-transport", Type: language.BoolOption, Doc: "Make the transport stub extensible from outside `transport.rs`."},
+		{Name: "extra-modules", Type: language.ListOption, Doc: "Additional modules, typically with handwritten code."},
+		{Name: "generate-rpc-samples", Type: language.BoolOption, Doc: "Generate reference documentation samples for RPCs."},
+		{Name: "generate-setter-samples", Type: language.BoolOption, Doc: "Generate reference documentation samples for message field setters."},
+		{Name: "has-veneer", Type: language.BoolOption, Doc: "The crate has a handwritten client surface."},
+		{Name: "include-grpc-only-methods", Type: language.BoolOption, Doc: "Include methods without HTTP annotations."},
+		{Name: "include-streaming-methods", Type: language.BoolOption, Doc: "Include streaming methods, defaults to `true`. Client-side and bidirectional streaming require the `grpc` transport."},
+		{Name: "internal-builders", Type: language.BoolOption, Doc: "Make the request builders visible to the crate."},
+		{Name: "internal-types", Type: language.ListOption, Doc: "Messages that are only visible to the crate."},
+		{Name: "module-path", Doc: "The path of the generated module within the crate, defaults to `crate::model`."},
+		{Name: "name-overrides", Doc: "Renames services and oneofs, in the form `id1=name1,id2=name2`."},
+		{Name: "not-for-publication", Type: language.BoolOption, Doc: "The crate is not published."},
+		{Name: "package-name-override", Doc: "Overrides the default crate name."},
+		{Name: "package:", Doc: "A dependency, in the form `package=name,source=proto.package,feature=f,ignore=bool,force-used=bool,used-if=cond`."},
+		{Name: "per-service-features", Type: language.BoolOption, Doc: "Generate a feature for each service."},
+		{Name: "quickstart-service-override", Doc: "The service used in the package-level quickstart."},
+		{Name: "release-level", Doc: "The release level used in the documentation, such as `preview` or `stable`."},
+		{Name: "routing-required", Type: language.BoolOption, Doc: "Fail requests locally if they do not yield a gRPC routing header."},
+		{Name: "template-overlay", Doc: "A directory with templates that shadow the built-in templates."},
+		{Name: "template-override", Doc: "Overrides the template directory."},
+		{Name: "transport", Values: []string{"rest", "grpc"}, Doc: "The transport used by the generated clients, defaults to `rest`."},
+		{Name: "version", Doc: "The version of the generated crate."},
+	},
+}
+
+// codecOptions holds the decoded [Options].
+type codecOptions struct {
+	CopyrightYear             string            `option:"copyright-year"`
+	DefaultFeatures           []string          `option:"default-features"`
+	DetailedTracingAttributes bool              `option:"detailed-tracing-attributes"`
+	DisabledClippyWarnings    []string          `option:"disabled-clippy-warnings"`
+	DisabledRustdocWarnings   []string          `option:"disabled-rustdoc-warnings"`
+	ExtendGrpcTransport       bool              `option:"extend-grpc-transport"`
+	ExtraModules              []string          `option:"extra-modules"`
+	GenerateRpcSamples        bool              `option:"generate-rpc-samples"`
+	GenerateSetterSamples     bool              `option:"generate-setter-samples"`
+	HasVeneer                 bool              `option:"has-veneer"`
+	IncludeGrpcOnlyMethods    bool              `option:"include-grpc-only-methods"`
+	IncludeStreamingMethods   bool              `option:"include-streaming-methods"`
+	InternalBuilders          bool              `option:"internal-builders"`
+	InternalTypes             []string          `option:"internal-types"`
+	ModulePath                string            `option:"module-path"`
+	NameOverrides             string            `option:"name-overrides"`
+	NotForPublication         bool              `option:"not-for-publication"`
+	PackageNameOverride       string            `option:"package-name-override"`
+	Packages                  map[string]string `option:"package:"`
+	PerServiceFeatures        bool              `option:"per-service-features"`
+	QuickstartServiceOverride string            `option:"quickstart-service-override"`
+	ReleaseLevel              string            `option:"release-level"`
+	RoutingRequired           bool              `option:"routing-required"`
+	TemplateOverlay           string            `option:"template-overlay"`
+	TemplateOverride          string            `option:"template-override"`
+	Transport                 string            `option:"transport"`
+	Version                   string            `option:"version"`
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rust
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/language"
+	"github.com/googleapis/librarian/internal/snippetmetadata"
+)
+
+var (
+	implRegex  = regexp.MustCompile(`^impl (\w+) \{$`)
+	pubFnRegex = regexp.MustCompile(`^pub fn (\w+)\(`)
+)
+
+// generateSnippetMetadata writes the snippet metadata for the RPC samples in
+// the documentation of the generated clients.
+func (c *codec) generateSnippetMetadata(outdir string, model *api.API, annotations *modelAnnotations, files []language.GeneratedFile) error {
+	if !c.generateRpcSamples || !annotations.HasServices() {
+		return nil
+	}
+	idx := -1
+	for i, f := range files {
+		if filepath.Base(f.OutputPath) == "client.rs" {
+			idx = i
+		}
+	}
+	if idx == -1 {
+		return nil
+	}
+	clientFile := strings.TrimPrefix(filepath.ToSlash(files[idx].OutputPath), "/")
+	content, err := os.ReadFile(filepath.Join(outdir, clientFile))
+	if err != nil {
+		return err
+	}
+	segments := sampleSegments(content)
+	var samples []*language.SnippetSample
+	for _, s := range model.Services {
+		serviceAnnotations := s.Codec.(*serviceAnnotations)
+		for _, m := range serviceAnnotations.Methods {
+			methodAnnotations := m.Codec.(*methodAnnotation)
+			segment, ok := segments[serviceAnnotations.Name][methodAnnotations.Name]
+			if !ok {
+				continue
+			}
+			samples = append(samples, &language.SnippetSample{
+				Service:    s,
+				Method:     m,
+				ClientName: serviceAnnotations.Name,
+				MethodName: methodAnnotations.Name,
+				ResultType: methodAnnotations.ReturnType,
+				File:       clientFile,
+				Segments:   []*snippetmetadata.Segment{segment},
+			})
+		}
+	}
+	if len(samples) == 0 {
+		return nil
+	}
+	index := language.NewSnippetIndex(model, snippetmetadata.LanguageRust, annotations.PackageName, annotations.PackageVersion, samples)
+	return snippetmetadata.Write(outdir, model.PackageName, index)
+}
+
+// sampleSegments finds the samples in the documentation of the client
+// methods. The result is indexed by the client name and then by the method
+// name.
+//
+// Each sample is the code block after an `# Example` heading, and belongs
+// to the next public function.
+func sampleSegments(content []byte) map[string]map[string]*snippetmetadata.Segment {
+	result := map[string]map[string]*snippetmetadata.Segment{}
+	var (
+		client  string
+		example bool
+		pending *snippetmetadata.Segment
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case implRegex.MatchString(text):
+			client = implRegex.FindStringSubmatch(text)[1]
+			pending = nil
+		case text == "/// # Example":
+			example = true
+			pending = nil
+		case example && text == "/// ```" && pending == nil:
+			pending = &snippetmetadata.Segment{Start: line + 1, Type: snippetmetadata.SegmentFull}
+		case example && text == "/// ```":
+			pending.End = line - 1
+			example = false
+		case pubFnRegex.MatchString(text):
+			if pending != nil && pending.End != 0 && client != "" {
+				if result[client] == nil {
+					result[client] = map[string]*snippetmetadata.Segment{}
+				}
+				result[client][pubFnRegex.FindStringSubmatch(text)[1]] = pending
+			}
+			pending = nil
+		}
+	}
+	return result
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rust
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/sidekick/parser"
+	"github.com/googleapis/librarian/internal/snippetmetadata"
+)
+
+func TestSampleSegments(t *testing.T) {
+	content := `/// # Example
+/// ` + "```" + `
+/// let client = Echo::builder().build().await?;
+/// ` + "```" + `
+pub struct Echo {}
+
+impl Echo {
+    /// Returns a builder.
+    /// ` + "```" + `
+    /// let builder = Echo::builder();
+    /// ` + "```" + `
+    pub fn builder() -> ClientBuilder {}
+
+    /// Echoes the request.
+    ///
+    /// # Example
+    /// ` + "```" + `
+    /// let response = client.echo().send().await?;
+    /// println!("response {:?}", response);
+    /// ` + "```" + `
+    pub fn echo(&self) -> Echo {}
+
+    pub fn expand(&self) -> Expand {}
+}
+`
+	got := sampleSegments([]byte(content))
+	want := map[string]map[string]*snippetmetadata.Segment{
+		"Echo": {
+			"echo": {Start: 18, End: 19, Type: snippetmetadata.SegmentFull},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateSnippetMetadata(t *testing.T) {
+	outDir := t.TempDir()
+	model := echoModel(t)
+	model.Services[0].DefaultHost = "showcase.googleapis.com"
+	cfg := &parser.ModelConfig{
+		Codec: map[string]string{
+			"copyright-year":           "2038",
+			"generate-rpc-samples":     "true",
+			"version":                  "1.2.3",
+			"package:wkt":              "source=google.protobuf,package=google-cloud-wkt",
+			"package:google-cloud-api": "source=google.api,package=google-cloud-api",
+			"package:google-cloud-rpc": "source=google.rpc,package=google-cloud-rpc",
+		},
+	}
+	if err := Generate(t.Context(), model, outDir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	name := snippetmetadata.FileName("google.showcase.v1beta1")
+	got, err := os.ReadFile(filepath.Join(outDir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	goldenFile := filepath.Join("testdata", "snippets", name+".golden")
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(goldenFile), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(goldenFile, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(goldenFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateSnippetMetadata_NoSamples(t *testing.T) {
+	outDir := t.TempDir()
+	cfg := &parser.ModelConfig{
+		Codec: map[string]string{
+			"package:wkt":              "source=google.protobuf,package=google-cloud-wkt",
+			"package:google-cloud-api": "source=google.api,package=google-cloud-api",
+			"package:google-cloud-rpc": "source=google.rpc,package=google-cloud-rpc",
+		},
+	}
+	if err := Generate(t.Context(), echoModel(t), outDir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	name := snippetmetadata.FileName("google.showcase.v1beta1")
+	if _, err := os.Stat(filepath.Join(outDir, name)); err == nil {
+		t.Errorf("expected no %s without RPC samples", name)
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rust
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	libconfig "github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/parser"
+	"github.com/googleapis/librarian/internal/sources"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+// echoModel returns a model for the `Echo` service in the showcase protos,
+// which has one method of each streaming kind.
+func echoModel(t *testing.T) *api.API {
+	t.Helper()
+	requireProtoc(t)
+	cfg := &parser.ModelConfig{
+		SpecificationFormat: libconfig.SpecProtobuf,
+		ServiceConfig:       "schema/google/showcase/v1beta1/showcase_v1beta1.yaml",
+		SpecificationSource: "schema/google/showcase/v1beta1",
+		Source: &sources.SourceConfig{
+			Sources: &sources.Sources{
+				Googleapis: filepath.Join(testdataDir, "googleapis"),
+				Showcase:   filepath.Join(testdataDir, "gapic-showcase"),
+			},
+			ActiveRoots: []string{"showcase", "googleapis"},
+		},
+	}
+	model, err := parser.CreateModel(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return model
+}
+
+func TestStreamingGolden(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		override  string
+		transport string
+		srcDir    string
+	}{
+		{"http", "", "rest", "src"},
+		{"grpc", "templates/grpc-client", "grpc", ""},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			outDir := t.TempDir()
+			cfg := &parser.ModelConfig{
+				Codec: map[string]string{
+					"copyright-year":            "2038",
+					"include-streaming-methods": "true",
+					"package:wkt":               "source=google.protobuf,package=google-cloud-wkt",
+					"package:google-cloud-api":  "source=google.api,package=google-cloud-api",
+					"package:google-cloud-rpc":  "source=google.rpc,package=google-cloud-rpc",
+					"transport":                 test.transport,
+				},
+			}
+			if test.override != "" {
+				cfg.Codec["template-override"] = test.override
+			}
+			if err := Generate(t.Context(), echoModel(t), outDir, cfg); err != nil {
+				t.Fatal(err)
+			}
+			for _, name := range []string{"builder.rs", "client.rs", "stub.rs", "transport.rs"} {
+				got, err := os.ReadFile(filepath.Join(outDir, test.srcDir, name))
+				if err != nil {
+					t.Fatal(err)
+				}
+				goldenFile := filepath.Join("testdata", "streaming", test.name, name+".golden")
+				if *updateGolden {
+					if err := os.MkdirAll(filepath.Dir(goldenFile), 0755); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(goldenFile, got, 0644); err != nil {
+						t.Fatal(err)
+					}
+					continue
+				}
+				want, err := os.ReadFile(goldenFile)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(string(want), string(got)); diff != "" {
+					t.Errorf("mismatch in %s (-want +got):\n%s", goldenFile, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestStreamingFeature(t *testing.T) {
+	for _, test := range []struct {
+		name             string
+		includeStreaming string
+		want             bool
+	}{
+		{"default", "", true},
+		{"disabled", "false", false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			outDir := t.TempDir()
+			cfg := &parser.ModelConfig{
+				Codec: map[string]string{
+					"copyright-year":           "2038",
+					"package:wkt":              "source=google.protobuf,package=google-cloud-wkt",
+					"package:google-cloud-api": "source=google.api,package=google-cloud-api",
+					"package:google-cloud-rpc": "source=google.rpc,package=google-cloud-rpc",
+				},
+			}
+			if test.includeStreaming != "" {
+				cfg.Codec["include-streaming-methods"] = test.includeStreaming
+			}
+			if err := Generate(t.Context(), echoModel(t), outDir, cfg); err != nil {
+				t.Fatal(err)
+			}
+			contents, err := os.ReadFile(filepath.Join(outDir, "Cargo.toml"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := strings.Contains(string(contents), "\nunstable-streaming = []\n")
+			if got != test.want {
+				t.Errorf("Cargo.toml defines the unstable-streaming feature = %v, want %v\n%s", got, test.want, contents)
+			}
+		})
+	}
+}
@@ -31,28 +31,27 @@ type codec struct {
 	// Most libraries are generated from `googleapis`. Rarely, we use protobuf,
 	// gapic-showcase, or a different root.
 	RootName string
+	// A directory with templates that shadow the built-in templates.
+	TemplateOverlay string
 }
 
-func newCodec(cfg *parser.ModelConfig) *codec {
+func newCodec(cfg *parser.ModelConfig) (*codec, error) {
 	year, _, _ := time.Now().Date()
+	opts := &codecOptions{
+		CopyrightYear: fmt.Sprintf("%04d", year),
+		RootName:      "googleapis",
+	}
+	if err := Options.Decode(cfg.Codec, opts); err != nil {
+		return nil, err
+	}
 	result := &codec{
-		GenerationYear: fmt.Sprintf("%04d", year),
-		PackageName:    "",
-		RootName:       "googleapis",
+		GenerationYear:  opts.CopyrightYear,
+		PackageName:     opts.PackageNameOverride,
+		RootName:        opts.RootName,
+		TemplateOverlay: opts.TemplateOverlay,
 	}
-	for key, definition := range cfg.Codec {
-		switch key {
-		case "copyright-year":
-			result.GenerationYear = definition
-		case "package-name-override":
-			result.PackageName = definition
-		case "post-process-protos":
-			result.PostProcessProtos = strings.Split(definition, "\n")
-		case "root-name":
-			result.RootName = definition
-		default:
-			// Ignore other options.
-		}
+	if opts.PostProcessProtos != "" {
+		result.PostProcessProtos = strings.Split(opts.PostProcessProtos, "\n")
 	}
-	return result
+	return result, nil
 }
@@ -15,10 +15,12 @@
 package rust_prost
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	libconfig "github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/sidekick/language"
 	"github.com/googleapis/librarian/internal/sidekick/parser"
 	"github.com/googleapis/librarian/internal/sources"
 )
@@ -34,15 +36,31 @@ func TestParseOptions(t *testing.T) {
 			"copyright-year":        "2038",
 			"package-name-override": "google-cloud-bigtable",
 			"root-name":             "test-root",
+			"post-process-protos":   "a.proto\nb.proto",
+			"template-overlay":      "overlay",
 		},
 	}
-	got := newCodec(cfg)
+	got, err := newCodec(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
 	want := &codec{
-		GenerationYear: "2038",
-		PackageName:    "google-cloud-bigtable",
-		RootName:       "test-root",
+		GenerationYear:    "2038",
+		PackageName:       "google-cloud-bigtable",
+		RootName:          "test-root",
+		PostProcessProtos: []string{"a.proto", "b.proto"},
+		TemplateOverlay:   "overlay",
 	}
 	if diff := cmp.Diff(want, got); diff != "" {
 		t.Errorf("mismatch in codec (-want, +got)\n:%s", diff)
 	}
 }
+
+func TestParseOptions_Error(t *testing.T) {
+	cfg := &parser.ModelConfig{
+		Codec: map[string]string{"root-nmae": "test-root"},
+	}
+	if _, err := newCodec(cfg); !errors.Is(err, language.ErrUnknownOption) {
+		t.Errorf("newCodec() error = %v, want %v", err, language.ErrUnknownOption)
+	}
+}
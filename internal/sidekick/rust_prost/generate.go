@@ -18,6 +18,7 @@ import (
 	"context"
 	"embed"
 	"fmt"
+	"io/fs"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -38,6 +39,10 @@ func Generate(ctx context.Context, model *api.API, outdir string, template strin
 	if cfg.SpecificationFormat != libconfig.SpecProtobuf {
 		return fmt.Errorf("the `rust+prost` generator only supports `protobuf` as a specification source, outdir=%s", outdir)
 	}
+	codec, err := newCodec(cfg)
+	if err != nil {
+		return err
+	}
 	if err := command.Run(ctx, command.Cargo, "--version"); err != nil {
 		return fmt.Errorf("got an error trying to run `cargo --version`, the instructions on https://www.rust-lang.org/learn/get-started may solve this problem: %w", err)
 	}
@@ -45,10 +50,10 @@ func Generate(ctx context.Context, model *api.API, outdir string, template strin
 		return fmt.Errorf("got an error trying to run `protoc --version`, the instructions on https://grpc.io/docs/protoc-installation/ may solve this problem: %w", err)
 	}
 
-	codec := newCodec(cfg)
 	codec.annotateModel(model, cfg)
-	provider := templatesProvider()
-	generatedFiles := language.WalkTemplatesDir(templates, "templates/"+template)
+	fsys := language.OverlayTemplates(templates, codec.TemplateOverlay)
+	provider := language.TemplatesProvider(fsys)
+	generatedFiles := language.WalkTemplatesDir(fsys, "templates/"+template)
 	tmpDir, err := os.MkdirTemp("", "rust-prost-*")
 	if err != nil {
 		return fmt.Errorf("cannot create temporary directory for rust+prost output: %w", err)
@@ -61,14 +66,9 @@ func Generate(ctx context.Context, model *api.API, outdir string, template strin
 	return buildRS(ctx, rootSource, tmpDir, outdir)
 }
 
-func templatesProvider() language.TemplateProvider {
-	return func(name string) (string, error) {
-		contents, err := templates.ReadFile(name)
-		if err != nil {
-			return "", err
-		}
-		return string(contents), nil
-	}
+// Templates returns the built-in templates for the rust+prost codec.
+func Templates() fs.FS {
+	return templates
 }
 
 func buildRS(ctx context.Context, rootName, tmpDir, outDir string) error {
@@ -0,0 +1,44 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rust_prost
+
+import (
+	"github.com/googleapis/librarian/internal/sidekick/language"
+	"github.com/googleapis/librarian/internal/sidekick/rust"
+)
+
+// Options describes the codec options accepted by the Rust Prost generator.
+//
+// The generator receives the same options as the Rust generator, as both are
+// configured by the same module in `librarian.yaml`.
+var Options = &language.OptionSchema{
+	Codec: "rust_prost",
+	Options: []language.Option{
+		{Name: "post-process-protos", Doc: "The Protobuf files post-processed after generation, one per line."},
+		{Name: "root-name", Doc: "The name of the source root, defaults to `googleapis`."},
+	},
+	Extends: []*language.OptionSchema{rust.Options},
+}
+
+// codecOptions holds the decoded [Options].
+//
+// The generator ignores most of the options it shares with the Rust generator.
+type codecOptions struct {
+	CopyrightYear       string `option:"copyright-year"`
+	PackageNameOverride string `option:"package-name-override"`
+	PostProcessProtos   string `option:"post-process-protos"`
+	RootName            string `option:"root-name"`
+	TemplateOverlay     string `option:"template-overlay"`
+}
@@ -16,12 +16,17 @@ package swift
 
 import (
 	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/language"
 )
 
 type fieldAnnotations struct {
 	Name      string
 	FieldType string
 	DocLines  []string
+	// The resource name helper type for fields with a resource reference.
+	ResourceName string
+	// The name of the setter accepting a `ResourceName`.
+	SetterName string
 }
 
 func (c *codec) annotateField(field *api.Field) error {
@@ -34,6 +39,24 @@ func (c *codec) annotateField(field *api.Field) error {
 		FieldType: fieldType,
 		DocLines:  c.formatDocumentation(field.Documentation),
 	}
+	if name := c.resourceNameFor(field); name != nil {
+		annotations.ResourceName = pascalCase(name.Name)
+		annotations.SetterName = camelCase("set_" + field.Name)
+	}
 	field.Codec = annotations
 	return nil
 }
+
+// resourceNameFor returns the resource name helper for fields that reference
+// a resource, or nil if the field has no helper.
+//
+// Only singular, non-optional string fields get convenience setters.
+func (c *codec) resourceNameFor(field *api.Field) *language.ResourceName {
+	if field.ResourceReference == nil || field.Typez != api.TypezString || field.IsOneOf {
+		return nil
+	}
+	if field.Repeated || field.Optional || field.Map {
+		return nil
+	}
+	return language.ResourceNameFor(c.ResourceNames, field.ResourceReference.Type)
+}
@@ -15,12 +15,19 @@
 package swift
 
 import (
+	"fmt"
+
 	"github.com/googleapis/librarian/internal/sidekick/api"
 	"github.com/googleapis/librarian/internal/sidekick/language"
+	"github.com/iancoleman/strcase"
 )
 
 type methodAnnotations struct {
-	Name           string
+	Name string
+	// The method name without keyword escaping, the fake clients use it as a prefix for other names.
+	BaseName string
+	// The type returned by the method, `Void` if the method returns nothing.
+	ReturnType     string
 	DocLines       []string
 	PathVariables  []*pathVariable
 	PathExpression string
@@ -29,6 +36,58 @@ type methodAnnotations struct {
 	IsBodyWildcard bool
 	BodyField      string
 	QueryParams    []*api.Field
+	// IsIdempotent is "true" if the method is idempotent by default, "false" otherwise.
+	//
+	// The generated code only retries idempotent methods, unless the application overrides this
+	// default in the request options.
+	IsIdempotent string
+	// Pagination is set for methods conforming to [AIP-4233](https://google.aip.dev/client-libraries/4233).
+	Pagination *paginationAnnotations
+	// Poller is set for long-running operations with a known polling method.
+	Poller *pollerAnnotations
+	// The gRPC path, such as `/google.cloud.secretmanager.v1.SecretManagerService/GetSecret`.
+	GrpcPath string
+	// The gRPC streaming shape. Exactly one of these is true.
+	GrpcUnary           bool
+	GrpcServerStreaming bool
+	GrpcClientStreaming bool
+	GrpcBidiStreaming   bool
+}
+
+// paginationAnnotations describes how to iterate over the pages of a list method.
+type paginationAnnotations struct {
+	// The name of the method returning an `AsyncSequence` of pages.
+	ByPageName string
+	// The name of the method returning an `AsyncSequence` of items.
+	ByItemName string
+	// The request property holding the page token, such as `pageToken`.
+	PageToken string
+	// The expression to get the next page token from `response`.
+	NextPageToken string
+	// The response property holding the items, such as `secrets`.
+	Items string
+	// The type of each item. For map fields this is a `(key: K, value: V)` tuple.
+	ItemType string
+}
+
+// pollerAnnotations describes how to poll a long-running operation.
+//
+// Methods with `google.longrunning.operation_info` annotations return a poller producing the
+// typed response and metadata. Discovery-based long-running operations return a poller producing
+// the operation itself.
+type pollerAnnotations struct {
+	// The name of the method returning the poller.
+	Name string
+	// The poller type, such as `GoogleCloudGax.Poller<Secret, OperationMetadata>`.
+	Type string
+	// The name of the method used to poll the operation.
+	GetOperation string
+	// The request type for the polling method.
+	GetOperationRequest string
+	// The property in the polling request holding the operation name.
+	OperationField string
+	// The request properties copied from the initial request into the polling request.
+	PathParameters []string
 }
 
 // pathVariable describes a variable used to build a request URL path.
@@ -84,8 +143,14 @@ func (c *codec) annotateMethod(method *api.Method, modelAnn *modelAnnotations) e
 	if err != nil {
 		return err
 	}
+	pagination, err := c.paginationAnnotations(method)
+	if err != nil {
+		return err
+	}
 	method.Codec = &methodAnnotations{
 		Name:           camelCase(method.Name),
+		BaseName:       strcase.ToLowerCamel(method.Name),
+		ReturnType:     c.returnType(method),
 		DocLines:       docLines,
 		PathExpression: pathExpression(binding.PathTemplate),
 		PathVariables:  pathVariables,
@@ -94,6 +159,185 @@ func (c *codec) annotateMethod(method *api.Method, modelAnn *modelAnnotations) e
 		IsBodyWildcard: isBodyWildcard,
 		BodyField:      bodyField,
 		QueryParams:    language.QueryParams(method, binding),
+		IsIdempotent:   isIdempotent(method.PathInfo),
+		Pagination:     pagination,
+	}
+	return nil
+}
+
+// annotateGrpcMethod adds the gRPC annotations for a method.
+//
+// Methods without HTTP annotations are only available over gRPC, they only get the annotations
+// shared by both transports.
+func (c *codec) annotateGrpcMethod(method *api.Method, modelAnn *modelAnnotations) error {
+	if method.Codec == nil {
+		if method.InputType != nil {
+			if err := c.annotateMessage(method.InputType, modelAnn); err != nil {
+				return err
+			}
+		}
+		if method.OutputType != nil {
+			if err := c.annotateMessage(method.OutputType, modelAnn); err != nil {
+				return err
+			}
+		}
+		method.Codec = &methodAnnotations{
+			Name:     camelCase(method.Name),
+			DocLines: c.formatDocumentation(method.Documentation),
+		}
+	}
+	ann := method.Codec.(*methodAnnotations)
+	ann.GrpcPath = language.GrpcPath(method)
+	ann.GrpcUnary = !method.ClientSideStreaming && !method.ServerSideStreaming
+	ann.GrpcServerStreaming = method.ServerSideStreaming && !method.ClientSideStreaming
+	ann.GrpcClientStreaming = method.ClientSideStreaming && !method.ServerSideStreaming
+	ann.GrpcBidiStreaming = method.ClientSideStreaming && method.ServerSideStreaming
+	return nil
+}
+
+func (c *codec) returnType(method *api.Method) string {
+	if method.ReturnsEmpty || method.OutputType == nil {
+		return "Void"
+	}
+	return method.OutputType.Codec.(*messageAnnotations).Name
+}
+
+func (c *codec) paginationAnnotations(method *api.Method) (*paginationAnnotations, error) {
+	if method.Pagination == nil || method.OutputType == nil || method.OutputType.Pagination == nil {
+		return nil, nil
+	}
+	info := method.OutputType.Pagination
+	if info.NextPageToken == nil || info.PageableItem == nil {
+		return nil, nil
+	}
+	var itemType string
+	var err error
+	if info.PageableItem.Map {
+		itemType, err = c.mapItemTypeName(info.PageableItem)
+	} else {
+		itemType, err = c.baseFieldTypeName(info.PageableItem)
+	}
+	if err != nil {
+		return nil, err
+	}
+	nextPageToken := "response." + camelCase(info.NextPageToken.Name)
+	if info.NextPageToken.Optional {
+		nextPageToken += ` ?? ""`
+	}
+	return &paginationAnnotations{
+		ByPageName:    camelCase(method.Name + "_by_page"),
+		ByItemName:    camelCase(method.Name + "_by_item"),
+		PageToken:     camelCase(method.Pagination.Name),
+		NextPageToken: nextPageToken,
+		Items:         camelCase(info.PageableItem.Name),
+		ItemType:      itemType,
+	}, nil
+}
+
+func (c *codec) mapItemTypeName(field *api.Field) (string, error) {
+	m, err := lookupMessage(c.Model, field.TypezID)
+	if err != nil {
+		return "", err
+	}
+	keyType, valueType, err := c.mapEntryTypeNames(m)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("(key: %s, value: %s)", keyType, valueType), nil
+}
+
+// annotatePoller finds the polling method for a long-running operation.
+//
+// The polling method must be part of the same service: either the `GetOperation` mixin for
+// `google.longrunning` operations, or the method injected by the discovery parser for discovery
+// long-running operations. Without a polling method the application must poll by hand.
+func (c *codec) annotatePoller(method *api.Method, methods []*api.Method) error {
+	annotations := method.Codec.(*methodAnnotations)
+	switch {
+	case method.OperationInfo != nil:
+		getOperation := findMethod(methods, func(m *api.Method) bool {
+			return m.Name == "GetOperation" && m.OutputTypeID == ".google.longrunning.Operation"
+		})
+		if getOperation == nil {
+			return nil
+		}
+		response, err := c.operationTypeName(method.OperationInfo.ResponseTypeID)
+		if err != nil {
+			return err
+		}
+		metadata, err := c.operationTypeName(method.OperationInfo.MetadataTypeID)
+		if err != nil {
+			return err
+		}
+		request, err := c.messageTypeName(getOperation.InputType)
+		if err != nil {
+			return err
+		}
+		annotations.Poller = &pollerAnnotations{
+			Name:                camelCase(method.Name + "_poller"),
+			Type:                fmt.Sprintf("GoogleCloudGax.Poller<%s, %s>", response, metadata),
+			GetOperation:        getOperation.Codec.(*methodAnnotations).Name,
+			GetOperationRequest: request,
+			OperationField:      "name",
+		}
+	case method.DiscoveryLro != nil:
+		getOperation := findMethod(methods, func(m *api.Method) bool {
+			return m.Name == "getOperation" && m.OutputTypeID == method.OutputTypeID
+		})
+		if getOperation == nil {
+			return nil
+		}
+		operation, err := c.messageTypeName(method.OutputType)
+		if err != nil {
+			return err
+		}
+		request, err := c.messageTypeName(getOperation.InputType)
+		if err != nil {
+			return err
+		}
+		var pathParameters []string
+		for _, p := range method.DiscoveryLro.PollingPathParameters {
+			pathParameters = append(pathParameters, camelCase(p))
+		}
+		annotations.Poller = &pollerAnnotations{
+			Name:                camelCase(method.Name + "_poller"),
+			Type:                fmt.Sprintf("GoogleCloudGax.DiscoveryPoller<%s>", operation),
+			GetOperation:        getOperation.Codec.(*methodAnnotations).Name,
+			GetOperationRequest: request,
+			OperationField:      "operation",
+			PathParameters:      pathParameters,
+		}
 	}
 	return nil
 }
+
+func (c *codec) operationTypeName(id string) (string, error) {
+	m, err := lookupMessage(c.Model, id)
+	if err != nil {
+		return "", err
+	}
+	return c.messageTypeName(m)
+}
+
+func findMethod(methods []*api.Method, pred func(*api.Method) bool) *api.Method {
+	for _, m := range methods {
+		if pred(m) {
+			return m
+		}
+	}
+	return nil
+}
+
+// isIdempotent returns "true" if the method is idempotent by default, and "false", if not.
+// Methods without HTTP bindings are not idempotent.
+func isIdempotent(p *api.PathInfo) string {
+	if p == nil {
+		return "false"
+	}
+	for _, b := range p.Bindings {
+		if b.Verb == "POST" || b.Verb == "PATCH" {
+			return "false"
+		}
+	}
+	return "true"
+}
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/config"
 	"github.com/googleapis/librarian/internal/sidekick/api"
 )
 
@@ -56,10 +57,13 @@ func TestAnnotateMethod(t *testing.T) {
 			},
 			want: &methodAnnotations{
 				Name:           "getOperation",
+				BaseName:       "getOperation",
+				ReturnType:     "Response",
 				PathExpression: "/v1/operations",
 				DocLines:       []string{"Gets a thing.", "", "Test multiple comment lines.", ""},
 				HTTPMethod:     "GET",
 				HasBody:        false,
+				IsIdempotent:   "true",
 			},
 		},
 		{
@@ -78,11 +82,14 @@ func TestAnnotateMethod(t *testing.T) {
 			},
 			want: &methodAnnotations{
 				Name:           "createKey",
+				BaseName:       "createKey",
+				ReturnType:     "Response",
 				PathExpression: "/v1/keys",
 				HTTPMethod:     "POST",
 				HasBody:        true,
 				IsBodyWildcard: false,
 				BodyField:      "key",
+				IsIdempotent:   "false",
 			},
 		},
 		{
@@ -101,10 +108,13 @@ func TestAnnotateMethod(t *testing.T) {
 			},
 			want: &methodAnnotations{
 				Name:           "uploadData",
+				BaseName:       "uploadData",
+				ReturnType:     "Response",
 				PathExpression: "/v1/data",
 				HTTPMethod:     "POST",
 				HasBody:        true,
 				IsBodyWildcard: true,
+				IsIdempotent:   "false",
 			},
 		},
 		{
@@ -124,11 +134,14 @@ func TestAnnotateMethod(t *testing.T) {
 			},
 			want: &methodAnnotations{
 				Name:           "listThings",
+				BaseName:       "listThings",
+				ReturnType:     "Response",
 				PathExpression: "/v1/things",
 				DocLines:       []string{"Lists things."},
 				HTTPMethod:     "GET",
 				HasBody:        false,
 				QueryParams:    []*api.Field{keyField},
+				IsIdempotent:   "true",
 			},
 		},
 	} {
@@ -161,10 +174,11 @@ func TestAnnotateMethod_EscapedName(t *testing.T) {
 		name       string
 		methodName string
 		wantName   string
+		wantBase   string
 	}{
-		{"escaped func", "Func", "`func`"},
-		{"escaped self", "Self", "self_"},
-		{"escaped default", "Default", "`default`"},
+		{"escaped func", "Func", "`func`", "func"},
+		{"escaped self", "Self", "self_", "self"},
+		{"escaped default", "Default", "`default`", "default"},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			inputType := &api.Message{
@@ -205,9 +219,12 @@ func TestAnnotateMethod_EscapedName(t *testing.T) {
 
 			want := &methodAnnotations{
 				Name:           test.wantName,
+				BaseName:       test.wantBase,
+				ReturnType:     "Response",
 				DocLines:       []string{"Test documentation."},
 				PathExpression: "/",
 				HTTPMethod:     "GET",
+				IsIdempotent:   "true",
 			}
 
 			if diff := cmp.Diff(want, method.Codec); diff != "" {
@@ -257,3 +274,231 @@ func TestAnnotateMethod_WithExternalMessages(t *testing.T) {
 		t.Error("expected output message to be annotated")
 	}
 }
+
+func TestAnnotateMethod_Pagination(t *testing.T) {
+	pageToken := &api.Field{Name: "page_token", ID: ".test.ListRequest.page_token", Typez: api.TypezString}
+	request := &api.Message{
+		Name:   "ListRequest",
+		ID:     ".test.ListRequest",
+		Fields: []*api.Field{pageToken},
+	}
+	entry := &api.Message{
+		Name:  "LabelsEntry",
+		ID:    ".test.ListResponse.LabelsEntry",
+		IsMap: true,
+		Fields: []*api.Field{
+			{Name: "key", ID: ".test.ListResponse.LabelsEntry.key", Typez: api.TypezString},
+			{Name: "value", ID: ".test.ListResponse.LabelsEntry.value", Typez: api.TypezInt32},
+		},
+	}
+	thing := &api.Message{Name: "Thing", ID: ".test.Thing"}
+	for _, test := range []struct {
+		name  string
+		items *api.Field
+		token *api.Field
+		want  *paginationAnnotations
+	}{
+		{
+			name:  "repeated",
+			items: &api.Field{Name: "things", ID: ".test.ListResponse.things", Typez: api.TypezMessage, TypezID: ".test.Thing", Repeated: true},
+			token: &api.Field{Name: "next_page_token", ID: ".test.ListResponse.next_page_token", Typez: api.TypezString},
+			want: &paginationAnnotations{
+				ByPageName:    "listThingsByPage",
+				ByItemName:    "listThingsByItem",
+				PageToken:     "pageToken",
+				NextPageToken: "response.nextPageToken",
+				Items:         "things",
+				ItemType:      "Thing",
+			},
+		},
+		{
+			name:  "map",
+			items: &api.Field{Name: "labels", ID: ".test.ListResponse.labels", Typez: api.TypezMessage, TypezID: ".test.ListResponse.LabelsEntry", Map: true},
+			token: &api.Field{Name: "next_page_token", ID: ".test.ListResponse.next_page_token", Typez: api.TypezString, Optional: true},
+			want: &paginationAnnotations{
+				ByPageName:    "listThingsByPage",
+				ByItemName:    "listThingsByItem",
+				PageToken:     "pageToken",
+				NextPageToken: `response.nextPageToken ?? ""`,
+				Items:         "labels",
+				ItemType:      "(key: String, value: Int32)",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			response := &api.Message{
+				Name:       "ListResponse",
+				ID:         ".test.ListResponse",
+				Fields:     []*api.Field{test.items, test.token},
+				Pagination: &api.PaginationInfo{NextPageToken: test.token, PageableItem: test.items},
+			}
+			method := &api.Method{
+				Name: "ListThings",
+				PathInfo: &api.PathInfo{
+					Bindings: []*api.PathBinding{{Verb: "GET", PathTemplate: &api.PathTemplate{}}},
+				},
+				InputTypeID:  request.ID,
+				InputType:    request,
+				OutputTypeID: response.ID,
+				OutputType:   response,
+				Pagination:   pageToken,
+			}
+			service := &api.Service{Name: "TestService", Methods: []*api.Method{method}}
+			model := api.NewTestAPI([]*api.Message{request, response, thing}, nil, []*api.Service{service})
+			model.AddMessage(entry)
+			codec := newTestCodec(t, model, nil)
+			if err := codec.annotateModel(); err != nil {
+				t.Fatal(err)
+			}
+			got := method.Codec.(*methodAnnotations).Pagination
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAnnotateMethod_OperationInfoPoller(t *testing.T) {
+	operation := &api.Message{Name: "Operation", Package: "google.longrunning", ID: ".google.longrunning.Operation"}
+	getRequest := &api.Message{Name: "GetOperationRequest", Package: "google.longrunning", ID: ".google.longrunning.GetOperationRequest"}
+	request := &api.Message{Name: "CreateThingRequest", Package: "test", ID: ".test.CreateThingRequest"}
+	thing := &api.Message{Name: "Thing", Package: "test", ID: ".test.Thing"}
+	metadata := &api.Message{Name: "OperationMetadata", Package: "test", ID: ".test.OperationMetadata"}
+	binding := &api.PathInfo{Bindings: []*api.PathBinding{{Verb: "POST", PathTemplate: &api.PathTemplate{}}}}
+	create := &api.Method{
+		Name:          "CreateThing",
+		PathInfo:      binding,
+		InputTypeID:   request.ID,
+		InputType:     request,
+		OutputTypeID:  operation.ID,
+		OutputType:    operation,
+		OperationInfo: &api.OperationInfo{ResponseTypeID: thing.ID, MetadataTypeID: metadata.ID},
+	}
+	getOperation := &api.Method{
+		Name:         "GetOperation",
+		PathInfo:     &api.PathInfo{Bindings: []*api.PathBinding{{Verb: "GET", PathTemplate: &api.PathTemplate{}}}},
+		InputTypeID:  getRequest.ID,
+		InputType:    getRequest,
+		OutputTypeID: operation.ID,
+		OutputType:   operation,
+	}
+	service := &api.Service{Name: "TestService", Methods: []*api.Method{create, getOperation}}
+	model := api.NewTestAPI([]*api.Message{request, thing, metadata}, nil, []*api.Service{service})
+	model.PackageName = "test"
+	model.AddMessage(operation)
+	model.AddMessage(getRequest)
+	codec := newTestCodec(t, model, nil)
+	codec.withExtraDependencies(t, []config.SwiftDependency{
+		{Name: "GoogleCloudLongrunning", ApiPackage: "google.longrunning"},
+	})
+	if err := codec.annotateModel(); err != nil {
+		t.Fatal(err)
+	}
+	want := &pollerAnnotations{
+		Name:                "createThingPoller",
+		Type:                "GoogleCloudGax.Poller<Thing, OperationMetadata>",
+		GetOperation:        "getOperation",
+		GetOperationRequest: "GoogleCloudLongrunning.GetOperationRequest",
+		OperationField:      "name",
+	}
+	if diff := cmp.Diff(want, create.Codec.(*methodAnnotations).Poller); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if got := getOperation.Codec.(*methodAnnotations).Poller; got != nil {
+		t.Errorf("expected no poller for GetOperation, got %v", got)
+	}
+}
+
+func TestAnnotateMethod_DiscoveryPoller(t *testing.T) {
+	operation := &api.Message{Name: "Operation", Package: "test", ID: ".test.Operation"}
+	request := &api.Message{Name: "InsertRequest", Package: "test", ID: ".test.InsertRequest"}
+	getRequest := &api.Message{Name: "GetOperationRequest", Package: "test", ID: ".test.GetOperationRequest"}
+	insert := &api.Method{
+		Name:         "insert",
+		PathInfo:     &api.PathInfo{Bindings: []*api.PathBinding{{Verb: "POST", PathTemplate: &api.PathTemplate{}}}},
+		InputTypeID:  request.ID,
+		InputType:    request,
+		OutputTypeID: operation.ID,
+		OutputType:   operation,
+		DiscoveryLro: &api.DiscoveryLro{PollingPathParameters: []string{"project", "zone"}},
+	}
+	getOperation := &api.Method{
+		Name:         "getOperation",
+		PathInfo:     &api.PathInfo{Bindings: []*api.PathBinding{{Verb: "GET", PathTemplate: &api.PathTemplate{}}}},
+		InputTypeID:  getRequest.ID,
+		InputType:    getRequest,
+		OutputTypeID: operation.ID,
+		OutputType:   operation,
+	}
+	service := &api.Service{Name: "Instances", Methods: []*api.Method{insert, getOperation}}
+	model := api.NewTestAPI([]*api.Message{operation, request, getRequest}, nil, []*api.Service{service})
+	model.PackageName = "test"
+	codec := newTestCodec(t, model, nil)
+	if err := codec.annotateModel(); err != nil {
+		t.Fatal(err)
+	}
+	want := &pollerAnnotations{
+		Name:                "insertPoller",
+		Type:                "GoogleCloudGax.DiscoveryPoller<Operation>",
+		GetOperation:        "getOperation",
+		GetOperationRequest: "GetOperationRequest",
+		OperationField:      "operation",
+		PathParameters:      []string{"project", "zone"},
+	}
+	if diff := cmp.Diff(want, insert.Codec.(*methodAnnotations).Poller); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAnnotateMethod_NoPollingMethod(t *testing.T) {
+	operation := &api.Message{Name: "Operation", Package: "test", ID: ".test.Operation"}
+	request := &api.Message{Name: "InsertRequest", Package: "test", ID: ".test.InsertRequest"}
+	insert := &api.Method{
+		Name:         "insert",
+		PathInfo:     &api.PathInfo{Bindings: []*api.PathBinding{{Verb: "POST", PathTemplate: &api.PathTemplate{}}}},
+		InputTypeID:  request.ID,
+		InputType:    request,
+		OutputTypeID: operation.ID,
+		OutputType:   operation,
+		DiscoveryLro: &api.DiscoveryLro{PollingPathParameters: []string{"project"}},
+	}
+	service := &api.Service{Name: "Instances", Methods: []*api.Method{insert}}
+	model := api.NewTestAPI([]*api.Message{operation, request}, nil, []*api.Service{service})
+	model.PackageName = "test"
+	codec := newTestCodec(t, model, nil)
+	if err := codec.annotateModel(); err != nil {
+		t.Fatal(err)
+	}
+	if got := insert.Codec.(*methodAnnotations).Poller; got != nil {
+		t.Errorf("expected no poller without a polling method, got %v", got)
+	}
+}
+
+func TestIsIdempotent(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		info *api.PathInfo
+		want string
+	}{
+		{
+			name: "get",
+			info: &api.PathInfo{Bindings: []*api.PathBinding{{Verb: "GET"}}},
+			want: "true",
+		},
+		{
+			name: "post",
+			info: &api.PathInfo{Bindings: []*api.PathBinding{{Verb: "POST"}}},
+			want: "false",
+		},
+		{
+			name: "no bindings",
+			want: "false",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isIdempotent(test.info); got != test.want {
+				t.Errorf("isIdempotent() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"slices"
 
 	"github.com/googleapis/librarian/internal/license"
+	"github.com/googleapis/librarian/internal/sidekick/language"
 )
 
 type modelAnnotations struct {
@@ -32,6 +33,7 @@ type modelAnnotations struct {
 	WktPackage     string
 	ServiceImports []string
 	MessageImports []string
+	ResourceNames  []*resourceAnnotations
 }
 
 // HasDependencies returns true if the package has dependencies on other packages.
@@ -49,6 +51,11 @@ func (ann *modelAnnotations) Dependencies() []*Dependency {
 	return deps
 }
 
+// HasResourceNames returns true if the package contains resource name helpers.
+func (ann *modelAnnotations) HasResourceNames() bool {
+	return len(ann.ResourceNames) != 0
+}
+
 // HasMessageImports returns true if the package needs imports for the methods.
 //
 // The mustache templates use this to format the generated code.
@@ -69,6 +76,10 @@ func (c *codec) annotateModel() error {
 		annotations.WktPackage = dep.Name
 	}
 	c.Model.Codec = annotations
+	c.ResourceNames = language.ResourceNames(c.Model)
+	for _, name := range c.ResourceNames {
+		annotations.ResourceNames = append(annotations.ResourceNames, c.annotateResourceName(name))
+	}
 	for _, message := range c.Model.Messages {
 		if err := c.annotateMessage(message, annotations); err != nil {
 			return err
@@ -0,0 +1,105 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swift
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/sidekick/language"
+)
+
+type resourceAnnotations struct {
+	Name           string
+	Type           string
+	IsMultiPattern bool
+	Patterns       []*resourcePatternAnnotations
+}
+
+type resourcePatternAnnotations struct {
+	Template string
+	// The name of the enum case, only used for multi-pattern resources.
+	CaseName string
+	// The properties (or associated values) holding each variable.
+	Properties []string
+	// The initializer parameters, such as `project: String, secret: String`.
+	Parameters string
+	// The condition to match a string, binding `values` if there are any
+	// variables in the pattern.
+	Condition string
+	// The initializer arguments, such as `project: values[0], secret: values[1]`.
+	Arguments string
+	// The enum case declaration, such as `projectSecret(project: String, secret: String)`.
+	CaseDeclaration string
+	// The enum case value, such as `.projectSecret(project: values[0], secret: values[1])`.
+	CaseValue string
+	// The enum case pattern, such as `.projectSecret(let project, let secret)`.
+	CasePattern string
+	// The string interpolation to format the name, such as `projects/\(project)/secrets/\(secret)`.
+	Format         string
+	WildcardParent string
+}
+
+func (c *codec) annotateResourceName(name *language.ResourceName) *resourceAnnotations {
+	annotations := &resourceAnnotations{
+		Name:           pascalCase(name.Name),
+		Type:           name.Resource.Type,
+		IsMultiPattern: name.IsMultiPattern(),
+	}
+	for _, p := range name.Patterns {
+		annotations.Patterns = append(annotations.Patterns, annotateResourcePattern(p))
+	}
+	name.Resource.Codec = annotations
+	return annotations
+}
+
+func annotateResourcePattern(p *language.ResourceNamePattern) *resourcePatternAnnotations {
+	var properties, parameters, arguments, bindings, matcher, format []string
+	for _, s := range p.Segments {
+		if s.Variable == "" {
+			matcher = append(matcher, fmt.Sprintf("%q", s.Literal))
+			format = append(format, s.Literal)
+			continue
+		}
+		property := camelCase(s.Variable)
+		properties = append(properties, property)
+		parameters = append(parameters, fmt.Sprintf("%s: String", property))
+		arguments = append(arguments, fmt.Sprintf("%s: values[%d]", property, len(arguments)))
+		bindings = append(bindings, "let "+property)
+		matcher = append(matcher, "nil")
+		format = append(format, fmt.Sprintf(`\(%s)`, property))
+	}
+	match := fmt.Sprintf("_parseResourceName(name, [%s])", strings.Join(matcher, ", "))
+	annotations := &resourcePatternAnnotations{
+		Template:        p.Template,
+		CaseName:        camelCase(p.Name),
+		Properties:      properties,
+		Parameters:      strings.Join(parameters, ", "),
+		Condition:       match + " != nil",
+		Arguments:       strings.Join(arguments, ", "),
+		CaseDeclaration: camelCase(p.Name),
+		CaseValue:       "." + camelCase(p.Name),
+		CasePattern:     "." + camelCase(p.Name),
+		Format:          strings.Join(format, "/"),
+		WildcardParent:  p.WildcardParent,
+	}
+	if len(properties) != 0 {
+		annotations.Condition = "let values = " + match
+		annotations.CaseDeclaration += "(" + annotations.Parameters + ")"
+		annotations.CaseValue += "(" + annotations.Arguments + ")"
+		annotations.CasePattern += "(" + strings.Join(bindings, ", ") + ")"
+	}
+	return annotations
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swift
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+)
+
+func TestAnnotateResource(t *testing.T) {
+	secret := api.NewTestMessage("Secret").
+		WithResource(api.NewTestResource("test.googleapis.com/Secret").WithPatterns(
+			api.NewTestResourcePattern("projects", "project", "secrets", "secret"),
+		))
+	model := api.NewTestAPI([]*api.Message{secret}, []*api.Enum{}, []*api.Service{})
+	codec := newTestCodec(t, model, map[string]string{})
+	if err := codec.annotateModel(); err != nil {
+		t.Fatal(err)
+	}
+	want := &resourceAnnotations{
+		Name: "SecretName",
+		Type: "test.googleapis.com/Secret",
+		Patterns: []*resourcePatternAnnotations{
+			{
+				Template:        "projects/{project}/secrets/{secret}",
+				CaseName:        "projectSecret",
+				Properties:      []string{"project", "secret"},
+				Parameters:      "project: String, secret: String",
+				Condition:       `let values = _parseResourceName(name, ["projects", nil, "secrets", nil])`,
+				Arguments:       "project: values[0], secret: values[1]",
+				CaseDeclaration: "projectSecret(project: String, secret: String)",
+				CaseValue:       ".projectSecret(project: values[0], secret: values[1])",
+				CasePattern:     ".projectSecret(let project, let secret)",
+				Format:          `projects/\(project)/secrets/\(secret)`,
+				WildcardParent:  "projects/-",
+			},
+		},
+	}
+	if diff := cmp.Diff(want, secret.Resource.Codec); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]*resourceAnnotations{want}, model.Codec.(*modelAnnotations).ResourceNames); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAnnotateResource_NoVariables(t *testing.T) {
+	settings := api.NewTestMessage("Settings").
+		WithResource(api.NewTestResource("test.googleapis.com/Settings").WithPatterns(
+			api.ResourcePattern{*(&api.PathSegment{}).WithLiteral("settings")},
+		))
+	model := api.NewTestAPI([]*api.Message{settings}, []*api.Enum{}, []*api.Service{})
+	codec := newTestCodec(t, model, map[string]string{})
+	if err := codec.annotateModel(); err != nil {
+		t.Fatal(err)
+	}
+	want := &resourcePatternAnnotations{
+		Template:        "settings",
+		CaseName:        "settings",
+		Condition:       `_parseResourceName(name, ["settings"]) != nil`,
+		CaseDeclaration: "settings",
+		CaseValue:       ".settings",
+		CasePattern:     ".settings",
+		Format:          "settings",
+	}
+	got := settings.Resource.Codec.(*resourceAnnotations).Patterns[0]
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAnnotateField_ResourceReference(t *testing.T) {
+	secret := api.NewTestMessage("Secret").
+		WithResource(api.NewTestResource("test.googleapis.com/Secret").WithPatterns(
+			api.NewTestResourcePattern("projects", "project", "secrets", "secret"),
+		))
+	for _, test := range []struct {
+		name  string
+		field *api.Field
+		want  string
+	}{
+		{"reference", api.NewTestField("name").WithType(api.TypezString).WithResourceReference("test.googleapis.com/Secret"), "setName"},
+		{"unknown", api.NewTestField("name").WithType(api.TypezString).WithResourceReference("test.googleapis.com/Unknown"), ""},
+		{"repeated", api.NewTestField("names").WithType(api.TypezString).WithRepeated().WithResourceReference("test.googleapis.com/Secret"), ""},
+		{"child type", api.NewTestField("parent").WithType(api.TypezString).WithChildTypeReference("test.googleapis.com/Secret"), ""},
+		{"no reference", api.NewTestField("name").WithType(api.TypezString), ""},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			request := api.NewTestMessage("Request").WithFields(test.field)
+			model := api.NewTestAPI([]*api.Message{secret, request}, []*api.Enum{}, []*api.Service{})
+			codec := newTestCodec(t, model, map[string]string{})
+			if err := codec.annotateModel(); err != nil {
+				t.Fatal(err)
+			}
+			got := test.field.Codec.(*fieldAnnotations)
+			if got.SetterName != test.want {
+				t.Errorf("SetterName = %q, want %q", got.SetterName, test.want)
+			}
+			if test.want != "" && got.ResourceName != "SecretName" {
+				t.Errorf("ResourceName = %q, want %q", got.ResourceName, "SecretName")
+			}
+		})
+	}
+}
@@ -19,9 +19,20 @@ import (
 )
 
 type serviceAnnotations struct {
-	Name             string
-	DocLines         []string
-	RestMethods      []*api.Method
+	Name        string
+	DocLines    []string
+	RestMethods []*api.Method
+	// The name of the protocol implemented by the client and its fake, such as
+	// `SecretManagerServiceStub`.
+	StubName string
+	// The name of the fake client, such as `FakeSecretManagerService`.
+	FakeName string
+	// HasHelpers is true if some methods have pagination or poller helpers.
+	HasHelpers bool
+	// The name of the gRPC client class, such as `SecretManagerServiceGrpc`.
+	GrpcName string
+	// The methods in the gRPC client. This includes methods without HTTP annotations.
+	GrpcMethods      []*api.Method
 	PackageName      string
 	QuickstartMethod *api.Method
 	Model            *modelAnnotations
@@ -31,21 +42,45 @@ func (c *codec) annotateService(service *api.Service, model *modelAnnotations) e
 	docLines := c.formatDocumentation(service.Documentation)
 	var restMethods []*api.Method
 	for _, method := range service.Methods {
-		if isGeneratedMethod(method) {
+		if c.Transports.REST && isGeneratedMethod(method) {
 			if err := c.annotateMethod(method, model); err != nil {
 				return err
 			}
 			restMethods = append(restMethods, method)
 		}
 	}
+	var hasHelpers bool
+	for _, method := range restMethods {
+		if err := c.annotatePoller(method, restMethods); err != nil {
+			return err
+		}
+		ann := method.Codec.(*methodAnnotations)
+		hasHelpers = hasHelpers || ann.Pagination != nil || ann.Poller != nil
+	}
+	var grpcName string
+	var grpcMethods []*api.Method
+	if c.Transports.GRPC {
+		grpcName = pascalCase(service.Name) + "Grpc"
+		for _, method := range service.Methods {
+			if err := c.annotateGrpcMethod(method, model); err != nil {
+				return err
+			}
+			grpcMethods = append(grpcMethods, method)
+		}
+	}
 	var quickstartMethod *api.Method
-	if service.QuickstartMethod != nil && isGeneratedMethod(service.QuickstartMethod) {
+	if c.Transports.REST && service.QuickstartMethod != nil && isGeneratedMethod(service.QuickstartMethod) {
 		quickstartMethod = service.QuickstartMethod
 	}
 	annotations := &serviceAnnotations{
 		Name:             pascalCase(service.Name),
 		DocLines:         docLines,
 		RestMethods:      restMethods,
+		StubName:         pascalCase(service.Name + "Stub"),
+		FakeName:         pascalCase("Fake" + service.Name),
+		HasHelpers:       hasHelpers,
+		GrpcName:         grpcName,
+		GrpcMethods:      grpcMethods,
 		PackageName:      c.PackageName,
 		QuickstartMethod: quickstartMethod,
 		Model:            model,
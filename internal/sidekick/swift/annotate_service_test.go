@@ -28,6 +28,8 @@ func TestAnnotateService(t *testing.T) {
 		serviceName string
 		doc         string
 		wantName    string
+		wantStub    string
+		wantFake    string
 		wantDocs    []string
 	}{
 		{
@@ -35,6 +37,8 @@ func TestAnnotateService(t *testing.T) {
 			serviceName: "IAM",
 			doc:         "IAM service documentation.",
 			wantName:    "IAM",
+			wantStub:    "IAMStub",
+			wantFake:    "FakeIAM",
 			wantDocs:    []string{"IAM service documentation."},
 		},
 		{
@@ -42,6 +46,8 @@ func TestAnnotateService(t *testing.T) {
 			serviceName: "SecretManagerService",
 			doc:         "Secret Manager Service documentation.\nLine 2.",
 			wantName:    "SecretManagerService",
+			wantStub:    "SecretManagerServiceStub",
+			wantFake:    "FakeSecretManagerService",
 			wantDocs:    []string{"Secret Manager Service documentation.", "Line 2."},
 		},
 	} {
@@ -59,6 +65,8 @@ func TestAnnotateService(t *testing.T) {
 
 			want := &serviceAnnotations{
 				Name:     test.wantName,
+				StubName: test.wantStub,
+				FakeName: test.wantFake,
 				DocLines: test.wantDocs,
 			}
 
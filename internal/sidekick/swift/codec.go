@@ -21,6 +21,7 @@ import (
 
 	"github.com/googleapis/librarian/internal/config"
 	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/language"
 	"github.com/googleapis/librarian/internal/sidekick/parser"
 )
 
@@ -49,10 +50,26 @@ type codec struct {
 	Model        *api.API
 	Dependencies []*Dependency
 	ApiPackages  map[string]*Dependency
+	// The resource name helpers generated for this package.
+	ResourceNames []*language.ResourceName
+	// The transports used by the generated clients. Defaults to REST.
+	Transports language.Transports
+	// A directory with templates that shadow the built-in templates.
+	TemplateOverlay string
+	// The version of the generated package.
+	Version string
 }
 
 func newCodec(model *api.API, cfg *parser.ModelConfig, swiftCfg *config.SwiftPackage, outdir string) (*codec, error) {
 	year, _, _ := time.Now().Date()
+	opts := &codecOptions{
+		CopyrightYear:       fmt.Sprintf("%04d", year),
+		PackageNameOverride: PackageName(model),
+		RootName:            "googleapis",
+	}
+	if err := Options.Decode(cfg.Codec, opts); err != nil {
+		return nil, err
+	}
 	absOutdir, err := filepath.Abs(outdir)
 	if err != nil {
 		return nil, err
@@ -69,12 +86,22 @@ func newCodec(model *api.API, cfg *parser.ModelConfig, swiftCfg *config.SwiftPac
 		return nil, err
 	}
 	result := &codec{
-		GenerationYear: fmt.Sprintf("%04d", year),
-		PackageName:    PackageName(model),
-		MonorepoRoot:   rel,
-		RootName:       "googleapis",
-		Model:          model,
-		ApiPackages:    map[string]*Dependency{},
+		GenerationYear:  opts.CopyrightYear,
+		PackageName:     opts.PackageNameOverride,
+		MonorepoRoot:    rel,
+		RootName:        opts.RootName,
+		Model:           model,
+		ApiPackages:     map[string]*Dependency{},
+		Transports:      language.Transports{REST: true},
+		TemplateOverlay: opts.TemplateOverlay,
+		Version:         opts.Version,
+	}
+	if opts.Transport != "" {
+		transports, err := language.ParseTransport(opts.Transport)
+		if err != nil {
+			return nil, err
+		}
+		result.Transports = transports
 	}
 	if swiftCfg != nil {
 		for _, d := range swiftCfg.Dependencies {
@@ -85,17 +112,5 @@ func newCodec(model *api.API, cfg *parser.ModelConfig, swiftCfg *config.SwiftPac
 			}
 		}
 	}
-	for key, definition := range cfg.Codec {
-		switch key {
-		case "copyright-year":
-			result.GenerationYear = definition
-		case "package-name-override":
-			result.PackageName = definition
-		case "root-name":
-			result.RootName = definition
-		default:
-			// Ignore other options.
-		}
-	}
 	return result, nil
 }
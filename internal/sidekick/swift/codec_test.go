@@ -15,11 +15,13 @@
 package swift
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/librarian/internal/config"
 	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/language"
 	"github.com/googleapis/librarian/internal/sidekick/parser"
 )
 
@@ -29,6 +31,7 @@ func TestParseOptions(t *testing.T) {
 			"copyright-year":        "2038",
 			"package-name-override": "GoogleCloudBigtable",
 			"root-name":             "test-root",
+			"transport":             "grpc+rest",
 		},
 	}
 	model := api.NewTestAPI([]*api.Message{}, []*api.Enum{}, []*api.Service{})
@@ -43,12 +46,23 @@ func TestParseOptions(t *testing.T) {
 		RootName:       "test-root",
 		Model:          model,
 		ApiPackages:    map[string]*Dependency{},
+		Transports:     language.Transports{GRPC: true, REST: true},
 	}
 	if diff := cmp.Diff(want, got); diff != "" {
 		t.Errorf("mismatch in codec (-want, +got)\n:%s", diff)
 	}
 }
 
+func TestParseOptions_Unknown(t *testing.T) {
+	cfg := &parser.ModelConfig{
+		Codec: map[string]string{"copyright-yaer": "2038"},
+	}
+	model := api.NewTestAPI([]*api.Message{}, []*api.Enum{}, []*api.Service{})
+	if _, err := newCodec(model, cfg, nil, "."); !errors.Is(err, language.ErrUnknownOption) {
+		t.Errorf("newCodec() error = %v, want %v", err, language.ErrUnknownOption)
+	}
+}
+
 func TestNewCodec_WithSwiftCfg(t *testing.T) {
 	swiftCfg := &config.SwiftPackage{
 		SwiftDefault: config.SwiftDefault{
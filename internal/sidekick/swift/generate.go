@@ -18,17 +18,25 @@ package swift
 import (
 	"context"
 	"embed"
+	"io/fs"
+	"os"
 	"path/filepath"
 
 	"github.com/googleapis/librarian/internal/config"
 	"github.com/googleapis/librarian/internal/sidekick/api"
 	"github.com/googleapis/librarian/internal/sidekick/language"
 	"github.com/googleapis/librarian/internal/sidekick/parser"
+	"github.com/googleapis/librarian/internal/snippetmetadata"
 )
 
 //go:embed all:templates
 var templates embed.FS
 
+// Templates returns the built-in templates for the Swift codec.
+func Templates() fs.FS {
+	return templates
+}
+
 // Generate generates code from the model.
 func Generate(ctx context.Context, model *api.API, outdir string, cfg *parser.ModelConfig, swiftCfg *config.SwiftPackage) error {
 	codec, err := newCodec(model, cfg, swiftCfg, outdir)
@@ -38,13 +46,8 @@ func Generate(ctx context.Context, model *api.API, outdir string, cfg *parser.Mo
 	if err := codec.annotateModel(); err != nil {
 		return err
 	}
-	provider := func(name string) (string, error) {
-		contents, err := templates.ReadFile(name)
-		if err != nil {
-			return "", err
-		}
-		return string(contents), nil
-	}
+	fsys := language.OverlayTemplates(templates, codec.TemplateOverlay)
+	provider := language.TemplatesProvider(fsys)
 	if err := codec.generateMessages(outdir, model, provider); err != nil {
 		return err
 	}
@@ -54,10 +57,13 @@ func Generate(ctx context.Context, model *api.API, outdir string, cfg *parser.Mo
 	if err := codec.generateServices(outdir, model, provider); err != nil {
 		return err
 	}
+	if err := codec.generateResourceNames(outdir, model, provider); err != nil {
+		return err
+	}
 	if err := codec.generateSnippets(outdir, model, provider); err != nil {
 		return err
 	}
-	generatedFiles := language.WalkTemplatesDir(templates, "templates/package")
+	generatedFiles := language.WalkTemplatesDir(fsys, "templates/package")
 	return language.GenerateFromModel(outdir, model, provider, generatedFiles)
 }
 
@@ -89,18 +95,47 @@ func (c *codec) generateEnums(outdir string, model *api.API, provider language.T
 
 func (c *codec) generateServices(outdir string, model *api.API, provider language.TemplateProvider) error {
 	for _, s := range model.Services {
-		generated := language.GeneratedFile{
-			TemplatePath: "templates/common/service.swift.mustache",
-			OutputPath:   filepath.Join("Sources", c.PackageName, s.Name+".swift"),
+		var files []language.GeneratedFile
+		if c.Transports.REST {
+			files = append(files, language.GeneratedFile{
+				TemplatePath: "templates/common/service.swift.mustache",
+				OutputPath:   filepath.Join("Sources", c.PackageName, s.Name+".swift"),
+			}, language.GeneratedFile{
+				TemplatePath: "templates/common/fake_service.swift.mustache",
+				OutputPath:   filepath.Join("Sources", c.PackageName, "Fake"+s.Name+".swift"),
+			})
 		}
-		if err := language.GenerateService(outdir, s, provider, generated); err != nil {
-			return err
+		if c.Transports.GRPC {
+			files = append(files, language.GeneratedFile{
+				TemplatePath: "templates/common/grpc_service.swift.mustache",
+				OutputPath:   filepath.Join("Sources", c.PackageName, s.Name+"Grpc.swift"),
+			})
+		}
+		for _, generated := range files {
+			if err := language.GenerateService(outdir, s, provider, generated); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
+func (c *codec) generateResourceNames(outdir string, model *api.API, provider language.TemplateProvider) error {
+	if len(c.ResourceNames) == 0 {
+		return nil
+	}
+	generated := language.GeneratedFile{
+		TemplatePath: "templates/common/resource_names.swift.mustache",
+		OutputPath:   filepath.Join("Sources", c.PackageName, "ResourceNames.swift"),
+	}
+	return language.GenerateFromModel(outdir, model, provider, []language.GeneratedFile{generated})
+}
+
 func (c *codec) generateSnippets(outdir string, model *api.API, provider language.TemplateProvider) error {
+	if !c.Transports.REST {
+		return nil
+	}
+	var samples []*language.SnippetSample
 	for _, s := range model.Services {
 		generated := language.GeneratedFile{
 			TemplatePath: "templates/common/snippet.swift.mustache",
@@ -109,6 +144,42 @@ func (c *codec) generateSnippets(outdir string, model *api.API, provider languag
 		if err := language.GenerateService(outdir, s, provider, generated); err != nil {
 			return err
 		}
+		sample, err := snippetSample(outdir, s, generated.OutputPath)
+		if err != nil {
+			return err
+		}
+		samples = append(samples, sample)
 	}
-	return nil
+	if len(samples) == 0 {
+		return nil
+	}
+	index := language.NewSnippetIndex(model, snippetmetadata.LanguageSwift, c.PackageName, c.Version, samples)
+	return snippetmetadata.Write(outdir, model.PackageName, index)
+}
+
+// snippetSample describes the quickstart snippet for a service, using the
+// `snippet.show` and `snippet.hide` markers for the short segment.
+func snippetSample(outdir string, service *api.Service, file string) (*language.SnippetSample, error) {
+	content, err := os.ReadFile(filepath.Join(outdir, file))
+	if err != nil {
+		return nil, err
+	}
+	segments := []*snippetmetadata.Segment{snippetmetadata.FullSegment(content)}
+	if short := snippetmetadata.ShortSegment(content, "// snippet.show", "// snippet.hide"); short != nil {
+		segments = append(segments, short)
+	}
+	annotations := service.Codec.(*serviceAnnotations)
+	sample := &language.SnippetSample{
+		Service:    service,
+		ClientName: annotations.Name,
+		File:       filepath.ToSlash(file),
+		Segments:   segments,
+	}
+	if m := annotations.QuickstartMethod; m != nil {
+		sample.Method = m
+		sample.MethodName = m.Codec.(*methodAnnotations).Name
+		sample.RequestType = m.InputType.Codec.(*messageAnnotations).Name
+		sample.ResultType = m.Codec.(*methodAnnotations).ReturnType
+	}
+	return sample, nil
 }
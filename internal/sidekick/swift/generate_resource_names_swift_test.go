@@ -0,0 +1,128 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swift
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/parser"
+)
+
+func TestGenerateResourceNames(t *testing.T) {
+	outDir := t.TempDir()
+
+	secret := api.NewTestMessage("Secret").WithPackage("google.cloud.test.v1").
+		WithResource(api.NewTestResource("test.googleapis.com/Secret").WithPatterns(
+			api.NewTestResourcePattern("projects", "project", "secrets", "secret"),
+			api.NewTestResourcePattern("projects", "project", "locations", "location", "secrets", "secret"),
+		))
+	project := api.NewTestMessage("Project").WithPackage("google.cloud.test.v1").
+		WithResource(api.NewTestResource("test.googleapis.com/Project").WithPatterns(
+			api.NewTestResourcePattern("projects", "project"),
+		))
+	request := api.NewTestMessage("GetSecretRequest").WithPackage("google.cloud.test.v1").
+		WithFields(api.NewTestField("name").WithType(api.TypezString).WithResourceReference("test.googleapis.com/Secret"))
+
+	model := api.NewTestAPI([]*api.Message{secret, project, request}, []*api.Enum{}, []*api.Service{})
+	model.PackageName = "google.cloud.test.v1"
+
+	cfg := &parser.ModelConfig{
+		Codec: map[string]string{
+			"copyright-year": "2038",
+		},
+	}
+	if err := Generate(t.Context(), model, outDir, cfg, swiftConfig(t, nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedDir := filepath.Join(outDir, "Sources", "GoogleCloudTestV1")
+	content, err := os.ReadFile(filepath.Join(expectedDir, "ResourceNames.swift"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	contentStr := string(content)
+
+	gotBlock := extractBlock(t, contentStr, "public struct ProjectName", "return nil }")
+	wantBlock := `public struct ProjectName: Equatable, Hashable, Sendable, CustomStringConvertible {
+  public var project: String
+
+  /// Initialize a new instance of ` + "`ProjectName`" + `.
+  public init(project: String) {
+    self.project = project
+  }
+
+  /// Parses a resource name in the ` + "`projects/{project}`" + ` format.
+  ///
+  /// Returns ` + "`nil`" + ` if ` + "`name`" + ` does not match the pattern.
+  public init?(_ name: String) {
+    guard let values = _parseResourceName(name, ["projects", nil]) else { return nil }`
+	if diff := cmp.Diff(wantBlock, gotBlock); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	gotBlock = extractBlock(t, contentStr, "  public var description: String {\n    switch self {", "    }\n  }")
+	wantBlock = `  public var description: String {
+    switch self {
+    case .projectSecret(let project, let secret):
+      return "projects/\(project)/secrets/\(secret)"
+    case .projectLocationSecret(let project, let location, let secret):
+      return "projects/\(project)/locations/\(location)/secrets/\(secret)"
+    }
+  }`
+	if diff := cmp.Diff(wantBlock, gotBlock); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	gotBlock = extractBlock(t, contentStr, "  public static let projectLocationSecretWildcardParent", "\n")
+	wantBlock = "  public static let projectLocationSecretWildcardParent = \"projects/-/locations/-\"\n"
+	if diff := cmp.Diff(wantBlock, gotBlock); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	content, err = os.ReadFile(filepath.Join(expectedDir, "GetSecretRequest.swift"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotBlock = extractBlock(t, string(content), "  public mutating func setName", "  }")
+	wantBlock = `  public mutating func setName(_ value: SecretName) {
+    self.name = value.description
+  }`
+	if diff := cmp.Diff(wantBlock, gotBlock); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateResourceNames_None(t *testing.T) {
+	outDir := t.TempDir()
+	secret := api.NewTestMessage("Secret").WithPackage("google.cloud.test.v1")
+	model := api.NewTestAPI([]*api.Message{secret}, []*api.Enum{}, []*api.Service{})
+	model.PackageName = "google.cloud.test.v1"
+	cfg := &parser.ModelConfig{
+		Codec: map[string]string{
+			"copyright-year": "2038",
+		},
+	}
+	if err := Generate(t.Context(), model, outDir, cfg, swiftConfig(t, nil)); err != nil {
+		t.Fatal(err)
+	}
+	filename := filepath.Join(outDir, "Sources", "GoogleCloudTestV1", "ResourceNames.swift")
+	if _, err := os.Stat(filename); err == nil {
+		t.Errorf("unexpected file generated: %s", filename)
+	}
+}
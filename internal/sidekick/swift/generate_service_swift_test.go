@@ -15,6 +15,8 @@
 package swift
 
 import (
+	"encoding/json"
+	"flag"
 	"os"
 	"path/filepath"
 	"strings"
@@ -24,8 +26,11 @@ import (
 	"github.com/googleapis/librarian/internal/config"
 	"github.com/googleapis/librarian/internal/sidekick/api"
 	"github.com/googleapis/librarian/internal/sidekick/parser"
+	"github.com/googleapis/librarian/internal/snippetmetadata"
 )
 
+var updateGolden = flag.Bool("update", false, "update golden files")
+
 func TestGenerateService_Files(t *testing.T) {
 	outDir := t.TempDir()
 
@@ -118,6 +123,62 @@ func TestGenerateService_SnippetFiles(t *testing.T) {
 	}
 }
 
+func TestGenerateService_SnippetMetadata(t *testing.T) {
+	outDir := t.TempDir()
+
+	service := &api.Service{
+		Name:        "SecretManagerService",
+		ID:          ".google.cloud.test.v1.SecretManagerService",
+		Package:     "google.cloud.test.v1",
+		DefaultHost: "test.googleapis.com",
+	}
+	model := api.NewTestAPI(nil, nil, []*api.Service{service})
+	model.PackageName = "google.cloud.test.v1"
+
+	cfg := &parser.ModelConfig{
+		Codec: map[string]string{
+			"copyright-year": "2038",
+			"version":        "1.2.3",
+		},
+	}
+	if err := Generate(t.Context(), model, outDir, cfg, swiftConfig(t, nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outDir, snippetmetadata.FileName("google.cloud.test.v1")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := &snippetmetadata.Index{}
+	if err := json.Unmarshal(content, got); err != nil {
+		t.Fatal(err)
+	}
+	want := &snippetmetadata.Index{
+		ClientLibrary: &snippetmetadata.ClientLibrary{
+			APIs:     []*snippetmetadata.API{{ID: "google.cloud.test.v1", Version: "v1"}},
+			Language: snippetmetadata.LanguageSwift,
+			Name:     "GoogleCloudTestV1",
+			Version:  "1.2.3",
+		},
+		Snippets: []*snippetmetadata.Snippet{
+			{
+				File:      "Snippets/SecretManagerServiceQuickstart.swift",
+				Language:  snippetmetadata.LanguageSwift,
+				Origin:    "API_DEFINITION",
+				RegionTag: "test_v1_generated_SecretManagerService_Quickstart_async",
+				Segments: []*snippetmetadata.Segment{
+					{Start: 1, End: 37, Type: snippetmetadata.SegmentFull},
+					{Start: 19, End: 25, Type: snippetmetadata.SegmentShort},
+				},
+				Title: "test SecretManagerService Quickstart",
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestGenerateService_WithImports(t *testing.T) {
 	outDir := t.TempDir()
 
@@ -335,3 +396,252 @@ func TestGenerateService_PathParameters(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateService_Pagination(t *testing.T) {
+	outDir := t.TempDir()
+
+	pageToken := &api.Field{Name: "page_token", ID: ".google.cloud.test.v1.ListSecretsRequest.page_token", Typez: api.TypezString}
+	request := &api.Message{
+		Name:    "ListSecretsRequest",
+		Package: "google.cloud.test.v1",
+		ID:      ".google.cloud.test.v1.ListSecretsRequest",
+		Fields:  []*api.Field{pageToken},
+	}
+	secret := &api.Message{Name: "Secret", Package: "google.cloud.test.v1", ID: ".google.cloud.test.v1.Secret"}
+	items := &api.Field{
+		Name:     "secrets",
+		ID:       ".google.cloud.test.v1.ListSecretsResponse.secrets",
+		Typez:    api.TypezMessage,
+		TypezID:  secret.ID,
+		Repeated: true,
+	}
+	nextPageToken := &api.Field{Name: "next_page_token", ID: ".google.cloud.test.v1.ListSecretsResponse.next_page_token", Typez: api.TypezString}
+	response := &api.Message{
+		Name:       "ListSecretsResponse",
+		Package:    "google.cloud.test.v1",
+		ID:         ".google.cloud.test.v1.ListSecretsResponse",
+		Fields:     []*api.Field{items, nextPageToken},
+		Pagination: &api.PaginationInfo{NextPageToken: nextPageToken, PageableItem: items},
+	}
+	service := &api.Service{
+		Name: "SecretManagerService",
+		Methods: []*api.Method{
+			{
+				Name:         "ListSecrets",
+				InputTypeID:  request.ID,
+				InputType:    request,
+				OutputTypeID: response.ID,
+				OutputType:   response,
+				Pagination:   pageToken,
+				PathInfo: &api.PathInfo{
+					Bindings: []*api.PathBinding{{Verb: "GET", PathTemplate: (&api.PathTemplate{}).WithLiteral("v1").WithLiteral("secrets")}},
+				},
+			},
+		},
+	}
+	model := api.NewTestAPI([]*api.Message{request, response, secret}, nil, []*api.Service{service})
+	model.PackageName = "google.cloud.test.v1"
+
+	cfg := &parser.ModelConfig{
+		Codec: map[string]string{
+			"copyright-year": "2038",
+		},
+	}
+	if err := Generate(t.Context(), model, outDir, cfg, swiftConfig(t, nil)); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(filepath.Join(outDir, "Sources", "GoogleCloudTestV1", "SecretManagerService.swift"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	contentStr := string(content)
+
+	gotBlock := extractBlock(t, contentStr, "  public func listSecretsByItem(", "  ) -> AsyncThrowingStream<Secret, Error> {")
+	wantBlock := `  public func listSecretsByItem(
+    request: ListSecretsRequest,
+    options: GoogleCloudGax.RequestOptions = GoogleCloudGax.RequestOptions(),
+  ) -> AsyncThrowingStream<Secret, Error> {`
+	if diff := cmp.Diff(wantBlock, gotBlock); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	gotBlock = extractBlock(t, contentStr, "            let token = ", "            request.pageToken = token")
+	wantBlock = `            let token = response.nextPageToken
+            if token.isEmpty {
+              break
+            }
+            request.pageToken = token`
+	if diff := cmp.Diff(wantBlock, gotBlock); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if !strings.Contains(contentStr, "let data = try await self.send(req, idempotent: true, options: options)") {
+		t.Errorf("expected idempotent send in generated code:\n%s", contentStr)
+	}
+}
+
+func TestGenerateService_GrpcGolden(t *testing.T) {
+	outDir := t.TempDir()
+	model := api.NewTestEchoAPI()
+	if err := api.CrossReference(model); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &parser.ModelConfig{
+		Codec: map[string]string{
+			"copyright-year": "2038",
+			"transport":      "grpc",
+		},
+	}
+	if err := Generate(t.Context(), model, outDir, cfg, swiftConfig(t, nil)); err != nil {
+		t.Fatal(err)
+	}
+	sources := filepath.Join(outDir, "Sources", "GoogleCloudTestV1")
+	if _, err := os.Stat(filepath.Join(sources, "Echo.swift")); err == nil {
+		t.Errorf("expected no REST client with a gRPC-only transport")
+	}
+	got, err := os.ReadFile(filepath.Join(sources, "EchoGrpc.swift"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join("testdata", "grpc", "EchoGrpc.swift.golden")
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Errorf("mismatch (-want +got), run with -update to refresh the golden files:\n%s", diff)
+	}
+}
+
+func TestGenerateService_GrpcAndRest(t *testing.T) {
+	outDir := t.TempDir()
+	model := api.NewTestEchoAPI()
+	if err := api.CrossReference(model); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &parser.ModelConfig{
+		Codec: map[string]string{
+			"copyright-year": "2038",
+			"transport":      "grpc+rest",
+		},
+	}
+	if err := Generate(t.Context(), model, outDir, cfg, swiftConfig(t, nil)); err != nil {
+		t.Fatal(err)
+	}
+	sources := filepath.Join(outDir, "Sources", "GoogleCloudTestV1")
+	for _, expected := range []string{"Echo.swift", "EchoGrpc.swift"} {
+		if _, err := os.Stat(filepath.Join(sources, expected)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestGenerateService_BadTransport(t *testing.T) {
+	model := api.NewTestEchoAPI()
+	if err := api.CrossReference(model); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &parser.ModelConfig{
+		Codec: map[string]string{"transport": "carrier-pigeon"},
+	}
+	if err := Generate(t.Context(), model, t.TempDir(), cfg, swiftConfig(t, nil)); err == nil {
+		t.Fatal("expected error with an invalid transport")
+	}
+}
+
+func TestGenerateService_FakeGolden(t *testing.T) {
+	outDir := t.TempDir()
+	name := &api.Field{Name: "name", ID: ".google.cloud.test.v1.GetSecretRequest.name", Typez: api.TypezString}
+	getRequest := &api.Message{
+		Name:    "GetSecretRequest",
+		Package: "google.cloud.test.v1",
+		ID:      ".google.cloud.test.v1.GetSecretRequest",
+		Fields:  []*api.Field{name},
+	}
+	deleteRequest := &api.Message{
+		Name:    "DeleteSecretRequest",
+		Package: "google.cloud.test.v1",
+		ID:      ".google.cloud.test.v1.DeleteSecretRequest",
+	}
+	secret := &api.Message{Name: "Secret", Package: "google.cloud.test.v1", ID: ".google.cloud.test.v1.Secret"}
+	binding := func(verb string) *api.PathInfo {
+		return &api.PathInfo{
+			Bindings: []*api.PathBinding{{Verb: verb, PathTemplate: (&api.PathTemplate{}).WithLiteral("v1").WithLiteral("secrets")}},
+		}
+	}
+	service := &api.Service{
+		Name:    "SecretManagerService",
+		Package: "google.cloud.test.v1",
+		Methods: []*api.Method{
+			{
+				Name:          "GetSecret",
+				Documentation: "Gets a secret.",
+				InputTypeID:   getRequest.ID,
+				InputType:     getRequest,
+				OutputTypeID:  secret.ID,
+				OutputType:    secret,
+				PathInfo:      binding("GET"),
+			},
+			{
+				Name:          "DeleteSecret",
+				Documentation: "Deletes a secret.",
+				InputTypeID:   deleteRequest.ID,
+				InputType:     deleteRequest,
+				OutputTypeID:  ".google.protobuf.Empty",
+				ReturnsEmpty:  true,
+				PathInfo:      binding("DELETE"),
+			},
+		},
+	}
+	model := api.NewTestAPI([]*api.Message{getRequest, deleteRequest, secret}, nil, []*api.Service{service})
+	model.PackageName = "google.cloud.test.v1"
+
+	cfg := &parser.ModelConfig{
+		Codec: map[string]string{
+			"copyright-year": "2038",
+		},
+	}
+	if err := Generate(t.Context(), model, outDir, cfg, swiftConfig(t, nil)); err != nil {
+		t.Fatal(err)
+	}
+	sources := filepath.Join(outDir, "Sources", "GoogleCloudTestV1")
+	content, err := os.ReadFile(filepath.Join(sources, "SecretManagerService.swift"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"public protocol SecretManagerServiceStub {",
+		"extension SecretManagerService: SecretManagerServiceStub {}",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("missing %q in generated service", want)
+		}
+	}
+
+	got, err := os.ReadFile(filepath.Join(sources, "FakeSecretManagerService.swift"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join("testdata", "fake", "FakeSecretManagerService.swift.golden")
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Errorf("mismatch (-want +got), run with -update to refresh the golden files:\n%s", diff)
+	}
+}
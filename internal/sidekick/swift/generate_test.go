@@ -48,10 +48,8 @@ func TestFromProtobuf(t *testing.T) {
 			ActiveRoots: []string{"googleapis"},
 		},
 		Codec: map[string]string{
-			"copyright-year":      "2038",
-			"not-for-publication": "true",
-			"version":             "0.1.0",
-			"skip-format":         "true",
+			"copyright-year": "2038",
+			"version":        "0.1.0",
 		},
 	}
 	model, err := parser.CreateModel(cfg)
@@ -0,0 +1,40 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swift
+
+import "github.com/googleapis/librarian/internal/sidekick/language"
+
+// Options describes the codec options accepted by the Swift generator.
+var Options = &language.OptionSchema{
+	Codec: "swift",
+	Options: []language.Option{
+		{Name: "copyright-year", Doc: "The year when the files were first generated."},
+		{Name: "package-name-override", Doc: "Overrides the default package name."},
+		{Name: "root-name", Doc: "The name of the source root, defaults to `googleapis`."},
+		{Name: "template-overlay", Doc: "A directory with templates that shadow the built-in templates."},
+		{Name: "transport", Values: []string{"rest", "grpc", "grpc+rest"}, Doc: "The transports used by the generated clients, defaults to `rest`."},
+		{Name: "version", Doc: "The version of the generated package."},
+	},
+}
+
+// codecOptions holds the decoded [Options].
+type codecOptions struct {
+	CopyrightYear       string `option:"copyright-year"`
+	PackageNameOverride string `option:"package-name-override"`
+	RootName            string `option:"root-name"`
+	TemplateOverlay     string `option:"template-overlay"`
+	Transport           string `option:"transport"`
+	Version             string `option:"version"`
+}
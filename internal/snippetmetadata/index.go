@@ -0,0 +1,213 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snippetmetadata
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// The values for the language fields in a snippet metadata file.
+const (
+	LanguageDart  = "DART"
+	LanguageRust  = "RUST"
+	LanguageSwift = "SWIFT"
+)
+
+// The values for the type of a segment.
+const (
+	SegmentFull  = "FULL"
+	SegmentShort = "SHORT"
+)
+
+// originAPIDefinition is the origin of samples generated from the API
+// definition.
+const originAPIDefinition = "API_DEFINITION"
+
+var versionRegex = regexp.MustCompile(`^v\d+`)
+
+// Index is the contents of a snippet metadata file.
+//
+// The fields follow the `snippet_index` schema used by the other generators.
+// They are sorted by their JSON name, so [ReformatAll] preserves the output.
+type Index struct {
+	ClientLibrary *ClientLibrary `json:"clientLibrary"`
+	Snippets      []*Snippet     `json:"snippets"`
+}
+
+// ClientLibrary describes the library containing the samples.
+type ClientLibrary struct {
+	APIs     []*API `json:"apis"`
+	Language string `json:"language"`
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+}
+
+// API identifies an API included in the library.
+type API struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+}
+
+// Snippet describes a single sample.
+type Snippet struct {
+	ClientMethod *ClientMethod `json:"clientMethod,omitempty"`
+	Description  string        `json:"description,omitempty"`
+	File         string        `json:"file"`
+	Language     string        `json:"language"`
+	Origin       string        `json:"origin"`
+	RegionTag    string        `json:"regionTag"`
+	Segments     []*Segment    `json:"segments"`
+	Title        string        `json:"title"`
+}
+
+// ClientMethod describes the client method demonstrated by a sample.
+type ClientMethod struct {
+	Async      bool         `json:"async,omitempty"`
+	Client     *Client      `json:"client"`
+	FullName   string       `json:"fullName"`
+	Method     *Method      `json:"method"`
+	Parameters []*Parameter `json:"parameters,omitempty"`
+	ResultType string       `json:"resultType,omitempty"`
+	ShortName  string       `json:"shortName"`
+}
+
+// Client identifies the client type in the library.
+type Client struct {
+	FullName  string `json:"fullName"`
+	ShortName string `json:"shortName"`
+}
+
+// Method identifies the RPC called by the client method.
+type Method struct {
+	FullName  string   `json:"fullName"`
+	Service   *Service `json:"service"`
+	ShortName string   `json:"shortName"`
+}
+
+// Service identifies the service defining the RPC.
+type Service struct {
+	FullName  string `json:"fullName"`
+	ShortName string `json:"shortName"`
+}
+
+// Parameter describes a parameter of the client method.
+type Parameter struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Segment is a range of lines in the sample file, starting at 1.
+type Segment struct {
+	End   int    `json:"end"`
+	Start int    `json:"start"`
+	Type  string `json:"type"`
+}
+
+// FileName returns the name of the snippet metadata file for a Protobuf
+// package, such as `snippet_metadata_google.cloud.secretmanager.v1.json`.
+func FileName(packageName string) string {
+	return fmt.Sprintf("snippet_metadata_%s.json", packageName)
+}
+
+// Write writes index to dir, in the file returned by [FileName] for
+// packageName.
+func Write(dir, packageName string, index *Index) error {
+	path := filepath.Join(dir, FileName(packageName))
+	content, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding snippet metadata file %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("error writing snippet metadata file %s: %w", path, err)
+	}
+	return nil
+}
+
+// NewSnippet returns a snippet with the fields common to all samples
+// generated from an API definition.
+func NewSnippet(language, regionTag, file string) *Snippet {
+	return &Snippet{
+		File:      file,
+		Language:  language,
+		Origin:    originAPIDefinition,
+		RegionTag: regionTag,
+	}
+}
+
+// APIVersion returns the version of a Protobuf package, such as `v1` for
+// `google.cloud.secretmanager.v1`. It returns an empty string for
+// unversioned packages.
+func APIVersion(packageName string) string {
+	idx := strings.LastIndex(packageName, ".")
+	last := packageName[idx+1:]
+	if !versionRegex.MatchString(last) {
+		return ""
+	}
+	return last
+}
+
+// RegionTag returns the region tag for a sample, such as
+// `secretmanager_v1_generated_SecretManagerService_CreateSecret_async`.
+//
+// The API short name is the first label of defaultHost. The method is empty
+// for samples that demonstrate a service, such as quickstarts.
+func RegionTag(defaultHost, packageName, service, method string) string {
+	shortName, _, _ := strings.Cut(defaultHost, ".")
+	if method == "" {
+		method = "Quickstart"
+	}
+	var parts []string
+	for _, p := range []string{shortName, APIVersion(packageName), "generated", service, method, "async"} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, "_")
+}
+
+// FullSegment returns a segment covering all the lines in content.
+func FullSegment(content []byte) *Segment {
+	lines := bytes.Count(content, []byte("\n"))
+	if len(content) > 0 && !bytes.HasSuffix(content, []byte("\n")) {
+		lines++
+	}
+	return &Segment{Start: 1, End: lines, Type: SegmentFull}
+}
+
+// ShortSegment returns a segment covering the lines between the first line
+// equal to begin and the next line equal to end, both exclusive. Leading and
+// trailing whitespace is ignored when comparing lines. It returns nil if the
+// markers are not found.
+func ShortSegment(content []byte, begin, end string) *Segment {
+	var start int
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case start == 0 && text == begin:
+			start = line + 1
+		case start != 0 && text == end:
+			return &Segment{Start: start, End: line - 1, Type: SegmentShort}
+		}
+	}
+	return nil
+}
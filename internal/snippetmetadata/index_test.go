@@ -0,0 +1,127 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snippetmetadata
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRegionTag(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		defaultHost string
+		packageName string
+		method      string
+		want        string
+	}{
+		{
+			name:        "method",
+			defaultHost: "secretmanager.googleapis.com",
+			packageName: "google.cloud.secretmanager.v1",
+			method:      "CreateSecret",
+			want:        "secretmanager_v1_generated_SecretManagerService_CreateSecret_async",
+		},
+		{
+			name:        "quickstart",
+			defaultHost: "secretmanager.googleapis.com",
+			packageName: "google.cloud.secretmanager.v1",
+			want:        "secretmanager_v1_generated_SecretManagerService_Quickstart_async",
+		},
+		{
+			name:        "unversioned",
+			defaultHost: "secretmanager.googleapis.com",
+			packageName: "google.cloud.secretmanager",
+			method:      "CreateSecret",
+			want:        "secretmanager_generated_SecretManagerService_CreateSecret_async",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := RegionTag(test.defaultHost, test.packageName, "SecretManagerService", test.method)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAPIVersion(t *testing.T) {
+	for _, test := range []struct {
+		packageName string
+		want        string
+	}{
+		{"google.cloud.secretmanager.v1", "v1"},
+		{"google.cloud.secretmanager.v1beta2", "v1beta2"},
+		{"google.cloud.secretmanager", ""},
+		{"google.type", ""},
+	} {
+		t.Run(test.packageName, func(t *testing.T) {
+			got := APIVersion(test.packageName)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSegments(t *testing.T) {
+	content := []byte("// header\n// [START tag]\nfirst\nsecond\n// [END tag]\n")
+	if diff := cmp.Diff(&Segment{Start: 1, End: 5, Type: SegmentFull}, FullSegment(content)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	got := ShortSegment(content, "// [START tag]", "// [END tag]")
+	if diff := cmp.Diff(&Segment{Start: 3, End: 4, Type: SegmentShort}, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if got := ShortSegment(content, "// [START other]", "// [END other]"); got != nil {
+		t.Errorf("expected no segment for missing markers, got %v", got)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	snippet := NewSnippet(LanguageRust, "secretmanager_v1_generated_SecretManagerService_Quickstart_async", "src/client.rs")
+	index := &Index{
+		ClientLibrary: &ClientLibrary{
+			APIs:     []*API{{ID: "google.cloud.secretmanager.v1", Version: "v1"}},
+			Language: LanguageRust,
+			Name:     "google-cloud-secretmanager-v1",
+			Version:  "1.0.0",
+		},
+		Snippets: []*Snippet{snippet},
+	}
+	if err := Write(dir, "google.cloud.secretmanager.v1", index); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, FileName("google.cloud.secretmanager.v1"))
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The generated files must be stable when reformatted or bumped.
+	if err := updateLibraryVersion(path, "1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(before), string(after)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
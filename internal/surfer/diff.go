@@ -0,0 +1,303 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package surfer
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/sidekick/gcloud"
+	"github.com/googleapis/librarian/internal/sidekick/gcloud/provider"
+	"github.com/iancoleman/strcase"
+)
+
+type diffConfig struct {
+	GcloudConfig              string
+	IncludeList               string
+	BaseGoogleapis            string
+	BaseServiceConfig         string
+	BaseDescriptorFiles       string
+	Googleapis                string
+	ServiceConfig             string
+	DescriptorFiles           string
+	DescriptorFilesToGenerate string
+}
+
+// change describes a difference between two command trees in a release
+// track.
+type change struct {
+	Track   string
+	Action  string // "added", "removed" or "renamed"
+	Kind    string // "command", "flag", "positional argument" or "resource argument"
+	Command string
+	Name    string
+	NewName string
+}
+
+func (c change) String() string {
+	if c.Kind == "command" {
+		if c.Action == "renamed" {
+			return fmt.Sprintf("%s: renamed command %q to %q", c.Track, c.Command, c.NewName)
+		}
+		return fmt.Sprintf("%s: %s command %q", c.Track, c.Action, c.Command)
+	}
+	switch c.Action {
+	case "added":
+		return fmt.Sprintf("%s: added %s %s to %q", c.Track, c.Kind, c.Name, c.Command)
+	case "removed":
+		return fmt.Sprintf("%s: removed %s %s from %q", c.Track, c.Kind, c.Name, c.Command)
+	default:
+		return fmt.Sprintf("%s: renamed %s %s to %s in %q", c.Track, c.Kind, c.Name, c.NewName, c.Command)
+	}
+}
+
+// diff builds the command trees for the base and the new API revision in cfg
+// and writes the changes between them to w.
+func diff(cfg diffConfig, w io.Writer) error {
+	overrides, err := provider.ReadGcloudConfig(cfg.GcloudConfig)
+	if err != nil {
+		return err
+	}
+	baseServiceConfig := cmp.Or(cfg.BaseServiceConfig, cfg.ServiceConfig)
+	baseDescriptorFiles := cmp.Or(cfg.BaseDescriptorFiles, cfg.DescriptorFiles)
+	base, err := buildSurface(overrides, cfg.BaseGoogleapis, cfg.IncludeList, baseServiceConfig, baseDescriptorFiles, cfg.DescriptorFilesToGenerate)
+	if err != nil {
+		return fmt.Errorf("failed to build base command tree: %w", err)
+	}
+	head, err := buildSurface(overrides, cfg.Googleapis, cfg.IncludeList, cfg.ServiceConfig, cfg.DescriptorFiles, cfg.DescriptorFilesToGenerate)
+	if err != nil {
+		return fmt.Errorf("failed to build command tree: %w", err)
+	}
+	changes := diffSurfaces(base, head)
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(w, "no changes")
+		return err
+	}
+	for _, c := range changes {
+		if _, err := fmt.Fprintln(w, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func buildSurface(overrides *provider.Config, googleapis, includeList, serviceConfig, descriptorFiles, descriptorFilesToGenerate string) (*gcloud.CommandGroupsByTrack, error) {
+	model, err := provider.CreateAPIModel(googleapis, includeList, serviceConfig, descriptorFiles, descriptorFilesToGenerate)
+	if err != nil {
+		return nil, err
+	}
+	return gcloud.BuildSurface(model, overrides)
+}
+
+// diffSurfaces returns the commands, flags and resource arguments added,
+// removed or renamed between base and head, by release track. A removed and
+// an added command that call the same API method are reported as a rename, as
+// are a removed and an added argument that set the same API field.
+func diffSurfaces(base, head *gcloud.CommandGroupsByTrack) []change {
+	var changes []change
+	for _, track := range []struct {
+		name       string
+		base, head *gcloud.CommandGroup
+	}{
+		{string(provider.ReleaseTrackGA), base.GA, head.GA},
+		{string(provider.ReleaseTrackBeta), base.BETA, head.BETA},
+		{string(provider.ReleaseTrackAlpha), base.ALPHA, head.ALPHA},
+	} {
+		changes = append(changes, diffTrack(track.name, collectCommands(track.base), collectCommands(track.head))...)
+	}
+	return changes
+}
+
+func diffTrack(track string, base, head map[string]*gcloud.Command) []change {
+	var removed, added []string
+	var common []string
+	for name := range base {
+		if _, ok := head[name]; ok {
+			common = append(common, name)
+		} else {
+			removed = append(removed, name)
+		}
+	}
+	for name := range head {
+		if _, ok := base[name]; !ok {
+			added = append(added, name)
+		}
+	}
+	key := func(c *gcloud.Command) string {
+		if c.Method == "" {
+			return ""
+		}
+		return strings.Join(c.Collection, ",") + "." + c.Method
+	}
+	renames := matchRenames(removed, added, func(name string) string { return key(base[name]) }, func(name string) string { return key(head[name]) })
+
+	var changes []change
+	for _, name := range removed {
+		if newName, ok := renames[name]; ok {
+			changes = append(changes, change{Track: track, Action: "renamed", Kind: "command", Command: name, NewName: newName})
+			continue
+		}
+		changes = append(changes, change{Track: track, Action: "removed", Kind: "command", Command: name})
+	}
+	renamed := map[string]bool{}
+	for _, newName := range renames {
+		renamed[newName] = true
+	}
+	for _, name := range added {
+		if !renamed[name] {
+			changes = append(changes, change{Track: track, Action: "added", Kind: "command", Command: name})
+		}
+	}
+	for _, name := range common {
+		changes = append(changes, diffArguments(track, name, base[name], head[name])...)
+	}
+	for oldName, newName := range renames {
+		changes = append(changes, diffArguments(track, newName, base[oldName], head[newName])...)
+	}
+	slices.SortFunc(changes, func(a, b change) int {
+		return cmp.Or(
+			cmp.Compare(a.Command, b.Command),
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(a.NewName, b.NewName),
+		)
+	})
+	return changes
+}
+
+func diffArguments(track, command string, base, head *gcloud.Command) []change {
+	baseArgs := collectArguments(base)
+	headArgs := collectArguments(head)
+	var removed, added []string
+	for name := range baseArgs {
+		if _, ok := headArgs[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	for name := range headArgs {
+		if _, ok := baseArgs[name]; !ok {
+			added = append(added, name)
+		}
+	}
+	key := func(arg gcloud.Argument) string {
+		if arg.APIField == "" {
+			return ""
+		}
+		return argumentKind(arg) + ":" + arg.APIField
+	}
+	renames := matchRenames(removed, added, func(name string) string { return key(baseArgs[name]) }, func(name string) string { return key(headArgs[name]) })
+
+	var changes []change
+	renamed := map[string]bool{}
+	for _, name := range removed {
+		arg := baseArgs[name]
+		if newName, ok := renames[name]; ok {
+			renamed[newName] = true
+			changes = append(changes, change{Track: track, Action: "renamed", Kind: argumentKind(arg), Command: command, Name: name, NewName: newName})
+			continue
+		}
+		changes = append(changes, change{Track: track, Action: "removed", Kind: argumentKind(arg), Command: command, Name: name})
+	}
+	for _, name := range added {
+		if !renamed[name] {
+			changes = append(changes, change{Track: track, Action: "added", Kind: argumentKind(headArgs[name]), Command: command, Name: name})
+		}
+	}
+	return changes
+}
+
+// matchRenames pairs each removed name with an added name that has the same
+// non-empty key. Names are considered in sorted order so the result is
+// deterministic when several candidates share a key.
+func matchRenames(removed, added []string, removedKey, addedKey func(string) string) map[string]string {
+	slices.Sort(removed)
+	slices.Sort(added)
+	byKey := map[string][]string{}
+	for _, name := range added {
+		if k := addedKey(name); k != "" {
+			byKey[k] = append(byKey[k], name)
+		}
+	}
+	renames := map[string]string{}
+	for _, name := range removed {
+		k := removedKey(name)
+		if k == "" || len(byKey[k]) == 0 {
+			continue
+		}
+		renames[name] = byKey[k][0]
+		byKey[k] = byKey[k][1:]
+	}
+	return renames
+}
+
+// collectCommands returns the commands in the tree rooted at group, keyed by
+// their command line path, such as "parallelstore instances create".
+func collectCommands(group *gcloud.CommandGroup) map[string]*gcloud.Command {
+	commands := map[string]*gcloud.Command{}
+	var walk func(g *gcloud.CommandGroup, path []string)
+	walk = func(g *gcloud.CommandGroup, path []string) {
+		if g == nil {
+			return
+		}
+		path = append(slices.Clip(path), strcase.ToKebab(g.Name))
+		for verb, c := range g.Commands {
+			commands[strings.Join(append(slices.Clip(path), strcase.ToKebab(verb)), " ")] = c
+		}
+		for _, sub := range g.Groups {
+			walk(sub, path)
+		}
+	}
+	walk(group, nil)
+	return commands
+}
+
+// collectArguments returns the arguments of c keyed by how they appear on the
+// command line: flags are prefixed with "--", positionals are upper case.
+func collectArguments(c *gcloud.Command) map[string]gcloud.Argument {
+	args := map[string]gcloud.Argument{}
+	addArguments(args, c.Arguments)
+	return args
+}
+
+// addArguments records each argument under its user-facing name. Argument
+// groups contribute the flags of their members rather than a flag of their
+// own.
+func addArguments(args map[string]gcloud.Argument, list []gcloud.Argument) {
+	for _, arg := range list {
+		if len(arg.Params) != 0 {
+			addArguments(args, arg.Params)
+			continue
+		}
+		name := "--" + arg.ArgName
+		if arg.IsPositional {
+			name = strings.ToUpper(strcase.ToSnake(arg.ArgName))
+		}
+		args[name] = arg
+	}
+}
+
+func argumentKind(arg gcloud.Argument) string {
+	switch {
+	case arg.ResourceSpec != nil:
+		return "resource argument"
+	case arg.IsPositional:
+		return "positional argument"
+	default:
+		return "flag"
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package surfer
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/librarian/internal/sidekick/gcloud"
+)
+
+func TestDiffSurfaces(t *testing.T) {
+	collection := []string{"parallelstore.projects.locations.instances"}
+	instance := gcloud.Argument{
+		ArgName:           "instance",
+		APIField:          "name",
+		IsPositional:      true,
+		IsPrimaryResource: true,
+		ResourceSpec:      &gcloud.ResourceSpec{Name: "instance"},
+	}
+	network := gcloud.Argument{
+		ArgName:      "network",
+		APIField:     "instance.network",
+		ResourceSpec: &gcloud.ResourceSpec{Name: "network"},
+	}
+	tree := func(commands map[string]*gcloud.Command) *gcloud.CommandGroup {
+		return &gcloud.CommandGroup{
+			Name: "parallelstore",
+			Groups: map[string]*gcloud.CommandGroup{
+				"instances": {Name: "instances", Commands: commands},
+			},
+		}
+	}
+	base := &gcloud.CommandGroupsByTrack{
+		GA: tree(map[string]*gcloud.Command{
+			"create": {
+				Method:     "create",
+				Collection: collection,
+				Arguments: []gcloud.Argument{
+					instance,
+					network,
+					{ArgName: "capacity-gib", APIField: "instance.capacityGib"},
+					{ArgName: "labels", APIField: "instance.labels"},
+				},
+			},
+			"export_data": {Method: "exportData", Collection: collection},
+			"delete":      {Method: "delete", Collection: collection},
+		}),
+		BETA: tree(map[string]*gcloud.Command{
+			"delete": {Method: "delete", Collection: collection},
+		}),
+	}
+	head := &gcloud.CommandGroupsByTrack{
+		GA: tree(map[string]*gcloud.Command{
+			"create": {
+				Method:     "create",
+				Collection: collection,
+				Arguments: []gcloud.Argument{
+					instance,
+					{ArgName: "capacity", APIField: "instance.capacityGib"},
+					{ArgName: "labels", APIField: "instance.labels"},
+					{ArgName: "description", APIField: "instance.description"},
+				},
+			},
+			"export": {Method: "exportData", Collection: collection},
+			"delete": {Method: "delete", Collection: collection},
+			"update": {Method: "patch", Collection: collection},
+		}),
+		ALPHA: tree(map[string]*gcloud.Command{
+			"delete": {Method: "delete", Collection: collection},
+		}),
+	}
+
+	got := diffSurfaces(base, head)
+	want := []change{
+		{Track: "GA", Action: "renamed", Kind: "flag", Command: "parallelstore instances create", Name: "--capacity-gib", NewName: "--capacity"},
+		{Track: "GA", Action: "added", Kind: "flag", Command: "parallelstore instances create", Name: "--description"},
+		{Track: "GA", Action: "removed", Kind: "resource argument", Command: "parallelstore instances create", Name: "--network"},
+		{Track: "GA", Action: "renamed", Kind: "command", Command: "parallelstore instances export-data", NewName: "parallelstore instances export"},
+		{Track: "GA", Action: "added", Kind: "command", Command: "parallelstore instances update"},
+		{Track: "BETA", Action: "removed", Kind: "command", Command: "parallelstore instances delete"},
+		{Track: "ALPHA", Action: "added", Kind: "command", Command: "parallelstore instances delete"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestChangeString(t *testing.T) {
+	for _, test := range []struct {
+		change change
+		want   string
+	}{
+		{
+			change: change{Track: "GA", Action: "added", Kind: "command", Command: "a b create"},
+			want:   `GA: added command "a b create"`,
+		},
+		{
+			change: change{Track: "GA", Action: "renamed", Kind: "command", Command: "a b export-data", NewName: "a b export"},
+			want:   `GA: renamed command "a b export-data" to "a b export"`,
+		},
+		{
+			change: change{Track: "BETA", Action: "added", Kind: "flag", Command: "a b create", Name: "--labels"},
+			want:   `BETA: added flag --labels to "a b create"`,
+		},
+		{
+			change: change{Track: "BETA", Action: "removed", Kind: "resource argument", Command: "a b create", Name: "--network"},
+			want:   `BETA: removed resource argument --network from "a b create"`,
+		},
+		{
+			change: change{Track: "ALPHA", Action: "renamed", Kind: "flag", Command: "a b create", Name: "--size", NewName: "--capacity"},
+			want:   `ALPHA: renamed flag --size to --capacity in "a b create"`,
+		},
+	} {
+		t.Run(test.want, func(t *testing.T) {
+			if got := test.change.String(); got != test.want {
+				t.Errorf("String() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCollectArguments(t *testing.T) {
+	cmd := &gcloud.Command{
+		Arguments: []gcloud.Argument{
+			{ArgName: "instance", IsPositional: true},
+			{ArgName: "description"},
+			{
+				ArgName: "config",
+				Params: []gcloud.Argument{
+					{ArgName: "config-mount-point"},
+				},
+			},
+		},
+	}
+	got := collectArguments(cmd)
+	want := map[string]gcloud.Argument{
+		"INSTANCE":             {ArgName: "instance", IsPositional: true},
+		"--description":        {ArgName: "description"},
+		"--config-mount-point": {ArgName: "config-mount-point"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
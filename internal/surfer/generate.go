@@ -15,6 +15,10 @@
 package surfer
 
 import (
+	"fmt"
+
+	"github.com/googleapis/librarian/internal/config"
+	"github.com/googleapis/librarian/internal/sidekick/api"
 	"github.com/googleapis/librarian/internal/sidekick/gcloud"
 	"github.com/googleapis/librarian/internal/sidekick/gcloud/provider"
 )
@@ -29,6 +33,12 @@ type generateConfig struct {
 	DescriptorFiles           string
 	Output                    string
 	BaseModule                string
+	// SpecificationFormat is the format of the API specification, either
+	// protobuf or discovery.
+	SpecificationFormat string
+	// SpecificationSource is the path to the Discovery document when
+	// SpecificationFormat is discovery.
+	SpecificationSource string
 }
 
 // generate generates gcloud commands for a service.
@@ -37,9 +47,24 @@ func generate(cfg generateConfig) error {
 	if err != nil {
 		return err
 	}
-	model, err := provider.CreateAPIModel(cfg.Googleapis, cfg.IncludeList, cfg.ServiceConfig, cfg.DescriptorFiles, cfg.DescriptorFilesToGenerate)
+	model, err := createModel(cfg, overrides)
 	if err != nil {
 		return err
 	}
 	return gcloud.Generate(model, overrides, cfg.Output, cfg.BaseModule)
 }
+
+// createModel parses the API specification in the format selected by cfg.
+func createModel(cfg generateConfig, overrides *provider.Config) (*api.API, error) {
+	switch cfg.SpecificationFormat {
+	case "", config.SpecProtobuf:
+		return provider.CreateAPIModel(cfg.Googleapis, cfg.IncludeList, cfg.ServiceConfig, cfg.DescriptorFiles, cfg.DescriptorFilesToGenerate)
+	case config.SpecDiscovery:
+		if cfg.SpecificationSource == "" {
+			return nil, fmt.Errorf("a Discovery document is required for specification format %q", cfg.SpecificationFormat)
+		}
+		return provider.CreateDiscoveryAPIModel(cfg.SpecificationSource, cfg.ServiceConfig, overrides.Discovery)
+	default:
+		return nil, fmt.Errorf("unsupported specification format %q", cfg.SpecificationFormat)
+	}
+}
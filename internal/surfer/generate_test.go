@@ -16,6 +16,8 @@ package surfer
 
 import (
 	"testing"
+
+	"github.com/googleapis/librarian/internal/sidekick/gcloud/provider"
 )
 
 func TestGenerate_InvalidConfig(t *testing.T) {
@@ -37,3 +39,39 @@ func TestGenerate_InvalidModel(t *testing.T) {
 		t.Error("generate() error = nil, want error for nonexistent googleapis dir")
 	}
 }
+
+func TestCreateModel_Error(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		cfg  generateConfig
+	}{
+		{
+			name: "unsupported format",
+			cfg:  generateConfig{SpecificationFormat: "openapi"},
+		},
+		{
+			name: "missing discovery document",
+			cfg:  generateConfig{SpecificationFormat: "discovery"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := createModel(test.cfg, &provider.Config{}); err == nil {
+				t.Error("createModel() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestCreateModel_Discovery(t *testing.T) {
+	model, err := createModel(generateConfig{
+		SpecificationFormat: "discovery",
+		SpecificationSource: "../testdata/discovery/small-compute.v1.json",
+		ServiceConfig:       "../testdata/googleapis/google/cloud/compute/v1/small-compute_v1.yaml",
+	}, &provider.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(model.Services) == 0 {
+		t.Error("createModel() returned a model with no services")
+	}
+}
@@ -18,6 +18,7 @@ package surfer
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/urfave/cli/v3"
 )
@@ -31,6 +32,8 @@ func Run(ctx context.Context, args ...string) error {
 		Description: "surfer generates gcloud command YAML files",
 		Commands: []*cli.Command{
 			generateCommand(),
+			validateCommand(),
+			diffCommand(),
 		},
 	}
 	return cmd.Run(ctx, args)
@@ -42,7 +45,8 @@ func generateCommand() *cli.Command {
 		Usage:     "generates gcloud commands",
 		UsageText: "surfer generate <path to gcloud.yaml> --googleapis <path>",
 		Description: `generate generates gcloud command files from protobuf API specifications,
-service config yaml, and gcloud.yaml.`,
+service config yaml, and gcloud.yaml. With --specification-format=discovery the
+API is read from the Discovery document given by --specification-source.`,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:  "out",
@@ -76,6 +80,15 @@ service config yaml, and gcloud.yaml.`,
 				Value: "googlecloudsdk",
 				Usage: "base python module path for surface command groups",
 			},
+			&cli.StringFlag{
+				Name:  "specification-format",
+				Value: "protobuf",
+				Usage: "format of the API specification, protobuf or discovery",
+			},
+			&cli.StringFlag{
+				Name:  "specification-source",
+				Usage: "path to the Discovery document, for --specification-format=discovery",
+			},
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			if cmd.Args().Len() == 0 {
@@ -98,7 +111,125 @@ service config yaml, and gcloud.yaml.`,
 				DescriptorFiles:           descriptorFiles,
 				Output:                    out,
 				BaseModule:                baseModule,
+				SpecificationFormat:       cmd.String("specification-format"),
+				SpecificationSource:       cmd.String("specification-source"),
 			})
 		},
 	}
 }
+
+func validateCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "validate",
+		Usage:     "validates gcloud.yaml and gcloud command files",
+		UsageText: "surfer validate [<path to gcloud.yaml>] [--surface <dir>] [--googleapis <path>]",
+		Description: `validate checks a gcloud.yaml file and existing gcloud command YAML files
+against their schemas. It reports unknown keys and malformed selectors in
+gcloud.yaml and in the command files under --surface. If the API sources are
+given with --googleapis or --descriptor-files, it also reports selectors that
+match no element of the API.`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "surface",
+				Usage: "directory with the gcloud command YAML files to validate",
+			},
+			&cli.StringFlag{
+				Name:  "service-config",
+				Usage: "path to the api service config",
+			},
+			&cli.StringFlag{
+				Name:  "proto-files-include-list",
+				Usage: "comma-separated list of protobuf files used to generate the gcloud commands",
+			},
+			&cli.StringFlag{
+				Name:  "googleapis",
+				Usage: "URL or directory path to googleapis",
+			},
+			&cli.StringFlag{
+				Name:  "descriptor-files-to-generate",
+				Usage: "comma-separated list of files to generate from the descriptors",
+			},
+			&cli.StringFlag{
+				Name:  "descriptor-files",
+				Usage: "comma-separated list of paths to binary FileDescriptorSet files",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			config := cmd.Args().First()
+			surface := cmd.String("surface")
+			if config == "" && surface == "" {
+				return fmt.Errorf("path to gcloud.yaml or --surface is required")
+			}
+			return validate(validateConfig{
+				GcloudConfig:              config,
+				Surface:                   surface,
+				ServiceConfig:             cmd.String("service-config"),
+				IncludeList:               cmd.String("proto-files-include-list"),
+				Googleapis:                cmd.String("googleapis"),
+				DescriptorFiles:           cmd.String("descriptor-files"),
+				DescriptorFilesToGenerate: cmd.String("descriptor-files-to-generate"),
+			}, os.Stdout)
+		},
+	}
+}
+
+func diffCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "diff",
+		Usage:     "reports changes to the gcloud command tree between two API revisions",
+		UsageText: "surfer diff <path to gcloud.yaml> --base-googleapis <path> --googleapis <path>",
+		Description: `diff builds the gcloud command tree for two revisions of an API and reports
+the commands, flags and resource arguments added, removed or renamed in each
+release track.`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "base-googleapis",
+				Usage: "URL or directory path to googleapis at the base revision",
+			},
+			&cli.StringFlag{
+				Name:  "base-service-config",
+				Usage: "path to the api service config at the base revision, defaults to --service-config",
+			},
+			&cli.StringFlag{
+				Name:  "base-descriptor-files",
+				Usage: "comma-separated list of paths to binary FileDescriptorSet files at the base revision, defaults to --descriptor-files",
+			},
+			&cli.StringFlag{
+				Name:  "googleapis",
+				Usage: "URL or directory path to googleapis at the new revision",
+			},
+			&cli.StringFlag{
+				Name:  "service-config",
+				Usage: "path to the api service config",
+			},
+			&cli.StringFlag{
+				Name:  "proto-files-include-list",
+				Usage: "comma-separated list of protobuf files used to generate the gcloud commands",
+			},
+			&cli.StringFlag{
+				Name:  "descriptor-files-to-generate",
+				Usage: "comma-separated list of files to generate from the descriptors",
+			},
+			&cli.StringFlag{
+				Name:  "descriptor-files",
+				Usage: "comma-separated list of paths to binary FileDescriptorSet files",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() == 0 {
+				return fmt.Errorf("path to gcloud.yaml is required")
+			}
+			return diff(diffConfig{
+				GcloudConfig:              cmd.Args().First(),
+				IncludeList:               cmd.String("proto-files-include-list"),
+				BaseGoogleapis:            cmd.String("base-googleapis"),
+				BaseServiceConfig:         cmd.String("base-service-config"),
+				BaseDescriptorFiles:       cmd.String("base-descriptor-files"),
+				Googleapis:                cmd.String("googleapis"),
+				ServiceConfig:             cmd.String("service-config"),
+				DescriptorFiles:           cmd.String("descriptor-files"),
+				DescriptorFilesToGenerate: cmd.String("descriptor-files-to-generate"),
+			}, os.Stdout)
+		},
+	}
+}
@@ -122,6 +122,14 @@ apis:
 			name: "missing googleapis flag",
 			args: []string{"surfer", "generate", tmpFile},
 		},
+		{
+			name: "validate without inputs",
+			args: []string{"surfer", "validate"},
+		},
+		{
+			name: "diff missing config arg",
+			args: []string{"surfer", "diff", "--base-googleapis", "base", "--googleapis", "head"},
+		},
 		{
 			name: "missing descriptor-files-to-generate",
 			args: []string{
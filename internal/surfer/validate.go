@@ -0,0 +1,177 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package surfer
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/googleapis/librarian/internal/sidekick/api"
+	"github.com/googleapis/librarian/internal/sidekick/gcloud/declarative"
+	"github.com/googleapis/librarian/internal/sidekick/gcloud/provider"
+	"github.com/googleapis/librarian/internal/yaml"
+)
+
+type validateConfig struct {
+	GcloudConfig              string
+	Surface                   string
+	ServiceConfig             string
+	IncludeList               string
+	Googleapis                string
+	DescriptorFiles           string
+	DescriptorFilesToGenerate string
+}
+
+// partialsStub is the content of a command file whose definitions live in
+// per-track files in the _partials directory.
+type partialsStub struct {
+	Partials bool `yaml:"_PARTIALS_"`
+}
+
+// validate checks the gcloud.yaml file and the generated command files in
+// cfg, writes each problem found to w, and returns an error if there are any.
+// Selectors are only matched against the API if cfg names its sources.
+func validate(cfg validateConfig, w io.Writer) error {
+	var problems []error
+	if cfg.GcloudConfig != "" {
+		errs, err := validateGcloudConfig(cfg)
+		if err != nil {
+			return err
+		}
+		problems = append(problems, errs...)
+	}
+	if cfg.Surface != "" {
+		errs, err := validateSurface(cfg.Surface)
+		if err != nil {
+			return err
+		}
+		problems = append(problems, errs...)
+	}
+	for _, p := range problems {
+		fmt.Fprintln(w, p)
+	}
+	if len(problems) != 0 {
+		return fmt.Errorf("found %d problems", len(problems))
+	}
+	return nil
+}
+
+func validateGcloudConfig(cfg validateConfig) ([]error, error) {
+	data, err := os.ReadFile(cfg.GcloudConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gcloud config file: %w", err)
+	}
+	overrides, err := yaml.UnmarshalStrict[provider.Config](data)
+	if err != nil {
+		return []error{fmt.Errorf("%s: %w", cfg.GcloudConfig, err)}, nil
+	}
+	var model *api.API
+	if cfg.Googleapis != "" || cfg.DescriptorFiles != "" {
+		model, err = provider.CreateAPIModel(cfg.Googleapis, cfg.IncludeList, cfg.ServiceConfig, cfg.DescriptorFiles, cfg.DescriptorFilesToGenerate)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var problems []error
+	for _, err := range provider.Validate(overrides, model) {
+		problems = append(problems, fmt.Errorf("%s: %w", cfg.GcloudConfig, err))
+	}
+	return problems, nil
+}
+
+// validateSurface checks every command YAML file under dir against the
+// declarative schema. Command files are either complete command lists or
+// stubs whose per-track definitions live in the _partials directory.
+func validateSurface(dir string) ([]error, error) {
+	var problems []error
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".yaml" || d.Name() == "gcloud.yaml" {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, p := range validateCommandFile(path, data) {
+			problems = append(problems, fmt.Errorf("%s: %w", filepath.ToSlash(rel), p))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return problems, nil
+}
+
+func validateCommandFile(path string, data []byte) []error {
+	isPartial := filepath.Base(filepath.Dir(path)) == "_partials"
+	if !isPartial {
+		if stub, err := yaml.UnmarshalStrict[partialsStub](data); err == nil {
+			if !stub.Partials {
+				return []error{fmt.Errorf("_PARTIALS_ must be true")}
+			}
+			verb := strings.TrimSuffix(filepath.Base(path), ".yaml")
+			matches, err := filepath.Glob(filepath.Join(filepath.Dir(path), "_partials", "_"+verb+"_*.yaml"))
+			if err != nil {
+				return []error{err}
+			}
+			if len(matches) == 0 {
+				return []error{fmt.Errorf("no partials found for %q", verb)}
+			}
+			return nil
+		}
+	}
+	commands, err := yaml.UnmarshalStrict[[]declarative.Command](data)
+	if err != nil {
+		return []error{err}
+	}
+	if isPartial && len(*commands) != 1 {
+		return []error{fmt.Errorf("partials must define exactly one command, found %d", len(*commands))}
+	}
+	var problems []error
+	for i, c := range *commands {
+		if len(c.ReleaseTracks) == 0 {
+			problems = append(problems, fmt.Errorf("command[%d] has no release_tracks", i))
+		}
+		for _, track := range c.ReleaseTracks {
+			switch provider.ReleaseTrack(track) {
+			case provider.ReleaseTrackAlpha, provider.ReleaseTrackBeta, provider.ReleaseTrackGA:
+			default:
+				problems = append(problems, fmt.Errorf("command[%d] has unknown release track %q", i, track))
+			}
+		}
+		primary := 0
+		for _, arg := range c.Arguments.Params {
+			if arg.IsPrimaryResource {
+				primary++
+			}
+		}
+		if primary > 1 {
+			problems = append(problems, fmt.Errorf("command[%d] has %d primary resource arguments, want at most one", i, primary))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package surfer
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const validPartial = `- release_tracks:
+  - GA
+  auto_generated: true
+  help_text:
+    brief: Describe an instance.
+    description: Describe an instance.
+  arguments:
+    params:
+    - help_text: The instance.
+      is_positional: true
+      is_primary_resource: true
+      required: true
+      default: 3
+  request:
+    api_version: v1
+    collection:
+    - parallelstore.projects.locations.instances
+    method: get
+`
+
+func TestValidate(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		files map[string]string
+		want  []string
+	}{
+		{
+			name: "valid",
+			files: map[string]string{
+				"gcloud.yaml":                                      "service_name: parallelstore.googleapis.com\napis:\n- name: Parallelstore\n  api_version: v1\n",
+				"surface/instances/describe.yaml":                  "_PARTIALS_: true\n",
+				"surface/instances/_partials/_describe_ga.yaml":    validPartial,
+				"surface/instances/_partials/_describe_alpha.yaml": strings.ReplaceAll(validPartial, "GA", "ALPHA"),
+			},
+		},
+		{
+			name: "unknown keys",
+			files: map[string]string{
+				"gcloud.yaml": "service_name: parallelstore.googleapis.com\nservice_version: v1\n",
+				"surface/instances/_partials/_describe_ga.yaml": strings.ReplaceAll(validPartial, "brief:", "summary:"),
+				"surface/instances/describe.yaml":               "_PARTIALS_: true\n",
+			},
+			want: []string{
+				"gcloud.yaml: yaml: unmarshal errors:\n  line 2: field service_version not found in type provider.Config",
+				"instances/_partials/_describe_ga.yaml: yaml: unmarshal errors:\n  line 5: field summary not found in type declarative.HelpText",
+			},
+		},
+		{
+			name: "bad selectors",
+			files: map[string]string{
+				"gcloud.yaml": `apis:
+- name: Parallelstore
+  output_formatting:
+  - selector: parallelstore.v1.Parallelstore.*
+    format: table(name)
+`,
+			},
+			want: []string{
+				`gcloud.yaml: apis[0].output_formatting[0].selector: selector "parallelstore.v1.Parallelstore.*" must not use wildcards`,
+			},
+		},
+		{
+			name: "command files",
+			files: map[string]string{
+				"surface/instances/describe.yaml":               "_PARTIALS_: true\n",
+				"surface/instances/list.yaml":                   "_PARTIALS_: false\n",
+				"surface/instances/delete.yaml":                 "_PARTIALS_: true\n",
+				"surface/instances/_partials/_describe_ga.yaml": validPartial + strings.ReplaceAll(validPartial, "GA", "BETA"),
+				"surface/instances/_partials/_list_ga.yaml":     strings.ReplaceAll(validPartial, "GA", "PREVIEW"),
+				"surface/instances/_partials/_update_ga.yaml":   strings.ReplaceAll(validPartial, "required: true", "required: true\n    - is_primary_resource: true"),
+			},
+			want: []string{
+				`instances/_partials/_describe_ga.yaml: partials must define exactly one command, found 2`,
+				`instances/_partials/_list_ga.yaml: command[0] has unknown release track "PREVIEW"`,
+				`instances/_partials/_update_ga.yaml: command[0] has 2 primary resource arguments, want at most one`,
+				`instances/delete.yaml: no partials found for "delete"`,
+				`instances/list.yaml: _PARTIALS_ must be true`,
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range test.files {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			cfg := validateConfig{}
+			if _, ok := test.files["gcloud.yaml"]; ok {
+				cfg.GcloudConfig = filepath.Join(dir, "gcloud.yaml")
+			}
+			if _, err := os.Stat(filepath.Join(dir, "surface")); err == nil {
+				cfg.Surface = filepath.Join(dir, "surface")
+			}
+			var out bytes.Buffer
+			err := validate(cfg, &out)
+			if (err != nil) != (len(test.want) != 0) {
+				t.Errorf("validate() error = %v, want problems %v", err, test.want)
+			}
+			got := strings.ReplaceAll(out.String(), dir+string(filepath.Separator), "")
+			want := ""
+			for _, w := range test.want {
+				want += w + "\n"
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidate_Error(t *testing.T) {
+	cfg := validateConfig{GcloudConfig: filepath.Join(t.TempDir(), "missing.yaml")}
+	if err := validate(cfg, &bytes.Buffer{}); err == nil {
+		t.Error("validate() error = nil, want error")
+	}
+}
@@ -17,6 +17,8 @@ package yaml
 
 import (
 	"bytes"
+	"errors"
+	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -53,6 +55,18 @@ func Unmarshal[T any](data []byte) (*T, error) {
 	return &v, nil
 }
 
+// UnmarshalStrict parses YAML data into a value of type T, like [Unmarshal],
+// but reports an error for keys that do not map to a field in T.
+func UnmarshalStrict[T any](data []byte) (*T, error) {
+	var v T
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&v); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return &v, nil
+}
+
 // Marshal converts a value to formatted YAML.
 func Marshal(v any) ([]byte, error) {
 	var buf bytes.Buffer
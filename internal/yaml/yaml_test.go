@@ -48,6 +48,51 @@ func TestUnmarshalError(t *testing.T) {
 	}
 }
 
+func TestUnmarshalStrict(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		data string
+		want *testConfig
+	}{
+		{
+			name: "known fields",
+			data: "name: test\nversion: v1.0.0\n",
+			want: &testConfig{Name: "test", Version: "v1.0.0"},
+		},
+		{
+			name: "empty",
+			data: "",
+			want: &testConfig{},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := UnmarshalStrict[testConfig]([]byte(test.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnmarshalStrictError(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		data string
+	}{
+		{name: "unknown field", data: "name: test\nrelease: v1.0.0\n"},
+		{name: "invalid YAML", data: "name: [invalid"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := UnmarshalStrict[testConfig]([]byte(test.data)); err == nil {
+				t.Error("UnmarshalStrict() expected error")
+			}
+		})
+	}
+}
+
 func TestMarshal(t *testing.T) {
 	input := &testConfig{Name: "test", Version: "v1.0.0"}
 	data, err := Marshal(input)